@@ -15,7 +15,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/huangc28/go-darkpanda-backend/internal/app"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/matching"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
 	"github.com/huangc28/go-darkpanda-backend/manager"
 	"github.com/spf13/viper"
 )
@@ -40,6 +43,20 @@ func main() {
 				log.Fatalf("failed to initialize dependency container %s", err.Error())
 			}
 
+			// Suspensions missing in the cache are looked up from the database, failing to sync only
+			// costs a few more queries.
+			var (
+				suspensionDao contracts.UserSuspensionDAOer
+				authDao       contracts.AuthDaoer
+			)
+
+			deps.Get().Container.Make(&suspensionDao)
+			deps.Get().Container.Make(&authDao)
+
+			if err := suspension.SyncCache(iniCtx, suspensionDao, authDao); err != nil {
+				log.Errorf("failed to sync suspension cache %s", err.Error())
+			}
+
 			r := gin.New()
 			app.StartApp(r)
 
@@ -73,6 +90,15 @@ func main() {
 				log.Fatalf("Server shutdown with error: %s", err.Error())
 			}
 
+			// Wait for matching waves dispatched by requests that have been served.
+			var dispatcher *matching.Dispatcher
+			deps.Get().Container.Make(&dispatcher)
+
+			dispatchCtx, cancelDispatch := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancelDispatch()
+
+			dispatcher.Shutdown(dispatchCtx)
+
 			log.Info("shutdown complete..")
 		})
 }
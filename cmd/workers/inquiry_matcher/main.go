@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/matching"
+	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	log "github.com/sirupsen/logrus"
+
+	logger "github.com/huangc28/go-darkpanda-backend/cmd/workers/loggers"
+)
+
+// This worker widens matching of random inquiries nobody has picked up. The first wave is run
+// when the inquiry is emitted, each following wave searches a wider radius and invites the next
+// top ranked providers until `MATCHING_MAX_WAVES` is reached or the inquiry is picked up.
+
+func init() {
+	ctx := context.Background()
+	manager.NewDefaultManager(ctx).Run(func() {
+		if err := deps.Get().Run(); err != nil {
+			log.Fatalf("failed to initialise dependency container %s", err.Error())
+		}
+
+		errLogPath := config.GetAppConf().ErrorLogPath
+		infoLogPath := config.GetAppConf().InfoLogPath
+
+		logger.InitErrLogger(errLogPath, "inquiry_matcher")
+		logger.InitInfoLogger(infoLogPath, "inquiry_matcher")
+	})
+}
+
+// RunDueWaves runs the due wave of each inquiry. Failing inquiries are logged so that the others
+// are still matched, they are retried on the next tick.
+func RunDueWaves(matchingDao contracts.MatchingDAOer, fcm dpfcm.DPFirebaseMessenger) error {
+	conf := config.GetAppConf().GetMatchingConf()
+
+	iqs, err := matchingDao.GetDueMatchingInquiries(conf.WaveInterval, conf.MaxWaves)
+
+	if err != nil {
+		return fmt.Errorf("failed to get due matching inquiries %s", err.Error())
+	}
+
+	ctx := context.Background()
+	engine := matching.NewEngine(matchingDao, fcm, conf)
+
+	for _, iq := range iqs {
+		wave := matching.WaveOf(conf, iq.CreatedAt, time.Now())
+
+		invited, err := engine.RunWave(ctx, iq, wave)
+
+		if err != nil {
+			logger.GetErrorLogger().Errorf("failed to run wave %d of inquiry %s %s", wave, iq.Uuid, err.Error())
+
+			continue
+		}
+
+		logger.GetInfoLogger().Infof("invited %d providers to inquiry %s in wave %d", invited, iq.Uuid, wave)
+	}
+
+	return nil
+}
+
+func main() {
+	tickSec := 60
+	tickSecEnv := os.Getenv("TICK_INTERVAL_IN_SECOND")
+
+	if len(tickSecEnv) > 0 {
+		tickSecEnvInt, err := strconv.Atoi(tickSecEnv)
+
+		if err == nil {
+			tickSec = tickSecEnvInt
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(tickSec) * time.Second)
+
+	quitTicker := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				depCon := deps.Get().Container
+
+				var (
+					matchingDao contracts.MatchingDAOer
+					fcm         dpfcm.DPFirebaseMessenger
+				)
+
+				depCon.Make(&matchingDao)
+				depCon.Make(&fcm)
+
+				if err := RunDueWaves(matchingDao, fcm); err != nil {
+					logger.GetErrorLogger().Error(err)
+				}
+			case <-quitTicker:
+				ticker.Stop()
+
+				return
+			}
+		}
+	}()
+
+	quitSig := make(chan os.Signal, 1)
+	signal.Notify(quitSig, syscall.SIGINT, syscall.SIGTERM)
+	<-quitSig
+
+	log.Info("graceful shutdown worker...")
+
+	close(quitTicker)
+
+	log.Info("worker shutdown complete")
+}
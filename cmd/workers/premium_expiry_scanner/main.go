@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	log "github.com/sirupsen/logrus"
+
+	logger "github.com/huangc28/go-darkpanda-backend/cmd/workers/loggers"
+)
+
+// This worker manages lifecycle of premium membership. Paid members whose `premium_expiry_date`
+// has passed are downgraded to normal members. Paid members whose membership expires within
+// `reminderWindow` receive a FCM reminder to renew, each expiry is reminded only once.
+
+const reminderWindow = 3 * 24 * time.Hour
+
+func init() {
+	ctx := context.Background()
+	manager.NewDefaultManager(ctx).Run(func() {
+		if err := deps.Get().Run(); err != nil {
+			log.Fatalf("failed to initialise dependency container %s", err.Error())
+		}
+
+		errLogPath := config.GetAppConf().ErrorLogPath
+		infoLogPath := config.GetAppConf().InfoLogPath
+
+		logger.InitErrLogger(errLogPath, "premium_expiry_scanner")
+		logger.InitInfoLogger(infoLogPath, "premium_expiry_scanner")
+	})
+}
+
+func DowngradeExpiredMembers(premiumDao contracts.PremiumDAOer) error {
+	uuids, err := premiumDao.DowngradeExpiredMembers()
+
+	if err != nil {
+		return fmt.Errorf("failed to downgrade expired members %s", err.Error())
+	}
+
+	if len(uuids) > 0 {
+		logger.GetInfoLogger().Infof("downgraded expired members %v", uuids)
+	}
+
+	return nil
+}
+
+// RemindExpiringMembers members are claimed before being notified, failing notifications are
+// logged rather than retried so that members are never reminded twice.
+func RemindExpiringMembers(premiumDao contracts.PremiumDAOer, fcm dpfcm.DPFirebaseMessenger) error {
+	members, err := premiumDao.ClaimExpiryReminders(reminderWindow)
+
+	if err != nil {
+		return fmt.Errorf("failed to claim expiry reminders %s", err.Error())
+	}
+
+	ctx := context.Background()
+
+	for _, member := range members {
+		if !member.FcmTopic.Valid {
+			continue
+		}
+
+		if err := fcm.PublishPremiumExpiryReminder(ctx, dpfcm.PremiumExpiryReminderMessage{
+			Topic:     member.FcmTopic.String,
+			Locale:    member.Locale,
+			ExpiresAt: member.ExpiresAt,
+		}); err != nil {
+			logger.GetErrorLogger().Errorf("failed to remind member %s %s", member.Uuid, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func main() {
+	tickSec := 60
+	tickSecEnv := os.Getenv("TICK_INTERVAL_IN_SECOND")
+
+	if len(tickSecEnv) > 0 {
+		tickSecEnvInt, err := strconv.Atoi(tickSecEnv)
+
+		if err == nil {
+			tickSec = tickSecEnvInt
+		}
+	}
+
+	ticker := time.NewTicker(time.Duration(tickSec) * time.Second)
+
+	quitTicker := make(chan struct{})
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				depCon := deps.Get().Container
+
+				var (
+					premiumDao contracts.PremiumDAOer
+					fcm        dpfcm.DPFirebaseMessenger
+				)
+
+				depCon.Make(&premiumDao)
+				depCon.Make(&fcm)
+
+				if err := DowngradeExpiredMembers(premiumDao); err != nil {
+					logger.GetErrorLogger().Error(err)
+				}
+
+				if err := RemindExpiringMembers(premiumDao, fcm); err != nil {
+					logger.GetErrorLogger().Error(err)
+				}
+			case <-quitTicker:
+				ticker.Stop()
+
+				return
+			}
+		}
+	}()
+
+	quitSig := make(chan os.Signal, 1)
+	signal.Notify(quitSig, syscall.SIGINT, syscall.SIGTERM)
+	<-quitSig
+
+	log.Info("graceful shutdown worker...")
+
+	close(quitTicker)
+
+	log.Info("worker shutdown complete")
+}
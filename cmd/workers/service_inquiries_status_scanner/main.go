@@ -12,9 +12,13 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/inquiry"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
+	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
 
 	logger "github.com/huangc28/go-darkpanda-backend/cmd/workers/loggers"
@@ -25,7 +29,8 @@ import (
 //
 // Inquiring:
 //    If created_at exceed 5 hour, we consider the inqury has canceled. Hence,
-//    we should set the inquiry status to `canceled`.
+//    we should set the inquiry status to `canceled`. Applicants still waiting
+//    for the inquirer to reply are declined and notified.
 //
 var (
 	errLogger  = log.New()
@@ -48,8 +53,36 @@ func init() {
 	})
 }
 
-func ScanInquiringServicesInquiries(srvDao contracts.ServiceDAOer) error {
-	cplSrvs, err := srvDao.ScanInquiringServiceInquiries()
+func ScanInquiringServicesInquiries(srvDao contracts.ServiceDAOer, iqDao contracts.InquiryDAOer, fm dpfcm.DPFirebaseMessenger) error {
+	var (
+		cplSrvs  []*models.ServiceScannerData
+		declined []models.DeclinedApplication
+	)
+
+	err := transition.Transact(
+		contracts.AnnotateTransitionParams{
+			Event: inquiry.Cancel.ToString(),
+			Cause: transition.CauseInquiryTimeout,
+		},
+		func(tx *sqlx.Tx) error {
+			var err error
+			cplSrvs, err = srvDao.WithTx(tx).ScanInquiringServiceInquiries()
+
+			if err != nil {
+				return err
+			}
+
+			iqUuids := make([]string, 0, len(cplSrvs))
+
+			for _, srv := range cplSrvs {
+				iqUuids = append(iqUuids, srv.UUID)
+			}
+
+			declined, err = iqDao.WithTx(tx).DeclineApplicantsOfInquiries(iqUuids)
+
+			return err
+		},
+	)
 
 	if err != nil {
 		return fmt.Errorf("failed to scan completed services %s", err.Error())
@@ -76,6 +109,8 @@ func ScanInquiringServicesInquiries(srvDao contracts.ServiceDAOer) error {
 			return fmt.Errorf("failed to scan completed services %s", err.Error())
 		}
 
+		inquiry.NotifyClosedApplications(ctx, df, fm, declined)
+
 		log.Printf("completed services %v", cplSrvs)
 	}
 
@@ -104,11 +139,18 @@ func main() {
 			case <-ticker.C:
 
 				depCon := deps.Get().Container
-				var serviceDao contracts.ServiceDAOer
+
+				var (
+					serviceDao contracts.ServiceDAOer
+					iqDao      contracts.InquiryDAOer
+					fm         dpfcm.DPFirebaseMessenger
+				)
 
 				depCon.Make(&serviceDao)
+				depCon.Make(&iqDao)
+				depCon.Make(&fm)
 
-				if err := ScanInquiringServicesInquiries(serviceDao); err != nil {
+				if err := ScanInquiringServicesInquiries(serviceDao, iqDao, fm); err != nil {
 					errLogger.Error(err)
 				}
 
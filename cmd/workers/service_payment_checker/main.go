@@ -18,7 +18,10 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
 	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/service"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/jmoiron/sqlx"
 )
 
 // This worker checks if service is paid within 30 minutes from the time being booked.
@@ -93,7 +96,17 @@ func main() {
 // we mark the service as `payment failed`. Moreover, we will notify girl
 // via firestore message that the service has been canceled.
 func ScanAndUpdateUnpaidServiceExceed30Minutes(srvDao contracts.ServiceDAOer) error {
-	ms, err := srvDao.CancelUnpaidServicesIfExceed30Minuties()
+	var ms []*models.CancelUnpaidServices
+
+	err := transition.Transact(
+		contracts.AnnotateTransitionParams{Event: service.PayFailed.ToString()},
+		func(tx *sqlx.Tx) error {
+			var err error
+			ms, err = srvDao.WithTx(tx).CancelUnpaidServicesIfExceed30Minuties()
+
+			return err
+		},
+	)
 
 	if err != nil {
 		return err
@@ -119,6 +132,7 @@ func ScanAndUpdateUnpaidServiceExceed30Minutes(srvDao contracts.ServiceDAOer) er
 		if m.CustomerFCMTopic != nil {
 			if err := fcm.PublishUnpaidServiceExpiredNotification(ctx, dpfcm.PublishUnpaidServiceExpiredMessage{
 				Topic:               *m.CustomerFCMTopic,
+				Locale:              m.CustomerLocale,
 				ServiceUUID:         m.Uuid.String,
 				CustomerName:        m.CustomerName,
 				ServiceProviderName: m.ServiceProviderName,
@@ -130,6 +144,7 @@ func ScanAndUpdateUnpaidServiceExceed30Minutes(srvDao contracts.ServiceDAOer) er
 		if m.ServiceProviderFCMTopic != nil {
 			if err := fcm.PublishUnpaidServiceExpiredNotification(ctx, dpfcm.PublishUnpaidServiceExpiredMessage{
 				Topic:               *m.ServiceProviderFCMTopic,
+				Locale:              m.ServiceProviderLocale,
 				ServiceUUID:         m.Uuid.String,
 				CustomerName:        m.CustomerName,
 				ServiceProviderName: m.ServiceProviderName,
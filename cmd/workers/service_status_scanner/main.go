@@ -16,7 +16,11 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
 	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/referral"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/service"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
 
 	logger "github.com/huangc28/go-darkpanda-backend/cmd/workers/loggers"
@@ -54,13 +58,27 @@ func init() {
 }
 
 func ScanCompletedServices(srvDao contracts.ServiceDAOer) error {
-	cplSrvs, err := srvDao.ScanCompletedServices()
+	var cplSrvs []*models.ServiceScannerData
+
+	err := transition.Transact(
+		contracts.AnnotateTransitionParams{Event: service.Complete.ToString()},
+		func(tx *sqlx.Tx) error {
+			var err error
+			cplSrvs, err = srvDao.WithTx(tx).ScanCompletedServices()
+
+			return err
+		},
+	)
 
 	if err != nil {
 		return fmt.Errorf("failed to scan completed services %s", err.Error())
 	}
 
 	if len(cplSrvs) > 0 {
+		// Services have been marked as completed. Rewards are granted before notifying
+		// the participants so that failing notifications do not skip rewards.
+		GrantFirstServiceCompletedRewards(cplSrvs)
+
 		ctx := context.Background()
 		srvUuids := make([]string, 0)
 
@@ -92,6 +110,7 @@ func ScanCompletedServices(srvDao contracts.ServiceDAOer) error {
 				ctx,
 				dpfcm.ServiceCompletedMessage{
 					Topic:               cplSrv.ServiceProvidersFCMTopic,
+					Locale:              cplSrv.ServiceProvidersLocale,
 					CounterPartUsername: cplSrv.CustomerUsername,
 					ServiceUUID:         cplSrv.UUID,
 				},
@@ -104,6 +123,7 @@ func ScanCompletedServices(srvDao contracts.ServiceDAOer) error {
 				ctx,
 				dpfcm.ServiceCompletedMessage{
 					Topic:               cplSrv.CustomerFCMTopic,
+					Locale:              cplSrv.CustomerLocale,
 					CounterPartUsername: cplSrv.ServiceProviderUsername,
 					ServiceUUID:         cplSrv.UUID,
 				},
@@ -118,8 +138,46 @@ func ScanCompletedServices(srvDao contracts.ServiceDAOer) error {
 	return nil
 }
 
+// GrantFirstServiceCompletedRewards grants referral rewards to invitees who have completed the first service.
+// Errors are logged instead of being returned since the services have already been marked as completed.
+func GrantFirstServiceCompletedRewards(cplSrvs []*models.ServiceScannerData) {
+	var rewardDao contracts.ReferralRewardDAOer
+	depCon := deps.Get().Container
+	depCon.Make(&rewardDao)
+
+	for _, cplSrv := range cplSrvs {
+		inviteeIDs, err := rewardDao.GetInviteesCompletedFirstService(cplSrv.UUID)
+
+		if err != nil {
+			errLogger.Errorf("failed to get invitees completed service %s %s", cplSrv.UUID, err.Error())
+
+			continue
+		}
+
+		for _, inviteeID := range inviteeIDs {
+			if _, err := referral.GrantRewardsInTx(
+				depCon,
+				inviteeID,
+				models.ReferralRewardEventFirstServiceCompleted,
+			); err != nil {
+				errLogger.Errorf("failed to grant referral rewards to user %d %s", inviteeID, err.Error())
+			}
+		}
+	}
+}
+
 func ScanExpiredServices(srvDao contracts.ServiceDAOer) error {
-	expSrvs, err := srvDao.ScanExpiredServices()
+	var expSrvs []*models.ServiceScannerData
+
+	err := transition.Transact(
+		contracts.AnnotateTransitionParams{Event: service.Expired.ToString()},
+		func(tx *sqlx.Tx) error {
+			var err error
+			expSrvs, err = srvDao.WithTx(tx).ScanExpiredServices()
+
+			return err
+		},
+	)
 
 	// If error occurs, we write error logs into system log.
 	if err != nil {
@@ -157,6 +215,7 @@ func ScanExpiredServices(srvDao contracts.ServiceDAOer) error {
 				ctx,
 				dpfcm.ServiceExpiredMessage{
 					Topic:               expSrv.ServiceProvidersFCMTopic,
+					Locale:              expSrv.ServiceProvidersLocale,
 					CounterPartUsername: expSrv.CustomerUsername,
 					ServiceUUID:         expSrv.UUID,
 				},
@@ -169,6 +228,7 @@ func ScanExpiredServices(srvDao contracts.ServiceDAOer) error {
 				ctx,
 				dpfcm.ServiceExpiredMessage{
 					Topic:               expSrv.CustomerFCMTopic,
+					Locale:              expSrv.CustomerLocale,
 					CounterPartUsername: expSrv.ServiceProviderUsername,
 					ServiceUUID:         expSrv.UUID,
 				},
@@ -4,6 +4,8 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -16,6 +18,10 @@ type AppConf struct {
 	ServiceQrCodeSecret string `mapstructure:"SERVICE_QRCODE_SECRET"`
 	AppTimeZone         string `mapstructure:"APP_TIME_ZONE"`
 
+	// Life time of the access token and refresh token. Default values are used if not specified.
+	AccessTokenTTLMinutes int `mapstructure:"ACCESS_TOKEN_TTL_MINUTES"`
+	RefreshTokenTTLDays   int `mapstructure:"REFRESH_TOKEN_TTL_DAYS"`
+
 	PGHost     string `mapstructure:"PG_HOST"`
 	PGPort     uint   `mapstructure:"PG_PORT"`
 	PGUser     string `mapstructure:"PG_USER"`
@@ -41,6 +47,11 @@ type AppConf struct {
 	TwilioDevAuthToken string `mapstructure:"TWILIO_DEV_AUTH_TOKEN"`
 	TwilioDevFrom      string `mapstructure:"TWILIO_DEV_FROM"`
 
+	// SMS driver to use: twilio, twilio_dev, file or failover. Defaults to twilio.
+	SMSDriver          string `mapstructure:"SMS_DRIVER"`
+	SMSFailoverDrivers string `mapstructure:"SMS_FAILOVER_DRIVERS"`
+	SMSSpoolPath       string `mapstructure:"SMS_SPOOL_PATH"`
+
 	GcpProjectID string `mapstructure:"GCP_PROJECT_ID"`
 
 	GcsGoogleServiceAccountName string `mapstructure:"GCS_GOOGLE_SERVICE_ACCOUNT_NAME"`
@@ -62,6 +73,32 @@ type AppConf struct {
 	AppcenterAppSecret                 string `mapstructure:"APPCENTER_APP_SECRET"`
 	AppcenterPublicDistributionGroupId string `mapstructure:"APPCENTER_PUBLIC_DISTRIBUTION_GROUP_ID"`
 
+	// Limits on SMS OTP verification. Default values are used if not specified.
+	OTPMaxAttempts          int `mapstructure:"OTP_MAX_ATTEMPTS"`
+	OTPMaxAttemptsPerIP     int `mapstructure:"OTP_MAX_ATTEMPTS_PER_IP"`
+	OTPLockoutBaseSeconds   int `mapstructure:"OTP_LOCKOUT_BASE_SECONDS"`
+	OTPLockoutMaxSeconds    int `mapstructure:"OTP_LOCKOUT_MAX_SECONDS"`
+	OTPSendCooldownSeconds  int `mapstructure:"OTP_SEND_COOLDOWN_SECONDS"`
+	OTPMaxSendsPerHour      int `mapstructure:"OTP_MAX_SENDS_PER_HOUR"`
+	OTPMaxSendsPerHourPerIP int `mapstructure:"OTP_MAX_SENDS_PER_HOUR_PER_IP"`
+
+	// Username policy applied on registration. Default length limits are used if not specified.
+	UsernameMinLength int `mapstructure:"USERNAME_MIN_LENGTH"`
+	UsernameMaxLength int `mapstructure:"USERNAME_MAX_LENGTH"`
+
+	// Comma separated words reserved in addition to the built-in reserved words.
+	UsernameReservedWords string `mapstructure:"USERNAME_RESERVED_WORDS"`
+
+	// Path of the profanity blocklist, one word per line.
+	UsernameProfanityFile string `mapstructure:"USERNAME_PROFANITY_FILE"`
+
+	// Matching engine of random inquiries. Defaults are used if not specified.
+	MatchingTopN                int `mapstructure:"MATCHING_TOP_N"`
+	MatchingMaxWaves            int `mapstructure:"MATCHING_MAX_WAVES"`
+	MatchingWaveIntervalSeconds int `mapstructure:"MATCHING_WAVE_INTERVAL_SECONDS"`
+	MatchingBaseRadiusKm        int `mapstructure:"MATCHING_BASE_RADIUS_KM"`
+	MatchingCandidatePoolSize   int `mapstructure:"MATCHING_CANDIDATE_POOL_SIZE"`
+
 	// Note: We are hardcoding app currency here. Since this app only operates in Taiwan for now.
 	Currency string `mapstructure:"CURRENCY"`
 
@@ -79,6 +116,135 @@ func (ac *AppConf) IsDevUser(username string) bool {
 	return false
 }
 
+func (ac *AppConf) GetAccessTokenTTL() time.Duration {
+	if ac.AccessTokenTTLMinutes <= 0 {
+		return time.Minute * 15
+	}
+
+	return time.Duration(ac.AccessTokenTTLMinutes) * time.Minute
+}
+
+func (ac *AppConf) GetRefreshTokenTTL() time.Duration {
+	if ac.RefreshTokenTTLDays <= 0 {
+		return time.Hour * 24 * 30
+	}
+
+	return time.Duration(ac.RefreshTokenTTLDays) * time.Hour * 24
+}
+
+// GetAppLocation time zone the app operates in, availability of providers are declared in this
+// time zone. UTC is used if `APP_TIME_ZONE` is not specified or invalid.
+func (ac *AppConf) GetAppLocation() *time.Location {
+	if len(ac.AppTimeZone) == 0 {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(ac.AppTimeZone)
+
+	if err != nil {
+		log.Warnf("invalid APP_TIME_ZONE %s, fallback to UTC", ac.AppTimeZone)
+
+		return time.UTC
+	}
+
+	return loc
+}
+
+// GetSMSSpoolPath path of the spool file `file` SMS driver writes to.
+func (ac *AppConf) GetSMSSpoolPath() string {
+	if len(ac.SMSSpoolPath) == 0 {
+		return filepath.Join(os.TempDir(), "darkpanda", "sms_spool.log")
+	}
+
+	return ac.SMSSpoolPath
+}
+
+type OTPLimits struct {
+	// Number of failed attempts allowed before the subject is locked out.
+	MaxAttempts      int
+	MaxAttemptsPerIP int
+
+	// Lockout duration doubles on each consecutive lockout, capped at `LockoutMax`.
+	LockoutBase time.Duration
+	LockoutMax  time.Duration
+
+	// Minimum interval between two verify code SMS sent to the same subject.
+	SendCooldown time.Duration
+
+	// Maximum number of verify code SMS sent to the same subject within an hour.
+	MaxSendsPerHour      int
+	MaxSendsPerHourPerIP int
+}
+
+func orDefault(val, def int) int {
+	if val <= 0 {
+		return def
+	}
+
+	return val
+}
+
+func (ac *AppConf) GetOTPLimits() OTPLimits {
+	return OTPLimits{
+		MaxAttempts:          orDefault(ac.OTPMaxAttempts, 5),
+		MaxAttemptsPerIP:     orDefault(ac.OTPMaxAttemptsPerIP, 20),
+		LockoutBase:          time.Duration(orDefault(ac.OTPLockoutBaseSeconds, 60)) * time.Second,
+		LockoutMax:           time.Duration(orDefault(ac.OTPLockoutMaxSeconds, 3600)) * time.Second,
+		SendCooldown:         time.Duration(orDefault(ac.OTPSendCooldownSeconds, 60)) * time.Second,
+		MaxSendsPerHour:      orDefault(ac.OTPMaxSendsPerHour, 5),
+		MaxSendsPerHourPerIP: orDefault(ac.OTPMaxSendsPerHourPerIP, 20),
+	}
+}
+
+type UsernamePolicy struct {
+	MinLength     int
+	MaxLength     int
+	ReservedWords []string
+	ProfanityFile string
+}
+
+func (ac *AppConf) GetUsernamePolicy() UsernamePolicy {
+	reserved := make([]string, 0)
+
+	for _, word := range strings.Split(ac.UsernameReservedWords, ",") {
+		if word = strings.TrimSpace(word); len(word) > 0 {
+			reserved = append(reserved, word)
+		}
+	}
+
+	return UsernamePolicy{
+		MinLength:     orDefault(ac.UsernameMinLength, 2),
+		MaxLength:     orDefault(ac.UsernameMaxLength, 20),
+		ReservedWords: reserved,
+		ProfanityFile: ac.UsernameProfanityFile,
+	}
+}
+
+type MatchingConf struct {
+	// Number of providers invited in each wave.
+	TopN int
+
+	// Inquiries are matched again in a wider radius on each wave until `MaxWaves` is reached.
+	MaxWaves     int
+	WaveInterval time.Duration
+
+	// Search radius of the first wave, doubles on each wave.
+	BaseRadiusKm float64
+
+	// Maximum number of nearest providers scored in each wave.
+	CandidatePoolSize int
+}
+
+func (ac *AppConf) GetMatchingConf() MatchingConf {
+	return MatchingConf{
+		TopN:              orDefault(ac.MatchingTopN, 5),
+		MaxWaves:          orDefault(ac.MatchingMaxWaves, 4),
+		WaveInterval:      time.Duration(orDefault(ac.MatchingWaveIntervalSeconds, 300)) * time.Second,
+		BaseRadiusKm:      float64(orDefault(ac.MatchingBaseRadiusKm, 5)),
+		CandidatePoolSize: orDefault(ac.MatchingCandidatePoolSize, 100),
+	}
+}
+
 var appConf AppConf
 
 // GetProjRootPath gets project root directory relative to `config/config.go`
@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TYPE user_role_type AS ENUM (
+	'admin',
+	'support',
+	'finance'
+);
+
+CREATE TABLE user_roles (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users (id) NOT NULL,
+	role user_role_type NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+CREATE UNIQUE INDEX idx_user_roles_user_id_role ON user_roles(user_id, role);
+
+CREATE TRIGGER user_roles_updated_at_set_timestamp
+BEFORE UPDATE ON user_roles
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
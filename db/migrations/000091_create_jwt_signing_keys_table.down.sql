@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS jwt_signing_keys;
+DROP TYPE IF EXISTS jwt_key_status;
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+CREATE TYPE jwt_key_status AS ENUM (
+	'standby',
+	'active',
+	'retired'
+);
+
+CREATE TABLE jwt_signing_keys (
+	id SERIAL PRIMARY KEY,
+	kid VARCHAR(64) NOT NULL UNIQUE,
+	secret TEXT NOT NULL,
+	status jwt_key_status NOT NULL DEFAULT 'standby',
+	activated_at timestamp,
+	retired_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN jwt_signing_keys.kid IS 'key id carried in the "kid" header of jwt signed by this key';
+
+-- Only one key can sign new tokens at a time.
+CREATE UNIQUE INDEX idx_jwt_signing_keys_active ON jwt_signing_keys(status) WHERE status = 'active';
+
+CREATE TRIGGER jwt_signing_keys_updated_at_set_timestamp
+BEFORE UPDATE ON jwt_signing_keys
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
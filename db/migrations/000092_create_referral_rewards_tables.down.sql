@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS referral_rewards;
+DROP TABLE IF EXISTS referral_reward_rules;
+DROP TYPE IF EXISTS referral_reward_recipient;
+DROP TYPE IF EXISTS referral_reward_event;
+
+COMMIT;
@@ -0,0 +1,61 @@
+BEGIN;
+
+CREATE TYPE referral_reward_event AS ENUM (
+	'phone_verified',
+	'first_service_completed'
+);
+
+CREATE TYPE referral_reward_recipient AS ENUM (
+	'invitor',
+	'invitee'
+);
+
+CREATE TABLE referral_reward_rules (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	event referral_reward_event NOT NULL,
+	recipient referral_reward_recipient NOT NULL DEFAULT 'invitor',
+	amount numeric(12, 2) NOT NULL,
+	enabled BOOLEAN NOT NULL DEFAULT true,
+	starts_at timestamp,
+	ends_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN referral_reward_rules.amount IS 'number of coins credited to the recipient';
+COMMENT ON COLUMN referral_reward_rules.starts_at IS 'rule applies to events occurred after this time, always applies if null';
+COMMENT ON COLUMN referral_reward_rules.ends_at IS 'rule applies to events occurred before this time, always applies if null';
+
+CREATE TRIGGER referral_reward_rules_updated_at_set_timestamp
+BEFORE UPDATE ON referral_reward_rules
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE referral_rewards (
+	id BIGSERIAL PRIMARY KEY,
+	rule_id INT REFERENCES referral_reward_rules(id) NOT NULL,
+	refcode_id INT REFERENCES user_refcodes(id) NOT NULL,
+	invitee_id INT REFERENCES users(id) NOT NULL,
+	recipient_id INT REFERENCES users(id) NOT NULL,
+	amount numeric(12, 2) NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN referral_rewards.amount IS 'number of coins credited, copied from the rule at the time of crediting';
+
+-- Each rule rewards an invitee at most once.
+CREATE UNIQUE INDEX idx_referral_rewards_rule_invitee ON referral_rewards(rule_id, invitee_id);
+CREATE INDEX idx_referral_rewards_recipient_id ON referral_rewards(recipient_id);
+
+CREATE TRIGGER referral_rewards_updated_at_set_timestamp
+BEFORE UPDATE ON referral_rewards
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS agency_members;
+DROP TABLE IF EXISTS agencies;
+
+COMMIT;
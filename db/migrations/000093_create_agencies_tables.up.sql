@@ -0,0 +1,42 @@
+BEGIN;
+
+CREATE TABLE agencies (
+	id SERIAL PRIMARY KEY,
+	manager_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	name VARCHAR(255) NOT NULL,
+	commission_rate numeric(5, 4) NOT NULL DEFAULT 0,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN agencies.manager_id IS 'user that owns the manager referral codes of the agency';
+COMMENT ON COLUMN agencies.commission_rate IS 'share of matching fees of member services paid to the agency, 0.3 means 30%';
+
+CREATE TRIGGER agencies_updated_at_set_timestamp
+BEFORE UPDATE ON agencies
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE agency_members (
+	id SERIAL PRIMARY KEY,
+	agency_id INT REFERENCES agencies(id) NOT NULL,
+	member_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	refcode_id INT REFERENCES user_refcodes(id) NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN agency_members.refcode_id IS 'manager referral code the member registered with';
+
+CREATE INDEX idx_agency_members_agency_id ON agency_members(agency_id);
+
+CREATE TRIGGER agency_members_updated_at_set_timestamp
+BEFORE UPDATE ON agency_members
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
@@ -0,0 +1,42 @@
+BEGIN;
+
+CREATE TABLE username_keys (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	normalized_username VARCHAR(255) NOT NULL UNIQUE,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN username_keys.normalized_username IS 'lower cased username with full-width characters folded to half-width, see usernamepolicy.Normalize';
+
+CREATE TRIGGER username_keys_updated_at_set_timestamp
+BEFORE UPDATE ON username_keys
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Backfill keys of existing users. Only full-width ASCII is folded here. If usernames of existing
+-- users collide after normalization, the earliest registered user keeps the key.
+INSERT INTO username_keys (user_id, normalized_username)
+SELECT DISTINCT ON (normalized_username)
+	id,
+	normalized_username
+FROM (
+	SELECT
+		id,
+		created_at,
+		lower(translate(
+			username,
+			'！＂＃＄％＆＇（）＊＋，－．／０１２３４５６７８９：；＜＝＞？＠ＡＢＣＤＥＦＧＨＩＪＫＬＭＮＯＰＱＲＳＴＵＶＷＸＹＺ［＼］＾＿｀ａｂｃｄｅｆｇｈｉｊｋｌｍｎｏｐｑｒｓｔｕｖｗｘｙｚ｛｜｝～　',
+			'!"#$%&''()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_`abcdefghijklmnopqrstuvwxyz{|}~ '
+		)) AS normalized_username
+	FROM
+		users
+	WHERE
+		deleted_at IS NULL
+) AS normalized_users
+ORDER BY normalized_username, created_at, id;
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_locations;
+DROP TYPE IF EXISTS location_precision;
+DROP EXTENSION IF EXISTS earthdistance;
+DROP EXTENSION IF EXISTS cube;
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+CREATE TYPE location_precision AS ENUM (
+	'city',
+	'district'
+);
+
+CREATE TABLE user_locations (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	lat double precision NOT NULL,
+	lng double precision NOT NULL,
+	precision location_precision NOT NULL DEFAULT 'district',
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN user_locations.lat IS 'latitude rounded according to precision, raw coordinates are never stored';
+COMMENT ON COLUMN user_locations.lng IS 'longitude rounded according to precision, raw coordinates are never stored';
+
+CREATE INDEX user_locations_earth_idx ON user_locations USING gist (ll_to_earth(lat, lng));
+
+CREATE TRIGGER user_locations_updated_at_set_timestamp
+BEFORE UPDATE ON user_locations
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
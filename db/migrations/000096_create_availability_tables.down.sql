@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS availability_blackouts;
+DROP TABLE IF EXISTS availability_slots;
+
+COMMIT;
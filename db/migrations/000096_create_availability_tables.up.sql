@@ -0,0 +1,43 @@
+BEGIN;
+
+CREATE TABLE availability_slots (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	start_time time NOT NULL,
+	end_time time NOT NULL CHECK (end_time > start_time),
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN availability_slots.weekday IS '0 is sunday, same as golang time.Weekday';
+COMMENT ON COLUMN availability_slots.start_time IS 'time of day in APP_TIME_ZONE';
+COMMENT ON COLUMN availability_slots.end_time IS 'time of day in APP_TIME_ZONE, slots crossing midnight should be split into two';
+
+CREATE INDEX availability_slots_user_id_idx ON availability_slots(user_id);
+
+CREATE TRIGGER availability_slots_updated_at_set_timestamp
+BEFORE UPDATE ON availability_slots
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE availability_blackouts (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	date date NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+CREATE UNIQUE INDEX availability_blackouts_user_id_date_idx ON availability_blackouts(user_id, date);
+
+CREATE TRIGGER availability_blackouts_updated_at_set_timestamp
+BEFORE UPDATE ON availability_blackouts
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS identity_verifications;
+DROP TYPE IF EXISTS identity_verification_status;
+
+COMMIT;
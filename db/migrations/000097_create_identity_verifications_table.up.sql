@@ -0,0 +1,41 @@
+BEGIN;
+
+CREATE TYPE identity_verification_status AS ENUM (
+	'pending',
+	'approved',
+	'rejected'
+);
+
+CREATE TABLE identity_verifications (
+	id SERIAL PRIMARY KEY,
+	uuid VARCHAR(60) UNIQUE NOT NULL,
+	user_id INT REFERENCES users(id) NOT NULL,
+	code VARCHAR(20) NOT NULL,
+	selfie_object VARCHAR(255) NOT NULL,
+	id_photo_object VARCHAR(255) NOT NULL,
+	status identity_verification_status NOT NULL DEFAULT 'pending',
+	reason TEXT,
+	reviewer_id INT REFERENCES users(id),
+	reviewed_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN identity_verifications.code IS 'code the user holds in the selfie';
+COMMENT ON COLUMN identity_verifications.selfie_object IS 'name of the private GCS object';
+COMMENT ON COLUMN identity_verifications.id_photo_object IS 'name of the private GCS object';
+COMMENT ON COLUMN identity_verifications.reason IS 'reason of rejection given by the reviewer';
+
+-- A user has at most one request waiting for review.
+CREATE UNIQUE INDEX identity_verifications_pending_user_id_idx ON identity_verifications(user_id) WHERE status = 'pending';
+
+CREATE INDEX identity_verifications_status_idx ON identity_verifications(status, created_at);
+
+CREATE TRIGGER identity_verifications_updated_at_set_timestamp
+BEFORE UPDATE ON identity_verifications
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE user_favorites (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	favorite_user_id INT REFERENCES users(id) NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+CREATE UNIQUE INDEX user_favorites_user_id_favorite_user_id_idx ON user_favorites (user_id, favorite_user_id);
+
+CREATE TRIGGER user_favorites_updated_at_set_timestamp
+BEFORE UPDATE ON user_favorites
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
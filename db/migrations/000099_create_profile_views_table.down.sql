@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS profile_views;
+
+COMMIT;
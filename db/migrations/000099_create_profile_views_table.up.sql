@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE profile_views (
+	id SERIAL PRIMARY KEY,
+	viewer_id INT REFERENCES users(id) NOT NULL,
+	viewee_id INT REFERENCES users(id) NOT NULL,
+	viewed_on date NOT NULL,
+	viewed_at timestamp NOT NULL DEFAULT NOW(),
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN profile_views.viewed_on IS 'date in app time zone, views are deduplicated per viewer per day';
+COMMENT ON COLUMN profile_views.viewed_at IS 'latest time the viewer viewed the profile on the date';
+
+CREATE UNIQUE INDEX profile_views_viewer_id_viewee_id_viewed_on_idx ON profile_views (viewer_id, viewee_id, viewed_on);
+CREATE INDEX profile_views_viewee_id_idx ON profile_views (viewee_id);
+
+CREATE TRIGGER profile_views_updated_at_set_timestamp
+BEFORE UPDATE ON profile_views
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
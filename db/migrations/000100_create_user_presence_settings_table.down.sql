@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_presence_settings;
+
+COMMIT;
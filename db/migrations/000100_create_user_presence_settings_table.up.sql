@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE user_presence_settings (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	hidden boolean NOT NULL DEFAULT false,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN user_presence_settings.hidden IS 'hidden users always appear offline to others';
+
+CREATE TRIGGER user_presence_settings_updated_at_set_timestamp
+BEFORE UPDATE ON user_presence_settings
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
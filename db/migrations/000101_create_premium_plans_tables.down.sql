@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS premium_purchases;
+DROP TABLE IF EXISTS premium_plans;
+
+COMMIT;
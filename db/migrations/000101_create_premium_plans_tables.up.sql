@@ -0,0 +1,47 @@
+BEGIN;
+
+CREATE TABLE premium_plans (
+	id SERIAL PRIMARY KEY,
+	name varchar(255) NOT NULL,
+	duration_days INT NOT NULL CHECK (duration_days > 0),
+	cost numeric(12, 2) NOT NULL CHECK (cost > 0),
+	enabled boolean NOT NULL DEFAULT true,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN premium_plans.cost IS 'number of coins deducted from user balance';
+
+CREATE TRIGGER premium_plans_updated_at_set_timestamp
+BEFORE UPDATE ON premium_plans
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE premium_purchases (
+	id SERIAL PRIMARY KEY,
+	uuid VARCHAR(60) UNIQUE NOT NULL,
+	user_id INT REFERENCES users(id) NOT NULL,
+	plan_id INT REFERENCES premium_plans(id) NOT NULL,
+	cost numeric(12, 2) NOT NULL,
+	starts_at timestamp NOT NULL,
+	expires_at timestamp NOT NULL,
+	expiry_reminded_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN premium_purchases.starts_at IS 'renewals purchased before expiry start from the previous expiry';
+COMMENT ON COLUMN premium_purchases.expiry_reminded_at IS 'time the member is reminded that the membership is about to expire';
+
+CREATE INDEX premium_purchases_user_id_idx ON premium_purchases (user_id);
+
+CREATE TRIGGER premium_purchases_updated_at_set_timestamp
+BEFORE UPDATE ON premium_purchases
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_restrictions;
+DROP TABLE IF EXISTS report_events;
+DROP TABLE IF EXISTS reports;
+DROP TYPE IF EXISTS report_status;
+DROP TYPE IF EXISTS report_category;
+DROP TYPE IF EXISTS report_target_type;
+
+COMMIT;
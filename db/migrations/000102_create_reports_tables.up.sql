@@ -0,0 +1,103 @@
+BEGIN;
+
+CREATE TYPE report_target_type AS ENUM (
+	'profile',
+	'chat_message',
+	'image',
+	'service'
+);
+
+CREATE TYPE report_category AS ENUM (
+	'harassment',
+	'spam',
+	'fake_profile',
+	'inappropriate_content',
+	'scam',
+	'underage',
+	'other'
+);
+
+CREATE TYPE report_status AS ENUM (
+	'open',
+	'in_review',
+	'resolved',
+	'dismissed'
+);
+
+CREATE TABLE reports (
+	id SERIAL PRIMARY KEY,
+	uuid VARCHAR(60) UNIQUE NOT NULL,
+	reporter_id INT REFERENCES users(id) NOT NULL,
+	reported_user_id INT REFERENCES users(id) NOT NULL,
+	target_type report_target_type NOT NULL,
+	target_ref VARCHAR(255),
+	category report_category NOT NULL,
+	description text,
+	status report_status NOT NULL DEFAULT 'open',
+	assignee_id INT REFERENCES users(id),
+	resolution_note text,
+	closed_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN reports.target_ref IS 'reported content, service uuid, image url or <channel_uuid>/<message_id>. NULL for profile';
+
+CREATE INDEX reports_reported_user_id_idx ON reports (reported_user_id);
+CREATE INDEX reports_status_idx ON reports (status);
+
+CREATE TRIGGER reports_updated_at_set_timestamp
+BEFORE UPDATE ON reports
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE report_events (
+	id SERIAL PRIMARY KEY,
+	report_id INT REFERENCES reports(id) NOT NULL,
+	actor_id INT REFERENCES users(id),
+	action VARCHAR(60) NOT NULL,
+	from_status report_status,
+	to_status report_status,
+	note text,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN report_events.actor_id IS 'NULL if the action is taken by the system';
+
+CREATE INDEX report_events_report_id_idx ON report_events (report_id);
+
+CREATE TRIGGER report_events_updated_at_set_timestamp
+BEFORE UPDATE ON report_events
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE user_restrictions (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	reason text NOT NULL,
+	expires_at timestamp NOT NULL,
+	created_by INT REFERENCES users(id),
+	lifted_by INT REFERENCES users(id),
+	lifted_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON TABLE user_restrictions IS 'restricted users can not emit inquiries, pickup inquiries or send chat messages';
+COMMENT ON COLUMN user_restrictions.created_by IS 'NULL if the restriction is imposed automatically by repeated reports';
+
+CREATE INDEX user_restrictions_user_id_idx ON user_restrictions (user_id);
+
+CREATE TRIGGER user_restrictions_updated_at_set_timestamp
+BEFORE UPDATE ON user_restrictions
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
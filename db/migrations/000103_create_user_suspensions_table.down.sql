@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS user_suspensions;
+DROP TYPE IF EXISTS user_suspension_type;
+
+COMMIT;
@@ -0,0 +1,33 @@
+BEGIN;
+
+CREATE TYPE user_suspension_type AS ENUM (
+	'suspend',
+	'ban'
+);
+
+CREATE TABLE user_suspensions (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	suspension_type user_suspension_type NOT NULL,
+	reason text NOT NULL,
+	expires_at timestamp,
+	created_by INT REFERENCES users(id) NOT NULL,
+	lifted_by INT REFERENCES users(id),
+	lifted_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON TABLE user_suspensions IS 'suspended and banned users can not access any API';
+COMMENT ON COLUMN user_suspensions.expires_at IS 'NULL if the suspension never expires';
+
+CREATE INDEX user_suspensions_user_id_idx ON user_suspensions (user_id);
+
+CREATE TRIGGER user_suspensions_updated_at_set_timestamp
+BEFORE UPDATE ON user_suspensions
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
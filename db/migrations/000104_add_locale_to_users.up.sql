@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS locale VARCHAR(16) NOT NULL DEFAULT 'zh-TW';
+
+COMMIT;
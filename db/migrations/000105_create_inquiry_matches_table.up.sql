@@ -0,0 +1,38 @@
+BEGIN;
+
+CREATE TABLE inquiry_matches (
+	id SERIAL PRIMARY KEY,
+	inquiry_id INT REFERENCES service_inquiries(id) NOT NULL,
+	user_id INT REFERENCES users(id) NOT NULL,
+	wave INT NOT NULL,
+	score double precision NOT NULL,
+	distance_km double precision,
+	available boolean NOT NULL,
+	offers_service_type boolean NOT NULL,
+	rating double precision,
+	responsiveness double precision NOT NULL,
+	invited_at timestamp,
+	responded_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp,
+
+	UNIQUE (inquiry_id, user_id)
+);
+
+COMMENT ON TABLE inquiry_matches IS 'providers scored for random inquiries, kept for analysing the matching engine';
+COMMENT ON COLUMN inquiry_matches.wave IS 'wave of the latest scoring, search radius widens on each wave';
+COMMENT ON COLUMN inquiry_matches.distance_km IS 'NULL if the inquiry has no location';
+COMMENT ON COLUMN inquiry_matches.rating IS 'NULL if the provider has not been rated';
+COMMENT ON COLUMN inquiry_matches.invited_at IS 'NULL if the provider was not ranked high enough to be invited';
+COMMENT ON COLUMN inquiry_matches.responded_at IS 'time the invited provider picked up the inquiry';
+
+CREATE INDEX inquiry_matches_user_id_idx ON inquiry_matches (user_id, invited_at);
+
+CREATE TRIGGER inquiry_matches_updated_at_set_timestamp
+BEFORE UPDATE ON inquiry_matches
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
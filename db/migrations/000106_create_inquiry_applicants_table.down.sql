@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS inquiry_applicants;
+DROP TYPE IF EXISTS inquiry_applicant_status;
+
+COMMIT;
@@ -0,0 +1,32 @@
+BEGIN;
+
+CREATE TYPE inquiry_applicant_status AS ENUM (
+	'applied',
+	'accepted',
+	'declined'
+);
+
+CREATE TABLE inquiry_applicants (
+	id SERIAL PRIMARY KEY,
+	inquiry_id INT REFERENCES service_inquiries(id) NOT NULL,
+	applicant_id INT REFERENCES users(id) NOT NULL,
+	status inquiry_applicant_status NOT NULL DEFAULT 'applied',
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp,
+
+	UNIQUE (inquiry_id, applicant_id)
+);
+
+COMMENT ON TABLE inquiry_applicants IS 'providers who picked up random inquiries, the inquirer agrees to chat with one of them';
+COMMENT ON COLUMN inquiry_applicants.status IS 'applicants other than the accepted one are declined once the inquirer agrees to chat';
+
+CREATE INDEX inquiry_applicants_applicant_id_idx ON inquiry_applicants (applicant_id);
+
+CREATE TRIGGER inquiry_applicants_updated_at_set_timestamp
+BEFORE UPDATE ON inquiry_applicants
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS services_record_status_transition ON services;
+DROP TRIGGER IF EXISTS service_inquiries_record_status_transition ON service_inquiries;
+DROP FUNCTION IF EXISTS record_status_transition();
+DROP TABLE IF EXISTS status_transitions;
+DROP TYPE IF EXISTS transition_entity_type;
+
+COMMIT;
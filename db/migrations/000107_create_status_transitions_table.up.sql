@@ -0,0 +1,97 @@
+BEGIN;
+
+CREATE TYPE transition_entity_type AS ENUM (
+	'inquiry',
+	'service'
+);
+
+CREATE TABLE status_transitions (
+	id BIGSERIAL PRIMARY KEY,
+	entity_type transition_entity_type NOT NULL,
+	entity_id INT NOT NULL,
+	from_status VARCHAR(255),
+	to_status VARCHAR(255) NOT NULL,
+	event VARCHAR(255),
+	actor_id INT REFERENCES users(id),
+	cause TEXT,
+
+	created_at timestamp NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE status_transitions IS 'audit trail of inquiry and service status, recorded by trigger in the same transaction as the status update';
+COMMENT ON COLUMN status_transitions.from_status IS 'NULL when the inquiry or service is created';
+COMMENT ON COLUMN status_transitions.actor_id IS 'NULL if the transition is made by the system, e.g. workers';
+
+CREATE INDEX status_transitions_entity_idx ON status_transitions (entity_type, entity_id, created_at);
+
+-- Actor, event and cause are annotated by the application via settings local to the transaction:
+--   darkpanda.transition_actor_uuid
+--   darkpanda.transition_event
+--   darkpanda.transition_cause
+CREATE OR REPLACE FUNCTION record_status_transition()
+RETURNS TRIGGER AS $$
+DECLARE
+	_entity_type transition_entity_type;
+	_from_status VARCHAR(255);
+	_to_status VARCHAR(255);
+	_actor_uuid TEXT := NULLIF(current_setting('darkpanda.transition_actor_uuid', true), '');
+	_event TEXT := NULLIF(current_setting('darkpanda.transition_event', true), '');
+	_cause TEXT := NULLIF(current_setting('darkpanda.transition_cause', true), '');
+BEGIN
+	IF TG_TABLE_NAME = 'service_inquiries' THEN
+		_entity_type := 'inquiry';
+		_to_status := NEW.inquiry_status::text;
+
+		IF TG_OP = 'UPDATE' THEN
+			_from_status := OLD.inquiry_status::text;
+		END IF;
+	ELSE
+		_entity_type := 'service';
+		_to_status := NEW.service_status::text;
+
+		IF TG_OP = 'UPDATE' THEN
+			_from_status := OLD.service_status::text;
+		END IF;
+	END IF;
+
+	IF TG_OP = 'UPDATE' AND _from_status IS NOT DISTINCT FROM _to_status THEN
+		RETURN NEW;
+	END IF;
+
+	IF TG_OP = 'INSERT' AND _event IS NULL THEN
+		_event := 'create';
+	END IF;
+
+	INSERT INTO status_transitions (
+		entity_type,
+		entity_id,
+		from_status,
+		to_status,
+		event,
+		actor_id,
+		cause
+	) VALUES (
+		_entity_type,
+		NEW.id,
+		_from_status,
+		_to_status,
+		_event,
+		(SELECT id FROM users WHERE uuid = _actor_uuid),
+		_cause
+	);
+
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER service_inquiries_record_status_transition
+AFTER INSERT OR UPDATE OF inquiry_status ON service_inquiries
+FOR EACH ROW
+EXECUTE PROCEDURE record_status_transition();
+
+CREATE TRIGGER services_record_status_transition
+AFTER INSERT OR UPDATE OF service_status ON services
+FOR EACH ROW
+EXECUTE PROCEDURE record_status_transition();
+
+COMMIT;
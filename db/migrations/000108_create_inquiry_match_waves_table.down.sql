@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS inquiry_match_waves;
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE inquiry_match_waves (
+	inquiry_id INT REFERENCES service_inquiries(id) NOT NULL,
+	wave INT NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+
+	PRIMARY KEY (inquiry_id, wave)
+);
+
+COMMENT ON TABLE inquiry_match_waves IS 'waves claimed by the matching engine, each wave of an inquiry is run once';
+
+COMMIT;
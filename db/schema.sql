@@ -877,3 +877,778 @@ ADD COLUMN currency TEXT;
 
 COMMIT;
 
+
+BEGIN;
+
+CREATE TYPE user_role_type AS ENUM (
+	'admin',
+	'support',
+	'finance'
+);
+
+CREATE TABLE user_roles (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users (id) NOT NULL,
+	role user_role_type NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+CREATE UNIQUE INDEX idx_user_roles_user_id_role ON user_roles(user_id, role);
+
+CREATE TRIGGER user_roles_updated_at_set_timestamp
+BEFORE UPDATE ON user_roles
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TYPE jwt_key_status AS ENUM (
+	'standby',
+	'active',
+	'retired'
+);
+
+CREATE TABLE jwt_signing_keys (
+	id SERIAL PRIMARY KEY,
+	kid VARCHAR(64) NOT NULL UNIQUE,
+	secret TEXT NOT NULL,
+	status jwt_key_status NOT NULL DEFAULT 'standby',
+	activated_at timestamp,
+	retired_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN jwt_signing_keys.kid IS 'key id carried in the "kid" header of jwt signed by this key';
+
+-- Only one key can sign new tokens at a time.
+CREATE UNIQUE INDEX idx_jwt_signing_keys_active ON jwt_signing_keys(status) WHERE status = 'active';
+
+CREATE TRIGGER jwt_signing_keys_updated_at_set_timestamp
+BEFORE UPDATE ON jwt_signing_keys
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TYPE referral_reward_event AS ENUM (
+	'phone_verified',
+	'first_service_completed'
+);
+
+CREATE TYPE referral_reward_recipient AS ENUM (
+	'invitor',
+	'invitee'
+);
+
+CREATE TABLE referral_reward_rules (
+	id SERIAL PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	event referral_reward_event NOT NULL,
+	recipient referral_reward_recipient NOT NULL DEFAULT 'invitor',
+	amount numeric(12, 2) NOT NULL,
+	enabled BOOLEAN NOT NULL DEFAULT true,
+	starts_at timestamp,
+	ends_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN referral_reward_rules.amount IS 'number of coins credited to the recipient';
+COMMENT ON COLUMN referral_reward_rules.starts_at IS 'rule applies to events occurred after this time, always applies if null';
+COMMENT ON COLUMN referral_reward_rules.ends_at IS 'rule applies to events occurred before this time, always applies if null';
+
+CREATE TRIGGER referral_reward_rules_updated_at_set_timestamp
+BEFORE UPDATE ON referral_reward_rules
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE referral_rewards (
+	id BIGSERIAL PRIMARY KEY,
+	rule_id INT REFERENCES referral_reward_rules(id) NOT NULL,
+	refcode_id INT REFERENCES user_refcodes(id) NOT NULL,
+	invitee_id INT REFERENCES users(id) NOT NULL,
+	recipient_id INT REFERENCES users(id) NOT NULL,
+	amount numeric(12, 2) NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN referral_rewards.amount IS 'number of coins credited, copied from the rule at the time of crediting';
+
+-- Each rule rewards an invitee at most once.
+CREATE UNIQUE INDEX idx_referral_rewards_rule_invitee ON referral_rewards(rule_id, invitee_id);
+CREATE INDEX idx_referral_rewards_recipient_id ON referral_rewards(recipient_id);
+
+CREATE TRIGGER referral_rewards_updated_at_set_timestamp
+BEFORE UPDATE ON referral_rewards
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE agencies (
+	id SERIAL PRIMARY KEY,
+	manager_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	name VARCHAR(255) NOT NULL,
+	commission_rate numeric(5, 4) NOT NULL DEFAULT 0,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN agencies.manager_id IS 'user that owns the manager referral codes of the agency';
+COMMENT ON COLUMN agencies.commission_rate IS 'share of matching fees of member services paid to the agency, 0.3 means 30%';
+
+CREATE TRIGGER agencies_updated_at_set_timestamp
+BEFORE UPDATE ON agencies
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE agency_members (
+	id SERIAL PRIMARY KEY,
+	agency_id INT REFERENCES agencies(id) NOT NULL,
+	member_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	refcode_id INT REFERENCES user_refcodes(id) NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN agency_members.refcode_id IS 'manager referral code the member registered with';
+
+CREATE INDEX idx_agency_members_agency_id ON agency_members(agency_id);
+
+CREATE TRIGGER agency_members_updated_at_set_timestamp
+BEFORE UPDATE ON agency_members
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE username_keys (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	normalized_username VARCHAR(255) NOT NULL UNIQUE,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN username_keys.normalized_username IS 'lower cased username with full-width characters folded to half-width, see usernamepolicy.Normalize';
+
+CREATE TRIGGER username_keys_updated_at_set_timestamp
+BEFORE UPDATE ON username_keys
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+-- Backfill keys of existing users. Only full-width ASCII is folded here. If usernames of existing
+-- users collide after normalization, the earliest registered user keeps the key.
+INSERT INTO username_keys (user_id, normalized_username)
+SELECT DISTINCT ON (normalized_username)
+	id,
+	normalized_username
+FROM (
+	SELECT
+		id,
+		created_at,
+		lower(translate(
+			username,
+			'！＂＃＄％＆＇（）＊＋，－．／０１２３４５６７８９：；＜＝＞？＠ＡＢＣＤＥＦＧＨＩＪＫＬＭＮＯＰＱＲＳＴＵＶＷＸＹＺ［＼］＾＿｀ａｂｃｄｅｆｇｈｉｊｋｌｍｎｏｐｑｒｓｔｕｖｗｘｙｚ｛｜｝～　',
+			'!"#$%&''()*+,-./0123456789:;<=>?@ABCDEFGHIJKLMNOPQRSTUVWXYZ[\]^_`abcdefghijklmnopqrstuvwxyz{|}~ '
+		)) AS normalized_username
+	FROM
+		users
+	WHERE
+		deleted_at IS NULL
+) AS normalized_users
+ORDER BY normalized_username, created_at, id;
+
+COMMIT;
+
+BEGIN;
+
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+CREATE TYPE location_precision AS ENUM (
+	'city',
+	'district'
+);
+
+CREATE TABLE user_locations (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	lat double precision NOT NULL,
+	lng double precision NOT NULL,
+	precision location_precision NOT NULL DEFAULT 'district',
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN user_locations.lat IS 'latitude rounded according to precision, raw coordinates are never stored';
+COMMENT ON COLUMN user_locations.lng IS 'longitude rounded according to precision, raw coordinates are never stored';
+
+CREATE INDEX user_locations_earth_idx ON user_locations USING gist (ll_to_earth(lat, lng));
+
+CREATE TRIGGER user_locations_updated_at_set_timestamp
+BEFORE UPDATE ON user_locations
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE availability_slots (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+	start_time time NOT NULL,
+	end_time time NOT NULL CHECK (end_time > start_time),
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN availability_slots.weekday IS '0 is sunday, same as golang time.Weekday';
+COMMENT ON COLUMN availability_slots.start_time IS 'time of day in APP_TIME_ZONE';
+COMMENT ON COLUMN availability_slots.end_time IS 'time of day in APP_TIME_ZONE, slots crossing midnight should be split into two';
+
+CREATE INDEX availability_slots_user_id_idx ON availability_slots(user_id);
+
+CREATE TRIGGER availability_slots_updated_at_set_timestamp
+BEFORE UPDATE ON availability_slots
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE availability_blackouts (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	date date NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+CREATE UNIQUE INDEX availability_blackouts_user_id_date_idx ON availability_blackouts(user_id, date);
+
+CREATE TRIGGER availability_blackouts_updated_at_set_timestamp
+BEFORE UPDATE ON availability_blackouts
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TYPE identity_verification_status AS ENUM (
+	'pending',
+	'approved',
+	'rejected'
+);
+
+CREATE TABLE identity_verifications (
+	id SERIAL PRIMARY KEY,
+	uuid VARCHAR(60) UNIQUE NOT NULL,
+	user_id INT REFERENCES users(id) NOT NULL,
+	code VARCHAR(20) NOT NULL,
+	selfie_object VARCHAR(255) NOT NULL,
+	id_photo_object VARCHAR(255) NOT NULL,
+	status identity_verification_status NOT NULL DEFAULT 'pending',
+	reason TEXT,
+	reviewer_id INT REFERENCES users(id),
+	reviewed_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN identity_verifications.code IS 'code the user holds in the selfie';
+COMMENT ON COLUMN identity_verifications.selfie_object IS 'name of the private GCS object';
+COMMENT ON COLUMN identity_verifications.id_photo_object IS 'name of the private GCS object';
+COMMENT ON COLUMN identity_verifications.reason IS 'reason of rejection given by the reviewer';
+
+-- A user has at most one request waiting for review.
+CREATE UNIQUE INDEX identity_verifications_pending_user_id_idx ON identity_verifications(user_id) WHERE status = 'pending';
+
+CREATE INDEX identity_verifications_status_idx ON identity_verifications(status, created_at);
+
+CREATE TRIGGER identity_verifications_updated_at_set_timestamp
+BEFORE UPDATE ON identity_verifications
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE user_favorites (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	favorite_user_id INT REFERENCES users(id) NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+CREATE UNIQUE INDEX user_favorites_user_id_favorite_user_id_idx ON user_favorites (user_id, favorite_user_id);
+
+CREATE TRIGGER user_favorites_updated_at_set_timestamp
+BEFORE UPDATE ON user_favorites
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE profile_views (
+	id SERIAL PRIMARY KEY,
+	viewer_id INT REFERENCES users(id) NOT NULL,
+	viewee_id INT REFERENCES users(id) NOT NULL,
+	viewed_on date NOT NULL,
+	viewed_at timestamp NOT NULL DEFAULT NOW(),
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN profile_views.viewed_on IS 'date in app time zone, views are deduplicated per viewer per day';
+COMMENT ON COLUMN profile_views.viewed_at IS 'latest time the viewer viewed the profile on the date';
+
+CREATE UNIQUE INDEX profile_views_viewer_id_viewee_id_viewed_on_idx ON profile_views (viewer_id, viewee_id, viewed_on);
+CREATE INDEX profile_views_viewee_id_idx ON profile_views (viewee_id);
+
+CREATE TRIGGER profile_views_updated_at_set_timestamp
+BEFORE UPDATE ON profile_views
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE user_presence_settings (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL UNIQUE,
+	hidden boolean NOT NULL DEFAULT false,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN user_presence_settings.hidden IS 'hidden users always appear offline to others';
+
+CREATE TRIGGER user_presence_settings_updated_at_set_timestamp
+BEFORE UPDATE ON user_presence_settings
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE premium_plans (
+	id SERIAL PRIMARY KEY,
+	name varchar(255) NOT NULL,
+	duration_days INT NOT NULL CHECK (duration_days > 0),
+	cost numeric(12, 2) NOT NULL CHECK (cost > 0),
+	enabled boolean NOT NULL DEFAULT true,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN premium_plans.cost IS 'number of coins deducted from user balance';
+
+CREATE TRIGGER premium_plans_updated_at_set_timestamp
+BEFORE UPDATE ON premium_plans
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE premium_purchases (
+	id SERIAL PRIMARY KEY,
+	uuid VARCHAR(60) UNIQUE NOT NULL,
+	user_id INT REFERENCES users(id) NOT NULL,
+	plan_id INT REFERENCES premium_plans(id) NOT NULL,
+	cost numeric(12, 2) NOT NULL,
+	starts_at timestamp NOT NULL,
+	expires_at timestamp NOT NULL,
+	expiry_reminded_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN premium_purchases.starts_at IS 'renewals purchased before expiry start from the previous expiry';
+COMMENT ON COLUMN premium_purchases.expiry_reminded_at IS 'time the member is reminded that the membership is about to expire';
+
+CREATE INDEX premium_purchases_user_id_idx ON premium_purchases (user_id);
+
+CREATE TRIGGER premium_purchases_updated_at_set_timestamp
+BEFORE UPDATE ON premium_purchases
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TYPE report_target_type AS ENUM (
+	'profile',
+	'chat_message',
+	'image',
+	'service'
+);
+
+CREATE TYPE report_category AS ENUM (
+	'harassment',
+	'spam',
+	'fake_profile',
+	'inappropriate_content',
+	'scam',
+	'underage',
+	'other'
+);
+
+CREATE TYPE report_status AS ENUM (
+	'open',
+	'in_review',
+	'resolved',
+	'dismissed'
+);
+
+CREATE TABLE reports (
+	id SERIAL PRIMARY KEY,
+	uuid VARCHAR(60) UNIQUE NOT NULL,
+	reporter_id INT REFERENCES users(id) NOT NULL,
+	reported_user_id INT REFERENCES users(id) NOT NULL,
+	target_type report_target_type NOT NULL,
+	target_ref VARCHAR(255),
+	category report_category NOT NULL,
+	description text,
+	status report_status NOT NULL DEFAULT 'open',
+	assignee_id INT REFERENCES users(id),
+	resolution_note text,
+	closed_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN reports.target_ref IS 'reported content, service uuid, image url or <channel_uuid>/<message_id>. NULL for profile';
+
+CREATE INDEX reports_reported_user_id_idx ON reports (reported_user_id);
+CREATE INDEX reports_status_idx ON reports (status);
+
+CREATE TRIGGER reports_updated_at_set_timestamp
+BEFORE UPDATE ON reports
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE report_events (
+	id SERIAL PRIMARY KEY,
+	report_id INT REFERENCES reports(id) NOT NULL,
+	actor_id INT REFERENCES users(id),
+	action VARCHAR(60) NOT NULL,
+	from_status report_status,
+	to_status report_status,
+	note text,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON COLUMN report_events.actor_id IS 'NULL if the action is taken by the system';
+
+CREATE INDEX report_events_report_id_idx ON report_events (report_id);
+
+CREATE TRIGGER report_events_updated_at_set_timestamp
+BEFORE UPDATE ON report_events
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+CREATE TABLE user_restrictions (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	reason text NOT NULL,
+	expires_at timestamp NOT NULL,
+	created_by INT REFERENCES users(id),
+	lifted_by INT REFERENCES users(id),
+	lifted_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON TABLE user_restrictions IS 'restricted users can not emit inquiries, pickup inquiries or send chat messages';
+COMMENT ON COLUMN user_restrictions.created_by IS 'NULL if the restriction is imposed automatically by repeated reports';
+
+CREATE INDEX user_restrictions_user_id_idx ON user_restrictions (user_id);
+
+CREATE TRIGGER user_restrictions_updated_at_set_timestamp
+BEFORE UPDATE ON user_restrictions
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TYPE user_suspension_type AS ENUM (
+	'suspend',
+	'ban'
+);
+
+CREATE TABLE user_suspensions (
+	id SERIAL PRIMARY KEY,
+	user_id INT REFERENCES users(id) NOT NULL,
+	suspension_type user_suspension_type NOT NULL,
+	reason text NOT NULL,
+	expires_at timestamp,
+	created_by INT REFERENCES users(id) NOT NULL,
+	lifted_by INT REFERENCES users(id),
+	lifted_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp
+);
+
+COMMENT ON TABLE user_suspensions IS 'suspended and banned users can not access any API';
+COMMENT ON COLUMN user_suspensions.expires_at IS 'NULL if the suspension never expires';
+
+CREATE INDEX user_suspensions_user_id_idx ON user_suspensions (user_id);
+
+CREATE TRIGGER user_suspensions_updated_at_set_timestamp
+BEFORE UPDATE ON user_suspensions
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+ALTER TABLE users
+ADD COLUMN IF NOT EXISTS locale VARCHAR(16) NOT NULL DEFAULT 'zh-TW';
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE inquiry_matches (
+	id SERIAL PRIMARY KEY,
+	inquiry_id INT REFERENCES service_inquiries(id) NOT NULL,
+	user_id INT REFERENCES users(id) NOT NULL,
+	wave INT NOT NULL,
+	score double precision NOT NULL,
+	distance_km double precision,
+	available boolean NOT NULL,
+	offers_service_type boolean NOT NULL,
+	rating double precision,
+	responsiveness double precision NOT NULL,
+	invited_at timestamp,
+	responded_at timestamp,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp,
+
+	UNIQUE (inquiry_id, user_id)
+);
+
+COMMENT ON TABLE inquiry_matches IS 'providers scored for random inquiries, kept for analysing the matching engine';
+COMMENT ON COLUMN inquiry_matches.wave IS 'wave of the latest scoring, search radius widens on each wave';
+COMMENT ON COLUMN inquiry_matches.distance_km IS 'NULL if the inquiry has no location';
+COMMENT ON COLUMN inquiry_matches.rating IS 'NULL if the provider has not been rated';
+COMMENT ON COLUMN inquiry_matches.invited_at IS 'NULL if the provider was not ranked high enough to be invited';
+COMMENT ON COLUMN inquiry_matches.responded_at IS 'time the invited provider picked up the inquiry';
+
+CREATE INDEX inquiry_matches_user_id_idx ON inquiry_matches (user_id, invited_at);
+
+CREATE TRIGGER inquiry_matches_updated_at_set_timestamp
+BEFORE UPDATE ON inquiry_matches
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TYPE inquiry_applicant_status AS ENUM (
+	'applied',
+	'accepted',
+	'declined'
+);
+
+CREATE TABLE inquiry_applicants (
+	id SERIAL PRIMARY KEY,
+	inquiry_id INT REFERENCES service_inquiries(id) NOT NULL,
+	applicant_id INT REFERENCES users(id) NOT NULL,
+	status inquiry_applicant_status NOT NULL DEFAULT 'applied',
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+	updated_at timestamp NULL DEFAULT current_timestamp,
+	deleted_at timestamp,
+
+	UNIQUE (inquiry_id, applicant_id)
+);
+
+COMMENT ON TABLE inquiry_applicants IS 'providers who picked up random inquiries, the inquirer agrees to chat with one of them';
+COMMENT ON COLUMN inquiry_applicants.status IS 'applicants other than the accepted one are declined once the inquirer agrees to chat';
+
+CREATE INDEX inquiry_applicants_applicant_id_idx ON inquiry_applicants (applicant_id);
+
+CREATE TRIGGER inquiry_applicants_updated_at_set_timestamp
+BEFORE UPDATE ON inquiry_applicants
+FOR EACH ROW
+EXECUTE PROCEDURE trigger_set_timestamp();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TYPE transition_entity_type AS ENUM (
+	'inquiry',
+	'service'
+);
+
+CREATE TABLE status_transitions (
+	id BIGSERIAL PRIMARY KEY,
+	entity_type transition_entity_type NOT NULL,
+	entity_id INT NOT NULL,
+	from_status VARCHAR(255),
+	to_status VARCHAR(255) NOT NULL,
+	event VARCHAR(255),
+	actor_id INT REFERENCES users(id),
+	cause TEXT,
+
+	created_at timestamp NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE status_transitions IS 'audit trail of inquiry and service status, recorded by trigger in the same transaction as the status update';
+COMMENT ON COLUMN status_transitions.from_status IS 'NULL when the inquiry or service is created';
+COMMENT ON COLUMN status_transitions.actor_id IS 'NULL if the transition is made by the system, e.g. workers';
+
+CREATE INDEX status_transitions_entity_idx ON status_transitions (entity_type, entity_id, created_at);
+
+-- Actor, event and cause are annotated by the application via settings local to the transaction:
+--   darkpanda.transition_actor_uuid
+--   darkpanda.transition_event
+--   darkpanda.transition_cause
+CREATE OR REPLACE FUNCTION record_status_transition()
+RETURNS TRIGGER AS $$
+DECLARE
+	_entity_type transition_entity_type;
+	_from_status VARCHAR(255);
+	_to_status VARCHAR(255);
+	_actor_uuid TEXT := NULLIF(current_setting('darkpanda.transition_actor_uuid', true), '');
+	_event TEXT := NULLIF(current_setting('darkpanda.transition_event', true), '');
+	_cause TEXT := NULLIF(current_setting('darkpanda.transition_cause', true), '');
+BEGIN
+	IF TG_TABLE_NAME = 'service_inquiries' THEN
+		_entity_type := 'inquiry';
+		_to_status := NEW.inquiry_status::text;
+
+		IF TG_OP = 'UPDATE' THEN
+			_from_status := OLD.inquiry_status::text;
+		END IF;
+	ELSE
+		_entity_type := 'service';
+		_to_status := NEW.service_status::text;
+
+		IF TG_OP = 'UPDATE' THEN
+			_from_status := OLD.service_status::text;
+		END IF;
+	END IF;
+
+	IF TG_OP = 'UPDATE' AND _from_status IS NOT DISTINCT FROM _to_status THEN
+		RETURN NEW;
+	END IF;
+
+	IF TG_OP = 'INSERT' AND _event IS NULL THEN
+		_event := 'create';
+	END IF;
+
+	INSERT INTO status_transitions (
+		entity_type,
+		entity_id,
+		from_status,
+		to_status,
+		event,
+		actor_id,
+		cause
+	) VALUES (
+		_entity_type,
+		NEW.id,
+		_from_status,
+		_to_status,
+		_event,
+		(SELECT id FROM users WHERE uuid = _actor_uuid),
+		_cause
+	);
+
+	RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER service_inquiries_record_status_transition
+AFTER INSERT OR UPDATE OF inquiry_status ON service_inquiries
+FOR EACH ROW
+EXECUTE PROCEDURE record_status_transition();
+
+CREATE TRIGGER services_record_status_transition
+AFTER INSERT OR UPDATE OF service_status ON services
+FOR EACH ROW
+EXECUTE PROCEDURE record_status_transition();
+
+COMMIT;
+
+BEGIN;
+
+CREATE TABLE inquiry_match_waves (
+	inquiry_id INT REFERENCES service_inquiries(id) NOT NULL,
+	wave INT NOT NULL,
+
+	created_at timestamp NOT NULL DEFAULT NOW(),
+
+	PRIMARY KEY (inquiry_id, wave)
+);
+
+COMMENT ON TABLE inquiry_match_waves IS 'waves claimed by the matching engine, each wave of an inquiry is run once';
+
+COMMIT;
@@ -0,0 +1,213 @@
+package admintests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/admin"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/verification"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type VerificationsTestSuite struct {
+	suite.Suite
+	depCon container.Container
+}
+
+func (suite *VerificationsTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+// createPendingVerification submits the verification of a new female user. The female user is placed
+// in a region of her own so that she can be looked up via `GetGirls`.
+func (suite *VerificationsTestSuite) createPendingVerification() (models.User, string, *models.IdentityVerification) {
+	female := util.CreateTestUser(suite.T(), models.GenderFemale)
+	region := util.GenRandStringRune(12)
+
+	if _, err := db.GetDB().Exec(`UPDATE users SET region = $1 WHERE id = $2;`, region, female.ID); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	v, err := verification.NewIdentityVerificationDAO(db.GetDB()).CreateVerification(contracts.CreateIdentityVerificationParams{
+		Uuid:          util.GenRandStringRune(10),
+		UserID:        female.ID,
+		Code:          "1234",
+		SelfieObject:  "identity_verifications/selfie.png",
+		IDPhotoObject: "identity_verifications/id_photo.png",
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return female, region, v
+}
+
+func (suite *VerificationsTestSuite) decide(uuid string, body url.Values) *httptest.ResponseRecorder {
+	reviewer := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, err := util.ComposeTestRequest("PUT", "/v1/admin/verifications/"+uuid, &body, map[string]string{})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	c.Request = req
+	c.Params = gin.Params{{Key: "uuid", Value: uuid}}
+	c.Set("uuid", reviewer.Uuid)
+
+	admin.DecideVerificationHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	return w
+}
+
+func errCodeOf(w *httptest.ResponseRecorder) string {
+	resp := struct {
+		ErrCode string `json:"err_code"`
+	}{}
+
+	json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return resp.ErrCode
+}
+
+// verifiedInGirls looks up the verified flag of the girl listed by `GetGirls`.
+func (suite *VerificationsTestSuite) verifiedInGirls(region string) bool {
+	male := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/users/girls?region="+url.QueryEscape(region), nil)
+	c.Set("uuid", male.Uuid)
+
+	user.GetGirls(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	var resp user.TrfedRandomGirls
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	if len(resp.Girls) != 1 {
+		suite.T().Fatalf("expect exactly 1 girl in region %s, got %d", region, len(resp.Girls))
+	}
+
+	return resp.Girls[0].Verified
+}
+
+// verifiedInProfile looks up the verified flag of the user transformed by `GetMyProfileHandler`.
+func (suite *VerificationsTestSuite) verifiedInProfile(u models.User) bool {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/users/me", nil)
+	c.Set("uuid", u.Uuid)
+
+	(&user.UserHandlers{Container: suite.depCon}).GetMyProfileHandler(c)
+	apperr.HandleError()(c)
+
+	var resp user.TransformedUser
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return resp.Verified
+}
+
+func (suite *VerificationsTestSuite) TestApproveVerification() {
+	female, region, v := suite.createPendingVerification()
+
+	assert := assert.New(suite.T())
+	assert.False(suite.verifiedInGirls(region))
+	assert.False(suite.verifiedInProfile(female))
+
+	body := url.Values{}
+	body.Set("status", string(models.IdentityVerificationStatusApproved))
+	w := suite.decide(v.Uuid, body)
+
+	assert.Equal(http.StatusOK, w.Code, w.Body.String())
+	assert.True(suite.verifiedInGirls(region))
+	assert.True(suite.verifiedInProfile(female))
+
+	// Decided requests can not be decided again.
+	body.Set("status", string(models.IdentityVerificationStatusRejected))
+	body.Set("reason", "blurry selfie")
+	w = suite.decide(v.Uuid, body)
+
+	assert.Equal(http.StatusNotFound, w.Code)
+	assert.Equal(apperr.IdentityVerificationNotFound, errCodeOf(w))
+}
+
+func (suite *VerificationsTestSuite) TestRejectVerificationNeedsReason() {
+	_, _, v := suite.createPendingVerification()
+
+	body := url.Values{}
+	body.Set("status", string(models.IdentityVerificationStatusRejected))
+	w := suite.decide(v.Uuid, body)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), apperr.IdentityVerificationRejectNeedReason, errCodeOf(w))
+}
+
+func (suite *VerificationsTestSuite) TestRejectVerificationWithReason() {
+	female, region, v := suite.createPendingVerification()
+
+	body := url.Values{}
+	body.Set("status", string(models.IdentityVerificationStatusRejected))
+	body.Set("reason", "ID photo is unreadable")
+	w := suite.decide(v.Uuid, body)
+
+	assert := assert.New(suite.T())
+	assert.Equal(http.StatusOK, w.Code, w.Body.String())
+
+	dao := verification.NewIdentityVerificationDAO(db.GetDB())
+	decided, err := dao.GetVerificationByUuid(v.Uuid)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(models.IdentityVerificationStatusRejected, decided.Status)
+	assert.Equal("ID photo is unreadable", decided.Reason.String)
+	assert.False(suite.verifiedInGirls(region))
+	assert.False(suite.verifiedInProfile(female))
+
+	// Rejected user may submit again.
+	_, err = dao.CreateVerification(contracts.CreateIdentityVerificationParams{
+		Uuid:          util.GenRandStringRune(10),
+		UserID:        female.ID,
+		Code:          "5678",
+		SelfieObject:  "identity_verifications/selfie.png",
+		IDPhotoObject: "identity_verifications/id_photo.png",
+	})
+
+	assert.NoError(err)
+}
+
+func TestVerificationsTestSuite(t *testing.T) {
+	suite.Run(t, new(VerificationsTestSuite))
+}
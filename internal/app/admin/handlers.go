@@ -0,0 +1,253 @@
+package admin
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+)
+
+type UuidUriParams struct {
+	Uuid string `uri:"uuid" binding:"required"`
+}
+
+func bindUuidUriParams(c *gin.Context) (*UuidUriParams, bool) {
+	params := UuidUriParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToBindAdminUriParams,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	return &params, true
+}
+
+func GetUserHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	var userDao contracts.UserDAOer
+	depCon.Make(&userDao)
+
+	user, err := userDao.GetUserByUuid(params.Uuid)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.AbortWithError(
+				http.StatusNotFound,
+				apperr.NewErr(apperr.AdminUserNotFound),
+			)
+
+			return
+		}
+
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	roles, err := userDao.GetUserRolesByUuid(user.Uuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserRoles,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformUser(user, roles))
+}
+
+func GetInquiryHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	var (
+		iqDao   contracts.InquiryDAOer
+		userDao contracts.UserDAOer
+	)
+
+	depCon.Make(&iqDao)
+	depCon.Make(&userDao)
+
+	iq, err := iqDao.GetInquiryByUuid(params.Uuid)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.AbortWithError(
+				http.StatusNotFound,
+				apperr.NewErr(apperr.AdminInquiryNotFound),
+			)
+
+			return
+		}
+
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetInquiryByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	pickerUuid := ""
+
+	if iq.PickerID.Valid {
+		picker, err := userDao.GetUserByID(int64(iq.PickerID.Int32), "uuid")
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToGetUserByID,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		pickerUuid = picker.Uuid
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformInquiry(iq, pickerUuid))
+}
+
+func GetServiceHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	var (
+		srvDao  contracts.ServiceDAOer
+		userDao contracts.UserDAOer
+	)
+
+	depCon.Make(&srvDao)
+	depCon.Make(&userDao)
+
+	srv, err := srvDao.GetServiceByUuid(params.Uuid)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.AbortWithError(
+				http.StatusNotFound,
+				apperr.NewErr(apperr.AdminServiceNotFound),
+			)
+
+			return
+		}
+
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetServiceByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	participants := make(map[int32]string)
+
+	for _, ID := range []sql.NullInt32{srv.CustomerID, srv.ServiceProviderID} {
+		if !ID.Valid {
+			continue
+		}
+
+		participant, err := userDao.GetUserByID(int64(ID.Int32), "uuid")
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToGetUserByID,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		participants[ID.Int32] = participant.Uuid
+	}
+
+	c.JSON(
+		http.StatusOK,
+		NewTransform().TransformService(
+			srv,
+			participants[srv.CustomerID.Int32],
+			participants[srv.ServiceProviderID.Int32],
+		),
+	)
+}
+
+func GetServicePaymentHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	var paymentDao contracts.PaymentDAOer
+	depCon.Make(&paymentDao)
+
+	payment, err := paymentDao.GetPaymentByServiceUuid(params.Uuid)
+
+	if err != nil && err != sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetPaymentByServiceUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	// Service exists but has not been paid yet.
+	if err == sql.ErrNoRows || !payment.PaymentID.Valid {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.AdminPaymentNotFound),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformPayment(params.Uuid, payment))
+}
@@ -0,0 +1,434 @@
+package admin
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/report"
+	"github.com/jmoiron/sqlx"
+)
+
+type GetReportsBody struct {
+	Status  string `form:"status,default=open" binding:"oneof=open in_review resolved dismissed"`
+	Offset  int    `form:"offset,default=0"`
+	PerPage int    `form:"per_page,default=20"`
+}
+
+// GetReportsHandler lists reports of the status. Open reports make up the moderation queue.
+func GetReportsHandler(c *gin.Context, depCon container.Container) {
+	body := GetReportsBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateReportParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var dao contracts.ReportDAOer
+	depCon.Make(&dao)
+
+	rs, err := dao.GetReports(contracts.GetReportsParams{
+		Status:  models.ReportStatus(body.Status),
+		Offset:  body.Offset,
+		PerPage: body.PerPage,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetReports,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformReports(rs))
+}
+
+// getReport aborts the request if the report does not exist.
+func getReport(c *gin.Context, dao contracts.ReportDAOer, uuid string) (*models.ReportDetail, bool) {
+	r, err := dao.GetReportByUuid(uuid)
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.ReportNotFound),
+		)
+
+		return nil, false
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetReport,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	return r, true
+}
+
+// GetReportHandler retrieves the report along with every action taken on it.
+func GetReportHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	var dao contracts.ReportDAOer
+	depCon.Make(&dao)
+
+	r, ok := getReport(c, dao, params.Uuid)
+
+	if !ok {
+		return
+	}
+
+	es, err := dao.GetReportEvents(int64(r.ID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetReportEvents,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformReportDetail(r, es))
+}
+
+type AssignReportBody struct {
+	AssigneeUuid string `form:"assignee_uuid" json:"assignee_uuid"`
+}
+
+// AssignReportHandler assigns the report to a moderator, the operator making the request if assignee is
+// not specified.
+func AssignReportHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	body := AssignReportBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateReportParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if len(body.AssigneeUuid) == 0 {
+		body.AssigneeUuid = c.GetString("uuid")
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.ReportDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	roles, err := userDao.GetUserRolesByUuid(body.AssigneeUuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserRoles,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	claim := jwtactor.Claim{Roles: roles}
+
+	if !claim.HasAnyRole(models.UserRoleTypeAdmin, models.UserRoleTypeSupport) {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.ReportAssigneeNotModerator),
+		)
+
+		return
+	}
+
+	actor, ok := getActor(c, userDao)
+
+	if !ok {
+		return
+	}
+
+	assignee, err := userDao.GetUserByUuid(body.AssigneeUuid, "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	r, ok := getReport(c, dao, params.Uuid)
+
+	if !ok {
+		return
+	}
+
+	var assigned *models.Report
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		var err error
+
+		txDao := dao.WithTx(tx)
+
+		if assigned, err = txDao.AssignReport(params.Uuid, assignee.ID); err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		return db.FormatResp{
+			Err: txDao.CreateReportEvent(contracts.CreateReportEventParams{
+				ReportID:   int64(r.ID),
+				ActorID:    &actor.ID,
+				Action:     report.ActionAssigned,
+				FromStatus: &r.Status,
+				ToStatus:   &assigned.Status,
+				Note:       &body.AssigneeUuid,
+			}),
+		}
+	})
+
+	if transResp.Err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToAssignReport,
+				transResp.Err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformReport(assigned, body.AssigneeUuid))
+}
+
+type UpdateReportStatusBody struct {
+	Status string  `form:"status" json:"status" binding:"required,oneof=open in_review resolved dismissed"`
+	Note   *string `form:"note" json:"note" binding:"omitempty,max=1000"`
+}
+
+// UpdateReportStatusHandler moves the report through the moderation workflow. Note given on closing
+// the report is kept as resolution note.
+func UpdateReportStatusHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	body := UpdateReportStatusBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateReportParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.ReportDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	actor, ok := getActor(c, userDao)
+
+	if !ok {
+		return
+	}
+
+	r, ok := getReport(c, dao, params.Uuid)
+
+	if !ok {
+		return
+	}
+
+	status := models.ReportStatus(body.Status)
+
+	if !report.CanTransit(r.Status, status) {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.InvalidReportStatusTransition),
+		)
+
+		return
+	}
+
+	var updated *models.Report
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		var err error
+
+		txDao := dao.WithTx(tx)
+
+		if updated, err = txDao.UpdateReportStatus(contracts.UpdateReportStatusParams{
+			Uuid:           params.Uuid,
+			FromStatus:     r.Status,
+			Status:         status,
+			ResolutionNote: body.Note,
+		}); err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		return db.FormatResp{
+			Err: txDao.CreateReportEvent(contracts.CreateReportEventParams{
+				ReportID:   int64(r.ID),
+				ActorID:    &actor.ID,
+				Action:     report.ActionStatusChanged,
+				FromStatus: &r.Status,
+				ToStatus:   &status,
+				Note:       body.Note,
+			}),
+		}
+	})
+
+	// The report has been changed by another moderator since retrieved.
+	if transResp.Err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusConflict,
+			apperr.NewErr(apperr.InvalidReportStatusTransition),
+		)
+
+		return
+	}
+
+	if transResp.Err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToUpdateReportStatus,
+				transResp.Err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformReport(updated, r.AssigneeUuid.String))
+}
+
+// LiftUserRestrictionsHandler lifts active restrictions of the user, e.g. when reports turn out to be
+// unfounded.
+func LiftUserRestrictionsHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	var (
+		userDao        contracts.UserDAOer
+		restrictionDao contracts.UserRestrictionDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&restrictionDao)
+
+	actor, ok := getActor(c, userDao)
+
+	if !ok {
+		return
+	}
+
+	user, ok := getTargetUser(c, userDao, params.Uuid)
+
+	if !ok {
+		return
+	}
+
+	lifted, err := restrictionDao.LiftRestrictions(user.ID, actor.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToLiftUserRestrictions,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		Lifted int64 `json:"lifted"`
+	}{
+		lifted,
+	})
+}
+
+// getActor retrieves the operator making the request.
+func getActor(c *gin.Context, userDao contracts.UserDAOer) (*models.User, bool) {
+	actor, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	return actor, true
+}
@@ -0,0 +1,111 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
+
+// Routes APIs for operators. Every route requires at least one of the operator roles. Routes
+// exposing financial records are further restricted to `admin` and `finance`.
+func Routes(r *gin.RouterGroup, depCon container.Container) {
+	var authDao contracts.AuthDaoer
+	depCon.Make(&authDao)
+
+	g := r.Group(
+		"/admin",
+		jwtactor.JwtValidator(
+			jwtactor.JwtMiddlewareOptions{},
+			authDao,
+		),
+		jwtactor.RequireRole(
+			models.UserRoleTypeAdmin,
+			models.UserRoleTypeSupport,
+			models.UserRoleTypeFinance,
+		),
+	)
+
+	g.GET("/users/:uuid", func(c *gin.Context) {
+		GetUserHandler(c, depCon)
+	})
+
+	g.GET("/inquiries/:uuid", func(c *gin.Context) {
+		GetInquiryHandler(c, depCon)
+	})
+
+	g.GET("/services/:uuid", func(c *gin.Context) {
+		GetServiceHandler(c, depCon)
+	})
+
+	// Payments do not have uuid of their own. Each payment belongs to exactly one service.
+	g.GET(
+		"/services/:uuid/payment",
+		jwtactor.RequireRole(
+			models.UserRoleTypeAdmin,
+			models.UserRoleTypeFinance,
+		),
+		func(c *gin.Context) {
+			GetServicePaymentHandler(c, depCon)
+		},
+	)
+
+	// Identity verification review queue.
+	vg := g.Group(
+		"/verifications",
+		jwtactor.RequireRole(
+			models.UserRoleTypeAdmin,
+			models.UserRoleTypeSupport,
+		),
+	)
+
+	vg.GET("", func(c *gin.Context) {
+		GetVerificationsHandler(c, depCon)
+	})
+
+	vg.GET("/:uuid/photos/:kind", func(c *gin.Context) {
+		GetVerificationPhotoHandler(c, depCon)
+	})
+
+	vg.POST("/:uuid/decision", func(c *gin.Context) {
+		DecideVerificationHandler(c, depCon)
+	})
+
+	// Moderation queue of user reports.
+	mod := jwtactor.RequireRole(
+		models.UserRoleTypeAdmin,
+		models.UserRoleTypeSupport,
+	)
+
+	rg := g.Group("/reports", mod)
+
+	rg.GET("", func(c *gin.Context) {
+		GetReportsHandler(c, depCon)
+	})
+
+	rg.GET("/:uuid", func(c *gin.Context) {
+		GetReportHandler(c, depCon)
+	})
+
+	rg.POST("/:uuid/assign", func(c *gin.Context) {
+		AssignReportHandler(c, depCon)
+	})
+
+	rg.POST("/:uuid/status", func(c *gin.Context) {
+		UpdateReportStatusHandler(c, depCon)
+	})
+
+	g.DELETE("/users/:uuid/restrictions", mod, func(c *gin.Context) {
+		LiftUserRestrictionsHandler(c, depCon)
+	})
+
+	// Banning is further restricted to admins in the handler.
+	g.POST("/users/:uuid/suspensions", mod, func(c *gin.Context) {
+		SuspendUserHandler(c, depCon)
+	})
+
+	g.DELETE("/users/:uuid/suspensions", mod, func(c *gin.Context) {
+		LiftUserSuspensionsHandler(c, depCon)
+	})
+}
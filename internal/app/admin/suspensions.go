@@ -0,0 +1,206 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
+)
+
+type SuspendUserBody struct {
+	SuspensionType string `form:"suspension_type" json:"suspension_type" binding:"required,oneof=suspend ban"`
+	Reason         string `form:"reason" json:"reason" binding:"required,max=1000"`
+
+	// DurationHours required when suspending. Ban never expires if not specified.
+	DurationHours *int `form:"duration_hours" json:"duration_hours" binding:"omitempty,gt=0"`
+}
+
+// getTargetUser aborts the request if the user does not exist.
+func getTargetUser(c *gin.Context, userDao contracts.UserDAOer, uuid string) (*models.User, bool) {
+	user, err := userDao.GetUserByUuid(uuid, "id", "uuid")
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.AdminUserNotFound),
+		)
+
+		return nil, false
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	return user, true
+}
+
+// SuspendUserHandler suspends or bans the user. Only admins are allowed to ban.
+func SuspendUserHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	body := SuspendUserBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateSuspensionParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	sType := models.UserSuspensionType(body.SuspensionType)
+
+	if sType == models.UserSuspensionTypeBan {
+		roles, _ := c.MustGet("roles").([]models.UserRoleType)
+		claim := jwtactor.Claim{Roles: roles}
+
+		if !claim.HasAnyRole(models.UserRoleTypeAdmin) {
+			c.AbortWithError(
+				http.StatusForbidden,
+				apperr.NewErr(apperr.RoleNotPermitted),
+			)
+
+			return
+		}
+	}
+
+	if sType == models.UserSuspensionTypeSuspend && body.DurationHours == nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.SuspensionExpiryRequired),
+		)
+
+		return
+	}
+
+	if params.Uuid == c.GetString("uuid") {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.CanNotSuspendYourself),
+		)
+
+		return
+	}
+
+	var userDao contracts.UserDAOer
+	depCon.Make(&userDao)
+
+	actor, ok := getActor(c, userDao)
+
+	if !ok {
+		return
+	}
+
+	user, ok := getTargetUser(c, userDao, params.Uuid)
+
+	if !ok {
+		return
+	}
+
+	var expiresAt *time.Time
+
+	if body.DurationHours != nil {
+		t := time.Now().Add(time.Duration(*body.DurationHours) * time.Hour)
+		expiresAt = &t
+	}
+
+	s, err := suspension.Suspend(context.Background(), depCon, suspension.SuspendParams{
+		UserID:         user.ID,
+		UserUuid:       user.Uuid,
+		SuspensionType: sType,
+		Reason:         body.Reason,
+		ExpiresAt:      expiresAt,
+		ActorID:        actor.ID,
+		ActorUuid:      c.GetString("uuid"),
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToSuspendUser,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformSuspension(user.Uuid, s))
+}
+
+// LiftUserSuspensionsHandler lifts suspensions of the user in effect.
+func LiftUserSuspensionsHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	var userDao contracts.UserDAOer
+	depCon.Make(&userDao)
+
+	actor, ok := getActor(c, userDao)
+
+	if !ok {
+		return
+	}
+
+	user, ok := getTargetUser(c, userDao, params.Uuid)
+
+	if !ok {
+		return
+	}
+
+	lifted, err := suspension.Lift(
+		context.Background(),
+		depCon,
+		user.ID,
+		user.Uuid,
+		actor.ID,
+	)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToLiftUserSuspensions,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		Lifted int64 `json:"lifted"`
+	}{
+		lifted,
+	})
+}
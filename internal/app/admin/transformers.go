@@ -0,0 +1,373 @@
+package admin
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type Transform struct{}
+
+func NewTransform() *Transform {
+	return &Transform{}
+}
+
+type TransformedUser struct {
+	Uuid              string                `json:"uuid"`
+	Username          string                `json:"username"`
+	Gender            models.Gender         `json:"gender"`
+	Mobile            string                `json:"mobile"`
+	PhoneVerified     bool                  `json:"phone_verified"`
+	PremiumType       models.PremiumType    `json:"premium_type"`
+	PremiumExpiryDate *time.Time            `json:"premium_expiry_date"`
+	AvatarUrl         string                `json:"avatar_url"`
+	Roles             []models.UserRoleType `json:"roles"`
+	CreatedAt         time.Time             `json:"created_at"`
+	DeletedAt         *time.Time            `json:"deleted_at"`
+}
+
+func (t *Transform) TransformUser(user *models.User, roles []models.UserRoleType) TransformedUser {
+	trf := TransformedUser{
+		Uuid:          user.Uuid,
+		Username:      user.Username,
+		Gender:        user.Gender,
+		Mobile:        user.Mobile.String,
+		PhoneVerified: user.PhoneVerified,
+		PremiumType:   user.PremiumType,
+		AvatarUrl:     user.AvatarUrl.String,
+		Roles:         roles,
+		CreatedAt:     user.CreatedAt,
+	}
+
+	if user.PremiumExpiryDate.Valid {
+		trf.PremiumExpiryDate = &user.PremiumExpiryDate.Time
+	}
+
+	if user.DeletedAt.Valid {
+		trf.DeletedAt = &user.DeletedAt.Time
+	}
+
+	return trf
+}
+
+type TransformedInquiry struct {
+	Uuid              string               `json:"uuid"`
+	InquiryStatus     models.InquiryStatus `json:"inquiry_status"`
+	InquiryType       models.InquiryType   `json:"inquiry_type"`
+	ExpectServiceType string               `json:"expect_service_type"`
+	Budget            string               `json:"budget"`
+	Currency          string               `json:"currency"`
+	Duration          int32                `json:"duration"`
+	AppointmentTime   *time.Time           `json:"appointment_time"`
+	Address           string               `json:"address"`
+	InquirerUuid      string               `json:"inquirer_uuid"`
+	InquirerUsername  string               `json:"inquirer_username"`
+	PickerUuid        string               `json:"picker_uuid"`
+	ExpiredAt         *time.Time           `json:"expired_at"`
+	CreatedAt         time.Time            `json:"created_at"`
+}
+
+func (t *Transform) TransformInquiry(iq *contracts.InquiryResult, pickerUuid string) TransformedInquiry {
+	trf := TransformedInquiry{
+		Uuid:              iq.Uuid,
+		InquiryStatus:     iq.InquiryStatus,
+		InquiryType:       iq.InquiryType,
+		ExpectServiceType: iq.ExpectServiceType.String,
+		Budget:            iq.Budget,
+		Currency:          iq.Currency.String,
+		Duration:          iq.Duration.Int32,
+		Address:           iq.Address.String,
+		InquirerUuid:      iq.UserUuid,
+		InquirerUsername:  iq.Username,
+		PickerUuid:        pickerUuid,
+		CreatedAt:         iq.CreatedAt,
+	}
+
+	if iq.AppointmentTime.Valid {
+		trf.AppointmentTime = &iq.AppointmentTime.Time
+	}
+
+	if iq.ExpiredAt.Valid {
+		trf.ExpiredAt = &iq.ExpiredAt.Time
+	}
+
+	return trf
+}
+
+type TransformedService struct {
+	Uuid            string               `json:"uuid"`
+	ServiceStatus   models.ServiceStatus `json:"service_status"`
+	ServiceType     string               `json:"service_type"`
+	Price           string               `json:"price"`
+	MatchingFee     string               `json:"matching_fee"`
+	Currency        string               `json:"currency"`
+	Duration        int32                `json:"duration"`
+	AppointmentTime *time.Time           `json:"appointment_time"`
+	StartTime       *time.Time           `json:"start_time"`
+	EndTime         *time.Time           `json:"end_time"`
+	Address         string               `json:"address"`
+	CancelCause     models.CancelCause   `json:"cancel_cause"`
+	CustomerUuid    string               `json:"customer_uuid"`
+	ProviderUuid    string               `json:"service_provider_uuid"`
+	CreatedAt       time.Time            `json:"created_at"`
+}
+
+func (t *Transform) TransformService(srv *models.Service, customerUuid, providerUuid string) TransformedService {
+	trf := TransformedService{
+		Uuid:          srv.Uuid.String,
+		ServiceStatus: srv.ServiceStatus,
+		ServiceType:   srv.ServiceType,
+		Price:         srv.Price.String,
+		MatchingFee:   srv.MatchingFee.String,
+		Currency:      srv.Currency.String,
+		Duration:      srv.Duration.Int32,
+		Address:       srv.Address.String,
+		CancelCause:   srv.CancelCause,
+		CustomerUuid:  customerUuid,
+		ProviderUuid:  providerUuid,
+		CreatedAt:     srv.CreatedAt,
+	}
+
+	if srv.AppointmentTime.Valid {
+		trf.AppointmentTime = &srv.AppointmentTime.Time
+	}
+
+	if srv.StartTime.Valid {
+		trf.StartTime = &srv.StartTime.Time
+	}
+
+	if srv.EndTime.Valid {
+		trf.EndTime = &srv.EndTime.Time
+	}
+
+	return trf
+}
+
+type TransformedPayment struct {
+	ServiceUuid string  `json:"service_uuid"`
+	Price       float64 `json:"price"`
+	Currency    string  `json:"currency"`
+	Refunded    bool    `json:"refunded"`
+	CancelCause string  `json:"cancel_cause"`
+	PickerUuid  string  `json:"picker_uuid"`
+}
+
+func (t *Transform) TransformPayment(srvUuid string, p *models.ServicePaymentDetail) TransformedPayment {
+	return TransformedPayment{
+		ServiceUuid: srvUuid,
+		Price:       p.Price.Float64,
+		Currency:    p.Currency.String,
+		Refunded:    p.Refunded,
+		CancelCause: p.CancelCause,
+		PickerUuid:  p.PickerUuid,
+	}
+}
+
+type TransformedVerification struct {
+	Uuid       string                            `json:"uuid"`
+	UserUuid   string                            `json:"user_uuid"`
+	Username   string                            `json:"username"`
+	Code       string                            `json:"code"`
+	Status     models.IdentityVerificationStatus `json:"status"`
+	Reason     *string                           `json:"reason"`
+	CreatedAt  time.Time                         `json:"created_at"`
+	ReviewedAt *time.Time                        `json:"reviewed_at"`
+}
+
+type TransformedVerifications struct {
+	Verifications []TransformedVerification `json:"verifications"`
+}
+
+func (t *Transform) TransformVerifications(vs []models.IdentityVerificationDetail) TransformedVerifications {
+	trf := TransformedVerifications{
+		Verifications: make([]TransformedVerification, 0),
+	}
+
+	for _, v := range vs {
+		tv := t.TransformDecidedVerification(&v.IdentityVerification)
+		tv.UserUuid = v.UserUuid
+		tv.Username = v.Username
+
+		trf.Verifications = append(trf.Verifications, tv)
+	}
+
+	return trf
+}
+
+func (t *Transform) TransformDecidedVerification(v *models.IdentityVerification) TransformedVerification {
+	trf := TransformedVerification{
+		Uuid:      v.Uuid,
+		Code:      v.Code,
+		Status:    v.Status,
+		CreatedAt: v.CreatedAt,
+	}
+
+	if v.Reason.Valid {
+		reason := v.Reason.String
+		trf.Reason = &reason
+	}
+
+	if v.ReviewedAt.Valid {
+		reviewedAt := v.ReviewedAt.Time
+		trf.ReviewedAt = &reviewedAt
+	}
+
+	return trf
+}
+
+type TransformedReport struct {
+	Uuid             string                  `json:"uuid"`
+	ReporterUuid     string                  `json:"reporter_uuid,omitempty"`
+	ReporterUsername string                  `json:"reporter_username,omitempty"`
+	ReportedUuid     string                  `json:"reported_uuid,omitempty"`
+	ReportedUsername string                  `json:"reported_username,omitempty"`
+	TargetType       models.ReportTargetType `json:"target_type"`
+	TargetRef        *string                 `json:"target_ref"`
+	Category         models.ReportCategory   `json:"category"`
+	Description      *string                 `json:"description"`
+	Status           models.ReportStatus     `json:"status"`
+	AssigneeUuid     *string                 `json:"assignee_uuid"`
+	ResolutionNote   *string                 `json:"resolution_note"`
+	CreatedAt        time.Time               `json:"created_at"`
+	ClosedAt         *time.Time              `json:"closed_at"`
+}
+
+type TransformedReports struct {
+	Reports []TransformedReport `json:"reports"`
+}
+
+func (t *Transform) TransformReports(rs []models.ReportDetail) TransformedReports {
+	trf := TransformedReports{
+		Reports: make([]TransformedReport, 0),
+	}
+
+	for _, r := range rs {
+		trf.Reports = append(trf.Reports, t.transformReportDetail(&r))
+	}
+
+	return trf
+}
+
+func (t *Transform) transformReportDetail(r *models.ReportDetail) TransformedReport {
+	tr := t.TransformReport(&r.Report, r.AssigneeUuid.String)
+	tr.ReporterUuid = r.ReporterUuid
+	tr.ReporterUsername = r.ReporterUsername
+	tr.ReportedUuid = r.ReportedUserUuid
+	tr.ReportedUsername = r.ReportedUsername
+
+	return tr
+}
+
+func (t *Transform) TransformReport(r *models.Report, assigneeUuid string) TransformedReport {
+	trf := TransformedReport{
+		Uuid:       r.Uuid,
+		TargetType: r.TargetType,
+		Category:   r.Category,
+		Status:     r.Status,
+		CreatedAt:  r.CreatedAt,
+	}
+
+	if r.TargetRef.Valid {
+		ref := r.TargetRef.String
+		trf.TargetRef = &ref
+	}
+
+	if r.Description.Valid {
+		desc := r.Description.String
+		trf.Description = &desc
+	}
+
+	if r.AssigneeID.Valid {
+		trf.AssigneeUuid = &assigneeUuid
+	}
+
+	if r.ResolutionNote.Valid {
+		note := r.ResolutionNote.String
+		trf.ResolutionNote = &note
+	}
+
+	if r.ClosedAt.Valid {
+		closedAt := r.ClosedAt.Time
+		trf.ClosedAt = &closedAt
+	}
+
+	return trf
+}
+
+type TransformedReportEvent struct {
+	ActorUuid  *string   `json:"actor_uuid"`
+	Action     string    `json:"action"`
+	FromStatus *string   `json:"from_status"`
+	ToStatus   *string   `json:"to_status"`
+	Note       *string   `json:"note"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type TransformedReportDetail struct {
+	TransformedReport
+	Events []TransformedReportEvent `json:"events"`
+}
+
+// TransformReportDetail events with no actor are taken by the system, e.g. automatic restriction.
+func (t *Transform) TransformReportDetail(r *models.ReportDetail, es []models.ReportEventDetail) TransformedReportDetail {
+	trf := TransformedReportDetail{
+		TransformedReport: t.transformReportDetail(r),
+		Events:            make([]TransformedReportEvent, 0),
+	}
+
+	for _, e := range es {
+		te := TransformedReportEvent{
+			Action:    e.Action,
+			CreatedAt: e.CreatedAt,
+		}
+
+		if e.ActorUuid.Valid {
+			actorUuid := e.ActorUuid.String
+			te.ActorUuid = &actorUuid
+		}
+
+		if e.FromStatus.Valid {
+			from := e.FromStatus.String
+			te.FromStatus = &from
+		}
+
+		if e.ToStatus.Valid {
+			to := e.ToStatus.String
+			te.ToStatus = &to
+		}
+
+		if e.Note.Valid {
+			note := e.Note.String
+			te.Note = &note
+		}
+
+		trf.Events = append(trf.Events, te)
+	}
+
+	return trf
+}
+
+type TransformedSuspension struct {
+	UserUuid       string                    `json:"user_uuid"`
+	SuspensionType models.UserSuspensionType `json:"suspension_type"`
+	Reason         string                    `json:"reason"`
+	ExpiresAt      *time.Time                `json:"expires_at"`
+	CreatedAt      time.Time                 `json:"created_at"`
+}
+
+func (t *Transform) TransformSuspension(userUuid string, s *models.UserSuspension) TransformedSuspension {
+	trf := TransformedSuspension{
+		UserUuid:       userUuid,
+		SuspensionType: s.SuspensionType,
+		Reason:         s.Reason,
+		CreatedAt:      s.CreatedAt,
+	}
+
+	if s.ExpiresAt.Valid {
+		expiresAt := s.ExpiresAt.Time
+		trf.ExpiresAt = &expiresAt
+	}
+
+	return trf
+}
@@ -0,0 +1,238 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	gcsenhancer "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/gcs_enhancer"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+type GetVerificationsBody struct {
+	Status  string `form:"status,default=pending" binding:"oneof=pending approved rejected"`
+	Offset  int    `form:"offset,default=0"`
+	PerPage int    `form:"per_page,default=20"`
+}
+
+// GetVerificationsHandler lists identity verification requests of the status. Pending requests make
+// up the review queue.
+func GetVerificationsHandler(c *gin.Context, depCon container.Container) {
+	body := GetVerificationsBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateVerificationParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var dao contracts.IdentityVerificationDAOer
+	depCon.Make(&dao)
+
+	vs, err := dao.GetVerifications(contracts.GetIdentityVerificationsParams{
+		Status:  models.IdentityVerificationStatus(body.Status),
+		Offset:  body.Offset,
+		PerPage: body.PerPage,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetIdentityVerifications,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformVerifications(vs))
+}
+
+type VerificationPhotoUriParams struct {
+	Uuid string `uri:"uuid" binding:"required"`
+	Kind string `uri:"kind" binding:"oneof=selfie id_photo"`
+}
+
+// GetVerificationPhotoHandler streams the private photo of the request to the reviewer.
+func GetVerificationPhotoHandler(c *gin.Context, depCon container.Container) {
+	params := VerificationPhotoUriParams{}
+
+	if err := c.ShouldBindUri(&params); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToBindAdminUriParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		dao         contracts.IdentityVerificationDAOer
+		gcsEnhancer gcsenhancer.GCSEnhancerInterface
+	)
+
+	depCon.Make(&dao)
+	depCon.Make(&gcsEnhancer)
+
+	v, err := dao.GetVerificationByUuid(params.Uuid)
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.IdentityVerificationNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	objName := v.SelfieObject
+
+	if params.Kind == "id_photo" {
+		objName = v.IDPhotoObject
+	}
+
+	r, err := gcsEnhancer.NewObjectReader(context.Background(), objName)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToReadVerificationPhoto,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	defer r.Close()
+
+	c.Header("Cache-Control", "no-store")
+	c.DataFromReader(
+		http.StatusOK,
+		r.Attrs.Size,
+		r.Attrs.ContentType,
+		r,
+		nil,
+	)
+}
+
+type DecideVerificationBody struct {
+	Status string  `form:"status" json:"status" binding:"required,oneof=approved rejected"`
+	Reason *string `form:"reason" json:"reason"`
+}
+
+// DecideVerificationHandler approves or rejects the pending request. Reason is required on rejection
+// so that the user knows what to fix before submitting again.
+func DecideVerificationHandler(c *gin.Context, depCon container.Container) {
+	params, ok := bindUuidUriParams(c)
+
+	if !ok {
+		return
+	}
+
+	body := DecideVerificationBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateVerificationParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	status := models.IdentityVerificationStatus(body.Status)
+
+	if status == models.IdentityVerificationStatusRejected && (body.Reason == nil || len(*body.Reason) == 0) {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.IdentityVerificationRejectNeedReason),
+		)
+
+		return
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.IdentityVerificationDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	reviewer, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	v, err := dao.DecideVerification(contracts.DecideIdentityVerificationParams{
+		Uuid:       params.Uuid,
+		Status:     status,
+		Reason:     body.Reason,
+		ReviewerID: reviewer.ID,
+	})
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.IdentityVerificationNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToDecideIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformDecidedVerification(v))
+}
@@ -0,0 +1,67 @@
+package agency_tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/agency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SettlementTestSuite struct {
+	suite.Suite
+	assert *assert.Assertions
+}
+
+func (suite *SettlementTestSuite) SetupSuite() {
+	suite.assert = assert.New(suite.T())
+}
+
+func (suite *SettlementTestSuite) TestParseSettlementPeriod() {
+	period, err := agency.ParseSettlementPeriod("2021-12")
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	loc := config.GetAppConf().GetAppLocation()
+
+	suite.assert.Equal(time.Date(2021, 12, 1, 0, 0, 0, 0, loc), period.From)
+	suite.assert.Equal(time.Date(2022, 1, 1, 0, 0, 0, 0, loc), period.To)
+}
+
+func (suite *SettlementTestSuite) TestParseSettlementPeriodDefaultsToCurrentMonth() {
+	period, err := agency.ParseSettlementPeriod("")
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	now := time.Now().In(config.GetAppConf().GetAppLocation())
+
+	suite.assert.Equal(now.Year(), period.From.Year())
+	suite.assert.Equal(now.Month(), period.From.Month())
+	suite.assert.Equal(1, period.From.Day())
+}
+
+func (suite *SettlementTestSuite) TestParseSettlementPeriodInvalidMonth() {
+	_, err := agency.ParseSettlementPeriod("2021-13")
+
+	suite.assert.Error(err)
+}
+
+func (suite *SettlementTestSuite) TestCalcCommission() {
+	commission, err := agency.CalcCommission("333.33", "0.3")
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.assert.Equal("100.00", commission.StringFixed(2))
+}
+
+func TestSettlementTestSuite(t *testing.T) {
+	suite.Run(t, new(SettlementTestSuite))
+}
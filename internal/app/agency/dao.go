@@ -0,0 +1,336 @@
+package agency
+
+import (
+	"database/sql"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type AgencyDAO struct {
+	db db.Conn
+}
+
+func NewAgencyDAO(db db.Conn) *AgencyDAO {
+	return &AgencyDAO{
+		db: db,
+	}
+}
+
+func AgencyDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.AgencyDAOer {
+			return NewAgencyDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *AgencyDAO) WithTx(tx db.Conn) contracts.AgencyDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+func (dao *AgencyDAO) CreateAgency(p contracts.CreateAgencyParams) (*models.Agency, error) {
+	query := `
+INSERT INTO agencies (
+	manager_id,
+	name,
+	commission_rate
+) VALUES ($1, $2, $3)
+RETURNING *;
+`
+	var m models.Agency
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.ManagerID,
+		p.Name,
+		p.CommissionRate,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *AgencyDAO) GetAgencyByManagerID(managerID int64) (*models.Agency, error) {
+	query := `
+SELECT
+	*
+FROM
+	agencies
+WHERE
+	manager_id = $1 AND
+	deleted_at IS NULL;
+`
+	var m models.Agency
+
+	if err := dao.db.QueryRowx(query, managerID).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetAgencyByRefcodeID retrieves the agency owning the manager referral code.
+func (dao *AgencyDAO) GetAgencyByRefcodeID(refcodeID int64) (*models.Agency, error) {
+	query := `
+SELECT
+	agencies.*
+FROM
+	agencies
+INNER JOIN user_refcodes ON user_refcodes.invitor_id = agencies.manager_id
+WHERE
+	user_refcodes.id = $1 AND
+	user_refcodes.ref_code_type = $2 AND
+	agencies.deleted_at IS NULL;
+`
+	var m models.Agency
+
+	if err := dao.db.QueryRowx(
+		query,
+		refcodeID,
+		models.RefCodeTypeManager,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *AgencyDAO) SetCommissionRate(agencyID int32, rate string) error {
+	query := `
+UPDATE agencies
+SET commission_rate = $1
+WHERE
+	id = $2 AND
+	deleted_at IS NULL;
+`
+	res, err := dao.db.Exec(query, rate, agencyID)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// CreateManagerRefcode creates a manager referral code. Unlike invitor referral codes, manager
+// referral codes never expire and can be used by any number of members.
+func (dao *AgencyDAO) CreateManagerRefcode(managerID int64, refCode string) (*models.UserRefcode, error) {
+	query := `
+INSERT INTO user_refcodes (
+	invitor_id,
+	ref_code,
+	ref_code_type,
+	expired_at
+) VALUES ($1, $2, $3, NULL)
+RETURNING *;
+`
+	var m models.UserRefcode
+
+	if err := dao.db.QueryRowx(
+		query,
+		managerID,
+		refCode,
+		models.RefCodeTypeManager,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *AgencyDAO) GetManagerRefcodes(managerID int64) ([]models.UserRefcode, error) {
+	query := `
+SELECT
+	*
+FROM
+	user_refcodes
+WHERE
+	invitor_id = $1 AND
+	ref_code_type = $2 AND
+	deleted_at IS NULL
+ORDER BY created_at DESC;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		managerID,
+		models.RefCodeTypeManager,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	refcodes := make([]models.UserRefcode, 0)
+
+	for rows.Next() {
+		var m models.UserRefcode
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		refcodes = append(refcodes, m)
+	}
+
+	return refcodes, nil
+}
+
+// AddMember adds the user to the agency. A user belongs to at most one agency, `sql.ErrNoRows`
+// is returned if the user is already a member of an agency.
+func (dao *AgencyDAO) AddMember(p contracts.AddAgencyMemberParams) (*models.AgencyMember, error) {
+	query := `
+INSERT INTO agency_members (
+	agency_id,
+	member_id,
+	refcode_id
+) VALUES ($1, $2, $3)
+ON CONFLICT (member_id) DO NOTHING
+RETURNING *;
+`
+	var m models.AgencyMember
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.AgencyID,
+		p.MemberID,
+		p.RefcodeID,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetMemberSummaries retrieves every member of the agency along with number of services the member
+// has provided and the sum of matching fees of those services within the settlement period.
+func (dao *AgencyDAO) GetMemberSummaries(agencyID int32, period contracts.AgencySettlementPeriod) ([]models.AgencyMemberSummary, error) {
+	query := `
+SELECT
+	users.uuid AS member_uuid,
+	users.username AS member_username,
+	users.avatar_url AS member_avatar_url,
+	agency_members.created_at AS joined_at,
+	COUNT(services.id) AS completed_services,
+	COALESCE(SUM(services.matching_fee), 0) AS matching_fee_total
+FROM
+	agency_members
+INNER JOIN users ON users.id = agency_members.member_id
+LEFT JOIN services ON
+	services.service_provider_id = agency_members.member_id AND
+	services.service_status = $2 AND
+	services.end_time >= $3 AND
+	services.end_time < $4
+WHERE
+	agency_members.agency_id = $1 AND
+	agency_members.deleted_at IS NULL
+GROUP BY
+	users.id,
+	agency_members.created_at
+ORDER BY agency_members.created_at;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		agencyID,
+		models.ServiceStatusCompleted,
+		period.From,
+		period.To,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	summaries := make([]models.AgencyMemberSummary, 0)
+
+	for rows.Next() {
+		var m models.AgencyMemberSummary
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, m)
+	}
+
+	return summaries, nil
+}
+
+// GetMemberServices retrieves services completed by the agency member within the settlement period.
+func (dao *AgencyDAO) GetMemberServices(p contracts.GetAgencyMemberServicesParams) ([]models.AgencyMemberService, error) {
+	query := `
+SELECT
+	services.uuid,
+	customers.username AS customer_username,
+	services.price,
+	services.matching_fee,
+	services.start_time,
+	services.end_time
+FROM
+	services
+INNER JOIN users AS members ON members.id = services.service_provider_id
+INNER JOIN agency_members ON agency_members.member_id = members.id
+INNER JOIN users AS customers ON customers.id = services.customer_id
+WHERE
+	agency_members.agency_id = $1 AND
+	agency_members.deleted_at IS NULL AND
+	members.uuid = $2 AND
+	services.service_status = $3 AND
+	services.end_time >= $4 AND
+	services.end_time < $5
+ORDER BY services.end_time DESC
+LIMIT $6
+OFFSET $7;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		p.AgencyID,
+		p.MemberUuid,
+		models.ServiceStatusCompleted,
+		p.Period.From,
+		p.Period.To,
+		p.PerPage,
+		p.Offset,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	srvs := make([]models.AgencyMemberService, 0)
+
+	for rows.Next() {
+		var m models.AgencyMemberService
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		srvs = append(srvs, m)
+	}
+
+	return srvs, nil
+}
@@ -0,0 +1,179 @@
+package agency
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+type GetDashboardBody struct {
+	Month string `form:"month" json:"month"`
+}
+
+func GetDashboardHandler(c *gin.Context, depCon container.Container) {
+	body := GetDashboardBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAgencyDashboardParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	period, err := ParseSettlementPeriod(body.Month)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAgencyDashboardParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	agency := c.MustGet("agency").(*models.Agency)
+
+	var agencyDao contracts.AgencyDAOer
+	depCon.Make(&agencyDao)
+
+	refcodes, err := agencyDao.GetManagerRefcodes(int64(agency.ManagerID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetManagerRefcodes,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	summaries, err := agencyDao.GetMemberSummaries(agency.ID, period)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetAgencyMembers,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	trf, err := NewTransform().TransformDashboard(
+		agency,
+		refcodes,
+		period,
+		summaries,
+	)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCalcAgencyCommission,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, trf)
+}
+
+type GetMemberServicesBody struct {
+	Month   string `form:"month" json:"month"`
+	Offset  int    `form:"offset,default=0"`
+	PerPage int    `form:"per_page,default=10"`
+}
+
+// GetMemberServicesHandler lists services completed by the member within the month so that
+// the manager can reconcile the matching fees.
+func GetMemberServicesHandler(c *gin.Context, depCon container.Container) {
+	body := GetMemberServicesBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAgencyDashboardParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	period, err := ParseSettlementPeriod(body.Month)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAgencyDashboardParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	agency := c.MustGet("agency").(*models.Agency)
+
+	var agencyDao contracts.AgencyDAOer
+	depCon.Make(&agencyDao)
+
+	srvs, err := agencyDao.GetMemberServices(contracts.GetAgencyMemberServicesParams{
+		AgencyID:   agency.ID,
+		MemberUuid: c.Param("uuid"),
+		Period:     period,
+		Offset:     body.Offset,
+		PerPage:    body.PerPage,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetAgencyMemberServices,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	trf, err := NewTransform().TransformMemberServices(agency, srvs)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCalcAgencyCommission,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, trf)
+}
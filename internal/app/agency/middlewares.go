@@ -0,0 +1,56 @@
+package agency
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+)
+
+// RequireAgencyManager aborts the request if the requester does not manage any agency. The agency
+// of the requester is set to the context as `agency`.
+func RequireAgencyManager(userDao contracts.UserDAOer, agencyDao contracts.AgencyDAOer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToGetUserByUuid,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		agency, err := agencyDao.GetAgencyByManagerID(user.ID)
+
+		if err == sql.ErrNoRows {
+			c.AbortWithError(
+				http.StatusForbidden,
+				apperr.NewErr(apperr.NotAgencyManager),
+			)
+
+			return
+		}
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToGetAgency,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		c.Set("agency", agency)
+		c.Next()
+	}
+}
@@ -0,0 +1,40 @@
+package agency
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
+
+// Routes APIs for agency managers to settle with the platform. Female users who registered with
+// the manager referral code are members of the agency.
+func Routes(r *gin.RouterGroup, depCon container.Container) {
+	var (
+		authDao   contracts.AuthDaoer
+		userDao   contracts.UserDAOer
+		agencyDao contracts.AgencyDAOer
+	)
+
+	depCon.Make(&authDao)
+	depCon.Make(&userDao)
+	depCon.Make(&agencyDao)
+
+	g := r.Group(
+		"/agency",
+		jwtactor.JwtValidator(
+			jwtactor.JwtMiddlewareOptions{},
+			authDao,
+		),
+		RequireAgencyManager(userDao, agencyDao),
+	)
+
+	// Members of the agency along with completed services, matching fees and commission of the month.
+	g.GET("/dashboard", func(c *gin.Context) {
+		GetDashboardHandler(c, depCon)
+	})
+
+	g.GET("/members/:uuid/services", func(c *gin.Context) {
+		GetMemberServicesHandler(c, depCon)
+	})
+}
@@ -0,0 +1,55 @@
+package agency
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/shopspring/decimal"
+)
+
+const SettlementMonthLayout = "2006-01"
+
+// ParseSettlementPeriod parses the month in `YYYY-MM` into the settlement period. Agencies are settled
+// monthly in the app time zone, current month is used if the month is not specified.
+func ParseSettlementPeriod(month string) (contracts.AgencySettlementPeriod, error) {
+	var (
+		from time.Time
+		err  error
+	)
+
+	loc := config.GetAppConf().GetAppLocation()
+
+	if month == "" {
+		now := time.Now().In(loc)
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	} else {
+		from, err = time.ParseInLocation(SettlementMonthLayout, month, loc)
+
+		if err != nil {
+			return contracts.AgencySettlementPeriod{}, err
+		}
+	}
+
+	return contracts.AgencySettlementPeriod{
+		From: from,
+		To:   from.AddDate(0, 1, 0),
+	}, nil
+}
+
+// CalcCommission calculates the share of matching fees paid to the agency, rounded to 2 decimal places.
+func CalcCommission(matchingFee string, commissionRate string) (decimal.Decimal, error) {
+	fee, err := decimal.NewFromString(matchingFee)
+
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rate, err := decimal.NewFromString(commissionRate)
+
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	return fee.Mul(rate).Round(2), nil
+}
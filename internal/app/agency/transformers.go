@@ -0,0 +1,153 @@
+package agency
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/shopspring/decimal"
+)
+
+type AgencyTransform struct{}
+
+func NewTransform() *AgencyTransform {
+	return &AgencyTransform{}
+}
+
+type TransformedAgencyMember struct {
+	Uuid              string    `json:"uuid"`
+	Username          string    `json:"username"`
+	AvatarUrl         string    `json:"avatar_url"`
+	JoinedAt          time.Time `json:"joined_at"`
+	CompletedServices int       `json:"completed_services"`
+	MatchingFeeTotal  string    `json:"matching_fee_total"`
+	Commission        string    `json:"commission"`
+}
+
+type TransformedDashboard struct {
+	Name              string                    `json:"name"`
+	CommissionRate    string                    `json:"commission_rate"`
+	ReferralCodes     []string                  `json:"referral_codes"`
+	Month             string                    `json:"month"`
+	CompletedServices int                       `json:"completed_services"`
+	MatchingFeeTotal  string                    `json:"matching_fee_total"`
+	Commission        string                    `json:"commission"`
+	Members           []TransformedAgencyMember `json:"members"`
+}
+
+// TransformDashboard sums up matching fees of every member. Commission of the agency is calculated
+// from the total matching fees so that it does not suffer from rounding of each member.
+func (t *AgencyTransform) TransformDashboard(
+	agency *models.Agency,
+	refcodes []models.UserRefcode,
+	period contracts.AgencySettlementPeriod,
+	summaries []models.AgencyMemberSummary,
+) (*TransformedDashboard, error) {
+	codes := make([]string, 0)
+
+	for _, refcode := range refcodes {
+		codes = append(codes, refcode.RefCode)
+	}
+
+	members := make([]TransformedAgencyMember, 0)
+	completedServices := 0
+	feeTotal := decimal.Zero
+
+	for _, summary := range summaries {
+		fee, err := decimal.NewFromString(summary.MatchingFeeTotal)
+
+		if err != nil {
+			return nil, err
+		}
+
+		commission, err := CalcCommission(summary.MatchingFeeTotal, agency.CommissionRate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		completedServices += summary.CompletedServices
+		feeTotal = feeTotal.Add(fee)
+
+		members = append(members, TransformedAgencyMember{
+			Uuid:              summary.MemberUuid,
+			Username:          summary.MemberUsername,
+			AvatarUrl:         summary.MemberAvatarUrl.String,
+			JoinedAt:          summary.JoinedAt,
+			CompletedServices: summary.CompletedServices,
+			MatchingFeeTotal:  fee.StringFixed(2),
+			Commission:        commission.StringFixed(2),
+		})
+	}
+
+	commission, err := CalcCommission(feeTotal.String(), agency.CommissionRate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransformedDashboard{
+		Name:              agency.Name,
+		CommissionRate:    agency.CommissionRate,
+		ReferralCodes:     codes,
+		Month:             period.From.Format(SettlementMonthLayout),
+		CompletedServices: completedServices,
+		MatchingFeeTotal:  feeTotal.StringFixed(2),
+		Commission:        commission.StringFixed(2),
+		Members:           members,
+	}, nil
+}
+
+type TransformedMemberService struct {
+	Uuid             string     `json:"uuid"`
+	CustomerUsername string     `json:"customer_username"`
+	Price            string     `json:"price"`
+	MatchingFee      string     `json:"matching_fee"`
+	Commission       string     `json:"commission"`
+	StartTime        *time.Time `json:"start_time"`
+	EndTime          *time.Time `json:"end_time"`
+}
+
+type TransformedMemberServices struct {
+	Services []TransformedMemberService `json:"services"`
+}
+
+func (t *AgencyTransform) TransformMemberServices(agency *models.Agency, srvs []models.AgencyMemberService) (*TransformedMemberServices, error) {
+	trfSrvs := make([]TransformedMemberService, 0)
+
+	for _, srv := range srvs {
+		fee := "0"
+
+		if srv.MatchingFee.Valid {
+			fee = srv.MatchingFee.String
+		}
+
+		commission, err := CalcCommission(fee, agency.CommissionRate)
+
+		if err != nil {
+			return nil, err
+		}
+
+		trfSrv := TransformedMemberService{
+			Uuid:             srv.Uuid,
+			CustomerUsername: srv.CustomerUsername,
+			Price:            srv.Price.String,
+			MatchingFee:      fee,
+			Commission:       commission.StringFixed(2),
+		}
+
+		if srv.StartTime.Valid {
+			startTime := srv.StartTime.Time
+			trfSrv.StartTime = &startTime
+		}
+
+		if srv.EndTime.Valid {
+			endTime := srv.EndTime.Time
+			trfSrv.EndTime = &endTime
+		}
+
+		trfSrvs = append(trfSrvs, trfSrv)
+	}
+
+	return &TransformedMemberServices{trfSrvs}, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/admin"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/agency"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/auth"
 	bankAccount "github.com/huangc28/go-darkpanda-backend/internal/app/bank_account"
@@ -17,11 +19,16 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/internal/app/inquiry"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/middlewares"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/payment"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/premium"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/referral"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/register"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/release"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/report"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/service"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/verification"
 )
 
 func StartApp(e *gin.Engine) *gin.Engine {
@@ -30,6 +37,7 @@ func StartApp(e *gin.Engine) *gin.Engine {
 	// Log the response so frontend can better normalize the result.
 	e.Use(middlewares.ResponseLogger)
 	e.Use(gin.Recovery())
+	e.Use(locale.Negotiate())
 	e.Use(apperr.HandleError())
 
 	e.GET("/health", func(c *gin.Context) {
@@ -136,6 +144,36 @@ func StartApp(e *gin.Engine) *gin.Engine {
 		rv1,
 	)
 
+	admin.Routes(
+		rv1,
+		deps.Get().Container,
+	)
+
+	agency.Routes(
+		rv1,
+		deps.Get().Container,
+	)
+
+	verification.Routes(
+		rv1,
+		deps.Get().Container,
+	)
+
+	presence.Routes(
+		rv1,
+		deps.Get().Container,
+	)
+
+	premium.Routes(
+		rv1,
+		deps.Get().Container,
+	)
+
+	report.Routes(
+		rv1,
+		deps.Get().Container,
+	)
+
 	e.NoRoute(func(c *gin.Context) {
 		c.AbortWithError(http.StatusNotFound, apperr.NewErr(apperr.APINotFound))
 
@@ -0,0 +1,35 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToBindAdminUriParams = "2500001"
+	AdminUserNotFound          = "2500002"
+	AdminInquiryNotFound       = "2500003"
+	AdminServiceNotFound       = "2500004"
+	AdminPaymentNotFound       = "2500005"
+)
+
+var adminErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToBindAdminUriParams: "路徑參數格式錯誤",
+		AdminUserNotFound:          "用戶不存在",
+		AdminInquiryNotFound:       "詢問不存在",
+		AdminServiceNotFound:       "服務不存在",
+		AdminPaymentNotFound:       "付款紀錄不存在",
+	},
+	locale.En: {
+		FailedToBindAdminUriParams: "failed to bind admin uri params",
+		AdminUserNotFound:          "user not found",
+		AdminInquiryNotFound:       "inquiry not found",
+		AdminServiceNotFound:       "service not found",
+		AdminPaymentNotFound:       "payment not found",
+	},
+	locale.Ja: {
+		FailedToBindAdminUriParams: "URI パラメータが正しくありません",
+		AdminUserNotFound:          "ユーザーが存在しません",
+		AdminInquiryNotFound:       "依頼が存在しません",
+		AdminServiceNotFound:       "サービスが存在しません",
+		AdminPaymentNotFound:       "支払い記録が存在しません",
+	},
+}
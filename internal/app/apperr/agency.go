@@ -0,0 +1,59 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToGetAgency                     = "2600001"
+	NotAgencyManager                      = "2600002"
+	FailedToValidateAgencyDashboardParams = "2600003"
+	FailedToGetAgencyMembers              = "2600004"
+	FailedToGetAgencyMemberServices       = "2600005"
+	FailedToGetManagerRefcodes            = "2600006"
+	FailedToCalcAgencyCommission          = "2600007"
+	FailedToJoinAgency                    = "2600008"
+	AlreadyAgencyMember                   = "2600009"
+	AgencyOnlyAcceptsFemale               = "2600010"
+	AgencyNotFound                        = "2600011"
+)
+
+var agencyErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToGetAgency:                     "取得經紀公司失敗",
+		NotAgencyManager:                      "非經紀公司管理者",
+		FailedToValidateAgencyDashboardParams: "經紀公司報表查詢資料格式錯誤",
+		FailedToGetAgencyMembers:              "取得經紀公司成員失敗",
+		FailedToGetAgencyMemberServices:       "取得成員服務紀錄失敗",
+		FailedToGetManagerRefcodes:            "取得管理者推薦碼失敗",
+		FailedToCalcAgencyCommission:          "計算經紀公司佣金失敗",
+		FailedToJoinAgency:                    "加入經紀公司失敗",
+		AlreadyAgencyMember:                   "已加入其他經紀公司",
+		AgencyOnlyAcceptsFemale:               "經紀公司僅接受女性用戶加入",
+		AgencyNotFound:                        "經紀公司不存在",
+	},
+	locale.En: {
+		FailedToGetAgency:                     "failed to get agency",
+		NotAgencyManager:                      "not an agency manager",
+		FailedToValidateAgencyDashboardParams: "failed to validate agency dashboard params",
+		FailedToGetAgencyMembers:              "failed to get agency members",
+		FailedToGetAgencyMemberServices:       "failed to get agency member services",
+		FailedToGetManagerRefcodes:            "failed to get manager referral codes",
+		FailedToCalcAgencyCommission:          "failed to calculate agency commission",
+		FailedToJoinAgency:                    "failed to join agency",
+		AlreadyAgencyMember:                   "already a member of another agency",
+		AgencyOnlyAcceptsFemale:               "agency only accepts female users",
+		AgencyNotFound:                        "agency not found",
+	},
+	locale.Ja: {
+		FailedToGetAgency:                     "事務所の取得に失敗しました",
+		NotAgencyManager:                      "事務所の管理者ではありません",
+		FailedToValidateAgencyDashboardParams: "事務所ダッシュボードの検索条件が正しくありません",
+		FailedToGetAgencyMembers:              "事務所のメンバーの取得に失敗しました",
+		FailedToGetAgencyMemberServices:       "メンバーのサービス履歴の取得に失敗しました",
+		FailedToGetManagerRefcodes:            "管理者の紹介コードの取得に失敗しました",
+		FailedToCalcAgencyCommission:          "事務所の手数料の計算に失敗しました",
+		FailedToJoinAgency:                    "事務所への加入に失敗しました",
+		AlreadyAgencyMember:                   "既に他の事務所に所属しています",
+		AgencyOnlyAcceptsFemale:               "事務所に加入できるのは女性ユーザーのみです",
+		AgencyNotFound:                        "事務所が存在しません",
+	},
+}
@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToValidateRegisterParams           = "1000001"
 	FailedToRetrieveReferCodeInfo            = "1000002"
@@ -47,20 +49,203 @@ const (
 	FailedToValidateToken                    = "1000044"
 	TokenIsInvalidated                       = "1000045"
 	FailedToGetServiceOption                 = "1000046"
+	RefreshTokenNotAllowed                   = "1000047"
+	FailedToValidateRefreshTokenParams       = "1000048"
+	InvalidRefreshToken                      = "1000049"
+	RefreshTokenReused                       = "1000050"
+	FailedToRotateRefreshToken               = "1000051"
+	FailedToRevokeTokenFamily                = "1000052"
+	FailedToUpsertSession                    = "1000053"
+	FailedToGetSessions                      = "1000054"
+	FailedToRevokeSession                    = "1000055"
+	SessionNotFound                          = "1000056"
+	FailedToGetUserRoles                     = "1000057"
+	RoleNotPermitted                         = "1000058"
+	SigningKeyNotAccepted                    = "1000059"
 )
 
-var AuthErrCodeMsgMap = map[string]string{
-	ReferCodeOccupied:                       "參考代碼已被佔用",
-	UsernameNotAvailable:                    "用戶名不可用",
-	ReferCodeNotExist:                       "參考代碼不存在",
-	UserHasPhoneVerified:                    "用戶已通過電話驗證",
-	UserNotFoundByVerifyCode:                "給定的驗證碼找不到用戶資料",
-	JWTNotProvided:                          "JWT token not exists",
-	FailedToFindInquiryByInquiererID:        "查詢ID失敗",
-	FailedToCheckSendLoginVerifyCodeParams:  "找不到用戶名發送驗證碼",
-	UnableToSendVerifyCodeToUnverfiedNumber: "無法將登錄代碼發送到未經驗證的手機號碼。請聯繫客服",
-	ExceedingLoginRetryLimit:                "嘗試登錄太多次。請稍後重試登錄",
-	VerifyCodeUnmatched:                     "手機驗證碼不匹配",
-	LoginVerifyCodeNotFound:                 "未找到驗證器的登錄驗證碼，請重新發送新的短信登錄碼",
-	TokenIsInvalidated:                      "jwt token is invalid",
+var AuthErrCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidateRegisterParams:           "註冊資料格式錯誤",
+		FailedToRetrieveReferCodeInfo:            "無法取得推薦碼資料",
+		FailedToCheckUsernameExistence:           "無法確認用戶名是否存在",
+		UsernameNotAvailable:                     "用戶名不可用",
+		FailedToCheckReferCodeExists:             "無法確認推薦碼是否存在",
+		ReferCodeOccupied:                        "參考代碼已被佔用",
+		ReferCodeNotExist:                        "參考代碼不存在",
+		FailedToCreateUser:                       "建立用戶失敗",
+		FailedToGenerateUuid:                     "無法產生識別碼",
+		FailedToValidateSendVerifyCodeParams:     "發送驗證碼的資料格式錯誤",
+		FailedToGetUserByUuid:                    "取得用戶資料失敗",
+		UserHasPhoneVerified:                     "用戶已通過電話驗證",
+		FailedToUpdateVerifyCode:                 "更新驗證碼失敗",
+		TwilioRespErr:                            "簡訊服務回應錯誤",
+		FailedToSendTwilioSMSErr:                 "簡訊發送失敗",
+		FailedToValidateVerifyPhoneParams:        "手機驗證資料格式錯誤",
+		FailedToGetUserByVerifyCode:              "無法以驗證碼取得用戶資料",
+		UserNotFoundByVerifyCode:                 "給定的驗證碼找不到用戶資料",
+		VerifyCodeNotMatching:                    "驗證碼不正確",
+		FailedToUpdateVerifyStatus:               "更新驗證狀態失敗",
+		FailedToGenerateJwtToken:                 "產生 jwt token 失敗",
+		FailedToValidateRevokeJwtParams:          "撤銷 token 的資料格式錯誤",
+		InvalidSignature:                         "簽章無效",
+		FailedToParseSignature:                   "無法解析簽章",
+		InvalidSigature:                          "簽章無效",
+		FailedToInvalidateSignature:              "無法撤銷簽章",
+		JWTNotProvided:                           "未提供 jwt token",
+		FailedToFindInquiryByInquiererID:         "查詢ID失敗",
+		FailedToCheckSendLoginVerifyCodeParams:   "找不到用戶名發送驗證碼",
+		FailedToGetUserByUsername:                "無法以用戶名取得用戶資料",
+		UnableToSendVerifyCodeToUnverfiedNumber:  "無法將登錄代碼發送到未經驗證的手機號碼。請聯繫客服",
+		UnableToCreateSendVerifyCode:             "無法建立驗證碼",
+		FailedToCreateAuthenticatorRecordInRedis: "無法建立登入驗證紀錄",
+		ExceedingLoginRetryLimit:                 "嘗試登錄太多次。請稍後重試登錄",
+		FailedToUpdateAuthenticatorRecordInRedis: "無法更新登入驗證紀錄",
+		FailedToValidateVerifyLoginParams:        "登入驗證資料格式錯誤",
+		VerifyCodeUnmatched:                      "手機驗證碼不匹配",
+		FailedToCreateJWTToken:                   "產生 jwt token 失敗",
+		FailedToValidateReferralCode:             "推薦碼格式錯誤",
+		FailedToValidateFindByUsernameParams:     "用戶名查詢資料格式錯誤",
+		LoginVerifyCodeNotFound:                  "未找到驗證器的登錄驗證碼，請重新發送新的短信登錄碼",
+		FailedToGetAuthenticatorRecord:           "無法取得登入驗證紀錄",
+		FailedToParseJwtToken:                    "無法解析 jwt token",
+		FailedToValidateToken:                    "無法驗證 token",
+		TokenIsInvalidated:                       "jwt token 已失效",
+		FailedToGetServiceOption:                 "取得服務項目失敗",
+		RefreshTokenNotAllowed:                   "refresh token 不可用於存取資源",
+		FailedToValidateRefreshTokenParams:       "refresh token 資料格式錯誤",
+		InvalidRefreshToken:                      "refresh token 無效",
+		RefreshTokenReused:                       "refresh token 已被使用，請重新登入",
+		FailedToRotateRefreshToken:               "無法更新 refresh token",
+		FailedToRevokeTokenFamily:                "無法撤銷 token",
+		FailedToUpsertSession:                    "無法儲存登入裝置",
+		FailedToGetSessions:                      "無法取得登入裝置",
+		FailedToRevokeSession:                    "無法登出裝置",
+		SessionNotFound:                          "登入裝置不存在",
+		FailedToGetUserRoles:                     "無法取得用戶權限",
+		RoleNotPermitted:                         "權限不足",
+		SigningKeyNotAccepted:                    "jwt token 簽署金鑰已不被接受，請重新登入",
+	},
+	locale.En: {
+		FailedToValidateRegisterParams:           "failed to validate register params",
+		FailedToRetrieveReferCodeInfo:            "failed to retrieve refer code info",
+		FailedToCheckUsernameExistence:           "failed to check username existence",
+		UsernameNotAvailable:                     "username is not available",
+		FailedToCheckReferCodeExists:             "failed to check refer code existence",
+		ReferCodeOccupied:                        "refer code is occupied",
+		ReferCodeNotExist:                        "refer code does not exist",
+		FailedToCreateUser:                       "failed to create user",
+		FailedToGenerateUuid:                     "failed to generate uuid",
+		FailedToValidateSendVerifyCodeParams:     "failed to validate send verify code params",
+		FailedToGetUserByUuid:                    "failed to get user by uuid",
+		UserHasPhoneVerified:                     "user has already verified phone",
+		FailedToUpdateVerifyCode:                 "failed to update verify code",
+		TwilioRespErr:                            "sms service responded with error",
+		FailedToSendTwilioSMSErr:                 "failed to send sms",
+		FailedToValidateVerifyPhoneParams:        "failed to validate verify phone params",
+		FailedToGetUserByVerifyCode:              "failed to get user by verify code",
+		UserNotFoundByVerifyCode:                 "no user found by the given verify code",
+		VerifyCodeNotMatching:                    "verify code does not match",
+		FailedToUpdateVerifyStatus:               "failed to update verify status",
+		FailedToGenerateJwtToken:                 "failed to generate jwt token",
+		FailedToValidateRevokeJwtParams:          "failed to validate revoke jwt params",
+		InvalidSignature:                         "invalid signature",
+		FailedToParseSignature:                   "failed to parse signature",
+		InvalidSigature:                          "invalid signature",
+		FailedToInvalidateSignature:              "failed to invalidate signature",
+		JWTNotProvided:                           "JWT token not exists",
+		FailedToFindInquiryByInquiererID:         "failed to find inquiry by inquirer id",
+		FailedToCheckSendLoginVerifyCodeParams:   "username to send login verify code to is not found",
+		FailedToGetUserByUsername:                "failed to get user by username",
+		UnableToSendVerifyCodeToUnverfiedNumber:  "unable to send login code to an unverified phone number, please contact customer service",
+		UnableToCreateSendVerifyCode:             "unable to create verify code",
+		FailedToCreateAuthenticatorRecordInRedis: "failed to create authenticator record",
+		ExceedingLoginRetryLimit:                 "too many login attempts, please try again later",
+		FailedToUpdateAuthenticatorRecordInRedis: "failed to update authenticator record",
+		FailedToValidateVerifyLoginParams:        "failed to validate verify login params",
+		VerifyCodeUnmatched:                      "verify code does not match",
+		FailedToCreateJWTToken:                   "failed to create jwt token",
+		FailedToValidateReferralCode:             "failed to validate referral code",
+		FailedToValidateFindByUsernameParams:     "failed to validate find by username params",
+		LoginVerifyCodeNotFound:                  "login verify code not found, please request a new sms login code",
+		FailedToGetAuthenticatorRecord:           "failed to get authenticator record",
+		FailedToParseJwtToken:                    "failed to parse jwt token",
+		FailedToValidateToken:                    "failed to validate token",
+		TokenIsInvalidated:                       "jwt token is invalid",
+		FailedToGetServiceOption:                 "failed to get service option",
+		RefreshTokenNotAllowed:                   "refresh token can not be used to access resources",
+		FailedToValidateRefreshTokenParams:       "failed to validate refresh token params",
+		InvalidRefreshToken:                      "refresh token is invalid",
+		RefreshTokenReused:                       "refresh token has been used, please login again",
+		FailedToRotateRefreshToken:               "failed to rotate refresh token",
+		FailedToRevokeTokenFamily:                "failed to revoke token family",
+		FailedToUpsertSession:                    "failed to upsert session",
+		FailedToGetSessions:                      "failed to get sessions",
+		FailedToRevokeSession:                    "failed to revoke session",
+		SessionNotFound:                          "session not found",
+		FailedToGetUserRoles:                     "failed to get user roles",
+		RoleNotPermitted:                         "permission denied",
+		SigningKeyNotAccepted:                    "jwt token is signed by a key that is no longer accepted, please login again",
+	},
+	locale.Ja: {
+		FailedToValidateRegisterParams:           "登録情報の形式が正しくありません",
+		FailedToRetrieveReferCodeInfo:            "紹介コードの情報を取得できませんでした",
+		FailedToCheckUsernameExistence:           "ユーザー名の存在を確認できませんでした",
+		UsernameNotAvailable:                     "このユーザー名は使用できません",
+		FailedToCheckReferCodeExists:             "紹介コードの存在を確認できませんでした",
+		ReferCodeOccupied:                        "紹介コードは既に使用されています",
+		ReferCodeNotExist:                        "紹介コードが存在しません",
+		FailedToCreateUser:                       "ユーザーの作成に失敗しました",
+		FailedToGenerateUuid:                     "識別子を生成できませんでした",
+		FailedToValidateSendVerifyCodeParams:     "認証コード送信の情報が正しくありません",
+		FailedToGetUserByUuid:                    "ユーザー情報の取得に失敗しました",
+		UserHasPhoneVerified:                     "電話番号は既に認証済みです",
+		FailedToUpdateVerifyCode:                 "認証コードの更新に失敗しました",
+		TwilioRespErr:                            "SMS サービスがエラーを返しました",
+		FailedToSendTwilioSMSErr:                 "SMS の送信に失敗しました",
+		FailedToValidateVerifyPhoneParams:        "電話番号認証の情報が正しくありません",
+		FailedToGetUserByVerifyCode:              "認証コードからユーザーを取得できませんでした",
+		UserNotFoundByVerifyCode:                 "この認証コードに該当するユーザーが見つかりません",
+		VerifyCodeNotMatching:                    "認証コードが一致しません",
+		FailedToUpdateVerifyStatus:               "認証状態の更新に失敗しました",
+		FailedToGenerateJwtToken:                 "jwt トークンの生成に失敗しました",
+		FailedToValidateRevokeJwtParams:          "トークン無効化の情報が正しくありません",
+		InvalidSignature:                         "署名が無効です",
+		FailedToParseSignature:                   "署名を解析できませんでした",
+		InvalidSigature:                          "署名が無効です",
+		FailedToInvalidateSignature:              "署名を無効化できませんでした",
+		JWTNotProvided:                           "jwt トークンがありません",
+		FailedToFindInquiryByInquiererID:         "依頼の取得に失敗しました",
+		FailedToCheckSendLoginVerifyCodeParams:   "認証コードの送信先ユーザー名が見つかりません",
+		FailedToGetUserByUsername:                "ユーザー名からユーザーを取得できませんでした",
+		UnableToSendVerifyCodeToUnverfiedNumber:  "未認証の電話番号にはログインコードを送信できません。サポートにお問い合わせください",
+		UnableToCreateSendVerifyCode:             "認証コードを作成できませんでした",
+		FailedToCreateAuthenticatorRecordInRedis: "ログイン認証の記録を作成できませんでした",
+		ExceedingLoginRetryLimit:                 "ログインの試行回数が多すぎます。しばらくしてから再度お試しください",
+		FailedToUpdateAuthenticatorRecordInRedis: "ログイン認証の記録を更新できませんでした",
+		FailedToValidateVerifyLoginParams:        "ログイン認証の情報が正しくありません",
+		VerifyCodeUnmatched:                      "認証コードが一致しません",
+		FailedToCreateJWTToken:                   "jwt トークンの生成に失敗しました",
+		FailedToValidateReferralCode:             "紹介コードの形式が正しくありません",
+		FailedToValidateFindByUsernameParams:     "ユーザー名検索の情報が正しくありません",
+		LoginVerifyCodeNotFound:                  "ログイン認証コードが見つかりません。新しい SMS ログインコードを再送信してください",
+		FailedToGetAuthenticatorRecord:           "ログイン認証の記録を取得できませんでした",
+		FailedToParseJwtToken:                    "jwt トークンを解析できませんでした",
+		FailedToValidateToken:                    "トークンを検証できませんでした",
+		TokenIsInvalidated:                       "jwt トークンは無効です",
+		FailedToGetServiceOption:                 "サービス項目の取得に失敗しました",
+		RefreshTokenNotAllowed:                   "リフレッシュトークンではリソースにアクセスできません",
+		FailedToValidateRefreshTokenParams:       "リフレッシュトークンの情報が正しくありません",
+		InvalidRefreshToken:                      "リフレッシュトークンが無効です",
+		RefreshTokenReused:                       "リフレッシュトークンは使用済みです。再度ログインしてください",
+		FailedToRotateRefreshToken:               "リフレッシュトークンを更新できませんでした",
+		FailedToRevokeTokenFamily:                "トークンを無効化できませんでした",
+		FailedToUpsertSession:                    "ログイン端末を保存できませんでした",
+		FailedToGetSessions:                      "ログイン端末を取得できませんでした",
+		FailedToRevokeSession:                    "端末をログアウトできませんでした",
+		SessionNotFound:                          "ログイン端末が見つかりません",
+		FailedToGetUserRoles:                     "ユーザーの権限を取得できませんでした",
+		RoleNotPermitted:                         "権限がありません",
+		SigningKeyNotAccepted:                    "jwt トークンの署名鍵は既に無効です。再度ログインしてください",
+	},
 }
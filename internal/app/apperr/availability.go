@@ -0,0 +1,67 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToValidateAvailabilityParams = "2700001"
+	FailedToGetAvailabilitySlots       = "2700002"
+	FailedToCreateAvailabilitySlot     = "2700003"
+	FailedToUpdateAvailabilitySlot     = "2700004"
+	FailedToDeleteAvailabilitySlot     = "2700005"
+	AvailabilitySlotNotFound           = "2700006"
+	FailedToGetAvailabilityBlackouts   = "2700007"
+	FailedToCreateAvailabilityBlackout = "2700008"
+	AvailabilityBlackoutExists         = "2700009"
+	FailedToDeleteAvailabilityBlackout = "2700010"
+	AvailabilityBlackoutNotFound       = "2700011"
+	FailedToCheckAvailability          = "2700012"
+	AppointmentOutsideAvailability     = "2700013"
+)
+
+var availabilityErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidateAvailabilityParams: "可預約時段資料格式錯誤",
+		FailedToGetAvailabilitySlots:       "取得可預約時段失敗",
+		FailedToCreateAvailabilitySlot:     "建立可預約時段失敗",
+		FailedToUpdateAvailabilitySlot:     "更新可預約時段失敗",
+		FailedToDeleteAvailabilitySlot:     "刪除可預約時段失敗",
+		AvailabilitySlotNotFound:           "時段不存在",
+		FailedToGetAvailabilityBlackouts:   "取得休假日期失敗",
+		FailedToCreateAvailabilityBlackout: "建立休假日期失敗",
+		AvailabilityBlackoutExists:         "該日期已設為休假",
+		FailedToDeleteAvailabilityBlackout: "刪除休假日期失敗",
+		AvailabilityBlackoutNotFound:       "休假日期不存在",
+		FailedToCheckAvailability:          "無法確認可預約時段",
+		AppointmentOutsideAvailability:     "預約時間不在對方的可預約時段內",
+	},
+	locale.En: {
+		FailedToValidateAvailabilityParams: "failed to validate availability params",
+		FailedToGetAvailabilitySlots:       "failed to get availability slots",
+		FailedToCreateAvailabilitySlot:     "failed to create availability slot",
+		FailedToUpdateAvailabilitySlot:     "failed to update availability slot",
+		FailedToDeleteAvailabilitySlot:     "failed to delete availability slot",
+		AvailabilitySlotNotFound:           "availability slot not found",
+		FailedToGetAvailabilityBlackouts:   "failed to get availability blackouts",
+		FailedToCreateAvailabilityBlackout: "failed to create availability blackout",
+		AvailabilityBlackoutExists:         "the date has already been set as day off",
+		FailedToDeleteAvailabilityBlackout: "failed to delete availability blackout",
+		AvailabilityBlackoutNotFound:       "day off not found",
+		FailedToCheckAvailability:          "failed to check availability",
+		AppointmentOutsideAvailability:     "appointment time is outside of the availability of the provider",
+	},
+	locale.Ja: {
+		FailedToValidateAvailabilityParams: "予約可能時間の情報が正しくありません",
+		FailedToGetAvailabilitySlots:       "予約可能時間の取得に失敗しました",
+		FailedToCreateAvailabilitySlot:     "予約可能時間の作成に失敗しました",
+		FailedToUpdateAvailabilitySlot:     "予約可能時間の更新に失敗しました",
+		FailedToDeleteAvailabilitySlot:     "予約可能時間の削除に失敗しました",
+		AvailabilitySlotNotFound:           "時間帯が存在しません",
+		FailedToGetAvailabilityBlackouts:   "休業日の取得に失敗しました",
+		FailedToCreateAvailabilityBlackout: "休業日の作成に失敗しました",
+		AvailabilityBlackoutExists:         "この日は既に休業日に設定されています",
+		FailedToDeleteAvailabilityBlackout: "休業日の削除に失敗しました",
+		AvailabilityBlackoutNotFound:       "休業日が存在しません",
+		FailedToCheckAvailability:          "予約可能時間を確認できませんでした",
+		AppointmentOutsideAvailability:     "予約時間が相手の予約可能時間外です",
+	},
+}
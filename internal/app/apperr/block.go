@@ -1,14 +1,41 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 var (
 	FailedToUnblockNotBlockedUser = "2300001"
 	FailedToCheckHasBlockedUser   = "2300002"
 	FailedToUnblockUser           = "2300003"
 	FailedToBlockUser             = "2300004"
 	UnableToFindBlockee           = "2300005"
+
+	// UserUnavailable does not reveal the block to the requester.
+	UserUnavailable = "2300006"
 )
 
-var blockErrorCodeMsgMap = map[string]string{
-	FailedToUnblockNotBlockedUser: "failed to unblock user that has not been blocked by you",
-	UnableToFindBlockee:           "the person to block is not found",
+var blockErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToUnblockNotBlockedUser: "無法解除封鎖未被您封鎖的用戶",
+		FailedToCheckHasBlockedUser:   "無法確認封鎖狀態",
+		FailedToUnblockUser:           "解除封鎖失敗",
+		FailedToBlockUser:             "封鎖用戶失敗",
+		UnableToFindBlockee:           "找不到要封鎖的用戶",
+		UserUnavailable:               "用戶不存在",
+	},
+	locale.En: {
+		FailedToUnblockNotBlockedUser: "failed to unblock user that has not been blocked by you",
+		FailedToCheckHasBlockedUser:   "failed to check if user has been blocked",
+		FailedToUnblockUser:           "failed to unblock user",
+		FailedToBlockUser:             "failed to block user",
+		UnableToFindBlockee:           "the person to block is not found",
+		UserUnavailable:               "user not found",
+	},
+	locale.Ja: {
+		FailedToUnblockNotBlockedUser: "ブロックしていないユーザーのブロックは解除できません",
+		FailedToCheckHasBlockedUser:   "ブロック状態を確認できませんでした",
+		FailedToUnblockUser:           "ブロックの解除に失敗しました",
+		FailedToBlockUser:             "ユーザーのブロックに失敗しました",
+		UnableToFindBlockee:           "ブロックするユーザーが見つかりません",
+		UserUnavailable:               "ユーザーが見つかりません",
+	},
 }
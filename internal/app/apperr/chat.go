@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToValidateEmitTextMessageParams                 = "7000001"
 	FailedToSendTextMessage                               = "7000002"
@@ -43,10 +45,119 @@ const (
 	FailedToUpdateIsRead = "7000036"
 )
 
-var ChatErrorMessageMap = map[string]string{
-	MessageExceedMaximumCount:       "exceed maximum message count. chatroom is closed",
-	ChatRoomHasExpired:              "chatroom has expired, please create another inquiry to proceed chatroom",
-	UserIsNotInTheChatroom:          "user is not in the chatroom",
-	FailedToGetInquiryByChannelUuid: "chatroom is not in relate to any inquiry",
-	ChatroomNotExists:               "chatroom not exists",
+var ChatErrorMessageMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidateEmitTextMessageParams:                 "訊息資料格式錯誤",
+		FailedToSendTextMessage:                               "發送訊息失敗",
+		FailedToGetChatRoomByChannelUuid:                      "取得聊天室失敗",
+		MessageExceedMaximumCount:                             "已超過訊息數量上限，聊天室已關閉",
+		ChatRoomHasExpired:                                    "聊天室已過期，請建立新的詢問以繼續聊天",
+		FailedToGetChatRoomByInquiryID:                        "取得詢問的聊天室失敗",
+		FailedToLeaveChat:                                     "離開聊天室失敗",
+		FailedToDeleteChat:                                    "刪除聊天室失敗",
+		FailedToLeaveAllMembers:                               "成員離開聊天室失敗",
+		FailedToCreatePrivateChatRoom:                         "建立私人聊天室失敗",
+		FailedToGetInquiryChatrooms:                           "取得詢問聊天室失敗",
+		FailedToGetMessageFromFireStore:                       "取得訊息失敗",
+		FailedGetHistoricalMessagesFromFireStore:              "取得歷史訊息失敗",
+		FailedToValidateGetChatroomsParams:                    "聊天室查詢資料格式錯誤",
+		FailedToValidateEmitServiceSettingMessageParams:       "服務設定訊息資料格式錯誤",
+		FailedToGetServiceByInquiryUUID:                       "取得詢問的服務失敗",
+		FailedToValidateEmitConfirmedServiceParams:            "確認服務的資料格式錯誤",
+		FailedToChangeStatusWhenEmittingServiceConfirmMessage: "確認服務時更新狀態失敗",
+		FailedToUpdateChatroom:                                "更新聊天室失敗",
+		FailedToSendUpdateInquiryMessage:                      "發送詢問更新訊息失敗",
+		UserIsNotInTheChatroom:                                "用戶不在聊天室中",
+		FailedToGetInquiryByChannelUuid:                       "聊天室沒有相關的詢問",
+		FailedToCheckIsUserInChatroom:                         "無法確認用戶是否在聊天室中",
+		FailedToUploadQRCode:                                  "上傳 QR code 失敗",
+		FailedToGenQRCodeUuid:                                 "產生 QR code 識別碼失敗",
+		FailedToMarshQRCodeContent:                            "產生 QR code 內容失敗",
+		FailedToCreateServiceQRCodeRecord:                     "建立服務 QR code 紀錄失敗",
+		ServiceStartTimeNotValid:                              "服務開始時間無效",
+		FirestoreFailedToCreateService:                        "同步建立服務失敗",
+		ChatroomNotExists:                                     "聊天室不存在",
+		FailedToGetChatroomByServiceId:                        "取得服務的聊天室失敗",
+		FailedToSendCompletePaymentMessage:                    "發送付款完成訊息失敗",
+		FailedToGetInquiryByServiceUuid:                       "取得服務的詢問失敗",
+		ServiceEditorIsNotServiceProvider:                     "只有服務提供者可以編輯服務",
+		FailedToCalcInquiryMatchingFee:                        "計算媒合費失敗",
+		FailedToUpdateIsRead:                                  "更新已讀狀態失敗",
+	},
+	locale.En: {
+		FailedToValidateEmitTextMessageParams:                 "failed to validate emit text message params",
+		FailedToSendTextMessage:                               "failed to send text message",
+		FailedToGetChatRoomByChannelUuid:                      "failed to get chatroom by channel uuid",
+		MessageExceedMaximumCount:                             "exceed maximum message count. chatroom is closed",
+		ChatRoomHasExpired:                                    "chatroom has expired, please create another inquiry to proceed chatroom",
+		FailedToGetChatRoomByInquiryID:                        "failed to get chatroom by inquiry id",
+		FailedToLeaveChat:                                     "failed to leave chat",
+		FailedToDeleteChat:                                    "failed to delete chat",
+		FailedToLeaveAllMembers:                               "failed to remove all members from chat",
+		FailedToCreatePrivateChatRoom:                         "failed to create private chatroom",
+		FailedToGetInquiryChatrooms:                           "failed to get inquiry chatrooms",
+		FailedToGetMessageFromFireStore:                       "failed to get messages from firestore",
+		FailedGetHistoricalMessagesFromFireStore:              "failed to get historical messages from firestore",
+		FailedToValidateGetChatroomsParams:                    "failed to validate get chatrooms params",
+		FailedToValidateEmitServiceSettingMessageParams:       "failed to validate emit service setting message params",
+		FailedToGetServiceByInquiryUUID:                       "failed to get service by inquiry uuid",
+		FailedToValidateEmitConfirmedServiceParams:            "failed to validate emit confirmed service params",
+		FailedToChangeStatusWhenEmittingServiceConfirmMessage: "failed to change status when emitting service confirmed message",
+		FailedToUpdateChatroom:                                "failed to update chatroom",
+		FailedToSendUpdateInquiryMessage:                      "failed to send update inquiry message",
+		UserIsNotInTheChatroom:                                "user is not in the chatroom",
+		FailedToGetInquiryByChannelUuid:                       "chatroom is not in relate to any inquiry",
+		FailedToCheckIsUserInChatroom:                         "failed to check if user is in chatroom",
+		FailedToUploadQRCode:                                  "failed to upload qr code",
+		FailedToGenQRCodeUuid:                                 "failed to generate qr code uuid",
+		FailedToMarshQRCodeContent:                            "failed to marshal qr code content",
+		FailedToCreateServiceQRCodeRecord:                     "failed to create service qr code record",
+		ServiceStartTimeNotValid:                              "service start time is not valid",
+		FirestoreFailedToCreateService:                        "failed to create service in firestore",
+		ChatroomNotExists:                                     "chatroom not exists",
+		FailedToGetChatroomByServiceId:                        "failed to get chatroom by service id",
+		FailedToSendCompletePaymentMessage:                    "failed to send complete payment message",
+		FailedToGetInquiryByServiceUuid:                       "failed to get inquiry by service uuid",
+		ServiceEditorIsNotServiceProvider:                     "only service provider can edit the service",
+		FailedToCalcInquiryMatchingFee:                        "failed to calculate inquiry matching fee",
+		FailedToUpdateIsRead:                                  "failed to update is read",
+	},
+	locale.Ja: {
+		FailedToValidateEmitTextMessageParams:                 "メッセージの情報が正しくありません",
+		FailedToSendTextMessage:                               "メッセージの送信に失敗しました",
+		FailedToGetChatRoomByChannelUuid:                      "チャットルームの取得に失敗しました",
+		MessageExceedMaximumCount:                             "メッセージ数の上限を超えたため、チャットルームは閉じられました",
+		ChatRoomHasExpired:                                    "チャットルームの期限が切れました。チャットを続けるには新しい依頼を作成してください",
+		FailedToGetChatRoomByInquiryID:                        "依頼のチャットルームの取得に失敗しました",
+		FailedToLeaveChat:                                     "チャットルームからの退出に失敗しました",
+		FailedToDeleteChat:                                    "チャットルームの削除に失敗しました",
+		FailedToLeaveAllMembers:                               "メンバーのチャットルームからの退出に失敗しました",
+		FailedToCreatePrivateChatRoom:                         "個別チャットルームの作成に失敗しました",
+		FailedToGetInquiryChatrooms:                           "依頼のチャットルームの取得に失敗しました",
+		FailedToGetMessageFromFireStore:                       "メッセージの取得に失敗しました",
+		FailedGetHistoricalMessagesFromFireStore:              "過去のメッセージの取得に失敗しました",
+		FailedToValidateGetChatroomsParams:                    "チャットルームの検索条件が正しくありません",
+		FailedToValidateEmitServiceSettingMessageParams:       "サービス設定メッセージの情報が正しくありません",
+		FailedToGetServiceByInquiryUUID:                       "依頼のサービスの取得に失敗しました",
+		FailedToValidateEmitConfirmedServiceParams:            "サービス確定の情報が正しくありません",
+		FailedToChangeStatusWhenEmittingServiceConfirmMessage: "サービス確定時の状態更新に失敗しました",
+		FailedToUpdateChatroom:                                "チャットルームの更新に失敗しました",
+		FailedToSendUpdateInquiryMessage:                      "依頼更新メッセージの送信に失敗しました",
+		UserIsNotInTheChatroom:                                "ユーザーはチャットルームに参加していません",
+		FailedToGetInquiryByChannelUuid:                       "チャットルームに関連する依頼がありません",
+		FailedToCheckIsUserInChatroom:                         "ユーザーがチャットルームに参加しているか確認できませんでした",
+		FailedToUploadQRCode:                                  "QR コードのアップロードに失敗しました",
+		FailedToGenQRCodeUuid:                                 "QR コードの識別子の生成に失敗しました",
+		FailedToMarshQRCodeContent:                            "QR コードの内容の生成に失敗しました",
+		FailedToCreateServiceQRCodeRecord:                     "サービスの QR コード記録の作成に失敗しました",
+		ServiceStartTimeNotValid:                              "サービス開始時間が正しくありません",
+		FirestoreFailedToCreateService:                        "サービス作成の同期に失敗しました",
+		ChatroomNotExists:                                     "チャットルームが存在しません",
+		FailedToGetChatroomByServiceId:                        "サービスのチャットルームの取得に失敗しました",
+		FailedToSendCompletePaymentMessage:                    "支払い完了メッセージの送信に失敗しました",
+		FailedToGetInquiryByServiceUuid:                       "サービスの依頼の取得に失敗しました",
+		ServiceEditorIsNotServiceProvider:                     "サービスを編集できるのはサービス提供者のみです",
+		FailedToCalcInquiryMatchingFee:                        "マッチング手数料の計算に失敗しました",
+		FailedToUpdateIsRead:                                  "既読状態の更新に失敗しました",
+	},
 }
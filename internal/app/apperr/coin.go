@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	TapPayFailedToPayByPrime      = "1200001"
 	FailedToGetPackageInfo        = "1200002"
@@ -13,3 +15,45 @@ const (
 	FailedToTransformCoinPackage  = "1200010"
 	FailedToConvertCostToInt      = "1200011"
 )
+
+var coinErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		TapPayFailedToPayByPrime:      "信用卡付款失敗",
+		FailedToGetPackageInfo:        "取得點數方案失敗",
+		FailedToCreateCoinOrder:       "建立點數訂單失敗",
+		FailedToUpdateCoinOrder:       "更新點數訂單失敗",
+		FailedToTopupUserBalance:      "儲值失敗",
+		FailedToUpdateCoinOrderStatus: "更新點數訂單狀態失敗",
+		FailedToGetUserBalance:        "取得餘額失敗",
+		FailedToCreateUserBalance:     "建立餘額失敗",
+		FailedToGetCoinPackages:       "取得點數方案列表失敗",
+		FailedToTransformCoinPackage:  "無法整理點數方案",
+		FailedToConvertCostToInt:      "金額轉換失敗",
+	},
+	locale.En: {
+		TapPayFailedToPayByPrime:      "failed to pay by credit card",
+		FailedToGetPackageInfo:        "failed to get package info",
+		FailedToCreateCoinOrder:       "failed to create coin order",
+		FailedToUpdateCoinOrder:       "failed to update coin order",
+		FailedToTopupUserBalance:      "failed to top up user balance",
+		FailedToUpdateCoinOrderStatus: "failed to update coin order status",
+		FailedToGetUserBalance:        "failed to get user balance",
+		FailedToCreateUserBalance:     "failed to create user balance",
+		FailedToGetCoinPackages:       "failed to get coin packages",
+		FailedToTransformCoinPackage:  "failed to transform coin package",
+		FailedToConvertCostToInt:      "failed to convert cost to int",
+	},
+	locale.Ja: {
+		TapPayFailedToPayByPrime:      "クレジットカードでの支払いに失敗しました",
+		FailedToGetPackageInfo:        "コインパッケージの取得に失敗しました",
+		FailedToCreateCoinOrder:       "コインの注文の作成に失敗しました",
+		FailedToUpdateCoinOrder:       "コインの注文の更新に失敗しました",
+		FailedToTopupUserBalance:      "チャージに失敗しました",
+		FailedToUpdateCoinOrderStatus: "コインの注文状態の更新に失敗しました",
+		FailedToGetUserBalance:        "残高の取得に失敗しました",
+		FailedToCreateUserBalance:     "残高の作成に失敗しました",
+		FailedToGetCoinPackages:       "コインパッケージ一覧の取得に失敗しました",
+		FailedToTransformCoinPackage:  "コインパッケージ情報を生成できませんでした",
+		FailedToConvertCostToInt:      "金額の変換に失敗しました",
+	},
+}
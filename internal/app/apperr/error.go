@@ -1,23 +1,21 @@
 package apperr
 
-func mergeMaps(maps ...map[string]string) map[string]string {
-	mm := make(map[string]string)
+import (
+	"math"
+	"time"
 
-	for _, m := range maps {
-		for k, v := range m {
-			mm[k] = v
-		}
-	}
-
-	return mm
-}
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+)
 
 type Error struct {
 	ErrCode string `json:"err_code"`
 	ErrMsg  string `json:"err_msg"`
+
+	// RetryAfter number of seconds the client should wait before retrying the request.
+	RetryAfter int64 `json:"retry_after,omitempty"`
 }
 
-var MasterErrorMessageMap map[string]string = make(map[string]string)
+var MasterErrorMessageMap locale.Catalog = make(locale.Catalog)
 
 func (e *Error) Error() string {
 	return e.ErrMsg
@@ -31,14 +29,29 @@ func NewErr(errCode string, args ...interface{}) *Error {
 	}
 
 	return &Error{
-		errCode,
-		errMsg,
+		ErrCode: errCode,
+		ErrMsg:  errMsg,
 	}
 }
 
+// NewRetryAfterErr creates an error that tells the client to retry after the given duration.
+func NewRetryAfterErr(errCode string, retryAfter time.Duration) *Error {
+	err := NewErr(errCode)
+	err.RetryAfter = int64(math.Ceil(retryAfter.Seconds()))
+
+	return err
+}
+
+// GetErrorMessage retrieves message of the error code in the default locale.
 func GetErrorMessage(code string) string {
+	return GetLocalizedErrorMessage(code, locale.Default)
+}
+
+// GetLocalizedErrorMessage retrieves message of the error code in the locale. Message missing in the
+// locale falls back along `locale.FallbackChain`.
+func GetLocalizedErrorMessage(code string, l string) string {
 	if len(MasterErrorMessageMap) == 0 {
-		MasterErrorMessageMap = mergeMaps(
+		MasterErrorMessageMap = locale.MergeCatalogs(
 			GeneralErrorMessageMap,
 			AuthErrCodeMsgMap,
 			InquiryErrCodeMsgMap,
@@ -49,17 +62,36 @@ func GetErrorMessage(code string) string {
 			RegisterErrCodeMsgMap,
 			RatingErrCodeMsgMap,
 			PaymentErrCodeMsgMap,
+			coinErrorCodeMsgMap,
 			userErrorCodeMsgMap,
 			blockErrorCodeMsgMap,
 			releaseErrorMap,
+			otpErrorCodeMsgMap,
+			adminErrorCodeMsgMap,
+			agencyErrorCodeMsgMap,
+			availabilityErrorCodeMsgMap,
+			verificationErrorCodeMsgMap,
+			presenceErrorCodeMsgMap,
+			premiumErrorCodeMsgMap,
+			reportErrorCodeMsgMap,
+			suspensionErrorCodeMsgMap,
+			transitionErrorCodeMsgMap,
 		)
 	}
 
-	message := ""
+	message, _ := MasterErrorMessageMap.Get(l, code)
 
-	if msg, exists := MasterErrorMessageMap[code]; exists {
-		message = msg
+	return message
+}
+
+// Localize translates the message into the locale. Messages carrying error details instead of the
+// catalog message are left untouched.
+func (e *Error) Localize(l string) {
+	if e.ErrMsg != GetErrorMessage(e.ErrCode) {
+		return
 	}
 
-	return message
+	if msg := GetLocalizedErrorMessage(e.ErrCode, l); len(msg) > 0 {
+		e.ErrMsg = msg
+	}
 }
@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	UnknownErrorToApplication           = "0000001"
 	FailedToBeginTx                     = "0000002"
@@ -17,8 +19,53 @@ const (
 	APINotFound                         = "0000014"
 )
 
-var GeneralErrorMessageMap = map[string]string{
-	MissingAuthToken: "missing auth token",
-	AssetNotFound:    "query results no asset found",
-	APINotFound:      "the api you are looking for is not found",
+var GeneralErrorMessageMap = locale.Catalog{
+	locale.ZhTW: {
+		UnknownErrorToApplication:           "發生未知錯誤",
+		FailedToBeginTx:                     "無法開始資料庫交易",
+		FailedToCommitTx:                    "無法提交資料庫交易",
+		FailedToValidateRequestBody:         "請求內容格式錯誤",
+		FailedToConvertNullSQLStringToFloat: "數值轉換失敗",
+		FailedToBindJwtInHeader:             "無法讀取標頭中的 jwt token",
+		MissingAuthToken:                    "缺少驗證 token",
+		FailedToBindBodyParams:              "請求參數格式錯誤",
+		FailedToParsePaginateParams:         "分頁參數格式錯誤",
+		AssetNotFound:                       "查無資料",
+		FailedToGenerateShortId:             "無法產生識別碼",
+		DBConnectionError:                   "資料庫連線異常",
+		RedisConnectionError:                "快取連線異常",
+		APINotFound:                         "找不到此 API",
+	},
+	locale.En: {
+		UnknownErrorToApplication:           "unknown error occurred",
+		FailedToBeginTx:                     "failed to begin transaction",
+		FailedToCommitTx:                    "failed to commit transaction",
+		FailedToValidateRequestBody:         "failed to validate request body",
+		FailedToConvertNullSQLStringToFloat: "failed to convert value to float",
+		FailedToBindJwtInHeader:             "failed to bind jwt token in header",
+		MissingAuthToken:                    "missing auth token",
+		FailedToBindBodyParams:              "failed to bind body params",
+		FailedToParsePaginateParams:         "failed to parse pagination params",
+		AssetNotFound:                       "query results no asset found",
+		FailedToGenerateShortId:             "failed to generate short id",
+		DBConnectionError:                   "database connection error",
+		RedisConnectionError:                "redis connection error",
+		APINotFound:                         "the api you are looking for is not found",
+	},
+	locale.Ja: {
+		UnknownErrorToApplication:           "不明なエラーが発生しました",
+		FailedToBeginTx:                     "トランザクションを開始できませんでした",
+		FailedToCommitTx:                    "トランザクションをコミットできませんでした",
+		FailedToValidateRequestBody:         "リクエスト内容が正しくありません",
+		FailedToConvertNullSQLStringToFloat: "数値の変換に失敗しました",
+		FailedToBindJwtInHeader:             "ヘッダーの jwt トークンを読み取れませんでした",
+		MissingAuthToken:                    "認証トークンがありません",
+		FailedToBindBodyParams:              "リクエストパラメータが正しくありません",
+		FailedToParsePaginateParams:         "ページングパラメータが正しくありません",
+		AssetNotFound:                       "データが見つかりません",
+		FailedToGenerateShortId:             "識別子を生成できませんでした",
+		DBConnectionError:                   "データベースの接続に異常があります",
+		RedisConnectionError:                "キャッシュの接続に異常があります",
+		APINotFound:                         "指定された API は存在しません",
+	},
 }
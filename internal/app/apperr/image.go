@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToRetrieveFormFileFromRequest = "4000001"
 	FailedToCopyFileToGCS               = "4000002"
@@ -17,7 +19,53 @@ const (
 	NoImageUploaded                     = "4000015"
 )
 
-var ImageErrCodeMap = map[string]string{
-	NoImageUploaded:    "no imaged found",
-	ImageFieldNotFound: "image field is required",
+var ImageErrCodeMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToRetrieveFormFileFromRequest: "無法讀取上傳的檔案",
+		FailedToCopyFileToGCS:               "上傳檔案失敗",
+		FailedToCloseObjectWriter:           "上傳檔案失敗",
+		FailedToSetObjectPublic:             "無法公開檔案",
+		FailedToGetObjectAttrs:              "無法取得檔案資訊",
+		FailedToInitGCSClient:               "無法連線檔案儲存服務",
+		FailedToParseMultipartForm:          "無法解析上傳內容",
+		FailedToOpenMultipartFile:           "無法開啟上傳的檔案",
+		FailedToGetImagesByUserID:           "取得用戶照片失敗",
+		FailedToUploadImagesToGCS:           "上傳照片失敗",
+		FailedToSendImageMessage:            "發送圖片訊息失敗",
+		FailedToCropImages:                  "裁切照片失敗",
+		ImageFieldNotFound:                  "請上傳照片",
+		NoImageUploaded:                     "沒有上傳任何照片",
+	},
+	locale.En: {
+		FailedToRetrieveFormFileFromRequest: "failed to retrieve form file from request",
+		FailedToCopyFileToGCS:               "failed to copy file to gcs",
+		FailedToCloseObjectWriter:           "failed to close object writer",
+		FailedToSetObjectPublic:             "failed to set object public",
+		FailedToGetObjectAttrs:              "failed to get object attrs",
+		FailedToInitGCSClient:               "failed to init gcs client",
+		FailedToParseMultipartForm:          "failed to parse multipart form",
+		FailedToOpenMultipartFile:           "failed to open multipart file",
+		FailedToGetImagesByUserID:           "failed to get images by user id",
+		FailedToUploadImagesToGCS:           "failed to upload images to gcs",
+		FailedToSendImageMessage:            "failed to send image message",
+		FailedToCropImages:                  "failed to crop images",
+		ImageFieldNotFound:                  "image field is required",
+		NoImageUploaded:                     "no imaged found",
+	},
+	locale.Ja: {
+		FailedToRetrieveFormFileFromRequest: "アップロードされたファイルを読み取れませんでした",
+		FailedToCopyFileToGCS:               "ファイルのアップロードに失敗しました",
+		FailedToCloseObjectWriter:           "ファイルのアップロードに失敗しました",
+		FailedToSetObjectPublic:             "ファイルを公開できませんでした",
+		FailedToGetObjectAttrs:              "ファイル情報を取得できませんでした",
+		FailedToInitGCSClient:               "ストレージサービスに接続できませんでした",
+		FailedToParseMultipartForm:          "アップロード内容を解析できませんでした",
+		FailedToOpenMultipartFile:           "アップロードされたファイルを開けませんでした",
+		FailedToGetImagesByUserID:           "ユーザーの写真の取得に失敗しました",
+		FailedToUploadImagesToGCS:           "写真のアップロードに失敗しました",
+		FailedToSendImageMessage:            "画像メッセージの送信に失敗しました",
+		FailedToCropImages:                  "写真の切り抜きに失敗しました",
+		ImageFieldNotFound:                  "写真をアップロードしてください",
+		NoImageUploaded:                     "写真がアップロードされていません",
+	},
 }
@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToValidateEmitInquiryParams      = "3000001"
 	OnlyMaleCanEmitInquiry                 = "3000002"
@@ -70,23 +72,254 @@ const (
 	FailedToGetDirectInquiryChatrooms        = "3000066"
 	FailedToGetInquiryRequest                = "3000067"
 	FailedToSendDirectInquiryFCM             = "3000068"
+	FailedToApplyInquiry                     = "3000069"
+	AlreadyAppliedInquiry                    = "3000070"
+	FailedToGetInquiryApplicants             = "3000071"
+	OnlyInquirerCanManageApplicants          = "3000072"
+	ApplicantUuidRequired                    = "3000073"
+	ApplicantNotApplied                      = "3000074"
+	FailedToAcceptApplicant                  = "3000075"
+	FailedToDeclineApplicant                 = "3000076"
+	FailedToUpdateFirestoreApplicants        = "3000077"
+	ApplicantAlreadySettled                  = "3000078"
 )
 
-var InquiryErrCodeMsgMap = map[string]string{
-	OnlyMaleCanEmitInquiry:                 "only male user can emit inquiry",
-	OnlyFemaleCanApproveInquiry:            "only female user can approve inquiry",
-	FailedToGetInquiryByInquirerID:         "failed to get inquiry by inquirer ID",
-	UserAlreadyHasActiveInquiry:            "user already has active inquiry",
-	UserNotOwnInquiry:                      "user does not own the inquiry",
-	FailedToPatchInquiryStatus:             "failed to patch inquiry status",
-	ParamsNotProperlySetInTheMiddleware:    "params not properly set to the context in the previous middleware, please check",
-	CanNotPickupExpiredInquiry:             "can not pickup expired inquiry",
-	OnlyMaleCanBookService:                 "only male can book service",
-	OnlyFemaleUserCanAccessAPI:             "only female user can access API",
-	FailedToPickupInquiryDueToDirtyVersion: "inquiry has been modified by other requests. Please pick another request or try again later",
-	FailedToPickupStatusNotWaiting:         "inquiry not available, status is not waiting",
-	InquiryUUIDNotInParams:                 "inquiry uuid not exists in uri param",
-	FailedToPickupStatusNotInquiring:       "can not pickup inquiry since status is not inquiring",
-	InquiryHasNoPicker:                     "can not start a chat since inquiry has no picker",
-	FailedToSendQuitChatroomMsg:            "failed to get send quit chatroom message",
+var InquiryErrCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidateEmitInquiryParams:        "發送詢問的資料格式錯誤",
+		OnlyMaleCanEmitInquiry:                   "只有男性用戶可以發送詢問",
+		FailedToGetInquiryByInquirerID:           "取得詢問失敗",
+		UserAlreadyHasActiveInquiry:              "您已有進行中的詢問",
+		FailedToCreateInquiry:                    "建立詢問失敗",
+		FailedToValidateCancelInquiryParams:      "詢問資料格式錯誤",
+		FailedToGetInquiryByUuid:                 "取得詢問失敗",
+		FailedToGetUserIDByUuid:                  "取得用戶資料失敗",
+		UserNotOwnInquiry:                        "此詢問不屬於您",
+		FailedToPatchInquiryStatus:               "更新詢問狀態失敗",
+		InquiryFSMTransitionFailed:               "詢問目前的狀態無法進行此操作",
+		FailedToCheckGender:                      "無法確認用戶性別",
+		ParamsNotProperlySetInTheMiddleware:      "中介層未正確設定參數",
+		CanNotPickupExpiredInquiry:               "無法接受已過期的詢問",
+		FailedToGetInquiererByID:                 "取得詢問者資料失敗",
+		GirlApproveInquiry:                       "女性用戶同意詢問失敗",
+		FailedToUpdateInquiryContent:             "更新詢問內容失敗",
+		FailedToTransformGirlApproveInquiry:      "無法整理同意詢問的回應",
+		OnlyFemaleCanApproveInquiry:              "只有女性用戶可以同意詢問",
+		FailedToValidateBookInquiryParams:        "預約資料格式錯誤",
+		OnlyMaleCanBookService:                   "只有男性用戶可以預約服務",
+		FSMNotSetInMiddleware:                    "中介層未設定詢問狀態機",
+		FailedToCheckActiveInquiry:               "無法確認進行中的詢問",
+		FailedToGetInquiryList:                   "取得詢問列表失敗",
+		FailedToValidateGetInquiryListParams:     "詢問列表查詢資料格式錯誤",
+		FailedToTransformGetInquiriesResponse:    "無法整理詢問列表的回應",
+		OnlyFemaleUserCanAccessAPI:               "只有女性用戶可以使用此功能",
+		FailedToCheckHasMoreInquiry:              "無法確認是否有更多詢問",
+		FailedToValidateGetInquiryParams:         "詢問查詢資料格式錯誤",
+		FailedToTransformGetInquiry:              "無法整理詢問的回應",
+		FailedToPickupInquiryDueToDirtyVersion:   "詢問已被其他請求修改，請選擇其他詢問或稍後再試",
+		FailedToLeaveLobby:                       "離開等待區失敗",
+		FailedToCheckLobbyExpiry:                 "無法確認等待區是否過期",
+		FailedToCreateAndJoinLobby:               "加入等待區失敗",
+		FailedToTransformResponse:                "無法整理回應",
+		FailedToPickupInquiry:                    "接受詢問失敗",
+		FailedToTransformServiceModel:            "無法整理服務資料",
+		FailedToGetInquirerByInquiryUUID:         "取得詢問者資料失敗",
+		FailedToTransformInquirerResponse:        "無法整理詢問者資料",
+		FailedToGetLobbyUserByInquiryID:          "取得等待區用戶失敗",
+		FailedToPickupStatusNotInquiring:         "詢問已不在詢問中，無法接受",
+		FailedToAskInquiringUser:                 "回覆詢問失敗",
+		FailedToBindInquiryUriParams:             "詢問路徑參數格式錯誤",
+		FailedToChangeFirestoreInquiryStatus:     "同步詢問狀態失敗",
+		FailedToPickupStatusNotWaiting:           "詢問無法接受，狀態不是等待中",
+		FailedToCreateFSM:                        "無法建立詢問狀態機",
+		FailedToCreatePrivateChatroomInFirestore: "建立私人聊天室失敗",
+		FailedToUpdateInquiry:                    "更新詢問失敗",
+		InquiryUUIDNotInParams:                   "路徑參數缺少詢問識別碼",
+		FailedToValidatePatchInquiryParams:       "更新詢問的資料格式錯誤",
+		FailedToPatchInquiry:                     "更新詢問失敗",
+		FailedToTransformUpdateInquiry:           "無法整理更新詢問的回應",
+		FailedToActiveInquiry:                    "取得進行中的詢問失敗",
+		FailedToTransformActiveInquiry:           "無法整理進行中詢問的回應",
+		NoActiveInquiry:                          "沒有進行中的詢問",
+		InquiryHasNoPicker:                       "詢問尚無人接受，無法開始聊天",
+		FailedToGetChatroomById:                  "取得聊天室失敗",
+		FailedToSendQuitChatroomMsg:              "發送離開聊天室訊息失敗",
+		FailedToPrepareStartInquiryChat:          "準備詢問聊天室失敗",
+		FailedToCreatePubsubTopic:                "建立推播頻道失敗",
+		FailedToDeletePubsubTopic:                "刪除推播頻道失敗",
+		FailedToPublishPickupInquiryFCM:          "發送接受詢問通知失敗",
+		FailedToPublishMaleAgreeToChatFCM:        "發送同意聊天通知失敗",
+		FailedToCreateDirectInquiry:              "建立指定詢問失敗",
+		FailedToGetDirectInquiryChatrooms:        "取得指定詢問聊天室失敗",
+		FailedToGetInquiryRequest:                "取得詢問請求失敗",
+		FailedToSendDirectInquiryFCM:             "發送指定詢問通知失敗",
+		FailedToApplyInquiry:                     "回覆詢問失敗",
+		AlreadyAppliedInquiry:                    "您已回覆過此詢問",
+		FailedToGetInquiryApplicants:             "取得回覆者列表失敗",
+		OnlyInquirerCanManageApplicants:          "只有發送詢問的用戶可以查看或回覆回覆者",
+		ApplicantUuidRequired:                    "請指定回覆者",
+		ApplicantNotApplied:                      "該用戶尚未回覆此詢問或已被婉拒",
+		FailedToAcceptApplicant:                  "接受回覆者失敗",
+		FailedToDeclineApplicant:                 "婉拒回覆者失敗",
+		FailedToUpdateFirestoreApplicants:        "更新回覆者狀態失敗",
+		ApplicantAlreadySettled:                  "此詢問或回覆者已被處理，請重新整理",
+	},
+	locale.En: {
+		FailedToValidateEmitInquiryParams:        "failed to validate emit inquiry params",
+		OnlyMaleCanEmitInquiry:                   "only male user can emit inquiry",
+		FailedToGetInquiryByInquirerID:           "failed to get inquiry by inquirer ID",
+		UserAlreadyHasActiveInquiry:              "user already has active inquiry",
+		FailedToCreateInquiry:                    "failed to create inquiry",
+		FailedToValidateCancelInquiryParams:      "failed to validate inquiry params",
+		FailedToGetInquiryByUuid:                 "failed to get inquiry by uuid",
+		FailedToGetUserIDByUuid:                  "failed to get user id by uuid",
+		UserNotOwnInquiry:                        "user does not own the inquiry",
+		FailedToPatchInquiryStatus:               "failed to patch inquiry status",
+		InquiryFSMTransitionFailed:               "inquiry can not transit to the requested status",
+		FailedToCheckGender:                      "failed to check gender",
+		ParamsNotProperlySetInTheMiddleware:      "params not properly set to the context in the previous middleware, please check",
+		CanNotPickupExpiredInquiry:               "can not pickup expired inquiry",
+		FailedToGetInquiererByID:                 "failed to get inquirer by id",
+		GirlApproveInquiry:                       "failed to approve inquiry",
+		FailedToUpdateInquiryContent:             "failed to update inquiry content",
+		FailedToTransformGirlApproveInquiry:      "failed to transform approve inquiry response",
+		OnlyFemaleCanApproveInquiry:              "only female user can approve inquiry",
+		FailedToValidateBookInquiryParams:        "failed to validate book inquiry params",
+		OnlyMaleCanBookService:                   "only male can book service",
+		FSMNotSetInMiddleware:                    "inquiry fsm is not set in the middleware",
+		FailedToCheckActiveInquiry:               "failed to check active inquiry",
+		FailedToGetInquiryList:                   "failed to get inquiry list",
+		FailedToValidateGetInquiryListParams:     "failed to validate get inquiry list params",
+		FailedToTransformGetInquiriesResponse:    "failed to transform inquiries response",
+		OnlyFemaleUserCanAccessAPI:               "only female user can access API",
+		FailedToCheckHasMoreInquiry:              "failed to check has more inquiry",
+		FailedToValidateGetInquiryParams:         "failed to validate get inquiry params",
+		FailedToTransformGetInquiry:              "failed to transform inquiry response",
+		FailedToPickupInquiryDueToDirtyVersion:   "inquiry has been modified by other requests. Please pick another request or try again later",
+		FailedToLeaveLobby:                       "failed to leave lobby",
+		FailedToCheckLobbyExpiry:                 "failed to check lobby expiry",
+		FailedToCreateAndJoinLobby:               "failed to create and join lobby",
+		FailedToTransformResponse:                "failed to transform response",
+		FailedToPickupInquiry:                    "failed to pickup inquiry",
+		FailedToTransformServiceModel:            "failed to transform service",
+		FailedToGetInquirerByInquiryUUID:         "failed to get inquirer by inquiry uuid",
+		FailedToTransformInquirerResponse:        "failed to transform inquirer response",
+		FailedToGetLobbyUserByInquiryID:          "failed to get lobby user by inquiry id",
+		FailedToPickupStatusNotInquiring:         "can not pickup inquiry since status is not inquiring",
+		FailedToAskInquiringUser:                 "failed to ask inquiring user",
+		FailedToBindInquiryUriParams:             "failed to bind inquiry uri params",
+		FailedToChangeFirestoreInquiryStatus:     "failed to change inquiry status in firestore",
+		FailedToPickupStatusNotWaiting:           "inquiry not available, status is not waiting",
+		FailedToCreateFSM:                        "failed to create fsm",
+		FailedToCreatePrivateChatroomInFirestore: "failed to create private chatroom in firestore",
+		FailedToUpdateInquiry:                    "failed to update inquiry",
+		InquiryUUIDNotInParams:                   "inquiry uuid not exists in uri param",
+		FailedToValidatePatchInquiryParams:       "failed to validate patch inquiry params",
+		FailedToPatchInquiry:                     "failed to patch inquiry",
+		FailedToTransformUpdateInquiry:           "failed to transform update inquiry response",
+		FailedToActiveInquiry:                    "failed to get active inquiry",
+		FailedToTransformActiveInquiry:           "failed to transform active inquiry response",
+		NoActiveInquiry:                          "no active inquiry",
+		InquiryHasNoPicker:                       "can not start a chat since inquiry has no picker",
+		FailedToGetChatroomById:                  "failed to get chatroom by id",
+		FailedToSendQuitChatroomMsg:              "failed to get send quit chatroom message",
+		FailedToPrepareStartInquiryChat:          "failed to prepare inquiry chat",
+		FailedToCreatePubsubTopic:                "failed to create pubsub topic",
+		FailedToDeletePubsubTopic:                "failed to delete pubsub topic",
+		FailedToPublishPickupInquiryFCM:          "failed to publish pickup inquiry notification",
+		FailedToPublishMaleAgreeToChatFCM:        "failed to publish agree to chat notification",
+		FailedToCreateDirectInquiry:              "failed to create direct inquiry",
+		FailedToGetDirectInquiryChatrooms:        "failed to get direct inquiry chatrooms",
+		FailedToGetInquiryRequest:                "failed to get inquiry requests",
+		FailedToSendDirectInquiryFCM:             "failed to send direct inquiry notification",
+		FailedToApplyInquiry:                     "failed to apply for the inquiry",
+		AlreadyAppliedInquiry:                    "already applied for the inquiry",
+		FailedToGetInquiryApplicants:             "failed to get applicants of the inquiry",
+		OnlyInquirerCanManageApplicants:          "only the inquirer can view or reply to applicants",
+		ApplicantUuidRequired:                    "applicant uuid is required",
+		ApplicantNotApplied:                      "the user has not applied for the inquiry or has been declined",
+		FailedToAcceptApplicant:                  "failed to accept the applicant",
+		FailedToDeclineApplicant:                 "failed to decline the applicant",
+		FailedToUpdateFirestoreApplicants:        "failed to update applicants on firestore",
+		ApplicantAlreadySettled:                  "the inquiry or the applicant has already been settled",
+	},
+	locale.Ja: {
+		FailedToValidateEmitInquiryParams:        "依頼の情報が正しくありません",
+		OnlyMaleCanEmitInquiry:                   "依頼を送信できるのは男性ユーザーのみです",
+		FailedToGetInquiryByInquirerID:           "依頼の取得に失敗しました",
+		UserAlreadyHasActiveInquiry:              "進行中の依頼が既にあります",
+		FailedToCreateInquiry:                    "依頼の作成に失敗しました",
+		FailedToValidateCancelInquiryParams:      "依頼の情報が正しくありません",
+		FailedToGetInquiryByUuid:                 "依頼の取得に失敗しました",
+		FailedToGetUserIDByUuid:                  "ユーザー情報の取得に失敗しました",
+		UserNotOwnInquiry:                        "この依頼はあなたのものではありません",
+		FailedToPatchInquiryStatus:               "依頼の状態の更新に失敗しました",
+		InquiryFSMTransitionFailed:               "現在の依頼の状態ではこの操作はできません",
+		FailedToCheckGender:                      "ユーザーの性別を確認できませんでした",
+		ParamsNotProperlySetInTheMiddleware:      "ミドルウェアでパラメータが正しく設定されていません",
+		CanNotPickupExpiredInquiry:               "期限切れの依頼は受けられません",
+		FailedToGetInquiererByID:                 "依頼者の情報の取得に失敗しました",
+		GirlApproveInquiry:                       "依頼の承認に失敗しました",
+		FailedToUpdateInquiryContent:             "依頼内容の更新に失敗しました",
+		FailedToTransformGirlApproveInquiry:      "依頼承認のレスポンスを生成できませんでした",
+		OnlyFemaleCanApproveInquiry:              "依頼を承認できるのは女性ユーザーのみです",
+		FailedToValidateBookInquiryParams:        "予約の情報が正しくありません",
+		OnlyMaleCanBookService:                   "サービスを予約できるのは男性ユーザーのみです",
+		FSMNotSetInMiddleware:                    "ミドルウェアで依頼のステートマシンが設定されていません",
+		FailedToCheckActiveInquiry:               "進行中の依頼を確認できませんでした",
+		FailedToGetInquiryList:                   "依頼一覧の取得に失敗しました",
+		FailedToValidateGetInquiryListParams:     "依頼一覧の検索条件が正しくありません",
+		FailedToTransformGetInquiriesResponse:    "依頼一覧のレスポンスを生成できませんでした",
+		OnlyFemaleUserCanAccessAPI:               "この機能は女性ユーザーのみ利用できます",
+		FailedToCheckHasMoreInquiry:              "依頼の続きがあるか確認できませんでした",
+		FailedToValidateGetInquiryParams:         "依頼の検索条件が正しくありません",
+		FailedToTransformGetInquiry:              "依頼のレスポンスを生成できませんでした",
+		FailedToPickupInquiryDueToDirtyVersion:   "依頼は他のリクエストにより変更されました。別の依頼を選ぶか、しばらくしてから再度お試しください",
+		FailedToLeaveLobby:                       "ロビーからの退出に失敗しました",
+		FailedToCheckLobbyExpiry:                 "ロビーの期限を確認できませんでした",
+		FailedToCreateAndJoinLobby:               "ロビーへの参加に失敗しました",
+		FailedToTransformResponse:                "レスポンスを生成できませんでした",
+		FailedToPickupInquiry:                    "依頼の受付に失敗しました",
+		FailedToTransformServiceModel:            "サービス情報を生成できませんでした",
+		FailedToGetInquirerByInquiryUUID:         "依頼者の情報の取得に失敗しました",
+		FailedToTransformInquirerResponse:        "依頼者情報のレスポンスを生成できませんでした",
+		FailedToGetLobbyUserByInquiryID:          "ロビーのユーザーの取得に失敗しました",
+		FailedToPickupStatusNotInquiring:         "依頼は募集中ではないため受けられません",
+		FailedToAskInquiringUser:                 "依頼者への返信に失敗しました",
+		FailedToBindInquiryUriParams:             "依頼の URI パラメータが正しくありません",
+		FailedToChangeFirestoreInquiryStatus:     "依頼の状態の同期に失敗しました",
+		FailedToPickupStatusNotWaiting:           "依頼は待機中ではないため受けられません",
+		FailedToCreateFSM:                        "依頼のステートマシンを作成できませんでした",
+		FailedToCreatePrivateChatroomInFirestore: "個別チャットルームの作成に失敗しました",
+		FailedToUpdateInquiry:                    "依頼の更新に失敗しました",
+		InquiryUUIDNotInParams:                   "URI パラメータに依頼の識別子がありません",
+		FailedToValidatePatchInquiryParams:       "依頼更新の情報が正しくありません",
+		FailedToPatchInquiry:                     "依頼の更新に失敗しました",
+		FailedToTransformUpdateInquiry:           "依頼更新のレスポンスを生成できませんでした",
+		FailedToActiveInquiry:                    "進行中の依頼の取得に失敗しました",
+		FailedToTransformActiveInquiry:           "進行中の依頼のレスポンスを生成できませんでした",
+		NoActiveInquiry:                          "進行中の依頼はありません",
+		InquiryHasNoPicker:                       "依頼を受けた人がいないためチャットを開始できません",
+		FailedToGetChatroomById:                  "チャットルームの取得に失敗しました",
+		FailedToSendQuitChatroomMsg:              "チャットルーム退出メッセージの送信に失敗しました",
+		FailedToPrepareStartInquiryChat:          "依頼のチャットの準備に失敗しました",
+		FailedToCreatePubsubTopic:                "配信トピックの作成に失敗しました",
+		FailedToDeletePubsubTopic:                "配信トピックの削除に失敗しました",
+		FailedToPublishPickupInquiryFCM:          "依頼受付の通知の送信に失敗しました",
+		FailedToPublishMaleAgreeToChatFCM:        "チャット承諾の通知の送信に失敗しました",
+		FailedToCreateDirectInquiry:              "指名依頼の作成に失敗しました",
+		FailedToGetDirectInquiryChatrooms:        "指名依頼のチャットルームの取得に失敗しました",
+		FailedToGetInquiryRequest:                "依頼リクエストの取得に失敗しました",
+		FailedToSendDirectInquiryFCM:             "指名依頼の通知の送信に失敗しました",
+		FailedToApplyInquiry:                     "依頼への応募に失敗しました",
+		AlreadyAppliedInquiry:                    "この依頼には既に応募しています",
+		FailedToGetInquiryApplicants:             "応募者一覧の取得に失敗しました",
+		OnlyInquirerCanManageApplicants:          "依頼者のみ応募者の確認や返答ができます",
+		ApplicantUuidRequired:                    "応募者を指定してください",
+		ApplicantNotApplied:                      "このユーザーは依頼に応募していないか、既に見送られています",
+		FailedToAcceptApplicant:                  "応募者の承諾に失敗しました",
+		FailedToDeclineApplicant:                 "応募者の見送りに失敗しました",
+		FailedToUpdateFirestoreApplicants:        "応募者の状態の更新に失敗しました",
+		ApplicantAlreadySettled:                  "この依頼または応募者は既に処理されています",
+	},
 }
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
 )
 
 // @TODO figure out why the following statement is able to convert gin.Error to apperr.Error
@@ -29,6 +30,8 @@ func HandleError() gin.HandlerFunc {
 				c.Writer.WriteHeader(http.StatusInternalServerError)
 			}
 
+			parsedErr.Localize(locale.FromContext(c))
+
 			c.JSON(c.Writer.Status(), parsedErr)
 
 			return
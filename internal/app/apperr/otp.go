@@ -0,0 +1,31 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	TooManyOTPAttempts       = "2400001"
+	TooManyOTPRequests       = "2400002"
+	FailedToCheckOTPGuard    = "2400003"
+	FailedToRecordOTPAttempt = "2400004"
+)
+
+var otpErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		TooManyOTPAttempts:       "驗證碼錯誤次數過多，請稍後再試",
+		TooManyOTPRequests:       "驗證碼發送過於頻繁，請稍後再試",
+		FailedToCheckOTPGuard:    "無法確認驗證碼請求限制",
+		FailedToRecordOTPAttempt: "無法記錄驗證碼嘗試",
+	},
+	locale.En: {
+		TooManyOTPAttempts:       "too many incorrect verify code attempts, please try again later",
+		TooManyOTPRequests:       "verify codes are requested too frequently, please try again later",
+		FailedToCheckOTPGuard:    "failed to check otp guard",
+		FailedToRecordOTPAttempt: "failed to record otp attempt",
+	},
+	locale.Ja: {
+		TooManyOTPAttempts:       "認証コードの誤入力が多すぎます。しばらくしてから再度お試しください",
+		TooManyOTPRequests:       "認証コードの送信が頻繁すぎます。しばらくしてから再度お試しください",
+		FailedToCheckOTPGuard:    "認証コードの制限を確認できませんでした",
+		FailedToRecordOTPAttempt: "認証コードの試行を記録できませんでした",
+	},
+}
@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 var (
 	PayerIsNotTheCustomerOfTheService      = "2000001"
 	ServiceStatusInvalidForPayment         = "2000002"
@@ -12,7 +14,38 @@ var (
 	FailedToInitStringToDeci               = "2000009"
 )
 
-var PaymentErrCodeMsgMap = map[string]string{
-	PayerIsNotTheCustomerOfTheService: "payer is not the customer of the service",
-	ServiceStatusInvalidForPayment:    "service status invalid to pay",
+var PaymentErrCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		PayerIsNotTheCustomerOfTheService:      "付款者不是此服務的客戶",
+		ServiceStatusInvalidForPayment:         "服務目前的狀態無法付款",
+		FailedToCheckHasEnoughBalance:          "無法確認餘額是否足夠",
+		FailedToGetMatchingFee:                 "取得媒合費失敗",
+		FailedToDeductBalance:                  "扣除餘額失敗",
+		FailedToCreatePayment:                  "建立付款失敗",
+		FailedToTransfromCreatePaymentResponse: "無法整理付款的回應",
+		FailedToPublishServicePaidNotification: "發送付款完成通知失敗",
+		FailedToInitStringToDeci:               "金額轉換失敗",
+	},
+	locale.En: {
+		PayerIsNotTheCustomerOfTheService:      "payer is not the customer of the service",
+		ServiceStatusInvalidForPayment:         "service status invalid to pay",
+		FailedToCheckHasEnoughBalance:          "failed to check has enough balance",
+		FailedToGetMatchingFee:                 "failed to get matching fee",
+		FailedToDeductBalance:                  "failed to deduct balance",
+		FailedToCreatePayment:                  "failed to create payment",
+		FailedToTransfromCreatePaymentResponse: "failed to transform create payment response",
+		FailedToPublishServicePaidNotification: "failed to publish service paid notification",
+		FailedToInitStringToDeci:               "failed to convert amount to decimal",
+	},
+	locale.Ja: {
+		PayerIsNotTheCustomerOfTheService:      "支払者はこのサービスの顧客ではありません",
+		ServiceStatusInvalidForPayment:         "現在のサービスの状態では支払いできません",
+		FailedToCheckHasEnoughBalance:          "残高が足りているか確認できませんでした",
+		FailedToGetMatchingFee:                 "マッチング手数料の取得に失敗しました",
+		FailedToDeductBalance:                  "残高の引き落としに失敗しました",
+		FailedToCreatePayment:                  "支払いの作成に失敗しました",
+		FailedToTransfromCreatePaymentResponse: "支払いのレスポンスを生成できませんでした",
+		FailedToPublishServicePaidNotification: "支払い完了の通知の送信に失敗しました",
+		FailedToInitStringToDeci:               "金額の変換に失敗しました",
+	},
 }
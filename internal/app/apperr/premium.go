@@ -0,0 +1,51 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToValidatePremiumParams = "3100001"
+	FailedToGetPremiumPlans       = "3100002"
+	PremiumPlanNotFound           = "3100003"
+	FailedToGetPremiumPlan        = "3100004"
+	LifetimePremiumCanNotPurchase = "3100005"
+	FailedToPurchasePremium       = "3100006"
+	FailedToGetPremiumPurchases   = "3100007"
+	FailedToCheckPremium          = "3100008"
+	PremiumRequired               = "3100009"
+)
+
+var premiumErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidatePremiumParams: "會員方案資料格式錯誤",
+		FailedToGetPremiumPlans:       "取得會員方案列表失敗",
+		PremiumPlanNotFound:           "方案不存在",
+		FailedToGetPremiumPlan:        "取得會員方案失敗",
+		LifetimePremiumCanNotPurchase: "您已是永久會員",
+		FailedToPurchasePremium:       "購買會員方案失敗",
+		FailedToGetPremiumPurchases:   "取得會員購買紀錄失敗",
+		FailedToCheckPremium:          "無法確認會員資格",
+		PremiumRequired:               "此功能僅限付費會員使用",
+	},
+	locale.En: {
+		FailedToValidatePremiumParams: "failed to validate premium params",
+		FailedToGetPremiumPlans:       "failed to get premium plans",
+		PremiumPlanNotFound:           "plan not found",
+		FailedToGetPremiumPlan:        "failed to get premium plan",
+		LifetimePremiumCanNotPurchase: "you are already a lifetime member",
+		FailedToPurchasePremium:       "failed to purchase premium",
+		FailedToGetPremiumPurchases:   "failed to get premium purchases",
+		FailedToCheckPremium:          "failed to check premium",
+		PremiumRequired:               "this feature is only available to premium members",
+	},
+	locale.Ja: {
+		FailedToValidatePremiumParams: "会員プランの情報が正しくありません",
+		FailedToGetPremiumPlans:       "会員プラン一覧の取得に失敗しました",
+		PremiumPlanNotFound:           "プランが存在しません",
+		FailedToGetPremiumPlan:        "会員プランの取得に失敗しました",
+		LifetimePremiumCanNotPurchase: "既に永久会員です",
+		FailedToPurchasePremium:       "会員プランの購入に失敗しました",
+		FailedToGetPremiumPurchases:   "会員プランの購入履歴の取得に失敗しました",
+		FailedToCheckPremium:          "会員資格を確認できませんでした",
+		PremiumRequired:               "この機能は有料会員のみ利用できます",
+	},
+}
@@ -0,0 +1,35 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToValidatePresenceParams = "2900001"
+	FailedToUpdatePresence         = "2900002"
+	FailedToGetPresenceSetting     = "2900003"
+	FailedToSetPresenceVisibility  = "2900004"
+	FailedToGetPresences           = "2900005"
+)
+
+var presenceErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidatePresenceParams: "上線狀態資料格式錯誤",
+		FailedToUpdatePresence:         "更新上線狀態失敗",
+		FailedToGetPresenceSetting:     "取得上線狀態設定失敗",
+		FailedToSetPresenceVisibility:  "設定上線狀態顯示失敗",
+		FailedToGetPresences:           "取得上線狀態失敗",
+	},
+	locale.En: {
+		FailedToValidatePresenceParams: "failed to validate presence params",
+		FailedToUpdatePresence:         "failed to update presence",
+		FailedToGetPresenceSetting:     "failed to get presence setting",
+		FailedToSetPresenceVisibility:  "failed to set presence visibility",
+		FailedToGetPresences:           "failed to get presences",
+	},
+	locale.Ja: {
+		FailedToValidatePresenceParams: "オンライン状態の情報が正しくありません",
+		FailedToUpdatePresence:         "オンライン状態の更新に失敗しました",
+		FailedToGetPresenceSetting:     "オンライン状態の設定の取得に失敗しました",
+		FailedToSetPresenceVisibility:  "オンライン状態の表示設定に失敗しました",
+		FailedToGetPresences:           "オンライン状態の取得に失敗しました",
+	},
+}
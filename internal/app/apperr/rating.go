@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 var (
 	FailedToGetServicePartnerInfo = "1900001"
 	FailedToGetServiceRating      = "1900002"
@@ -11,7 +13,35 @@ var (
 	UserNotServiceParticipant     = "1900008"
 )
 
-var RatingErrCodeMsgMap = map[string]string{
-	NotInvolveInService:       "requester no involved in service",
-	UserNotServiceParticipant: "user is not a service participant",
+var RatingErrCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToGetServicePartnerInfo: "取得服務對象資料失敗",
+		FailedToGetServiceRating:      "取得服務評價失敗",
+		NotInvolveInService:           "您未參與此服務",
+		ServiceNotRatable:             "此服務目前無法評價",
+		FailedToCreateServiceRating:   "建立服務評價失敗",
+		FailedToGetRatings:            "取得評價失敗",
+		FailedToCheckIsParticipant:    "無法確認是否為服務參與者",
+		UserNotServiceParticipant:     "用戶不是服務參與者",
+	},
+	locale.En: {
+		FailedToGetServicePartnerInfo: "failed to get service partner info",
+		FailedToGetServiceRating:      "failed to get service rating",
+		NotInvolveInService:           "requester no involved in service",
+		ServiceNotRatable:             "service is not ratable",
+		FailedToCreateServiceRating:   "failed to create service rating",
+		FailedToGetRatings:            "failed to get ratings",
+		FailedToCheckIsParticipant:    "failed to check if user is a service participant",
+		UserNotServiceParticipant:     "user is not a service participant",
+	},
+	locale.Ja: {
+		FailedToGetServicePartnerInfo: "サービス相手の情報の取得に失敗しました",
+		FailedToGetServiceRating:      "サービス評価の取得に失敗しました",
+		NotInvolveInService:           "このサービスに参加していません",
+		ServiceNotRatable:             "このサービスは現在評価できません",
+		FailedToCreateServiceRating:   "サービス評価の作成に失敗しました",
+		FailedToGetRatings:            "評価の取得に失敗しました",
+		FailedToCheckIsParticipant:    "サービスの参加者か確認できませんでした",
+		UserNotServiceParticipant:     "ユーザーはサービスの参加者ではありません",
+	},
 }
@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToValidateVerifyReferralCodeParams = "1700001"
 	FailedToGetReferralCode                  = "1700002"
@@ -9,10 +11,45 @@ const (
 	ReferralCodeExpired                      = "1700006"
 	FailedToGetOccupiedRefcode               = "1700007"
 	FailedToCreateReferralCode               = "1700008"
+	FailedToValidateGetReferralRewardsParams = "1700009"
+	FailedToGetReferralRewards               = "1700010"
 )
 
-var ReferralErrorMessageMap = map[string]string{
-	ReferralCodeNotFound:   "referral code given is not found",
-	ReferralCodeIsOccupied: "referral code is occupied",
-	ReferralCodeExpired:    "referral code has expired",
+var ReferralErrorMessageMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidateVerifyReferralCodeParams: "推薦碼資料格式錯誤",
+		FailedToGetReferralCode:                  "取得推薦碼失敗",
+		ReferralCodeNotFound:                     "找不到此推薦碼",
+		ReferralCodeIsOccupied:                   "推薦碼已被使用",
+		FailedToUpdateReferralcode:               "更新推薦碼失敗",
+		ReferralCodeExpired:                      "推薦碼已過期",
+		FailedToGetOccupiedRefcode:               "取得已使用的推薦碼失敗",
+		FailedToCreateReferralCode:               "建立推薦碼失敗",
+		FailedToValidateGetReferralRewardsParams: "推薦獎勵查詢資料格式錯誤",
+		FailedToGetReferralRewards:               "取得推薦獎勵失敗",
+	},
+	locale.En: {
+		FailedToValidateVerifyReferralCodeParams: "failed to validate verify referral code params",
+		FailedToGetReferralCode:                  "failed to get referral code",
+		ReferralCodeNotFound:                     "referral code given is not found",
+		ReferralCodeIsOccupied:                   "referral code is occupied",
+		FailedToUpdateReferralcode:               "failed to update referral code",
+		ReferralCodeExpired:                      "referral code has expired",
+		FailedToGetOccupiedRefcode:               "failed to get occupied referral code",
+		FailedToCreateReferralCode:               "failed to create referral code",
+		FailedToValidateGetReferralRewardsParams: "failed to validate get referral rewards params",
+		FailedToGetReferralRewards:               "failed to get referral rewards",
+	},
+	locale.Ja: {
+		FailedToValidateVerifyReferralCodeParams: "紹介コードの情報が正しくありません",
+		FailedToGetReferralCode:                  "紹介コードの取得に失敗しました",
+		ReferralCodeNotFound:                     "紹介コードが見つかりません",
+		ReferralCodeIsOccupied:                   "紹介コードは既に使用されています",
+		FailedToUpdateReferralcode:               "紹介コードの更新に失敗しました",
+		ReferralCodeExpired:                      "紹介コードの有効期限が切れています",
+		FailedToGetOccupiedRefcode:               "使用済みの紹介コードの取得に失敗しました",
+		FailedToCreateReferralCode:               "紹介コードの作成に失敗しました",
+		FailedToValidateGetReferralRewardsParams: "紹介報酬の検索条件が正しくありません",
+		FailedToGetReferralRewards:               "紹介報酬の取得に失敗しました",
+	},
 }
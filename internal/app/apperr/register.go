@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToValidateVerifyUsernameParams   = "1800001"
 	FailedToVerifyReferralCode             = "1800002"
@@ -12,9 +14,70 @@ const (
 	FailedToUpdateInviteeIdByRefCode       = "1800009"
 	FailedToCheckUserExistsInSMSWhiteList  = "1800010"
 	FailedToSendTwilioSMS                  = "1800011"
+	UsernameTooShort                       = "1800012"
+	UsernameTooLong                        = "1800013"
+	UsernameHasInvalidCharacters           = "1800014"
+	UsernameReserved                       = "1800015"
+	UsernameProfane                        = "1800016"
+	FailedToClaimUsername                  = "1800017"
 )
 
-var RegisterErrCodeMsgMap = map[string]string{
-	PhoneVerifyCodeNotMatch:   "mobile verify code does not match",
-	UserAlreadyMobileVerified: "user mobile has already verified",
+var RegisterErrCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidateVerifyUsernameParams:   "用戶名資料格式錯誤",
+		FailedToVerifyReferralCode:             "驗證推薦碼失敗",
+		FailedToSendMobileVerifyCode:           "發送手機驗證碼失敗",
+		UserNotFoundByUuid:                     "找不到此用戶",
+		FailedToCreateRegisterMobileVerifyCode: "建立註冊驗證碼失敗",
+		UserAlreadyMobileVerified:              "用戶手機已通過驗證",
+		PhoneVerifyCodeNotMatch:                "手機驗證碼不正確",
+		FailedToUpdateUserByUuid:               "更新用戶資料失敗",
+		FailedToUpdateInviteeIdByRefCode:       "更新推薦關係失敗",
+		FailedToCheckUserExistsInSMSWhiteList:  "無法確認簡訊白名單",
+		FailedToSendTwilioSMS:                  "簡訊發送失敗",
+		UsernameTooShort:                       "用戶名太短",
+		UsernameTooLong:                        "用戶名太長",
+		UsernameHasInvalidCharacters:           "用戶名含有無效字元",
+		UsernameReserved:                       "此用戶名為保留名稱",
+		UsernameProfane:                        "用戶名含有不當字詞",
+		FailedToClaimUsername:                  "無法取得此用戶名",
+	},
+	locale.En: {
+		FailedToValidateVerifyUsernameParams:   "failed to validate verify username params",
+		FailedToVerifyReferralCode:             "failed to verify referral code",
+		FailedToSendMobileVerifyCode:           "failed to send mobile verify code",
+		UserNotFoundByUuid:                     "user not found by uuid",
+		FailedToCreateRegisterMobileVerifyCode: "failed to create register mobile verify code",
+		UserAlreadyMobileVerified:              "user mobile has already verified",
+		PhoneVerifyCodeNotMatch:                "mobile verify code does not match",
+		FailedToUpdateUserByUuid:               "failed to update user by uuid",
+		FailedToUpdateInviteeIdByRefCode:       "failed to update invitee by referral code",
+		FailedToCheckUserExistsInSMSWhiteList:  "failed to check sms white list",
+		FailedToSendTwilioSMS:                  "failed to send sms",
+		UsernameTooShort:                       "username is too short",
+		UsernameTooLong:                        "username is too long",
+		UsernameHasInvalidCharacters:           "username contains invalid characters",
+		UsernameReserved:                       "username is reserved",
+		UsernameProfane:                        "username contains inappropriate words",
+		FailedToClaimUsername:                  "failed to claim username",
+	},
+	locale.Ja: {
+		FailedToValidateVerifyUsernameParams:   "ユーザー名の情報が正しくありません",
+		FailedToVerifyReferralCode:             "紹介コードの確認に失敗しました",
+		FailedToSendMobileVerifyCode:           "携帯電話の認証コードの送信に失敗しました",
+		UserNotFoundByUuid:                     "ユーザーが見つかりません",
+		FailedToCreateRegisterMobileVerifyCode: "登録用の認証コードの作成に失敗しました",
+		UserAlreadyMobileVerified:              "携帯電話は既に認証済みです",
+		PhoneVerifyCodeNotMatch:                "携帯電話の認証コードが一致しません",
+		FailedToUpdateUserByUuid:               "ユーザー情報の更新に失敗しました",
+		FailedToUpdateInviteeIdByRefCode:       "紹介関係の更新に失敗しました",
+		FailedToCheckUserExistsInSMSWhiteList:  "SMS のホワイトリストを確認できませんでした",
+		FailedToSendTwilioSMS:                  "SMS の送信に失敗しました",
+		UsernameTooShort:                       "ユーザー名が短すぎます",
+		UsernameTooLong:                        "ユーザー名が長すぎます",
+		UsernameHasInvalidCharacters:           "ユーザー名に使用できない文字が含まれています",
+		UsernameReserved:                       "このユーザー名は予約されています",
+		UsernameProfane:                        "ユーザー名に不適切な言葉が含まれています",
+		FailedToClaimUsername:                  "ユーザー名を確保できませんでした",
+	},
 }
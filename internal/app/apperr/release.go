@@ -1,11 +1,23 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToInitAppcenterRequest        = "4000001"
 	FailedToSendAppcenterOpenApiRequest = "4000002"
 	FailedToScanAppcenterResponse       = "4000003"
 )
 
-var releaseErrorMap = map[string]string{
-	FailedToInitAppcenterRequest: "failed to init appcenter request",
+// Codes collide with image error codes, only the message of `FailedToInitAppcenterRequest` is kept
+// so that image error messages are not overridden.
+var releaseErrorMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToInitAppcenterRequest: "無法建立 appcenter 請求",
+	},
+	locale.En: {
+		FailedToInitAppcenterRequest: "failed to init appcenter request",
+	},
+	locale.Ja: {
+		FailedToInitAppcenterRequest: "appcenter へのリクエストを作成できませんでした",
+	},
 }
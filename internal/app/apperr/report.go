@@ -0,0 +1,87 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToValidateReportParams  = "3200001"
+	CanNotReportYourself          = "3200002"
+	ReportedUserNotFound          = "3200003"
+	ReportTargetRefRequired       = "3200004"
+	ReportTargetNotFound          = "3200005"
+	FailedToValidateReportTarget  = "3200006"
+	FailedToCreateReport          = "3200007"
+	FailedToGetReports            = "3200008"
+	ReportNotFound                = "3200009"
+	FailedToGetReport             = "3200010"
+	FailedToAssignReport          = "3200011"
+	InvalidReportStatusTransition = "3200012"
+	FailedToUpdateReportStatus    = "3200013"
+	FailedToGetReportEvents       = "3200014"
+	FailedToCheckUserRestriction  = "3200015"
+	UserRestricted                = "3200016"
+	FailedToLiftUserRestrictions  = "3200017"
+	ReportAssigneeNotModerator    = "3200018"
+)
+
+var reportErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidateReportParams:  "檢舉資料格式錯誤",
+		CanNotReportYourself:          "無法檢舉自己",
+		ReportedUserNotFound:          "檢舉的用戶不存在",
+		ReportTargetRefRequired:       "請提供檢舉的內容",
+		ReportTargetNotFound:          "檢舉的內容不存在",
+		FailedToValidateReportTarget:  "無法確認檢舉的內容",
+		FailedToCreateReport:          "建立檢舉失敗",
+		FailedToGetReports:            "取得檢舉列表失敗",
+		ReportNotFound:                "檢舉不存在",
+		FailedToGetReport:             "取得檢舉失敗",
+		FailedToAssignReport:          "指派檢舉失敗",
+		InvalidReportStatusTransition: "無法變更檢舉狀態",
+		FailedToUpdateReportStatus:    "更新檢舉狀態失敗",
+		FailedToGetReportEvents:       "取得檢舉處理紀錄失敗",
+		FailedToCheckUserRestriction:  "無法確認帳號限制狀態",
+		UserRestricted:                "您的帳號暫時受到限制",
+		FailedToLiftUserRestrictions:  "解除帳號限制失敗",
+		ReportAssigneeNotModerator:    "指派對象不是審核人員",
+	},
+	locale.En: {
+		FailedToValidateReportParams:  "failed to validate report params",
+		CanNotReportYourself:          "can not report yourself",
+		ReportedUserNotFound:          "reported user not found",
+		ReportTargetRefRequired:       "reported content is required",
+		ReportTargetNotFound:          "reported content not found",
+		FailedToValidateReportTarget:  "failed to validate report target",
+		FailedToCreateReport:          "failed to create report",
+		FailedToGetReports:            "failed to get reports",
+		ReportNotFound:                "report not found",
+		FailedToGetReport:             "failed to get report",
+		FailedToAssignReport:          "failed to assign report",
+		InvalidReportStatusTransition: "report status can not be changed",
+		FailedToUpdateReportStatus:    "failed to update report status",
+		FailedToGetReportEvents:       "failed to get report events",
+		FailedToCheckUserRestriction:  "failed to check user restriction",
+		UserRestricted:                "your account is temporarily restricted",
+		FailedToLiftUserRestrictions:  "failed to lift user restrictions",
+		ReportAssigneeNotModerator:    "assignee is not a moderator",
+	},
+	locale.Ja: {
+		FailedToValidateReportParams:  "通報の情報が正しくありません",
+		CanNotReportYourself:          "自分を通報することはできません",
+		ReportedUserNotFound:          "通報したユーザーが存在しません",
+		ReportTargetRefRequired:       "通報する内容を指定してください",
+		ReportTargetNotFound:          "通報した内容が存在しません",
+		FailedToValidateReportTarget:  "通報した内容を確認できませんでした",
+		FailedToCreateReport:          "通報の作成に失敗しました",
+		FailedToGetReports:            "通報一覧の取得に失敗しました",
+		ReportNotFound:                "通報が存在しません",
+		FailedToGetReport:             "通報の取得に失敗しました",
+		FailedToAssignReport:          "通報の割り当てに失敗しました",
+		InvalidReportStatusTransition: "通報の状態を変更できません",
+		FailedToUpdateReportStatus:    "通報の状態の更新に失敗しました",
+		FailedToGetReportEvents:       "通報の対応履歴の取得に失敗しました",
+		FailedToCheckUserRestriction:  "アカウントの制限状態を確認できませんでした",
+		UserRestricted:                "アカウントは一時的に制限されています",
+		FailedToLiftUserRestrictions:  "アカウントの制限の解除に失敗しました",
+		ReportAssigneeNotModerator:    "割り当て先はモデレーターではありません",
+	},
+}
@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToCreateService      = "1100001"
 	FailedToUpdateService      = "1100002"
@@ -39,12 +41,110 @@ const (
 	FailedToCalcServiceMatchingFee            = "1100033"
 )
 
-var ServiceErrorMessageMap = map[string]string{
-	FailedToCreateService:             "failed to create service",
-	FailedServiceQrCodeSecretNotMatch: "qr code secret does not match",
-	NotAServiceParticipant:            "scanner is not a service participant",
-	ServiceNotYetEnd:                  "no payment detail since service has not ended yet",
-	ServiceStatusNotValidToCancel:     "service status is not valid for canceling",
-	ServiceHasBeenCanceled:            "service has been canceld by partner",
-	OverlappingService:                "service can not be booked due to overlapping service, pick another time",
+var ServiceErrorMessageMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToCreateService:                     "建立服務失敗",
+		FailedToUpdateService:                     "更新服務失敗",
+		FailedToGetIncomingService:                "取得即將進行的服務失敗",
+		FailedToGetOverdueService:                 "取得已逾期的服務失敗",
+		FailedToBindApiBodyParams:                 "請求參數格式錯誤",
+		FailedServiceQrCodeSecretNotMatch:         "QR code 驗證碼不符",
+		FailedToGetServiceByQrCodeUuid:            "以 QR code 取得服務失敗",
+		NotAServiceParticipant:                    "掃描者不是服務參與者",
+		InvalidServiceStatus:                      "服務狀態無效",
+		FailedToChangeServiceStatus:               "更新服務狀態失敗",
+		FirestoreFailedToUpdateService:            "同步更新服務失敗",
+		FailedToGetQrCodeByServiceUuid:            "取得服務 QR code 失敗",
+		NoQRCodeFound:                             "找不到 QR code",
+		FailedToGetServiceNames:                   "取得服務項目名稱失敗",
+		FailedToGetServiceByUuid:                  "取得服務失敗",
+		ServiceNotYetEnd:                          "服務尚未結束，尚無付款明細",
+		FailedToGetPaymentByServiceUuid:           "取得服務付款資料失敗",
+		FailedToCheckHasCommented:                 "無法確認是否已評價",
+		ServiceStatusNotValidToCancel:             "服務目前的狀態無法取消",
+		ServiceHasBeenCanceled:                    "服務已被對方取消",
+		FailedToDeleteChatroomByServiceId:         "刪除服務聊天室失敗",
+		FailedToSendCancelMessage:                 "發送取消訊息失敗",
+		FailedToStartService:                      "開始服務失敗",
+		FailedToMarshQRCodeInfo:                   "產生 QR code 資料失敗",
+		FailedToSendServiceConfirmedMsg:           "發送服務確認訊息失敗",
+		FailedToSendServiceDetailMsg:              "發送服務內容訊息失敗",
+		FailedToGetOverlappedServices:             "無法確認時間重疊的服務",
+		OverlappingService:                        "此時段已有其他服務，請選擇其他時間",
+		FailedToGetServiceProviderByServiceUUID:   "取得服務提供者失敗",
+		FailedToPerformRefundCustomerIfRefundable: "退款失敗",
+		FailedToSendServiceCancelledFCM:           "發送服務取消通知失敗",
+		FailedToSendRefundedFCM:                   "發送退款通知失敗",
+		FailedToCalcServiceMatchingFee:            "計算服務媒合費失敗",
+	},
+	locale.En: {
+		FailedToCreateService:                     "failed to create service",
+		FailedToUpdateService:                     "failed to update service",
+		FailedToGetIncomingService:                "failed to get incoming service",
+		FailedToGetOverdueService:                 "failed to get overdue service",
+		FailedToBindApiBodyParams:                 "failed to bind api body params",
+		FailedServiceQrCodeSecretNotMatch:         "qr code secret does not match",
+		FailedToGetServiceByQrCodeUuid:            "failed to get service by qr code uuid",
+		NotAServiceParticipant:                    "scanner is not a service participant",
+		InvalidServiceStatus:                      "invalid service status",
+		FailedToChangeServiceStatus:               "failed to change service status",
+		FirestoreFailedToUpdateService:            "failed to update service in firestore",
+		FailedToGetQrCodeByServiceUuid:            "failed to get qr code by service uuid",
+		NoQRCodeFound:                             "no qr code found",
+		FailedToGetServiceNames:                   "failed to get service names",
+		FailedToGetServiceByUuid:                  "failed to get service by uuid",
+		ServiceNotYetEnd:                          "no payment detail since service has not ended yet",
+		FailedToGetPaymentByServiceUuid:           "failed to get payment by service uuid",
+		FailedToCheckHasCommented:                 "failed to check has commented",
+		ServiceStatusNotValidToCancel:             "service status is not valid for canceling",
+		ServiceHasBeenCanceled:                    "service has been canceld by partner",
+		FailedToDeleteChatroomByServiceId:         "failed to delete chatroom by service id",
+		FailedToSendCancelMessage:                 "failed to send cancel message",
+		FailedToStartService:                      "failed to start service",
+		FailedToMarshQRCodeInfo:                   "failed to marshal qr code info",
+		FailedToSendServiceConfirmedMsg:           "failed to send service confirmed message",
+		FailedToSendServiceDetailMsg:              "failed to send service detail message",
+		FailedToGetOverlappedServices:             "failed to get overlapped services",
+		OverlappingService:                        "service can not be booked due to overlapping service, pick another time",
+		FailedToGetServiceProviderByServiceUUID:   "failed to get service provider by service uuid",
+		FailedToPerformRefundCustomerIfRefundable: "failed to refund customer",
+		FailedToSendServiceCancelledFCM:           "failed to send service cancelled notification",
+		FailedToSendRefundedFCM:                   "failed to send refunded notification",
+		FailedToCalcServiceMatchingFee:            "failed to calculate service matching fee",
+	},
+	locale.Ja: {
+		FailedToCreateService:                     "サービスの作成に失敗しました",
+		FailedToUpdateService:                     "サービスの更新に失敗しました",
+		FailedToGetIncomingService:                "予定のサービスの取得に失敗しました",
+		FailedToGetOverdueService:                 "期限切れのサービスの取得に失敗しました",
+		FailedToBindApiBodyParams:                 "リクエストパラメータが正しくありません",
+		FailedServiceQrCodeSecretNotMatch:         "QR コードのシークレットが一致しません",
+		FailedToGetServiceByQrCodeUuid:            "QR コードからサービスを取得できませんでした",
+		NotAServiceParticipant:                    "スキャンした人はサービスの参加者ではありません",
+		InvalidServiceStatus:                      "サービスの状態が正しくありません",
+		FailedToChangeServiceStatus:               "サービスの状態の更新に失敗しました",
+		FirestoreFailedToUpdateService:            "サービス更新の同期に失敗しました",
+		FailedToGetQrCodeByServiceUuid:            "サービスの QR コードの取得に失敗しました",
+		NoQRCodeFound:                             "QR コードが見つかりません",
+		FailedToGetServiceNames:                   "サービス名の取得に失敗しました",
+		FailedToGetServiceByUuid:                  "サービスの取得に失敗しました",
+		ServiceNotYetEnd:                          "サービスが終了していないため、支払い明細はまだありません",
+		FailedToGetPaymentByServiceUuid:           "サービスの支払い情報の取得に失敗しました",
+		FailedToCheckHasCommented:                 "評価済みか確認できませんでした",
+		ServiceStatusNotValidToCancel:             "現在のサービスの状態ではキャンセルできません",
+		ServiceHasBeenCanceled:                    "サービスは相手によりキャンセルされました",
+		FailedToDeleteChatroomByServiceId:         "サービスのチャットルームの削除に失敗しました",
+		FailedToSendCancelMessage:                 "キャンセルメッセージの送信に失敗しました",
+		FailedToStartService:                      "サービスの開始に失敗しました",
+		FailedToMarshQRCodeInfo:                   "QR コード情報の生成に失敗しました",
+		FailedToSendServiceConfirmedMsg:           "サービス確定メッセージの送信に失敗しました",
+		FailedToSendServiceDetailMsg:              "サービス内容メッセージの送信に失敗しました",
+		FailedToGetOverlappedServices:             "時間が重なるサービスを確認できませんでした",
+		OverlappingService:                        "この時間帯には他のサービスがあります。別の時間を選んでください",
+		FailedToGetServiceProviderByServiceUUID:   "サービス提供者の取得に失敗しました",
+		FailedToPerformRefundCustomerIfRefundable: "返金に失敗しました",
+		FailedToSendServiceCancelledFCM:           "サービスキャンセルの通知の送信に失敗しました",
+		FailedToSendRefundedFCM:                   "返金の通知の送信に失敗しました",
+		FailedToCalcServiceMatchingFee:            "サービスのマッチング手数料の計算に失敗しました",
+	},
 }
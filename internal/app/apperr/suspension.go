@@ -0,0 +1,47 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	UserSuspended                    = "3300001"
+	UserBanned                       = "3300002"
+	FailedToCheckUserSuspension      = "3300003"
+	FailedToValidateSuspensionParams = "3300004"
+	SuspensionExpiryRequired         = "3300005"
+	CanNotSuspendYourself            = "3300006"
+	FailedToSuspendUser              = "3300007"
+	FailedToLiftUserSuspensions      = "3300008"
+)
+
+var suspensionErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		UserSuspended:                    "您的帳號已被暫時停權",
+		UserBanned:                       "您的帳號已被永久停權",
+		FailedToCheckUserSuspension:      "無法確認帳號停權狀態",
+		FailedToValidateSuspensionParams: "停權資料格式錯誤",
+		SuspensionExpiryRequired:         "請設定停權期限",
+		CanNotSuspendYourself:            "無法停權自己",
+		FailedToSuspendUser:              "停權用戶失敗",
+		FailedToLiftUserSuspensions:      "解除停權失敗",
+	},
+	locale.En: {
+		UserSuspended:                    "your account has been suspended",
+		UserBanned:                       "your account has been banned",
+		FailedToCheckUserSuspension:      "failed to check user suspension",
+		FailedToValidateSuspensionParams: "failed to validate suspension params",
+		SuspensionExpiryRequired:         "suspension expiry is required",
+		CanNotSuspendYourself:            "can not suspend yourself",
+		FailedToSuspendUser:              "failed to suspend user",
+		FailedToLiftUserSuspensions:      "failed to lift user suspensions",
+	},
+	locale.Ja: {
+		UserSuspended:                    "アカウントは一時的に停止されています",
+		UserBanned:                       "アカウントは永久に停止されています",
+		FailedToCheckUserSuspension:      "アカウントの停止状態を確認できませんでした",
+		FailedToValidateSuspensionParams: "停止の情報が正しくありません",
+		SuspensionExpiryRequired:         "停止期間を指定してください",
+		CanNotSuspendYourself:            "自分を停止することはできません",
+		FailedToSuspendUser:              "ユーザーの停止に失敗しました",
+		FailedToLiftUserSuspensions:      "停止の解除に失敗しました",
+	},
+}
@@ -0,0 +1,27 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToAnnotateTransition   = "3400001"
+	FailedToGetTransitionHistory = "3400002"
+	NotParticipantOfHistory      = "3400003"
+)
+
+var transitionErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToAnnotateTransition:   "記錄狀態變更失敗",
+		FailedToGetTransitionHistory: "取得狀態變更紀錄失敗",
+		NotParticipantOfHistory:      "只有參與者可以查看狀態變更紀錄",
+	},
+	locale.En: {
+		FailedToAnnotateTransition:   "failed to annotate status transition",
+		FailedToGetTransitionHistory: "failed to get status transition history",
+		NotParticipantOfHistory:      "only participants can view the status history",
+	},
+	locale.Ja: {
+		FailedToAnnotateTransition:   "状態の変更の記録に失敗しました",
+		FailedToGetTransitionHistory: "状態の変更履歴の取得に失敗しました",
+		NotParticipantOfHistory:      "参加者のみ状態の変更履歴を確認できます",
+	},
+}
@@ -1,5 +1,7 @@
 package apperr
 
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
 const (
 	FailedToValidatePutUserParams           = "5000001"
 	FailedToValidateUserURIParams           = "5000002"
@@ -29,11 +31,201 @@ const (
 	FailedToCheckServiceOptionExistence     = "5000026"
 	ServiceOptionNotAvailable               = "5000027"
 	FailedToGetGirlsInfo                    = "5000028"
+	FailedToCheckOngoingServices            = "5000029"
+	HasOngoingServices                      = "5000030"
+	FailedToLeaveChatrooms                  = "5000031"
+	FailedToDeleteUserImages                = "5000032"
+	FailedToDeleteUserBankAccounts          = "5000033"
+	FailedToAnonymizeUser                   = "5000034"
+	FailedToValidateExportParams            = "5000035"
+	FailedToExportUserData                  = "5000036"
+	FailedToGetUserChatrooms                = "5000037"
+	FailedToValidateGetGirlsParams          = "5000038"
+	FailedToValidateUserLocationParams      = "5000039"
+	FailedToUpsertUserLocation              = "5000040"
+	FailedToDeleteUserLocation              = "5000041"
+	FailedToValidateNearbyGirlsParams       = "5000042"
+	FailedToGetNearbyGirls                  = "5000043"
+	FailedToAddFavorite                     = "5000044"
+	FailedToRemoveFavorite                  = "5000045"
+	FailedToGetFavoriteGirls                = "5000046"
+	FavoriteNotFound                        = "5000047"
+	CanOnlyFavoriteFemale                   = "5000048"
+	FailedToGetBlockRelatedUsers            = "5000049"
+	FailedToCheckBlockedUser                = "5000050"
+	UserBlockedCanNotFavorite               = "5000051"
+	FavoriteUserNotFound                    = "5000052"
+	FailedToGetProfileViewCount             = "5000053"
+	FailedToGetProfileViewers               = "5000054"
 )
 
-var userErrorCodeMsgMap = map[string]string{
-	ChangeMobileVerifyCodeNotExists:     "verify code does not exist, please send verify code via mobile again",
-	ChangeMobileVerifyCodeNotMatching:   "verify code does not match",
-	FailedToGetRegisterMobileVerifyCode: "verify code not found, please resend verify code again",
-	ServiceOptionNotAvailable:           "Service option exists",
+var userErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToValidatePutUserParams:           "用戶資料格式錯誤",
+		FailedToValidateUserURIParams:           "用戶路徑參數格式錯誤",
+		FailedToPatchUserInfo:                   "更新用戶資料失敗",
+		FailedToValidateGetUserProfileParams:    "用戶檔案查詢資料格式錯誤",
+		FailedToGetImagesByUserUUID:             "取得用戶照片失敗",
+		FailedToValidateGetUserImagesParams:     "用戶照片查詢資料格式錯誤",
+		FailedToGetUserPayments:                 "取得付款紀錄失敗",
+		FailedToTransformUserPayments:           "無法整理付款紀錄",
+		FailedToValidateGetServiceHistoryParams: "服務紀錄查詢資料格式錯誤",
+		FailedToGetHistoricalServices:           "取得服務紀錄失敗",
+		FailedToTransformHistoricalServices:     "無法整理服務紀錄",
+		FailedToGetUserByID:                     "取得用戶資料失敗",
+		FailedToCreateChangeMobileVerifyCode:    "建立更換手機驗證碼失敗",
+		FailedToSendTwilioMessage:               "簡訊發送失敗",
+		FailedToGetChangeMobileVerifyCode:       "取得更換手機驗證碼失敗",
+		ChangeMobileVerifyCodeNotExists:         "驗證碼不存在，請重新發送手機驗證碼",
+		ChangeMobileVerifyCodeNotMatching:       "驗證碼不正確",
+		FailedToGetRegisterMobileVerifyCode:     "找不到驗證碼，請重新發送驗證碼",
+		FailedToGetUserRating:                   "取得用戶評價失敗",
+		FailedToGetUserProfiles:                 "取得用戶檔案失敗",
+		FailedToTransformGirlProfile:            "無法整理女生檔案",
+		FailedToGetGirlIDOfDirectInquiry:        "取得指定詢問的女生失敗",
+		FailedToCreateUserServiceOption:         "建立用戶服務項目失敗",
+		FailedToCreateServiceOption:             "建立服務項目失敗",
+		FailedToGetUserServiceOption:            "取得用戶服務項目失敗",
+		FailedToCheckServiceOptionExistence:     "無法確認服務項目是否存在",
+		ServiceOptionNotAvailable:               "服務項目已存在",
+		FailedToGetGirlsInfo:                    "取得女生列表失敗",
+		FailedToCheckOngoingServices:            "無法確認進行中的服務",
+		HasOngoingServices:                      "帳號尚有進行中的服務，請於服務結束後再刪除帳號",
+		FailedToLeaveChatrooms:                  "離開聊天室失敗",
+		FailedToDeleteUserImages:                "刪除用戶照片失敗",
+		FailedToDeleteUserBankAccounts:          "刪除用戶銀行帳戶失敗",
+		FailedToAnonymizeUser:                   "帳號匿名化失敗",
+		FailedToValidateExportParams:            "匯出資料格式錯誤",
+		FailedToExportUserData:                  "匯出用戶資料失敗",
+		FailedToGetUserChatrooms:                "取得用戶聊天室失敗",
+		FailedToValidateGetGirlsParams:          "女生列表查詢資料格式錯誤",
+		FailedToValidateUserLocationParams:      "位置資料格式錯誤",
+		FailedToUpsertUserLocation:              "更新位置失敗",
+		FailedToDeleteUserLocation:              "刪除位置失敗",
+		FailedToValidateNearbyGirlsParams:       "附近女生查詢資料格式錯誤",
+		FailedToGetNearbyGirls:                  "取得附近女生失敗",
+		FailedToAddFavorite:                     "加入收藏失敗",
+		FailedToRemoveFavorite:                  "移除收藏失敗",
+		FailedToGetFavoriteGirls:                "取得收藏列表失敗",
+		FavoriteNotFound:                        "此用戶不在您的收藏中",
+		CanOnlyFavoriteFemale:                   "只能收藏女性用戶",
+		FailedToGetBlockRelatedUsers:            "取得封鎖相關用戶失敗",
+		FailedToCheckBlockedUser:                "無法確認封鎖狀態",
+		UserBlockedCanNotFavorite:               "無法收藏已封鎖的用戶",
+		FavoriteUserNotFound:                    "用戶不存在",
+		FailedToGetProfileViewCount:             "取得檔案瀏覽次數失敗",
+		FailedToGetProfileViewers:               "取得檔案瀏覽者失敗",
+	},
+	locale.En: {
+		FailedToValidatePutUserParams:           "failed to validate put user params",
+		FailedToValidateUserURIParams:           "failed to validate user uri params",
+		FailedToPatchUserInfo:                   "failed to patch user info",
+		FailedToValidateGetUserProfileParams:    "failed to validate get user profile params",
+		FailedToGetImagesByUserUUID:             "failed to get images by user uuid",
+		FailedToValidateGetUserImagesParams:     "failed to validate get user images params",
+		FailedToGetUserPayments:                 "failed to get user payments",
+		FailedToTransformUserPayments:           "failed to transform user payments",
+		FailedToValidateGetServiceHistoryParams: "failed to validate get service history params",
+		FailedToGetHistoricalServices:           "failed to get historical services",
+		FailedToTransformHistoricalServices:     "failed to transform historical services",
+		FailedToGetUserByID:                     "failed to get user by id",
+		FailedToCreateChangeMobileVerifyCode:    "failed to create change mobile verify code",
+		FailedToSendTwilioMessage:               "failed to send sms",
+		FailedToGetChangeMobileVerifyCode:       "failed to get change mobile verify code",
+		ChangeMobileVerifyCodeNotExists:         "verify code does not exist, please send verify code via mobile again",
+		ChangeMobileVerifyCodeNotMatching:       "verify code does not match",
+		FailedToGetRegisterMobileVerifyCode:     "verify code not found, please resend verify code again",
+		FailedToGetUserRating:                   "failed to get user rating",
+		FailedToGetUserProfiles:                 "failed to get user profiles",
+		FailedToTransformGirlProfile:            "failed to transform girl profile",
+		FailedToGetGirlIDOfDirectInquiry:        "failed to get girl of direct inquiry",
+		FailedToCreateUserServiceOption:         "failed to create user service option",
+		FailedToCreateServiceOption:             "failed to create service option",
+		FailedToGetUserServiceOption:            "failed to get user service option",
+		FailedToCheckServiceOptionExistence:     "failed to check service option existence",
+		ServiceOptionNotAvailable:               "Service option exists",
+		FailedToGetGirlsInfo:                    "failed to get girls info",
+		FailedToCheckOngoingServices:            "failed to check ongoing services",
+		HasOngoingServices:                      "account has ongoing services, please delete the account after the services end",
+		FailedToLeaveChatrooms:                  "failed to leave chatrooms",
+		FailedToDeleteUserImages:                "failed to delete user images",
+		FailedToDeleteUserBankAccounts:          "failed to delete user bank accounts",
+		FailedToAnonymizeUser:                   "failed to anonymize user",
+		FailedToValidateExportParams:            "failed to validate export params",
+		FailedToExportUserData:                  "failed to export user data",
+		FailedToGetUserChatrooms:                "failed to get user chatrooms",
+		FailedToValidateGetGirlsParams:          "failed to validate get girls params",
+		FailedToValidateUserLocationParams:      "failed to validate user location params",
+		FailedToUpsertUserLocation:              "failed to upsert user location",
+		FailedToDeleteUserLocation:              "failed to delete user location",
+		FailedToValidateNearbyGirlsParams:       "failed to validate nearby girls params",
+		FailedToGetNearbyGirls:                  "failed to get nearby girls",
+		FailedToAddFavorite:                     "failed to add favorite",
+		FailedToRemoveFavorite:                  "failed to remove favorite",
+		FailedToGetFavoriteGirls:                "failed to get favorite girls",
+		FavoriteNotFound:                        "user is not in your favorites",
+		CanOnlyFavoriteFemale:                   "only female users can be added to favorites",
+		FailedToGetBlockRelatedUsers:            "failed to get block related users",
+		FailedToCheckBlockedUser:                "failed to check blocked user",
+		UserBlockedCanNotFavorite:               "can not add blocked user to favorites",
+		FavoriteUserNotFound:                    "user does not exist",
+		FailedToGetProfileViewCount:             "failed to get profile view count",
+		FailedToGetProfileViewers:               "failed to get profile viewers",
+	},
+	locale.Ja: {
+		FailedToValidatePutUserParams:           "ユーザー情報の形式が正しくありません",
+		FailedToValidateUserURIParams:           "ユーザーの URI パラメータが正しくありません",
+		FailedToPatchUserInfo:                   "ユーザー情報の更新に失敗しました",
+		FailedToValidateGetUserProfileParams:    "プロフィールの検索条件が正しくありません",
+		FailedToGetImagesByUserUUID:             "ユーザーの写真の取得に失敗しました",
+		FailedToValidateGetUserImagesParams:     "写真の検索条件が正しくありません",
+		FailedToGetUserPayments:                 "支払い履歴の取得に失敗しました",
+		FailedToTransformUserPayments:           "支払い履歴を生成できませんでした",
+		FailedToValidateGetServiceHistoryParams: "サービス履歴の検索条件が正しくありません",
+		FailedToGetHistoricalServices:           "サービス履歴の取得に失敗しました",
+		FailedToTransformHistoricalServices:     "サービス履歴を生成できませんでした",
+		FailedToGetUserByID:                     "ユーザー情報の取得に失敗しました",
+		FailedToCreateChangeMobileVerifyCode:    "電話番号変更の認証コードの作成に失敗しました",
+		FailedToSendTwilioMessage:               "SMS の送信に失敗しました",
+		FailedToGetChangeMobileVerifyCode:       "電話番号変更の認証コードの取得に失敗しました",
+		ChangeMobileVerifyCodeNotExists:         "認証コードが存在しません。認証コードを再送信してください",
+		ChangeMobileVerifyCodeNotMatching:       "認証コードが一致しません",
+		FailedToGetRegisterMobileVerifyCode:     "認証コードが見つかりません。認証コードを再送信してください",
+		FailedToGetUserRating:                   "ユーザーの評価の取得に失敗しました",
+		FailedToGetUserProfiles:                 "プロフィールの取得に失敗しました",
+		FailedToTransformGirlProfile:            "プロフィールを生成できませんでした",
+		FailedToGetGirlIDOfDirectInquiry:        "指名依頼の相手の取得に失敗しました",
+		FailedToCreateUserServiceOption:         "ユーザーのサービス項目の作成に失敗しました",
+		FailedToCreateServiceOption:             "サービス項目の作成に失敗しました",
+		FailedToGetUserServiceOption:            "ユーザーのサービス項目の取得に失敗しました",
+		FailedToCheckServiceOptionExistence:     "サービス項目の存在を確認できませんでした",
+		ServiceOptionNotAvailable:               "サービス項目は既に存在します",
+		FailedToGetGirlsInfo:                    "女性ユーザー一覧の取得に失敗しました",
+		FailedToCheckOngoingServices:            "進行中のサービスを確認できませんでした",
+		HasOngoingServices:                      "進行中のサービスがあります。サービス終了後にアカウントを削除してください",
+		FailedToLeaveChatrooms:                  "チャットルームからの退出に失敗しました",
+		FailedToDeleteUserImages:                "ユーザーの写真の削除に失敗しました",
+		FailedToDeleteUserBankAccounts:          "ユーザーの銀行口座の削除に失敗しました",
+		FailedToAnonymizeUser:                   "アカウントの匿名化に失敗しました",
+		FailedToValidateExportParams:            "エクスポートの情報が正しくありません",
+		FailedToExportUserData:                  "ユーザーデータのエクスポートに失敗しました",
+		FailedToGetUserChatrooms:                "ユーザーのチャットルームの取得に失敗しました",
+		FailedToValidateGetGirlsParams:          "女性ユーザー一覧の検索条件が正しくありません",
+		FailedToValidateUserLocationParams:      "位置情報の形式が正しくありません",
+		FailedToUpsertUserLocation:              "位置情報の更新に失敗しました",
+		FailedToDeleteUserLocation:              "位置情報の削除に失敗しました",
+		FailedToValidateNearbyGirlsParams:       "近くの女性ユーザーの検索条件が正しくありません",
+		FailedToGetNearbyGirls:                  "近くの女性ユーザーの取得に失敗しました",
+		FailedToAddFavorite:                     "お気に入りへの追加に失敗しました",
+		FailedToRemoveFavorite:                  "お気に入りからの削除に失敗しました",
+		FailedToGetFavoriteGirls:                "お気に入り一覧の取得に失敗しました",
+		FavoriteNotFound:                        "このユーザーはお気に入りにありません",
+		CanOnlyFavoriteFemale:                   "お気に入りに追加できるのは女性ユーザーのみです",
+		FailedToGetBlockRelatedUsers:            "ブロック関連のユーザーの取得に失敗しました",
+		FailedToCheckBlockedUser:                "ブロック状態を確認できませんでした",
+		UserBlockedCanNotFavorite:               "ブロックしたユーザーはお気に入りに追加できません",
+		FavoriteUserNotFound:                    "ユーザーが存在しません",
+		FailedToGetProfileViewCount:             "プロフィール閲覧数の取得に失敗しました",
+		FailedToGetProfileViewers:               "プロフィール閲覧者の取得に失敗しました",
+	},
 }
@@ -0,0 +1,87 @@
+package apperr
+
+import "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+
+const (
+	FailedToGetIdentityVerification      = "2800001"
+	FailedToCheckUserVerified            = "2800002"
+	UserAlreadyVerified                  = "2800003"
+	IdentityVerificationPending          = "2800004"
+	FailedToCreateVerificationCode       = "2800005"
+	FailedToGetVerificationCode          = "2800006"
+	VerificationCodeExpired              = "2800007"
+	VerificationPhotoNotFound            = "2800008"
+	VerificationPhotoNotImage            = "2800009"
+	FailedToUploadVerificationPhoto      = "2800010"
+	FailedToCreateIdentityVerification   = "2800011"
+	FailedToValidateVerificationParams   = "2800012"
+	FailedToGetIdentityVerifications     = "2800013"
+	IdentityVerificationNotFound         = "2800014"
+	FailedToReadVerificationPhoto        = "2800015"
+	FailedToDecideIdentityVerification   = "2800016"
+	IdentityVerificationRejectNeedReason = "2800017"
+	FailedToDeleteIdentityVerifications  = "2800018"
+)
+
+var verificationErrorCodeMsgMap = locale.Catalog{
+	locale.ZhTW: {
+		FailedToGetIdentityVerification:      "取得身分驗證失敗",
+		FailedToCheckUserVerified:            "無法確認身分驗證狀態",
+		UserAlreadyVerified:                  "已完成身分驗證",
+		IdentityVerificationPending:          "身分驗證審核中",
+		FailedToCreateVerificationCode:       "建立身分驗證碼失敗",
+		FailedToGetVerificationCode:          "取得身分驗證碼失敗",
+		VerificationCodeExpired:              "驗證碼已過期，請重新取得驗證碼",
+		VerificationPhotoNotFound:            "請上傳手持驗證碼自拍照及證件照",
+		VerificationPhotoNotImage:            "僅接受 jpeg 或 png 格式的照片",
+		FailedToUploadVerificationPhoto:      "上傳驗證照片失敗",
+		FailedToCreateIdentityVerification:   "建立身分驗證申請失敗",
+		FailedToValidateVerificationParams:   "身分驗證資料格式錯誤",
+		FailedToGetIdentityVerifications:     "取得身分驗證申請列表失敗",
+		IdentityVerificationNotFound:         "身分驗證申請不存在或已審核",
+		FailedToReadVerificationPhoto:        "讀取驗證照片失敗",
+		FailedToDecideIdentityVerification:   "審核身分驗證失敗",
+		IdentityVerificationRejectNeedReason: "拒絕身分驗證須提供原因",
+		FailedToDeleteIdentityVerifications:  "刪除身分驗證失敗",
+	},
+	locale.En: {
+		FailedToGetIdentityVerification:      "failed to get identity verification",
+		FailedToCheckUserVerified:            "failed to check if user is verified",
+		UserAlreadyVerified:                  "identity has already been verified",
+		IdentityVerificationPending:          "identity verification is under review",
+		FailedToCreateVerificationCode:       "failed to create verification code",
+		FailedToGetVerificationCode:          "failed to get verification code",
+		VerificationCodeExpired:              "verification code has expired, please request a new one",
+		VerificationPhotoNotFound:            "please upload a selfie holding the verification code and a photo of your id",
+		VerificationPhotoNotImage:            "only jpeg or png photos are accepted",
+		FailedToUploadVerificationPhoto:      "failed to upload verification photo",
+		FailedToCreateIdentityVerification:   "failed to create identity verification",
+		FailedToValidateVerificationParams:   "failed to validate verification params",
+		FailedToGetIdentityVerifications:     "failed to get identity verifications",
+		IdentityVerificationNotFound:         "identity verification does not exist or has been reviewed",
+		FailedToReadVerificationPhoto:        "failed to read verification photo",
+		FailedToDecideIdentityVerification:   "failed to decide identity verification",
+		IdentityVerificationRejectNeedReason: "reason is required to reject identity verification",
+		FailedToDeleteIdentityVerifications:  "failed to delete identity verifications",
+	},
+	locale.Ja: {
+		FailedToGetIdentityVerification:      "本人確認情報の取得に失敗しました",
+		FailedToCheckUserVerified:            "本人確認の状態を確認できませんでした",
+		UserAlreadyVerified:                  "本人確認は既に完了しています",
+		IdentityVerificationPending:          "本人確認は審査中です",
+		FailedToCreateVerificationCode:       "本人確認コードの作成に失敗しました",
+		FailedToGetVerificationCode:          "本人確認コードの取得に失敗しました",
+		VerificationCodeExpired:              "確認コードの有効期限が切れました。新しいコードを取得してください",
+		VerificationPhotoNotFound:            "確認コードを持った自撮り写真と身分証の写真をアップロードしてください",
+		VerificationPhotoNotImage:            "jpeg または png 形式の写真のみ受け付けます",
+		FailedToUploadVerificationPhoto:      "確認用写真のアップロードに失敗しました",
+		FailedToCreateIdentityVerification:   "本人確認の申請の作成に失敗しました",
+		FailedToValidateVerificationParams:   "本人確認の情報が正しくありません",
+		FailedToGetIdentityVerifications:     "本人確認の申請一覧の取得に失敗しました",
+		IdentityVerificationNotFound:         "本人確認の申請が存在しないか、既に審査済みです",
+		FailedToReadVerificationPhoto:        "確認用写真の読み込みに失敗しました",
+		FailedToDecideIdentityVerification:   "本人確認の審査に失敗しました",
+		IdentityVerificationRejectNeedReason: "本人確認を却下するには理由が必要です",
+		FailedToDeleteIdentityVerifications:  "本人確認の削除に失敗しました",
+	},
+}
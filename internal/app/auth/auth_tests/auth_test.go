@@ -200,6 +200,114 @@ func (suite *UserAuthTestSuite) TestRevokeJwtSuccess() {
 	assert.Equal(suite.T(), isMember, true)
 }
 
+func (suite *UserAuthTestSuite) issueTestTokenPair() *jwtactor.TokenPair {
+	pair, err := jwtactor.IssueTokenPair(
+		context.Background(),
+		auth.NewAuthDao(db.GetRedis()),
+		jwtactor.CreateTokenPairParams{
+			Uuid:   "someuuid",
+			Secret: config.GetAppConf().JwtSecret,
+		},
+	)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return pair
+}
+
+func (suite *UserAuthTestSuite) TestRefreshTokenRotateSuccess() {
+	pair := suite.issueTestTokenPair()
+
+	params := &url.Values{}
+	params.Add("refresh_token", pair.RefreshToken)
+
+	resp, err := suite.sendURLEncodedRequest(
+		"POST",
+		"/v1/auth/refresh",
+		params,
+		make(map[string]string),
+	)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert := assert.New(suite.T())
+	assert.Equal(http.StatusOK, resp.Result().StatusCode)
+
+	respStruct := auth.TransformedTokenPair{}
+
+	if err := json.Unmarshal(resp.Body.Bytes(), &respStruct); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.NotEmpty(respStruct.Jwt)
+	assert.NotEmpty(respStruct.RefreshToken)
+	assert.NotEqual(pair.RefreshToken, respStruct.RefreshToken)
+
+	// New tokens stay in the same token family.
+	claims, err := jwtactor.ParseToken(respStruct.RefreshToken, config.GetAppConf().JwtSecret)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(pair.Family, claims.Family)
+}
+
+func (suite *UserAuthTestSuite) TestRefreshTokenReuseRevokesFamily() {
+	pair := suite.issueTestTokenPair()
+
+	params := &url.Values{}
+	params.Add("refresh_token", pair.RefreshToken)
+
+	// First rotation succeeds.
+	resp, err := suite.sendURLEncodedRequest("POST", "/v1/auth/refresh", params, make(map[string]string))
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert := assert.New(suite.T())
+	assert.Equal(http.StatusOK, resp.Result().StatusCode)
+
+	// Reusing the same refresh token revokes the whole family.
+	resp, err = suite.sendURLEncodedRequest("POST", "/v1/auth/refresh", params, make(map[string]string))
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(http.StatusForbidden, resp.Result().StatusCode)
+
+	isRevoked, err := auth.
+		NewAuthDao(db.GetRedis()).
+		IsTokenFamilyRevoked(context.Background(), pair.Family)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.True(isRevoked)
+}
+
+func (suite *UserAuthTestSuite) TestRefreshTokenCanNotAccessResources() {
+	pair := suite.issueTestTokenPair()
+
+	headers := make(map[string]string)
+	headers["Authorization"] = fmt.Sprintf("Bearer %s", pair.RefreshToken)
+
+	resp, err := suite.sendRequest("POST", "/v1/auth/revoke-jwt", struct{}{}, headers)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, resp.Result().StatusCode)
+}
+
 func TestUserAuthTestSuite(t *testing.T) {
 	suite.Run(t, new(UserAuthTestSuite))
 }
@@ -3,7 +3,6 @@ package auth
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"net/http"
 
@@ -18,15 +17,15 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	genverifycode "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/generate_verify_code"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/otpguard"
 
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
-	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/twilio"
+	smssender "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/sms_sender"
 )
 
 type AuthController struct {
-	TwilioClient *twilio.TwilioClient
-	Container    container.Container
+	Container container.Container
 }
 
 // store jwt token in redis and db
@@ -69,6 +68,33 @@ func (ac *AuthController) RevokeJwtHandler(c *gin.Context) {
 		return
 	}
 
+	// Revoke refresh tokens issued along with the access token as well.
+	if len(claims.Family) > 0 {
+		if err := authDao.RevokeTokenFamily(ctx, claims.Family); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToRevokeTokenFamily,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		if err := authDao.RemoveSession(ctx, claims.Uuid, claims.Family); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToRevokeSession,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, struct{}{})
 }
 
@@ -132,48 +158,25 @@ func (ac *AuthController) SendVerifyCodeHandler(c *gin.Context, depCon container
 		return
 	}
 
-	// Generate an SMS login code.
-	verifyCode := genverifycode.GenVerifyCode()
-	authDao := NewAuthDao(db.GetRedis())
-
-	var (
-		tc     twilio.TwilioServicer
-		regDao contracts.Registerar
-		from   string = config.GetAppConf().TwilioFrom
-	)
-
-	ac.Container.Make(&tc)
-	ac.Container.Make(&regDao)
-
-	// If login user is in white list, we will send sandbox message instead
-	// of real message to save some money.
-	exists, err := regDao.CheckUserInSMSWhiteList(ctx, contracts.CheckUserInSMSWhiteListParams{
-		RedisClient: db.GetRedis(),
-		Username:    body.Username,
-	})
-
-	if err != nil && !errors.Is(err, redis.Nil) {
-		c.AbortWithError(
-			http.StatusInternalServerError,
-			apperr.NewErr(
-				apperr.FailedToCheckUserExistsInSMSWhiteList,
-				err.Error(),
-			),
-		)
+	// Throttle sending login code SMS so that one number can not be SMS bombed.
+	retryAfter, err := otpguard.
+		New(db.GetRedis(), config.GetAppConf().GetOTPLimits()).
+		ConsumeSendQuota(ctx, otpguard.LoginScope, otpguard.Subjects{
+			UserUuid: user.Uuid,
+			Mobile:   user.Mobile.String,
+			IP:       c.ClientIP(),
+		})
 
+	if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPRequests) {
 		return
 	}
 
-	if exists {
-		log.Info("DEV account, bypassing real sms sending")
-
-		tc.SetConfig(twilio.TwilioConf{
-			AccountSID:   config.GetAppConf().TwilioDevAccountID,
-			AccountToken: config.GetAppConf().TwilioDevAuthToken,
-		})
+	// Generate an SMS login code.
+	verifyCode := genverifycode.GenVerifyCode()
+	authDao := NewAuthDao(db.GetRedis())
 
-		from = config.GetAppConf().TwilioDevFrom
-	}
+	var smsSender smssender.SMSSender
+	ac.Container.Make(&smsSender)
 
 	// Check if login record already exists in redis.
 	authenticator, err := authDao.GetLoginRecord(ctx, user.Uuid)
@@ -202,11 +205,10 @@ func (ac *AuthController) SendVerifyCodeHandler(c *gin.Context, depCon container
 				return
 			}
 
-			smsResp, err := tc.SendSMS(
-				from,
-				user.Mobile.String,
-				fmt.Sprintf("your darkpanda verify code: \n\n %s", verifyCode.BuildCode()),
-			)
+			smsResp, err := smsSender.Send(ctx, smssender.Message{
+				To:      user.Mobile.String,
+				Content: fmt.Sprintf("your darkpanda verify code: \n\n %s", verifyCode.BuildCode()),
+			})
 
 			if err != nil {
 				c.AbortWithError(
@@ -225,7 +227,7 @@ func (ac *AuthController) SendVerifyCodeHandler(c *gin.Context, depCon container
 					"user_uuid": user.Uuid,
 					"mobile":    user.Mobile.String,
 				}).
-				Infof("sends twilio SMS success, login verify code created ! %v", smsResp.SID)
+				Infof("sends %s SMS success, login verify code created ! %v", smsResp.Driver, smsResp.MessageID)
 
 			c.JSON(http.StatusOK, NewTransform().TransformSendLoginMobileVerifyCode(
 				user.Uuid,
@@ -274,11 +276,10 @@ func (ac *AuthController) SendVerifyCodeHandler(c *gin.Context, depCon container
 		return
 	}
 
-	smsResp, err := tc.SendSMS(
-		from,
-		user.Mobile.String,
-		fmt.Sprintf("[Darkpanda] login verify code: \n\n %s", verifyCode.BuildCode()),
-	)
+	smsResp, err := smsSender.Send(ctx, smssender.Message{
+		To:      user.Mobile.String,
+		Content: fmt.Sprintf("[Darkpanda] login verify code: \n\n %s", verifyCode.BuildCode()),
+	})
 
 	if err != nil {
 		c.AbortWithError(
@@ -297,7 +298,7 @@ func (ac *AuthController) SendVerifyCodeHandler(c *gin.Context, depCon container
 			"user_uuid": user.Uuid,
 			"mobile":    user.Mobile.String,
 		}).
-		Infof("sends twilio SMS success, login verify code updated ! %v", smsResp.SID)
+		Infof("sends %s SMS success, login verify code updated ! %v", smsResp.Driver, smsResp.MessageID)
 
 	c.JSON(http.StatusOK, NewTransform().TransformSendLoginMobileVerifyCode(
 		user.Uuid,
@@ -311,6 +312,11 @@ type VerifyLoginCodeBody struct {
 	UUID       string `form:"uuid" json:"uuid" bind:"required,gt=0"`
 	VerifyChar string `form:"verify_char" json:"verify_char" bind:"required,gt=0"`
 	VerifyDig  int    `form:"verify_dig" json:"verify_dig" bind:"required,gt=0"`
+
+	// Device info of the login session. Displayed in the session list so that the user can
+	// tell which device has logged into the account.
+	DeviceName string `form:"device_name" json:"device_name"`
+	AppVersion string `form:"app_version" json:"app_version"`
 }
 
 func (ac *AuthController) VerifyLoginCode(c *gin.Context, depCon container.Container) {
@@ -350,6 +356,18 @@ func (ac *AuthController) VerifyLoginCode(c *gin.Context, depCon container.Conta
 
 	// By pass verification code checking if is dev user.
 	if !config.GetAppConf().IsDevUser(user.Username) {
+		guard := otpguard.New(db.GetRedis(), config.GetAppConf().GetOTPLimits())
+		subjects := otpguard.Subjects{
+			UserUuid: user.Uuid,
+			IP:       c.ClientIP(),
+		}
+
+		retryAfter, err := guard.CheckLockout(ctx, otpguard.LoginScope, subjects)
+
+		if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPAttempts) {
+			return
+		}
+
 		// Retrieve auth record from redis
 		authDao := NewAuthDao(db.GetRedis())
 		authRecord, err := authDao.GetLoginRecord(ctx, user.Uuid)
@@ -377,6 +395,12 @@ func (ac *AuthController) VerifyLoginCode(c *gin.Context, depCon container.Conta
 		}
 
 		if authRecord.VerifyCode != fmt.Sprintf("%s-%d", body.VerifyChar, body.VerifyDig) {
+			retryAfter, err := guard.RecordFailure(ctx, otpguard.LoginScope, subjects)
+
+			if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPAttempts) {
+				return
+			}
+
 			c.AbortWithError(
 				http.StatusBadRequest,
 				apperr.NewErr(apperr.VerifyCodeUnmatched),
@@ -384,14 +408,69 @@ func (ac *AuthController) VerifyLoginCode(c *gin.Context, depCon container.Conta
 
 			return
 		}
+
+		if err := guard.Reset(ctx, otpguard.LoginScope, subjects); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToRecordOTPAttempt,
+					err.Error(),
+				),
+			)
+
+			return
+		}
 	}
 
-	// Verify code matches, generate jwt token.
-	jwt, err := jwtactor.CreateToken(
-		user.Uuid,
-		config.GetAppConf().JwtSecret,
+	// Verify code matches, generate access token and refresh token.
+	var (
+		authDaoer contracts.AuthDaoer
+		userDao   contracts.UserDAOer
 	)
 
+	depCon.Make(&authDaoer)
+	depCon.Make(&userDao)
+
+	// Do not issue tokens to suspended users, they would be rejected by every API anyway.
+	mark, err := authDaoer.GetUserSuspension(ctx, user.Uuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckUserSuspension,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if jwtactor.AbortIfSuspended(c, mark) {
+		return
+	}
+
+	roles, err := userDao.GetUserRolesByUuid(user.Uuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserRoles,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	tokenPair, err := jwtactor.IssueTokenPair(ctx, authDaoer, jwtactor.CreateTokenPairParams{
+		Uuid:            user.Uuid,
+		Roles:           roles,
+		AccessTokenTTL:  config.GetAppConf().GetAccessTokenTTL(),
+		RefreshTokenTTL: config.GetAppConf().GetRefreshTokenTTL(),
+	})
+
 	if err != nil {
 		c.AbortWithError(
 			http.StatusInternalServerError,
@@ -404,7 +483,204 @@ func (ac *AuthController) VerifyLoginCode(c *gin.Context, depCon container.Conta
 		return
 	}
 
-	c.JSON(http.StatusOK, struct {
-		JWT string `json:"jwt"`
-	}{jwt})
+	// Register the login session so that the user can manage devices logged into the account.
+	if _, err := NewAuthDao(db.GetRedis()).UpsertSession(ctx, UpsertSessionParams{
+		UserUuid:     user.Uuid,
+		Family:       tokenPair.Family,
+		DeviceName:   body.DeviceName,
+		AppVersion:   body.AppVersion,
+		IP:           c.ClientIP(),
+		Jwt:          tokenPair.Jwt,
+		JwtExpiresAt: tokenPair.JwtExpiresAt,
+	}); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToUpsertSession,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformTokenPair(tokenPair))
+}
+
+type RefreshTokenBody struct {
+	RefreshToken string `form:"refresh_token" json:"refresh_token" binding:"required,gt=0"`
+}
+
+// RefreshTokenHandler exchanges a refresh token for a new token pair. Each refresh token can only be
+// used once. If a consumed refresh token is presented again, we assume the token has been stolen and
+// revoke every token in the token family. The user would have to login again.
+func (ac *AuthController) RefreshTokenHandler(c *gin.Context, depCon container.Container) {
+	var (
+		body RefreshTokenBody
+		ctx  context.Context = context.Background()
+	)
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateRefreshTokenParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	claims, err := jwtactor.ParseToken(body.RefreshToken, config.GetAppConf().JwtSecret)
+
+	if err != nil || !claims.IsRefreshToken() || len(claims.Family) == 0 {
+		c.AbortWithError(
+			http.StatusUnauthorized,
+			apperr.NewErr(apperr.InvalidRefreshToken),
+		)
+
+		return
+	}
+
+	var authDaoer contracts.AuthDaoer
+	depCon.Make(&authDaoer)
+
+	isRevoked, err := authDaoer.IsTokenFamilyRevoked(ctx, claims.Family)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToValidateToken,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if isRevoked {
+		c.AbortWithError(
+			http.StatusForbidden,
+			apperr.NewErr(apperr.TokenIsInvalidated),
+		)
+
+		return
+	}
+
+	consumed, err := authDaoer.ConsumeRefreshToken(ctx, claims.Id)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRotateRefreshToken,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	// Refresh token has been used before. Revoke the whole token family.
+	if !consumed {
+		log.
+			WithFields(log.Fields{
+				"user_uuid": claims.Uuid,
+				"family":    claims.Family,
+			}).
+			Warn("refresh token reuse detected, revoking token family")
+
+		if err := authDaoer.RevokeTokenFamily(ctx, claims.Family); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToRevokeTokenFamily,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		if err := NewAuthDao(db.GetRedis()).RemoveSession(ctx, claims.Uuid, claims.Family); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToRevokeSession,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		c.AbortWithError(
+			http.StatusForbidden,
+			apperr.NewErr(apperr.RefreshTokenReused),
+		)
+
+		return
+	}
+
+	// Roles are retrieved on every rotation so that granted / revoked roles take effect
+	// once the access token expires.
+	var userDao contracts.UserDAOer
+	depCon.Make(&userDao)
+
+	roles, err := userDao.GetUserRolesByUuid(claims.Uuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserRoles,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	tokenPair, err := jwtactor.IssueTokenPair(ctx, authDaoer, jwtactor.CreateTokenPairParams{
+		Uuid:            claims.Uuid,
+		Family:          claims.Family,
+		Roles:           roles,
+		AccessTokenTTL:  config.GetAppConf().GetAccessTokenTTL(),
+		RefreshTokenTTL: config.GetAppConf().GetRefreshTokenTTL(),
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRotateRefreshToken,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	// Keep the session alive with the latest access token.
+	if _, err := NewAuthDao(db.GetRedis()).UpsertSession(ctx, UpsertSessionParams{
+		UserUuid:     claims.Uuid,
+		Family:       tokenPair.Family,
+		IP:           c.ClientIP(),
+		Jwt:          tokenPair.Jwt,
+		JwtExpiresAt: tokenPair.JwtExpiresAt,
+	}); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToUpsertSession,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformTokenPair(tokenPair))
 }
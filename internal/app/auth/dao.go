@@ -8,9 +8,11 @@ import (
 
 	"github.com/go-redis/redis/v8"
 	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
 	"github.com/jmoiron/sqlx"
 )
 
@@ -77,12 +79,18 @@ type AuthDAOer interface {
 
 type AuthDAO struct {
 	redis *redis.Client
+
+	// suspensionDao looks up suspensions missing in the cache. See `GetUserSuspension`.
+	suspensionDao contracts.UserSuspensionDAOer
 }
 
 func AuthDaoerServiceProvider(c container.Container) func() error {
 	return func() error {
 		c.Transient(func() contracts.AuthDaoer {
-			return NewAuthDao(db.GetRedis())
+			dao := NewAuthDao(db.GetRedis())
+			dao.suspensionDao = suspension.NewUserSuspensionDAO(db.GetDB())
+
+			return dao
 		})
 
 		return nil
@@ -235,16 +243,130 @@ func (dao *AuthDAO) CreateLoginVerifyCode(ctx context.Context, loginVerifyCode,
 	return ParseLoginAuthenticatorFromMap(val)
 }
 
-func (dao *AuthDAO) IsTokenInvalid(ctx context.Context, jwtToken string) (bool, error) {
+// IsTokenInvalid checks if the given token has been revoked. Tokens belonging to a revoked
+// token family are also considered invalid.
+func (dao *AuthDAO) IsTokenInvalid(ctx context.Context, p contracts.IsTokenInvalidParams) (bool, error) {
 	exists, err := dao.redis.HExists(
 		ctx,
 		INVALIDATE_TOKEN_REDIS_KEY,
-		jwtToken,
+		p.Token,
 	).Result()
 
 	if err != nil {
 		return false, err
 	}
 
-	return exists, nil
+	if exists || len(p.Family) == 0 {
+		return exists, nil
+	}
+
+	return dao.IsTokenFamilyRevoked(ctx, p.Family)
+}
+
+// Refresh tokens are stored in redis with the following structure:
+//
+// refresh_token:{{ REFRESH_TOKEN_ID }}: {{ FAMILY }}   expires along with the refresh token.
+// refresh_token_family:{{ FAMILY }}: { REFRESH_TOKEN_ID_1, REFRESH_TOKEN_ID_2 }
+// revoked_token_family:{{ FAMILY }}: 1   exists if the family has been revoked.
+//
+// A refresh token can only be consumed once. Consuming a refresh token deletes the record,
+// thus any later attempt to consume the same token indicates that the token has been stolen.
+const (
+	RefreshTokenRedisKey       = "refresh_token:%s"
+	RefreshTokenFamilyRedisKey = "refresh_token_family:%s"
+	RevokedTokenFamilyRedisKey = "revoked_token_family:%s"
+)
+
+func (dao *AuthDAO) StoreRefreshToken(ctx context.Context, p contracts.StoreRefreshTokenParams) error {
+	pipe := dao.redis.TxPipeline()
+	defer pipe.Close()
+
+	exp := time.Unix(p.ExpiresAt, 0)
+
+	pipe.Set(
+		ctx,
+		fmt.Sprintf(RefreshTokenRedisKey, p.ID),
+		p.Family,
+		time.Until(exp),
+	)
+
+	pipe.SAdd(
+		ctx,
+		fmt.Sprintf(RefreshTokenFamilyRedisKey, p.Family),
+		p.ID,
+	)
+
+	pipe.ExpireAt(
+		ctx,
+		fmt.Sprintf(RefreshTokenFamilyRedisKey, p.Family),
+		exp,
+	)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConsumeRefreshToken removes the refresh token record from redis. It returns false if the refresh
+// token has already been consumed or revoked.
+func (dao *AuthDAO) ConsumeRefreshToken(ctx context.Context, ID string) (bool, error) {
+	numDeleted, err := dao.redis.Del(
+		ctx,
+		fmt.Sprintf(RefreshTokenRedisKey, ID),
+	).Result()
+
+	if err != nil {
+		return false, err
+	}
+
+	return numDeleted > 0, nil
+}
+
+// RevokeTokenFamily marks the token family as revoked and removes every outstanding refresh token of the family.
+// The revoke mark lives as long as the longest living refresh token.
+func (dao *AuthDAO) RevokeTokenFamily(ctx context.Context, family string) error {
+	familyKey := fmt.Sprintf(RefreshTokenFamilyRedisKey, family)
+
+	IDs, err := dao.redis.SMembers(ctx, familyKey).Result()
+
+	if err != nil {
+		return err
+	}
+
+	pipe := dao.redis.TxPipeline()
+	defer pipe.Close()
+
+	pipe.Set(
+		ctx,
+		fmt.Sprintf(RevokedTokenFamilyRedisKey, family),
+		1,
+		config.GetAppConf().GetRefreshTokenTTL(),
+	)
+
+	for _, ID := range IDs {
+		pipe.Del(ctx, fmt.Sprintf(RefreshTokenRedisKey, ID))
+	}
+
+	pipe.Del(ctx, familyKey)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dao *AuthDAO) IsTokenFamilyRevoked(ctx context.Context, family string) (bool, error) {
+	exists, err := dao.redis.Exists(
+		ctx,
+		fmt.Sprintf(RevokedTokenFamilyRedisKey, family),
+	).Result()
+
+	if err != nil {
+		return false, err
+	}
+
+	return exists > 0, nil
 }
@@ -3,7 +3,6 @@ package auth
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 )
@@ -31,14 +30,30 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 		authController.VerifyLoginCode(c, depCon)
 	})
 
+	// Exchange refresh token for a new pair of access token and refresh token.
+	g.POST("/refresh", func(c *gin.Context) {
+		authController.RefreshTokenHandler(c, depCon)
+	})
+
 	g.POST(
 		"/revoke-jwt",
 		jwtactor.JwtValidator(
-			jwtactor.JwtMiddlewareOptions{
-				Secret: config.GetAppConf().JwtSecret,
-			},
+			jwtactor.JwtMiddlewareOptions{},
 			authDaoer,
 		),
 		authController.RevokeJwtHandler,
 	)
+
+	// Devices that are logged into the account. User can log out any of them remotely.
+	sg := g.Group(
+		"/sessions",
+		jwtactor.JwtValidator(
+			jwtactor.JwtMiddlewareOptions{},
+			authDaoer,
+		),
+	)
+
+	sg.GET("", authController.GetSessionsHandler)
+	sg.DELETE("", authController.RevokeAllSessionsHandler)
+	sg.DELETE("/:session_id", authController.RevokeSessionHandler)
 }
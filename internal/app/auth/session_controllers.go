@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+// GetSessionsHandler lists devices that are currently logged into the account.
+func (ac *AuthController) GetSessionsHandler(c *gin.Context) {
+	ctx := context.Background()
+
+	sessions, err := NewAuthDao(db.GetRedis()).GetSessions(ctx, c.GetString("uuid"))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetSessions,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(
+		http.StatusOK,
+		NewTransform().TransformSessions(sessions, c.GetString("token_family")),
+	)
+}
+
+// RevokeSessionHandler logs out a single device.
+func (ac *AuthController) RevokeSessionHandler(c *gin.Context) {
+	var (
+		ctx      = context.Background()
+		authDao  = NewAuthDao(db.GetRedis())
+		userUuid = c.GetString("uuid")
+	)
+
+	sess, err := authDao.GetSession(ctx, userUuid, c.Param("session_id"))
+
+	if err != nil {
+		if err == redis.Nil {
+			c.AbortWithError(
+				http.StatusNotFound,
+				apperr.NewErr(apperr.SessionNotFound),
+			)
+
+			return
+		}
+
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetSessions,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if err := authDao.RevokeSession(ctx, userUuid, sess); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRevokeSession,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+type RevokeAllSessionsBody struct {
+	// Keep the session of the requester alive. Useful when the user wants to log out all other devices.
+	KeepCurrent bool `form:"keep_current" json:"keep_current"`
+}
+
+// RevokeAllSessionsHandler logs out every device logged into the account.
+func (ac *AuthController) RevokeAllSessionsHandler(c *gin.Context) {
+	body := RevokeAllSessionsBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToBindApiBodyParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	excludes := make([]string, 0)
+
+	if body.KeepCurrent && len(c.GetString("token_family")) > 0 {
+		excludes = append(excludes, c.GetString("token_family"))
+	}
+
+	if err := NewAuthDao(db.GetRedis()).RevokeAllSessions(
+		context.Background(),
+		c.GetString("uuid"),
+		excludes...,
+	); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRevokeSession,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
@@ -0,0 +1,216 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/huangc28/go-darkpanda-backend/config"
+)
+
+// Sessions of a user are stored in a redis hash keyed by user uuid. Each field of the hash is the
+// token family issued on login. Value of the field is the JSON encoded `Session`.
+//
+//	user_sessions:{{ USER_UUID }}: {
+//		{{ FAMILY_1 }}: { device_name, app_version, ip, ... }
+//		{{ FAMILY_2 }}: { device_name, app_version, ip, ... }
+//	}
+const (
+	UserSessionsRedisKey = "user_sessions:%s"
+)
+
+type Session struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	AppVersion string    `json:"app_version"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+
+	// Latest access token issued to the session. We need it to revoke the access token when the session is revoked.
+	Jwt          string `json:"jwt"`
+	JwtExpiresAt int64  `json:"jwt_expires_at"`
+}
+
+func (s *Session) IsExpired() bool {
+	return time.Now().After(s.LastSeenAt.Add(config.GetAppConf().GetRefreshTokenTTL()))
+}
+
+type UpsertSessionParams struct {
+	UserUuid     string
+	Family       string
+	DeviceName   string
+	AppVersion   string
+	IP           string
+	Jwt          string
+	JwtExpiresAt int64
+}
+
+// UpsertSession creates a session record of the token family. If the session already exists, last seen time,
+// IP and the latest access token will be updated. Device info is only updated when provided.
+func (dao *AuthDAO) UpsertSession(ctx context.Context, p UpsertSessionParams) (*Session, error) {
+	sess, err := dao.GetSession(ctx, p.UserUuid, p.Family)
+
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	if err == redis.Nil {
+		sess = &Session{
+			ID:        p.Family,
+			CreatedAt: now,
+		}
+	}
+
+	if len(p.DeviceName) > 0 {
+		sess.DeviceName = p.DeviceName
+	}
+
+	if len(p.AppVersion) > 0 {
+		sess.AppVersion = p.AppVersion
+	}
+
+	sess.IP = p.IP
+	sess.LastSeenAt = now
+	sess.Jwt = p.Jwt
+	sess.JwtExpiresAt = p.JwtExpiresAt
+
+	sessBytes, err := json.Marshal(sess)
+
+	if err != nil {
+		return nil, err
+	}
+
+	key := fmt.Sprintf(UserSessionsRedisKey, p.UserUuid)
+
+	pipe := dao.redis.TxPipeline()
+	defer pipe.Close()
+
+	pipe.HSet(ctx, key, p.Family, string(sessBytes))
+	pipe.Expire(ctx, key, config.GetAppConf().GetRefreshTokenTTL())
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return sess, nil
+}
+
+func (dao *AuthDAO) GetSession(ctx context.Context, userUuid, family string) (*Session, error) {
+	val, err := dao.redis.HGet(
+		ctx,
+		fmt.Sprintf(UserSessionsRedisKey, userUuid),
+		family,
+	).Result()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var sess Session
+
+	if err := json.Unmarshal([]byte(val), &sess); err != nil {
+		return nil, err
+	}
+
+	return &sess, nil
+}
+
+// GetSessions retrieves active sessions of the user ordered by last seen time. Expired sessions are
+// removed from the registry.
+func (dao *AuthDAO) GetSessions(ctx context.Context, userUuid string) ([]*Session, error) {
+	key := fmt.Sprintf(UserSessionsRedisKey, userUuid)
+
+	vals, err := dao.redis.HGetAll(ctx, key).Result()
+
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*Session, 0)
+	expired := make([]string, 0)
+
+	for family, val := range vals {
+		var sess Session
+
+		if err := json.Unmarshal([]byte(val), &sess); err != nil {
+			return nil, err
+		}
+
+		if sess.IsExpired() {
+			expired = append(expired, family)
+
+			continue
+		}
+
+		sessions = append(sessions, &sess)
+	}
+
+	if len(expired) > 0 {
+		if err := dao.redis.HDel(ctx, key, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession revokes the latest access token and the token family of the session,
+// then removes the session from the registry.
+func (dao *AuthDAO) RevokeSession(ctx context.Context, userUuid string, sess *Session) error {
+	if len(sess.Jwt) > 0 {
+		if err := dao.RevokeJwt(ctx, sess.Jwt, sess.JwtExpiresAt); err != nil {
+			return err
+		}
+	}
+
+	if err := dao.RevokeTokenFamily(ctx, sess.ID); err != nil {
+		return err
+	}
+
+	return dao.RemoveSession(ctx, userUuid, sess.ID)
+}
+
+func (dao *AuthDAO) RemoveSession(ctx context.Context, userUuid, family string) error {
+	return dao.redis.HDel(
+		ctx,
+		fmt.Sprintf(UserSessionsRedisKey, userUuid),
+		family,
+	).Err()
+}
+
+// RevokeAllSessions revokes every session of the user except those specified in `excludes`.
+func (dao *AuthDAO) RevokeAllSessions(ctx context.Context, userUuid string, excludes ...string) error {
+	sessions, err := dao.GetSessions(ctx, userUuid)
+
+	if err != nil {
+		return err
+	}
+
+	excludeMap := make(map[string]struct{})
+
+	for _, exclude := range excludes {
+		excludeMap[exclude] = struct{}{}
+	}
+
+	for _, sess := range sessions {
+		if _, ok := excludeMap[sess.ID]; ok {
+			continue
+		}
+
+		if err := dao.RevokeSession(ctx, userUuid, sess); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
+
+var (
+	ErrSigningKeyNotStandby = errors.New("signing key does not exist or is not in standby")
+	ErrSigningKeyReserved   = errors.New("signing key id is reserved")
+	ErrNoActiveSigningKey   = errors.New("legacy secret can only be retired once another signing key is active")
+)
+
+// SigningKeyDAO manages the keyring used to sign / verify jwt. Key lifecycle:
+//
+//	standby --activate--> active --activate another key--> standby --retire--> retired
+//
+// Tokens signed by standby and active keys are accepted. Tokens signed by retired keys are rejected.
+type SigningKeyDAO struct {
+	db db.Conn
+}
+
+func NewSigningKeyDAO(db db.Conn) *SigningKeyDAO {
+	return &SigningKeyDAO{
+		db: db,
+	}
+}
+
+func SigningKeyDAOServiceProvider(c container.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.SigningKeyDAOer {
+			return NewSigningKeyDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *SigningKeyDAO) WithTx(tx db.Conn) contracts.SigningKeyDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+func (dao *SigningKeyDAO) GetSigningKeys() ([]models.JwtSigningKey, error) {
+	query := `
+SELECT *
+FROM jwt_signing_keys
+WHERE deleted_at IS NULL
+ORDER BY created_at;
+`
+	rows, err := dao.db.Queryx(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	keys := make([]models.JwtSigningKey, 0)
+
+	for rows.Next() {
+		var key models.JwtSigningKey
+
+		if err := rows.StructScan(&key); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// AddSigningKey adds a key in standby. Standby keys are accepted when verifying tokens so that
+// every app instance already knows the key by the time it's activated.
+func (dao *SigningKeyDAO) AddSigningKey(kid, secret string) (*models.JwtSigningKey, error) {
+	if kid == jwtactor.LegacyKeyID {
+		return nil, ErrSigningKeyReserved
+	}
+
+	query := `
+INSERT INTO jwt_signing_keys (kid, secret)
+VALUES ($1, $2)
+RETURNING *;
+`
+	var key models.JwtSigningKey
+
+	if err := dao.db.QueryRowx(query, kid, secret).StructScan(&key); err != nil {
+		return nil, err
+	}
+
+	return &key, nil
+}
+
+// ActivateSigningKey signs new tokens with the given standby key. Current active key is put back
+// to standby. It should be performed within a transaction.
+func (dao *SigningKeyDAO) ActivateSigningKey(kid string) error {
+	demoteQuery := `
+UPDATE jwt_signing_keys
+SET status = 'standby'
+WHERE status = 'active';
+`
+	if _, err := dao.db.Exec(demoteQuery); err != nil {
+		return err
+	}
+
+	activateQuery := `
+UPDATE jwt_signing_keys
+SET
+	status = 'active',
+	activated_at = now()
+WHERE kid = $1
+	AND status = 'standby'
+	AND deleted_at IS NULL;
+`
+	res, err := dao.db.Exec(activateQuery, kid)
+
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res.RowsAffected())
+}
+
+// RetireSigningKey rejects every token signed by the key. Active key can not be retired, activate
+// another key first. Retiring `jwtactor.LegacyKeyID` rejects tokens signed by `AppConf.JwtSecret`.
+func (dao *SigningKeyDAO) RetireSigningKey(kid string) error {
+	if kid == jwtactor.LegacyKeyID {
+		return dao.retireLegacyKey()
+	}
+
+	query := `
+UPDATE jwt_signing_keys
+SET
+	status = 'retired',
+	retired_at = now()
+WHERE kid = $1
+	AND status = 'standby'
+	AND deleted_at IS NULL;
+`
+	res, err := dao.db.Exec(query, kid)
+
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(res.RowsAffected())
+}
+
+// Legacy secret lives in app config. We record its retirement as a secretless key.
+func (dao *SigningKeyDAO) retireLegacyKey() error {
+	query := `
+INSERT INTO jwt_signing_keys (kid, secret, status, retired_at)
+SELECT $1, '', 'retired', now()
+WHERE EXISTS (
+	SELECT 1
+	FROM jwt_signing_keys
+	WHERE status = 'active'
+		AND deleted_at IS NULL
+)
+ON CONFLICT (kid) DO UPDATE SET retired_at = jwt_signing_keys.retired_at;
+`
+	res, err := dao.db.Exec(query, jwtactor.LegacyKeyID)
+
+	if err != nil {
+		return err
+	}
+
+	if err := checkAffected(res.RowsAffected()); err != nil {
+		return ErrNoActiveSigningKey
+	}
+
+	return nil
+}
+
+func checkAffected(affected int64, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrSigningKeyNotStandby
+	}
+
+	return nil
+}
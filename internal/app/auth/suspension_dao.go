@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
+)
+
+// Suspensions are stored in postgres. Active suspensions are cached in redis so that `JwtValidator`
+// does not hit the database on every request. The cache expires along with the suspension. Users
+// who are not suspended are cached with an empty mark for `NotSuspendedTTL`.
+//
+//	suspended_user:{{ USER_UUID }}: { suspension_type, reason, expires_at }
+const (
+	SuspendedUserRedisKey = "suspended_user:%s"
+	NotSuspendedTTL       = 5 * time.Minute
+)
+
+func (dao *AuthDAO) SetUserSuspension(ctx context.Context, userUuid string, mark contracts.UserSuspensionMark) error {
+	var ttl time.Duration
+
+	if mark.ExpiresAt != nil {
+		ttl = time.Until(*mark.ExpiresAt)
+
+		// Already expired, nothing to cache.
+		if ttl <= 0 {
+			return dao.ClearUserSuspension(ctx, userUuid)
+		}
+	}
+
+	b, err := json.Marshal(mark)
+
+	if err != nil {
+		return err
+	}
+
+	return dao.redis.Set(
+		ctx,
+		fmt.Sprintf(SuspendedUserRedisKey, userUuid),
+		b,
+		ttl,
+	).Err()
+}
+
+func (dao *AuthDAO) ClearUserSuspension(ctx context.Context, userUuid string) error {
+	return dao.redis.Del(
+		ctx,
+		fmt.Sprintf(SuspendedUserRedisKey, userUuid),
+	).Err()
+}
+
+// GetUserSuspension retrieves the suspension of the user. nil is returned if the user is not suspended.
+func (dao *AuthDAO) GetUserSuspension(ctx context.Context, userUuid string) (*contracts.UserSuspensionMark, error) {
+	b, err := dao.redis.Get(
+		ctx,
+		fmt.Sprintf(SuspendedUserRedisKey, userUuid),
+	).Bytes()
+
+	if err == redis.Nil {
+		return dao.loadUserSuspension(ctx, userUuid)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var mark contracts.UserSuspensionMark
+
+	if err := json.Unmarshal(b, &mark); err != nil {
+		return nil, err
+	}
+
+	if mark.SuspensionType == "" {
+		return nil, nil
+	}
+
+	return &mark, nil
+}
+
+// loadUserSuspension looks up the suspension in postgres when the cache misses, e.g. redis has lost
+// the data. The result is cached unless the key has been set in the meantime by `suspension.Suspend`.
+func (dao *AuthDAO) loadUserSuspension(ctx context.Context, userUuid string) (*contracts.UserSuspensionMark, error) {
+	// Daos created by `NewAuthDao` only read from the cache.
+	if dao.suspensionDao == nil {
+		return nil, nil
+	}
+
+	s, err := dao.suspensionDao.GetActiveSuspensionByUserUuid(userUuid)
+
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	var (
+		mark contracts.UserSuspensionMark
+		ttl  = NotSuspendedTTL
+	)
+
+	if s != nil {
+		mark = suspension.MarkOf(s)
+		ttl = 0
+
+		if mark.ExpiresAt != nil {
+			ttl = time.Until(*mark.ExpiresAt)
+
+			// Expired right after being retrieved.
+			if ttl <= 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	b, err := json.Marshal(mark)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := dao.redis.SetNX(
+		ctx,
+		fmt.Sprintf(SuspendedUserRedisKey, userUuid),
+		b,
+		ttl,
+	).Err(); err != nil {
+		return nil, err
+	}
+
+	if s == nil {
+		return nil, nil
+	}
+
+	return &mark, nil
+}
@@ -1,6 +1,11 @@
 package auth
 
-import "github.com/huangc28/go-darkpanda-backend/internal/app/models"
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
 
 type TransformedUser struct {
 	Username      string `json:"username"`
@@ -32,3 +37,53 @@ func (at *AuthTransform) TransformSendLoginMobileVerifyCode(uuid string, verifyP
 		Mobile:       mobile,
 	}
 }
+
+type TransformedTokenPair struct {
+	Jwt                   string `json:"jwt"`
+	JwtExpiresAt          int64  `json:"jwt_expires_at"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at"`
+}
+
+func (at *AuthTransform) TransformTokenPair(pair *jwtactor.TokenPair) TransformedTokenPair {
+	return TransformedTokenPair{
+		Jwt:                   pair.Jwt,
+		JwtExpiresAt:          pair.JwtExpiresAt,
+		RefreshToken:          pair.RefreshToken,
+		RefreshTokenExpiresAt: pair.RefreshTokenExpiresAt,
+	}
+}
+
+type TransformedSession struct {
+	ID         string    `json:"id"`
+	DeviceName string    `json:"device_name"`
+	AppVersion string    `json:"app_version"`
+	IP         string    `json:"ip"`
+	IsCurrent  bool      `json:"is_current"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+type TransformedSessions struct {
+	Sessions []TransformedSession `json:"sessions"`
+}
+
+func (at *AuthTransform) TransformSessions(sessions []*Session, currentFamily string) TransformedSessions {
+	trfSessions := make([]TransformedSession, 0)
+
+	for _, sess := range sessions {
+		trfSessions = append(trfSessions, TransformedSession{
+			ID:         sess.ID,
+			DeviceName: sess.DeviceName,
+			AppVersion: sess.AppVersion,
+			IP:         sess.IP,
+			IsCurrent:  sess.ID == currentFamily,
+			CreatedAt:  sess.CreatedAt,
+			LastSeenAt: sess.LastSeenAt,
+		})
+	}
+
+	return TransformedSessions{
+		Sessions: trfSessions,
+	}
+}
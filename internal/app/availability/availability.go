@@ -0,0 +1,149 @@
+// Package availability manages weekly availability slots and blackout dates declared by female
+// users. Appointment times of inquiries and services are checked against them before booked.
+package availability
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+// IsWithinSlots tells if the appointment from t lasting for duration is covered by the weekly slots.
+// Adjacent slots are joined, so an appointment crossing midnight is covered by a slot ending at
+// 23:59 followed by a slot starting at 00:00 on the next day. The appointment time should have been
+// converted to the app time zone. Providers who have not declared any slot are considered available
+// at any time.
+func IsWithinSlots(slots []models.AvailabilitySlot, t time.Time, duration time.Duration) bool {
+	if len(slots) == 0 {
+		return true
+	}
+
+	// Slots are laid out in seconds of the week starting from sunday. Slots are repeated on the following
+	// week so that appointments on saturday night can be joined with slots on sunday.
+	type interval struct{ start, end int }
+
+	intervals := make([]interval, 0, len(slots)*2)
+
+	for _, slot := range slots {
+		end := secondsOfDay(slot.EndTime)
+
+		// Slots can not cross midnight, 23:59 is the end of the day.
+		if end == secondsOfDay(endOfDay) {
+			end = secondsPerDay
+		}
+
+		offset := int(slot.Weekday) * secondsPerDay
+
+		intervals = append(
+			intervals,
+			interval{offset + secondsOfDay(slot.StartTime), offset + end},
+			interval{offset + secondsOfDay(slot.StartTime) + secondsPerWeek, offset + end + secondsPerWeek},
+		)
+	}
+
+	cursor := int(t.Weekday())*secondsPerDay + secondsOfDay(t)
+	until := cursor + int(duration/time.Second)
+
+	for {
+		covered := false
+
+		for _, iv := range intervals {
+			if cursor >= iv.start && cursor < iv.end {
+				cursor = iv.end
+				covered = true
+
+				break
+			}
+		}
+
+		if !covered {
+			return false
+		}
+
+		if cursor >= until {
+			return true
+		}
+	}
+}
+
+// IsAvailable tells if the provider is available for the appointment lasting for duration, that is,
+// none of the dates it spans is blacked out and the whole appointment is covered by the weekly slots.
+func IsAvailable(dao contracts.AvailabilityDAOer, providerID int64, appointmentTime time.Time, duration time.Duration) (bool, error) {
+	t := appointmentTime.In(config.GetAppConf().GetAppLocation())
+
+	dates := []time.Time{t}
+
+	// Appointments ending at midnight do not take the next day.
+	if duration > 0 {
+		dates = append(dates, t.Add(duration-time.Nanosecond))
+	}
+
+	for _, date := range dates {
+		blackout, err := dao.HasBlackout(providerID, date)
+
+		if err != nil {
+			return false, err
+		}
+
+		if blackout {
+			return false, nil
+		}
+	}
+
+	slots, err := dao.GetSlots(providerID)
+
+	if err != nil {
+		return false, err
+	}
+
+	return IsWithinSlots(slots, t, duration), nil
+}
+
+// RequireAvailable aborts the request if the provider is not available for the appointment lasting
+// for duration. Returns false if the request has been aborted.
+func RequireAvailable(c *gin.Context, depCon container.Container, providerID int64, appointmentTime time.Time, duration time.Duration) bool {
+	var dao contracts.AvailabilityDAOer
+	depCon.Make(&dao)
+
+	available, err := IsAvailable(dao, providerID, appointmentTime, duration)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckAvailability,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	if !available {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.AppointmentOutsideAvailability),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+const (
+	secondsPerDay  = 24 * 60 * 60
+	secondsPerWeek = 7 * secondsPerDay
+)
+
+var endOfDay = time.Date(0, 1, 1, 23, 59, 0, 0, time.UTC)
+
+func secondsOfDay(t time.Time) int {
+	return t.Hour()*3600 + t.Minute()*60 + t.Second()
+}
@@ -0,0 +1,102 @@
+package availabilitytests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/availability"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type AvailabilityTestSuite struct {
+	suite.Suite
+}
+
+func clock(s string) time.Time {
+	t, _ := time.Parse("15:04", s)
+
+	return t
+}
+
+func (suite *AvailabilityTestSuite) TestNoSlotsDeclared() {
+	assert.True(suite.T(), availability.IsWithinSlots(nil, time.Now(), time.Hour))
+}
+
+func (suite *AvailabilityTestSuite) TestWithinSlots() {
+	slots := []models.AvailabilitySlot{
+		{
+			Weekday:   int16(time.Friday),
+			StartTime: clock("18:00"),
+			EndTime:   clock("23:30"),
+		},
+		{
+			Weekday:   int16(time.Saturday),
+			StartTime: clock("00:00"),
+			EndTime:   clock("02:00"),
+		},
+	}
+
+	// 2021-10-01 is a friday.
+	cases := []struct {
+		t        time.Time
+		expected bool
+	}{
+		{time.Date(2021, 10, 1, 18, 0, 0, 0, time.UTC), true},
+		{time.Date(2021, 10, 1, 23, 29, 59, 0, time.UTC), true},
+		{time.Date(2021, 10, 1, 23, 30, 0, 0, time.UTC), false},
+		{time.Date(2021, 10, 1, 17, 59, 0, 0, time.UTC), false},
+		{time.Date(2021, 10, 2, 1, 0, 0, 0, time.UTC), true},
+		{time.Date(2021, 10, 2, 18, 30, 0, 0, time.UTC), false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(suite.T(), c.expected, availability.IsWithinSlots(slots, c.t, 0), c.t.String())
+	}
+}
+
+func (suite *AvailabilityTestSuite) TestWholeAppointmentWithinSlots() {
+	slots := []models.AvailabilitySlot{
+		{
+			Weekday:   int16(time.Friday),
+			StartTime: clock("18:00"),
+			EndTime:   clock("23:30"),
+		},
+		{
+			Weekday:   int16(time.Saturday),
+			StartTime: clock("20:00"),
+			EndTime:   clock("23:59"),
+		},
+		{
+			Weekday:   int16(time.Sunday),
+			StartTime: clock("00:00"),
+			EndTime:   clock("02:00"),
+		},
+	}
+
+	// 2021-10-01 is a friday.
+	cases := []struct {
+		t        time.Time
+		duration time.Duration
+		expected bool
+	}{
+		{time.Date(2021, 10, 1, 18, 0, 0, 0, time.UTC), 5*time.Hour + 30*time.Minute, true},
+		{time.Date(2021, 10, 1, 23, 0, 0, 0, time.UTC), 30 * time.Minute, true},
+		{time.Date(2021, 10, 1, 23, 0, 0, 0, time.UTC), 31 * time.Minute, false},
+		{time.Date(2021, 10, 1, 23, 0, 0, 0, time.UTC), 3 * time.Hour, false},
+
+		// Saturday night crosses midnight into sunday of the next week.
+		{time.Date(2021, 10, 2, 23, 0, 0, 0, time.UTC), 3 * time.Hour, true},
+		{time.Date(2021, 10, 2, 23, 0, 0, 0, time.UTC), 3*time.Hour + time.Minute, false},
+		{time.Date(2021, 10, 2, 19, 0, 0, 0, time.UTC), time.Hour, false},
+	}
+
+	for _, c := range cases {
+		assert.Equal(suite.T(), c.expected, availability.IsWithinSlots(slots, c.t, c.duration), c.t.String()+" "+c.duration.String())
+	}
+}
+
+func TestAvailabilityTestSuite(t *testing.T) {
+	suite.Run(t, new(AvailabilityTestSuite))
+}
@@ -0,0 +1,252 @@
+package availability
+
+import (
+	"database/sql"
+	"time"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+const dateLayout = "2006-01-02"
+
+type AvailabilityDAO struct {
+	db db.Conn
+}
+
+func NewAvailabilityDAO(db db.Conn) *AvailabilityDAO {
+	return &AvailabilityDAO{
+		db: db,
+	}
+}
+
+func AvailabilityDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.AvailabilityDAOer {
+			return NewAvailabilityDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *AvailabilityDAO) WithTx(tx db.Conn) contracts.AvailabilityDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+func (dao *AvailabilityDAO) GetSlots(userID int64) ([]models.AvailabilitySlot, error) {
+	query := `
+SELECT
+	*
+FROM
+	availability_slots
+WHERE
+	user_id = $1 AND
+	deleted_at IS NULL
+ORDER BY weekday, start_time;
+`
+	rows, err := dao.db.Queryx(query, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	slots := make([]models.AvailabilitySlot, 0)
+
+	for rows.Next() {
+		var m models.AvailabilitySlot
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		slots = append(slots, m)
+	}
+
+	return slots, nil
+}
+
+func (dao *AvailabilityDAO) CreateSlot(p contracts.CreateAvailabilitySlotParams) (*models.AvailabilitySlot, error) {
+	query := `
+INSERT INTO availability_slots (
+	user_id,
+	weekday,
+	start_time,
+	end_time
+) VALUES ($1, $2, $3, $4)
+RETURNING *;
+`
+	var m models.AvailabilitySlot
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.UserID,
+		p.Weekday,
+		p.StartTime,
+		p.EndTime,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// UpdateSlot updates the slot owned by the user, `sql.ErrNoRows` is returned if the slot does not exist.
+func (dao *AvailabilityDAO) UpdateSlot(p contracts.UpdateAvailabilitySlotParams) (*models.AvailabilitySlot, error) {
+	query := `
+UPDATE availability_slots
+SET
+	weekday = $1,
+	start_time = $2,
+	end_time = $3
+WHERE
+	id = $4 AND
+	user_id = $5 AND
+	deleted_at IS NULL
+RETURNING *;
+`
+	var m models.AvailabilitySlot
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Weekday,
+		p.StartTime,
+		p.EndTime,
+		p.ID,
+		p.UserID,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// DeleteSlot deletes the slot owned by the user, `sql.ErrNoRows` is returned if the slot does not exist.
+func (dao *AvailabilityDAO) DeleteSlot(userID int64, ID int32) error {
+	query := `
+UPDATE availability_slots
+SET deleted_at = NOW()
+WHERE
+	id = $1 AND
+	user_id = $2 AND
+	deleted_at IS NULL;
+`
+	return execAffectingRow(dao.db, query, ID, userID)
+}
+
+// GetBlackouts retrieves blackout dates of the user on or after the given date.
+func (dao *AvailabilityDAO) GetBlackouts(userID int64, since time.Time) ([]models.AvailabilityBlackout, error) {
+	query := `
+SELECT
+	*
+FROM
+	availability_blackouts
+WHERE
+	user_id = $1 AND
+	date >= $2 AND
+	deleted_at IS NULL
+ORDER BY date;
+`
+	rows, err := dao.db.Queryx(query, userID, since.Format(dateLayout))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	blackouts := make([]models.AvailabilityBlackout, 0)
+
+	for rows.Next() {
+		var m models.AvailabilityBlackout
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		blackouts = append(blackouts, m)
+	}
+
+	return blackouts, nil
+}
+
+func (dao *AvailabilityDAO) HasBlackout(userID int64, date time.Time) (bool, error) {
+	query := `
+SELECT EXISTS(
+	SELECT 1
+	FROM availability_blackouts
+	WHERE
+		user_id = $1 AND
+		date = $2 AND
+		deleted_at IS NULL
+);
+`
+	var exists bool
+
+	if err := dao.db.QueryRowx(query, userID, date.Format(dateLayout)).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// CreateBlackout marks the date as unavailable. `sql.ErrNoRows` is returned if the date has been blacked out.
+func (dao *AvailabilityDAO) CreateBlackout(userID int64, date time.Time) (*models.AvailabilityBlackout, error) {
+	query := `
+INSERT INTO availability_blackouts (
+	user_id,
+	date
+) VALUES ($1, $2)
+ON CONFLICT (user_id, date) DO UPDATE SET
+	deleted_at = NULL
+WHERE
+	availability_blackouts.deleted_at IS NOT NULL
+RETURNING *;
+`
+	var m models.AvailabilityBlackout
+
+	if err := dao.db.QueryRowx(query, userID, date.Format(dateLayout)).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// DeleteBlackout deletes the blackout date owned by the user, `sql.ErrNoRows` is returned if it does not exist.
+func (dao *AvailabilityDAO) DeleteBlackout(userID int64, ID int32) error {
+	query := `
+UPDATE availability_blackouts
+SET deleted_at = NOW()
+WHERE
+	id = $1 AND
+	user_id = $2 AND
+	deleted_at IS NULL;
+`
+	return execAffectingRow(dao.db, query, ID, userID)
+}
+
+func execAffectingRow(conn db.Conn, query string, args ...interface{}) error {
+	res, err := conn.Exec(query, args...)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
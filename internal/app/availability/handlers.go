@@ -0,0 +1,474 @@
+package availability
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+// GetMyAvailabilityHandler lists weekly slots and upcoming blackout dates of the requester.
+func GetMyAvailabilityHandler(c *gin.Context, depCon container.Container) {
+	getAvailability(c, depCon, c.GetString("uuid"))
+}
+
+// GetUserAvailabilityHandler lists weekly slots and upcoming blackout dates of the provider so
+// that the male user can pick an appointment time the provider is available.
+func GetUserAvailabilityHandler(c *gin.Context, depCon container.Container) {
+	getAvailability(c, depCon, c.Param("uuid"))
+}
+
+func getAvailability(c *gin.Context, depCon container.Container, userUuid string) {
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.AvailabilityDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	user, err := userDao.GetUserByUuid(userUuid, "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	slots, err := dao.GetSlots(user.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetAvailabilitySlots,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	loc := config.GetAppConf().GetAppLocation()
+
+	blackouts, err := dao.GetBlackouts(user.ID, time.Now().In(loc))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetAvailabilityBlackouts,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(
+		http.StatusOK,
+		NewTransform().TransformAvailability(loc.String(), slots, blackouts),
+	)
+}
+
+type SlotBody struct {
+	Weekday   *int   `form:"weekday" json:"weekday" binding:"required,min=0,max=6"`
+	StartTime string `form:"start_time" json:"start_time" binding:"required"`
+	EndTime   string `form:"end_time" json:"end_time" binding:"required"`
+}
+
+// validate start time and end time should be in `HH:MM` format, slot should not cross midnight.
+func (b *SlotBody) validate() error {
+	st, err := time.Parse(clockLayout, b.StartTime)
+
+	if err != nil {
+		return err
+	}
+
+	et, err := time.Parse(clockLayout, b.EndTime)
+
+	if err != nil {
+		return err
+	}
+
+	if !et.After(st) {
+		return errors.New("end_time should be later than start_time, split slots crossing midnight into two")
+	}
+
+	return nil
+}
+
+func bindSlotBody(c *gin.Context) (*SlotBody, bool) {
+	body := SlotBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAvailabilityParams,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	if err := body.validate(); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAvailabilityParams,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	return &body, true
+}
+
+type IDURIBody struct {
+	ID int32 `uri:"id" binding:"required"`
+}
+
+func bindIDURI(c *gin.Context) (*IDURIBody, bool) {
+	uri := IDURIBody{}
+
+	if err := c.ShouldBindUri(&uri); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAvailabilityParams,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	return &uri, true
+}
+
+func CreateSlotHandler(c *gin.Context, depCon container.Container) {
+	body, ok := bindSlotBody(c)
+
+	if !ok {
+		return
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.AvailabilityDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	slot, err := dao.CreateSlot(contracts.CreateAvailabilitySlotParams{
+		UserID:    me.ID,
+		Weekday:   *body.Weekday,
+		StartTime: body.StartTime,
+		EndTime:   body.EndTime,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCreateAvailabilitySlot,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformSlot(slot))
+}
+
+func UpdateSlotHandler(c *gin.Context, depCon container.Container) {
+	uri, ok := bindIDURI(c)
+
+	if !ok {
+		return
+	}
+
+	body, ok := bindSlotBody(c)
+
+	if !ok {
+		return
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.AvailabilityDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	slot, err := dao.UpdateSlot(contracts.UpdateAvailabilitySlotParams{
+		ID:        uri.ID,
+		UserID:    me.ID,
+		Weekday:   *body.Weekday,
+		StartTime: body.StartTime,
+		EndTime:   body.EndTime,
+	})
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.AvailabilitySlotNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToUpdateAvailabilitySlot,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformSlot(slot))
+}
+
+func DeleteSlotHandler(c *gin.Context, depCon container.Container) {
+	uri, ok := bindIDURI(c)
+
+	if !ok {
+		return
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.AvailabilityDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	err = dao.DeleteSlot(me.ID, uri.ID)
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.AvailabilitySlotNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToDeleteAvailabilitySlot,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+type CreateBlackoutBody struct {
+	// Date in the format of `YYYY-MM-DD`.
+	Date string `form:"date" json:"date" binding:"required"`
+}
+
+func CreateBlackoutHandler(c *gin.Context, depCon container.Container) {
+	body := CreateBlackoutBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAvailabilityParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	date, err := time.Parse(dateLayout, body.Date)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateAvailabilityParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.AvailabilityDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	blackout, err := dao.CreateBlackout(me.ID, date)
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.AvailabilityBlackoutExists),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCreateAvailabilityBlackout,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformBlackout(blackout))
+}
+
+func DeleteBlackoutHandler(c *gin.Context, depCon container.Container) {
+	uri, ok := bindIDURI(c)
+
+	if !ok {
+		return
+	}
+
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.AvailabilityDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	err = dao.DeleteBlackout(me.ID, uri.ID)
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.AvailabilityBlackoutNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToDeleteAvailabilityBlackout,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
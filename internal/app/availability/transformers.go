@@ -0,0 +1,65 @@
+package availability
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+const clockLayout = "15:04"
+
+type AvailabilityTransform struct{}
+
+func NewTransform() *AvailabilityTransform {
+	return &AvailabilityTransform{}
+}
+
+type TrfedSlot struct {
+	ID        int32  `json:"id"`
+	Weekday   int16  `json:"weekday"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+type TrfedBlackout struct {
+	ID   int32  `json:"id"`
+	Date string `json:"date"`
+}
+
+type TrfedAvailability struct {
+	TimeZone  string          `json:"time_zone"`
+	Slots     []TrfedSlot     `json:"slots"`
+	Blackouts []TrfedBlackout `json:"blackouts"`
+}
+
+func (t *AvailabilityTransform) TransformSlot(m *models.AvailabilitySlot) TrfedSlot {
+	return TrfedSlot{
+		ID:        m.ID,
+		Weekday:   m.Weekday,
+		StartTime: m.StartTime.Format(clockLayout),
+		EndTime:   m.EndTime.Format(clockLayout),
+	}
+}
+
+func (t *AvailabilityTransform) TransformBlackout(m *models.AvailabilityBlackout) TrfedBlackout {
+	return TrfedBlackout{
+		ID:   m.ID,
+		Date: m.Date.Format(dateLayout),
+	}
+}
+
+func (t *AvailabilityTransform) TransformAvailability(timeZone string, slots []models.AvailabilitySlot, blackouts []models.AvailabilityBlackout) TrfedAvailability {
+	trf := TrfedAvailability{
+		TimeZone:  timeZone,
+		Slots:     make([]TrfedSlot, 0),
+		Blackouts: make([]TrfedBlackout, 0),
+	}
+
+	for i := range slots {
+		trf.Slots = append(trf.Slots, t.TransformSlot(&slots[i]))
+	}
+
+	for i := range blackouts {
+		trf.Blackouts = append(trf.Blackouts, t.TransformBlackout(&blackouts[i]))
+	}
+
+	return trf
+}
@@ -132,3 +132,14 @@ func (dao *BankAccountDAO) InsertBankAccount(params contracts.InsertBankAccountP
 
 	return err
 }
+
+// DeleteUserBankAccounts removes bank accounts of the user permanently.
+func (dao *BankAccountDAO) DeleteUserBankAccounts(userID int64) error {
+	query := `
+DELETE FROM bank_accounts
+WHERE user_id = $1;
+`
+	_, err := dao.db.Exec(query, userID)
+
+	return err
+}
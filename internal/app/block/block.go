@@ -0,0 +1,43 @@
+package block
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+)
+
+// RequireNotBlocked aborts the request if either of the users has blocked the other one. The
+// counterpart is reported as not found so that the block is not revealed to either side.
+// Returns false if the request has been aborted.
+func RequireNotBlocked(c *gin.Context, depCon container.Container, userID, otherUserID int64) bool {
+	var dao contracts.BlockDAOer
+	depCon.Make(&dao)
+
+	blocked, err := dao.IsBlockedBetween(userID, otherUserID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckHasBlockedUser,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	if blocked {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.UserUnavailable),
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,85 @@
+package blocktests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/block"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeBlockDAO considers a pair blocked regardless of who initiated the block.
+type fakeBlockDAO struct {
+	blocks [][2]int64
+}
+
+func (dao *fakeBlockDAO) WithTx(tx db.Conn) contracts.BlockDAOer { return dao }
+
+func (dao *fakeBlockDAO) HasBlockedByUser(p contracts.HasBlockedByUserParams) (bool, error) {
+	return false, nil
+}
+
+func (dao *fakeBlockDAO) HasBlockedByUserById(p contracts.HasBlockedByUserByIdParams) (bool, error) {
+	return false, nil
+}
+
+func (dao *fakeBlockDAO) GetBlockRelatedUserIDs(userID int) ([]int64, error) {
+	return nil, nil
+}
+
+func (dao *fakeBlockDAO) IsBlockedBetween(userID, otherUserID int64) (bool, error) {
+	for _, b := range dao.blocks {
+		if (b[0] == userID && b[1] == otherUserID) || (b[0] == otherUserID && b[1] == userID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (dao *fakeBlockDAO) IsBlockedBetweenByUuid(uuid, otherUuid string) (bool, error) {
+	return false, nil
+}
+
+type BlockTestSuite struct {
+	suite.Suite
+	depCon container.Container
+}
+
+func (suite *BlockTestSuite) SetupSuite() {
+	gin.SetMode(gin.TestMode)
+
+	suite.depCon = container.NewContainer()
+	suite.depCon.Singleton(func() contracts.BlockDAOer {
+		// User 1 has blocked user 2.
+		return &fakeBlockDAO{blocks: [][2]int64{{1, 2}}}
+	})
+}
+
+func (suite *BlockTestSuite) TestRequireNotBlockedIsBidirectional() {
+	for _, pair := range [][2]int64{{1, 2}, {2, 1}} {
+		res := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(res)
+
+		assert.False(suite.T(), block.RequireNotBlocked(c, suite.depCon, pair[0], pair[1]))
+		assert.True(suite.T(), c.IsAborted())
+		assert.Equal(suite.T(), http.StatusNotFound, res.Code)
+	}
+}
+
+func (suite *BlockTestSuite) TestRequireNotBlockedPassesUnrelatedUsers() {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	assert.True(suite.T(), block.RequireNotBlocked(c, suite.depCon, 1, 3))
+	assert.False(suite.T(), c.IsAborted())
+}
+
+func TestBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(BlockTestSuite))
+}
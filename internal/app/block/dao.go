@@ -223,3 +223,94 @@ SELECT EXISTS (
 
 	return hasBlocked, nil
 }
+
+// IsBlockedBetween checks if either of the users has blocked the other one.
+func (dao *BlockDAO) IsBlockedBetween(userID, otherUserID int64) (bool, error) {
+	query := `
+SELECT EXISTS (
+	SELECT
+		1
+	FROM
+		block_list
+	WHERE
+		(
+			(user_id = $1 AND blocked_user_id = $2) OR
+			(user_id = $2 AND blocked_user_id = $1)
+		) AND
+		deleted_at IS NULL
+);
+	`
+	var blocked bool
+
+	if err := dao.db.QueryRow(query, userID, otherUserID).Scan(&blocked); err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
+
+// IsBlockedBetweenByUuid is identical to `IsBlockedBetween` but identifies users by uuid.
+func (dao *BlockDAO) IsBlockedBetweenByUuid(uuid, otherUuid string) (bool, error) {
+	query := `
+SELECT EXISTS (
+	SELECT
+		1
+	FROM
+		block_list bl
+	INNER JOIN users u ON u.id = bl.user_id
+	INNER JOIN users bu ON bu.id = bl.blocked_user_id
+	WHERE
+		(
+			(u.uuid = $1 AND bu.uuid = $2) OR
+			(u.uuid = $2 AND bu.uuid = $1)
+		) AND
+		bl.deleted_at IS NULL
+);
+	`
+	var blocked bool
+
+	if err := dao.db.QueryRow(query, uuid, otherUuid).Scan(&blocked); err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
+
+// GetBlockRelatedUserIDs retrieves IDs of users blocked by the user as well as users who have
+// blocked the user.
+func (dao *BlockDAO) GetBlockRelatedUserIDs(userID int) ([]int64, error) {
+	query := `
+SELECT blocked_user_id AS user_id
+FROM block_list
+WHERE
+	user_id = $1 AND
+	deleted_at IS NULL
+UNION
+SELECT user_id
+FROM block_list
+WHERE
+	blocked_user_id = $1 AND
+	deleted_at IS NULL;
+`
+	rows, err := dao.db.Queryx(query, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userIDs := make([]int64, 0)
+
+	for rows.Next() {
+		var userID int64
+
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
@@ -3,7 +3,6 @@ package block
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 )
@@ -14,9 +13,7 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 
 	g := r.Group(
 		"/block",
-		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{
-			Secret: config.GetAppConf().JwtSecret,
-		}, authDaoer),
+		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{}, authDaoer),
 	)
 
 	g.GET("", func(c *gin.Context) {
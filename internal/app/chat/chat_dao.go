@@ -635,3 +635,37 @@ WHERE
 
 //return dics, nil
 //}
+
+// GetChatroomsByUserID retrieves chatrooms the user has joined. Chatrooms the user has left are
+// included if `includeLeft` is true.
+func (dao *ChatDao) GetChatroomsByUserID(userID int64, includeLeft bool) ([]models.Chatroom, error) {
+	query := `
+SELECT chatrooms.*
+FROM chatrooms
+INNER JOIN chatroom_users ON chatroom_users.chatroom_id = chatrooms.id
+WHERE chatroom_users.user_id = $1
+	AND ($2 OR chatroom_users.deleted_at IS NULL)
+ORDER BY chatrooms.created_at;
+`
+	rows, err := dao.DB.Queryx(query, userID, includeLeft)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	chatrooms := make([]models.Chatroom, 0)
+
+	for rows.Next() {
+		var m models.Chatroom
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		chatrooms = append(chatrooms, m)
+	}
+
+	return chatrooms, rows.Err()
+}
@@ -20,11 +20,16 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/availability"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/block"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/inquiry"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
 	gcsenhancer "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/gcs_enhancer"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
 
 	convertnullsql "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/convert_null_sql"
@@ -42,6 +47,54 @@ type EmitTextMessageBody struct {
 	ChannelUUID string `form:"channel_uuid" binding:"required"`
 }
 
+// requireNotBlockedInChatroom aborts the request if the sender and the other party of the inquiry
+// chatroom have blocked one another. Returns false if the request has been aborted.
+func requireNotBlockedInChatroom(c *gin.Context, depCon container.Container, channelUuid string) bool {
+	var (
+		userDao contracts.UserDAOer
+		chatDao contracts.ChatDaoer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&chatDao)
+
+	sender, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	iq, err := chatDao.GetInquiryByChannelUuid(channelUuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToGetInquiryByChannelUuid,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	otherID := int64(iq.InquirerID.Int32)
+
+	if otherID == sender.ID {
+		otherID = int64(iq.PickerID.Int32)
+	}
+
+	return block.RequireNotBlocked(c, depCon, sender.ID, otherID)
+}
+
 // @TODOs
 //   - Check if chatroom has expired
 func EmitTextMessage(c *gin.Context, depCon container.Container) {
@@ -59,9 +112,9 @@ func EmitTextMessage(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	var chatDao contracts.ChatDaoer
-
-	depCon.Make(&chatDao)
+	if !requireNotBlockedInChatroom(c, depCon, body.ChannelUUID) {
+		return
+	}
 
 	// Emit message to firestore.
 	ctx := context.Background()
@@ -445,11 +498,32 @@ func GetInquiryChatrooms(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	// Presence of the other party of each chatroom.
+	otherPartyUuids := make([]string, 0)
+
+	for _, chatroom := range chatrooms {
+		otherPartyUuid := chatroom.PickerUUID
+
+		if user.Gender == models.GenderFemale {
+			otherPartyUuid = chatroom.InquirerUUID
+		}
+
+		otherPartyUuids = append(otherPartyUuids, otherPartyUuid)
+	}
+
+	presences := presence.LookupStatusesOrOffline(depCon, otherPartyUuids)
+	channelPresenceMap := make(map[string]presence.Status)
+
+	for i, chatroom := range chatrooms {
+		channelPresenceMap[chatroom.ChannelUUID] = presences[otherPartyUuids[i]]
+	}
+
 	c.JSON(
 		http.StatusOK,
 		NewTransformer().TransformInquiryChats(
 			chatrooms,
 			channelUUIDMessageMap,
+			channelPresenceMap,
 		),
 	)
 }
@@ -626,6 +700,8 @@ func EmitServiceUpdateMessage(c *gin.Context, depCon container.Container) {
 		chatDao    contracts.ChatDaoer
 		coinPkgDao contracts.CoinPackageDAOer
 		userDao    contracts.UserDAOer
+
+		transitionDao contracts.TransitionDAOer
 	)
 
 	depCon.Make(&iqDao)
@@ -633,6 +709,7 @@ func EmitServiceUpdateMessage(c *gin.Context, depCon container.Container) {
 	depCon.Make(&chatDao)
 	depCon.Make(&coinPkgDao)
 	depCon.Make(&userDao)
+	depCon.Make(&transitionDao)
 
 	sender, err := userDao.GetUserByUuid(c.GetString("uuid"), "username", "id")
 
@@ -735,6 +812,17 @@ func EmitServiceUpdateMessage(c *gin.Context, depCon container.Container) {
 
 	// Update service detail by service ID.
 	txResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+			Event:     inquiry.GirlApprove.ToString(),
+			ActorUuid: c.GetString("uuid"),
+		}); err != nil {
+			return db.FormatResp{
+				HttpStatusCode: http.StatusInternalServerError,
+				Err:            err,
+				ErrCode:        apperr.FailedToAnnotateTransition,
+			}
+		}
+
 		srvDao.WithTx(tx)
 
 		usrv, err := srvDao.UpdateServiceByID(contracts.UpdateServiceByIDParams{
@@ -756,7 +844,7 @@ func EmitServiceUpdateMessage(c *gin.Context, depCon container.Container) {
 			}
 		}
 
-		if err := iqDao.PatchInquiryStatusByUUID(
+		if err := iqDao.WithTx(tx).PatchInquiryStatusByUUID(
 			contracts.PatchInquiryStatusByUUIDParams{
 				InquiryStatus: models.InquiryStatusWaitForInquirerApprove,
 				UUID:          iq.Uuid,
@@ -887,10 +975,22 @@ func EmitInquiryUpdatedMessage(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	if err := iqDao.PatchInquiryStatusByUUID(
-		contracts.PatchInquiryStatusByUUIDParams{
-			InquiryStatus: models.InquiryStatusWaitForInquirerApprove,
-			UUID:          iq.Uuid,
+	if iq.PickerID.Valid && !availability.RequireAvailable(c, depCon, int64(iq.PickerID.Int32), body.AppointmentTime, time.Duration(body.Duration)*time.Minute) {
+		return
+	}
+
+	if err := transition.Transact(
+		contracts.AnnotateTransitionParams{
+			Event:     inquiry.GirlApprove.ToString(),
+			ActorUuid: c.GetString("uuid"),
+		},
+		func(tx *sqlx.Tx) error {
+			return iqDao.WithTx(tx).PatchInquiryStatusByUUID(
+				contracts.PatchInquiryStatusByUUIDParams{
+					InquiryStatus: models.InquiryStatusWaitForInquirerApprove,
+					UUID:          iq.Uuid,
+				},
+			)
 		},
 	); err != nil {
 		c.AbortWithError(
@@ -1010,6 +1110,8 @@ func EmitServiceConfirmedMessage(c *gin.Context, depCon container.Container) {
 		inquiryDao  contracts.InquiryDAOer
 		chatDao     contracts.ChatDaoer
 		gcsEnhancer gcsenhancer.GCSEnhancerInterface
+
+		transitionDao contracts.TransitionDAOer
 	)
 
 	depCon.Make(&userDao)
@@ -1017,6 +1119,7 @@ func EmitServiceConfirmedMessage(c *gin.Context, depCon container.Container) {
 	depCon.Make(&inquiryDao)
 	depCon.Make(&chatDao)
 	depCon.Make(&gcsEnhancer)
+	depCon.Make(&transitionDao)
 
 	// Get user by uuid.
 	sender, err := userDao.GetUserByUuid(c.GetString("uuid"), "username", "id")
@@ -1078,6 +1181,10 @@ func EmitServiceConfirmedMessage(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	if !availability.RequireAvailable(c, depCon, sender.ID, iqRes.AppointmentTime.Time, time.Duration(iqRes.Duration.Int32)*time.Minute) {
+		return
+	}
+
 	// Retrieve chatroom by inquiry id.
 	chatroom, err := chatDao.GetChatRoomByInquiryID(iqRes.ID, "channel_uuid")
 
@@ -1121,7 +1228,18 @@ func EmitServiceConfirmedMessage(c *gin.Context, depCon container.Container) {
 			}
 		}
 
-		srvModel, err := serviceDao.UpdateServiceByID(
+		if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+			Event:     inquiry.Book.ToString(),
+			ActorUuid: c.GetString("uuid"),
+		}); err != nil {
+			return db.FormatResp{
+				HttpStatusCode: http.StatusInternalServerError,
+				Err:            err,
+				ErrCode:        apperr.FailedToAnnotateTransition,
+			}
+		}
+
+		srvModel, err := serviceDao.WithTx(tx).UpdateServiceByID(
 			contracts.UpdateServiceByIDParams{
 				ID:            srv.ID,
 				ServiceStatus: &statusToBeFulfilled,
@@ -1464,13 +1582,31 @@ func QuitChatroomHandler(c *gin.Context, depCon container.Container) {
 			// change inquiry status to "canceled"
 
 			newStatus := models.InquiryStatusInquiring
+			event := inquiry.RevertChat
 
 			if models.InquiryStatus(iq.InquiryType) == models.InquiryStatus(models.InquiryTypeDirect) {
 				newStatus = models.InquiryStatusCanceled
+				event = inquiry.Cancel
 			}
 
-			var iqDao contracts.InquiryDAOer
+			var (
+				iqDao         contracts.InquiryDAOer
+				transitionDao contracts.TransitionDAOer
+			)
+
 			depCon.Make(&iqDao)
+			depCon.Make(&transitionDao)
+
+			if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+				Event:     event.ToString(),
+				ActorUuid: c.GetString("uuid"),
+			}); err != nil {
+				return db.FormatResp{
+					HttpStatusCode: http.StatusInternalServerError,
+					Err:            err,
+					ErrCode:        apperr.FailedToAnnotateTransition,
+				}
+			}
 
 			uiq, err := iqDao.
 				WithTx(tx).
@@ -1645,10 +1781,18 @@ func EmitDisagreeInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	if err := iqDao.PatchInquiryStatusByUUID(contracts.PatchInquiryStatusByUUIDParams{
-		UUID:          iq.Uuid,
-		InquiryStatus: models.InquiryStatusChatting,
-	}); err != nil {
+	if err := transition.Transact(
+		contracts.AnnotateTransitionParams{
+			Event:     inquiry.Disagree.ToString(),
+			ActorUuid: c.GetString("uuid"),
+		},
+		func(tx *sqlx.Tx) error {
+			return iqDao.WithTx(tx).PatchInquiryStatusByUUID(contracts.PatchInquiryStatusByUUIDParams{
+				UUID:          iq.Uuid,
+				InquiryStatus: models.InquiryStatusChatting,
+			})
+		},
+	); err != nil {
 		c.AbortWithError(
 			http.StatusInternalServerError,
 			apperr.NewErr(
@@ -1711,6 +1855,10 @@ func EmitImageMessage(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	if !requireNotBlockedInChatroom(c, depCon, body.ChannelUuid) {
+		return
+	}
+
 	ctx := context.Background()
 	df := darkfirestore.Get()
 	err := df.SendImageMessage(ctx, darkfirestore.SendImageMessageParams{
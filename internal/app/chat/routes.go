@@ -5,7 +5,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/middlewares"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
@@ -19,18 +18,18 @@ type ChatRoutesParams struct {
 
 func Routes(r *gin.RouterGroup, depCon container.Container) {
 	var (
-		userDao contracts.UserDAOer
-		authDao contracts.AuthDaoer
+		userDao        contracts.UserDAOer
+		authDao        contracts.AuthDaoer
+		restrictionDao contracts.UserRestrictionDAOer
 	)
 
 	depCon.Make(&userDao)
 	depCon.Make(&authDao)
+	depCon.Make(&restrictionDao)
 
 	g := r.Group(
 		"/chat",
-		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{
-			Secret: config.GetAppConf().JwtSecret,
-		}, authDao),
+		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{}, authDao),
 	)
 
 	g.GET("/:channel_uuid", func(c *gin.Context) {
@@ -61,6 +60,7 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 
 	g.POST(
 		"/emit-text-message",
+		middlewares.RejectRestricted(restrictionDao),
 		func(c *gin.Context) {
 			EmitTextMessage(c, depCon)
 		},
@@ -68,6 +68,7 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 
 	g.POST(
 		"/emit-image-message",
+		middlewares.RejectRestricted(restrictionDao),
 		func(c *gin.Context) {
 			EmitImageMessage(c, depCon)
 		},
@@ -5,6 +5,7 @@ import (
 
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
 )
 
 type ChatTransformer struct{}
@@ -38,6 +39,9 @@ type TransformedInquiryChat struct {
 	PickerUUID    string    `json:"picker_uuid"`
 	InquiryStatus string    `json:"inquiry_status"`
 
+	// Presence of the other party of the chatroom.
+	Presence presence.Status `json:"presence"`
+
 	// Messages only contains the latest message of the chatroom. It's an empty array
 	// If the chatroom does not contain any message.
 	Messages []*darkfirestore.ChatMessage `json:"messages"`
@@ -47,7 +51,7 @@ type TransformedInquiryChats struct {
 	Chats []TransformedInquiryChat `json:"chats"`
 }
 
-func (t *ChatTransformer) TransformInquiryChats(chatModels []models.InquiryChatRoom, latestMessageMap map[string][]*darkfirestore.ChatMessage) TransformedInquiryChats {
+func (t *ChatTransformer) TransformInquiryChats(chatModels []models.InquiryChatRoom, latestMessageMap map[string][]*darkfirestore.ChatMessage, channelPresenceMap map[string]presence.Status) TransformedInquiryChats {
 	chats := make([]TransformedInquiryChat, 0)
 
 	for _, m := range chatModels {
@@ -69,6 +73,7 @@ func (t *ChatTransformer) TransformInquiryChats(chatModels []models.InquiryChatR
 			InquirerUUID:  m.InquirerUUID,
 			PickerUUID:    m.PickerUUID,
 			InquiryStatus: m.InquiryStatus,
+			Presence:      channelPresenceMap[m.ChannelUUID],
 		}
 
 		if m.AvatarURL.Valid {
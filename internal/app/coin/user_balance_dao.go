@@ -3,7 +3,6 @@ package coin
 import (
 	"database/sql"
 	"errors"
-	"fmt"
 	"math"
 
 	cintrnal "github.com/golobby/container/pkg/container"
@@ -13,6 +12,9 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// ErrInsufficientBalance is returned when the balance of the user can not cover the cost.
+var ErrInsufficientBalance = errors.New("insufficient fund")
+
 type UserBalanceDAO struct {
 	db db.Conn
 }
@@ -78,11 +80,14 @@ SELECT * FROM user_balance WHERE user_id = $1;
 	return &userBal, nil
 }
 
+// deductCostFromBalance deducts cost from the balance only if the balance covers it. The
+// condition is evaluated against the locked row, so concurrent deductions can not overdraw.
 func (dao *UserBalanceDAO) deductCostFromBalance(userID int, cost float64) (*models.UserBalance, error) {
 	query := `
 UPDATE user_balance
 SET balance = balance - $1
 WHERE user_id = $2
+	AND balance >= $1
 RETURNING *;
 `
 	var m models.UserBalance
@@ -92,6 +97,10 @@ RETURNING *;
 		math.Round(cost*100)/100,
 		userID,
 	).StructScan(&m); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrInsufficientBalance
+		}
+
 		return nil, err
 	}
 
@@ -116,6 +125,12 @@ func (s *UserBalanceDAO) DeductMatchingFee(userID int, matchingFee decimal.Decim
 	return s.deductCostFromBalance(userID, mf)
 }
 
+func (s *UserBalanceDAO) DeductCost(userID int, cost decimal.Decimal) (*models.UserBalance, error) {
+	c, _ := cost.Float64()
+
+	return s.deductCostFromBalance(userID, c)
+}
+
 func (s *UserBalanceDAO) HasEnoughBalanceToChargePackage(userID int, pkg *models.CoinPackage) error {
 	ub, err := s.GetCoinBalanceByUserId(userID)
 
@@ -138,7 +153,7 @@ func (s *UserBalanceDAO) HasEnoughBalanceToChargePackage(userID int, pkg *models
 	hasEnough := balanceDeci.GreaterThan(pkgCost) || balanceDeci.Equal(pkgCost)
 
 	if !hasEnough {
-		return errors.New("insufficient fund")
+		return ErrInsufficientBalance
 	}
 
 	return nil
@@ -156,7 +171,11 @@ func (s *UserBalanceDAO) HasEnoughBalanceToCharge(userID int, cost decimal.Decim
 				TopupAmount: 0,
 			})
 
-			return fmt.Errorf("insufficient fund: %v", err.Error())
+			if err != nil {
+				return err
+			}
+
+			return ErrInsufficientBalance
 		}
 
 		return err
@@ -171,7 +190,7 @@ func (s *UserBalanceDAO) HasEnoughBalanceToCharge(userID int, cost decimal.Decim
 	hasEnough := balanceDeci.GreaterThan(cost) || balanceDeci.Equal(cost)
 
 	if !hasEnough {
-		return errors.New("insufficient fund")
+		return ErrInsufficientBalance
 	}
 
 	return nil
@@ -0,0 +1,50 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type CreateAgencyParams struct {
+	ManagerID      int64
+	Name           string
+	CommissionRate string
+}
+
+type AddAgencyMemberParams struct {
+	AgencyID  int32
+	MemberID  int64
+	RefcodeID int64
+}
+
+// AgencySettlementPeriod services completed within [From, To) are settled.
+type AgencySettlementPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+type GetAgencyMemberServicesParams struct {
+	AgencyID   int32
+	MemberUuid string
+	Period     AgencySettlementPeriod
+	Offset     int
+	PerPage    int
+}
+
+type AgencyDAOer interface {
+	WithTx(tx db.Conn) AgencyDAOer
+
+	CreateAgency(p CreateAgencyParams) (*models.Agency, error)
+	GetAgencyByManagerID(managerID int64) (*models.Agency, error)
+	GetAgencyByRefcodeID(refcodeID int64) (*models.Agency, error)
+	SetCommissionRate(agencyID int32, rate string) error
+
+	CreateManagerRefcode(managerID int64, refCode string) (*models.UserRefcode, error)
+	GetManagerRefcodes(managerID int64) ([]models.UserRefcode, error)
+
+	AddMember(p AddAgencyMemberParams) (*models.AgencyMember, error)
+	GetMemberSummaries(agencyID int32, period AgencySettlementPeriod) ([]models.AgencyMemberSummary, error)
+	GetMemberServices(p GetAgencyMemberServicesParams) ([]models.AgencyMemberService, error)
+}
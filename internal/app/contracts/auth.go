@@ -1,7 +1,44 @@
 package contracts
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type IsTokenInvalidParams struct {
+	Token     string
+	TokenType string
+	Family    string
+}
+
+type StoreRefreshTokenParams struct {
+	ID        string
+	UserUuid  string
+	Family    string
+	ExpiresAt int64
+}
+
+// UserSuspensionMark cached suspension of the user that is checked on every authenticated request.
+type UserSuspensionMark struct {
+	SuspensionType models.UserSuspensionType `json:"suspension_type"`
+	Reason         string                    `json:"reason"`
+	ExpiresAt      *time.Time                `json:"expires_at"`
+}
 
 type AuthDaoer interface {
-	IsTokenInvalid(ctx context.Context, jwtToken string) (bool, error)
+	IsTokenInvalid(ctx context.Context, p IsTokenInvalidParams) (bool, error)
+	RevokeJwt(ctx context.Context, jwt string, expTs int64) error
+
+	StoreRefreshToken(ctx context.Context, p StoreRefreshTokenParams) error
+	ConsumeRefreshToken(ctx context.Context, ID string) (bool, error)
+	RevokeTokenFamily(ctx context.Context, family string) error
+	IsTokenFamilyRevoked(ctx context.Context, family string) (bool, error)
+
+	RevokeAllSessions(ctx context.Context, userUuid string, excludes ...string) error
+
+	SetUserSuspension(ctx context.Context, userUuid string, mark UserSuspensionMark) error
+	ClearUserSuspension(ctx context.Context, userUuid string) error
+	GetUserSuspension(ctx context.Context, userUuid string) (*UserSuspensionMark, error)
 }
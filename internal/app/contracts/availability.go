@@ -0,0 +1,37 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type CreateAvailabilitySlotParams struct {
+	UserID    int64
+	Weekday   int
+	StartTime string
+	EndTime   string
+}
+
+type UpdateAvailabilitySlotParams struct {
+	ID        int32
+	UserID    int64
+	Weekday   int
+	StartTime string
+	EndTime   string
+}
+
+type AvailabilityDAOer interface {
+	WithTx(tx db.Conn) AvailabilityDAOer
+
+	GetSlots(userID int64) ([]models.AvailabilitySlot, error)
+	CreateSlot(p CreateAvailabilitySlotParams) (*models.AvailabilitySlot, error)
+	UpdateSlot(p UpdateAvailabilitySlotParams) (*models.AvailabilitySlot, error)
+	DeleteSlot(userID int64, ID int32) error
+
+	GetBlackouts(userID int64, since time.Time) ([]models.AvailabilityBlackout, error)
+	HasBlackout(userID int64, date time.Time) (bool, error)
+	CreateBlackout(userID int64, date time.Time) (*models.AvailabilityBlackout, error)
+	DeleteBlackout(userID int64, ID int32) error
+}
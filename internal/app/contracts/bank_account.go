@@ -24,4 +24,5 @@ type InsertBankAccountParams struct {
 type BankAccountDAOer interface {
 	WithTx(tx db.Conn) BankAccountDAOer
 	GetUserBankAccount(uuid string) (*models.BankAccount, error)
+	DeleteUserBankAccounts(userID int64) error
 }
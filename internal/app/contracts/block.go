@@ -25,4 +25,7 @@ type BlockDAOer interface {
 	WithTx(tx db.Conn) BlockDAOer
 	HasBlockedByUser(p HasBlockedByUserParams) (bool, error)
 	HasBlockedByUserById(p HasBlockedByUserByIdParams) (bool, error)
+	GetBlockRelatedUserIDs(userID int) ([]int64, error)
+	IsBlockedBetween(userID, otherUserID int64) (bool, error)
+	IsBlockedBetweenByUuid(uuid, otherUuid string) (bool, error)
 }
@@ -55,4 +55,5 @@ type ChatDaoer interface {
 	GetChatroomByServiceId(srvId int) (*models.Chatroom, error)
 	DeleteChatroomByServiceId(srvId int) error
 	DeleteChatroomByInquiryId(iqId int) error
+	GetChatroomsByUserID(userID int64, includeLeft bool) ([]models.Chatroom, error)
 }
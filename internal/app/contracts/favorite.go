@@ -0,0 +1,23 @@
+package contracts
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type GetFavoriteGirlsParams struct {
+	UserID int64
+
+	// ExcludeUserIDs users that should not be listed, e.g. users blocked by or blocking the user.
+	ExcludeUserIDs []int64
+	Offset         int
+	PerPage        int
+}
+
+type UserFavoriteDAOer interface {
+	WithTx(tx db.Conn) UserFavoriteDAOer
+
+	AddFavorite(userID, favoriteUserID int64) (*models.UserFavorite, error)
+	RemoveFavorite(userID, favoriteUserID int64) error
+	GetFavoriteGirls(p GetFavoriteGirlsParams) ([]*models.RandomGirl, error)
+}
@@ -9,4 +9,5 @@ type ImageDAOer interface {
 	WithTx(tx *sqlx.Tx) ImageDAOer
 	GetImagesByUserID(ID int) ([]models.Image, error)
 	CreateImages(imagesParams []models.Image) error
+	DeleteImagesByUserID(ID int64) error
 }
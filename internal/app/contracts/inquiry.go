@@ -12,6 +12,14 @@ type PatchInquiryStatusByUUIDParams struct {
 	InquiryStatus models.InquiryStatus
 }
 
+type PatchInquiriesStatusParams struct {
+	Uuids []string
+
+	// Inquiries are left as is unless the status is one of `From`.
+	From []models.InquiryStatus
+	To   models.InquiryStatus
+}
+
 type InquiryResult struct {
 	models.ServiceInquiry
 	Username  string         `json:"username"`
@@ -33,6 +41,11 @@ type GetInquiryRequestsParams struct {
 	PerPage int
 }
 
+type AcceptApplicantParams struct {
+	InquiryID   int64
+	ApplicantID int64
+}
+
 type InquiryDAOer interface {
 	WithTx(tx db.Conn) InquiryDAOer
 	CheckHasActiveRandomInquiryByID(id int64) (bool, error)
@@ -46,4 +59,14 @@ type InquiryDAOer interface {
 	GetActiveInquiry(inquirerId int) (*models.ActiveInquiry, error)
 	GetInquiryByChannelUuid(channelUuid string) (*models.ServiceInquiry, error)
 	GetInquiryRequests(p GetInquiryRequestsParams) ([]models.InquiryRequest, error)
+	GetOpenInquiriesOfUser(userID int64) ([]models.ServiceInquiry, error)
+	PatchInquiriesStatus(p PatchInquiriesStatusParams) ([]models.ServiceInquiry, error)
+
+	ApplyInquiry(inquiryID, applicantID int64) (*models.InquiryApplicant, error)
+	GetInquiryApplicants(inquiryID int64) ([]models.InquiryApplicantProfile, error)
+	GetApplicantStatus(inquiryID, applicantID int64) (models.InquiryApplicantStatus, error)
+	AcceptApplicant(p AcceptApplicantParams) ([]models.DeclinedApplicant, error)
+	DeclineApplicant(inquiryID, applicantID int64) (*models.DeclinedApplicant, error)
+	DeclineApplicationsOfUser(applicantID int64) ([]models.DeclinedApplication, error)
+	DeclineApplicantsOfInquiries(inquiryUuids []string) ([]models.DeclinedApplication, error)
 }
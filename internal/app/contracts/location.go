@@ -0,0 +1,32 @@
+package contracts
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type UpsertUserLocationParams struct {
+	UserID    int64
+	Lat       float64
+	Lng       float64
+	Precision models.LocationPrecision
+}
+
+type GetNearbyGirlsParams struct {
+	Lat      float64
+	Lng      float64
+	RadiusKm float64
+	Offset   int
+	PerPage  int
+
+	// ExcludeUserIDs girls to hide from the requester, e.g. block related users.
+	ExcludeUserIDs []int64
+}
+
+type UserLocationDAOer interface {
+	WithTx(tx db.Conn) UserLocationDAOer
+
+	UpsertLocation(p UpsertUserLocationParams) (*models.UserLocation, error)
+	DeleteLocation(userID int64) error
+	GetNearbyGirls(p GetNearbyGirlsParams) ([]models.NearbyGirl, error)
+}
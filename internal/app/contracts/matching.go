@@ -0,0 +1,54 @@
+package contracts
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type GetMatchCandidatesParams struct {
+	InquiryID  int64
+	InquirerID int64
+
+	// Providers are searched within the radius if the location is valid.
+	Lat      sql.NullFloat64
+	Lng      sql.NullFloat64
+	RadiusKm float64
+
+	// AppointmentTime should have been converted to the app time zone.
+	AppointmentTime   time.Time
+	ExpectServiceType sql.NullString
+
+	PoolSize int
+}
+
+type SaveInquiryMatchParams struct {
+	UserID            int64
+	Score             float64
+	DistanceKm        sql.NullFloat64
+	Available         bool
+	OffersServiceType bool
+	Rating            sql.NullFloat64
+	Responsiveness    float64
+	Invited           bool
+}
+
+type SaveInquiryMatchesParams struct {
+	InquiryID int64
+	Wave      int
+	Matches   []SaveInquiryMatchParams
+}
+
+type MatchingDAOer interface {
+	WithTx(tx db.Conn) MatchingDAOer
+
+	GetMatchingInquiry(inquiryID int64) (*models.MatchingInquiry, error)
+	GetDueMatchingInquiries(waveInterval time.Duration, maxWaves int) ([]models.MatchingInquiry, error)
+	GetMatchCandidates(p GetMatchCandidatesParams) ([]models.MatchCandidate, error)
+	SaveMatches(p SaveInquiryMatchesParams) error
+	ClaimWave(inquiryID int64, wave int) (bool, error)
+	ReleaseWave(inquiryID int64, wave int) error
+	MarkResponded(inquiryID, userID int64) error
+}
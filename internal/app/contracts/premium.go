@@ -0,0 +1,51 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type CreatePremiumPlanParams struct {
+	Name         string
+	DurationDays int
+	Cost         string
+}
+
+type CreatePremiumPurchaseParams struct {
+	Uuid      string
+	UserID    int64
+	PlanID    int32
+	Cost      string
+	StartsAt  time.Time
+	ExpiresAt time.Time
+}
+
+type GetPremiumPurchasesParams struct {
+	UserID  int64
+	Offset  int
+	PerPage int
+}
+
+// PremiumExtension period the membership is extended by a purchase.
+type PremiumExtension struct {
+	StartsAt  time.Time `json:"starts_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+type PremiumDAOer interface {
+	WithTx(tx db.Conn) PremiumDAOer
+
+	GetPlans(enabledOnly bool) ([]models.PremiumPlan, error)
+	GetEnabledPlan(ID int32) (*models.PremiumPlan, error)
+	CreatePlan(p CreatePremiumPlanParams) (*models.PremiumPlan, error)
+	SetPlanEnabled(ID int32, enabled bool) error
+
+	ExtendPremium(userID int64, durationDays int32) (*PremiumExtension, error)
+	CreatePurchase(p CreatePremiumPurchaseParams) (*models.PremiumPurchase, error)
+	GetPurchases(p GetPremiumPurchasesParams) ([]models.PremiumPurchaseHistory, error)
+
+	DowngradeExpiredMembers() ([]string, error)
+	ClaimExpiryReminders(within time.Duration) ([]models.PremiumExpiringMember, error)
+}
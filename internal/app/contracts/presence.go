@@ -0,0 +1,15 @@
+package contracts
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type PresenceDAOer interface {
+	WithTx(tx db.Conn) PresenceDAOer
+
+	SetHidden(userID int64, hidden bool) (*models.UserPresenceSetting, error)
+	IsHidden(userID int64) (bool, error)
+	GetHiddenUserUuids(uuids []string) ([]string, error)
+	GetUuidsOfUserIDs(uuids []string, userIDs []int64) ([]string, error)
+}
@@ -0,0 +1,34 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type RecordProfileViewParams struct {
+	ViewerID int64
+	VieweeID int64
+	ViewedAt time.Time
+
+	// ViewedOn date of the view in app time zone.
+	ViewedOn string
+}
+
+type GetProfileViewersParams struct {
+	VieweeID int64
+
+	// ExcludeUserIDs users that should not be listed, e.g. users blocked by or blocking the viewee.
+	ExcludeUserIDs []int64
+	Offset         int
+	PerPage        int
+}
+
+type ProfileViewDAOer interface {
+	WithTx(tx db.Conn) ProfileViewDAOer
+
+	RecordView(p RecordProfileViewParams) error
+	GetViewCount(vieweeID int64) (int, error)
+	GetViewers(p GetProfileViewersParams) ([]models.ProfileViewer, error)
+}
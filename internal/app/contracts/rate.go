@@ -9,6 +9,9 @@ type GetUserRatingsParams struct {
 	UserID  int
 	PerPage int
 	Offset  int
+
+	// ExcludeUserIDs raters that should not be listed, e.g. users blocked by or blocking the viewer.
+	ExcludeUserIDs []int64
 }
 
 type GetServicePartnerInfoParams struct {
@@ -1,7 +1,49 @@
 package contracts
 
-import "github.com/huangc28/go-darkpanda-backend/internal/app/models"
+import (
+	"database/sql"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
 
 type ReferralDaoer interface {
 	GetByRefCode(refCode string, fields []string) (*models.UserRefcode, error)
 }
+
+type CreateReferralRewardParams struct {
+	RuleID      int32
+	RefcodeID   int64
+	InviteeID   int64
+	RecipientID int64
+	Amount      string
+}
+
+type GetReferralRewardsParams struct {
+	RecipientID int64
+	Offset      int
+	PerPage     int
+}
+
+type CreateReferralRewardRuleParams struct {
+	Name      string
+	Event     models.ReferralRewardEvent
+	Recipient models.ReferralRewardRecipient
+	Amount    string
+	StartsAt  sql.NullTime
+	EndsAt    sql.NullTime
+}
+
+type ReferralRewardDAOer interface {
+	WithTx(tx db.Conn) ReferralRewardDAOer
+
+	GetInvitorRefcodeByInviteeID(inviteeID int64) (*models.UserRefcode, error)
+	GetApplicableRewardRules(event models.ReferralRewardEvent) ([]models.ReferralRewardRule, error)
+	CreateReward(p CreateReferralRewardParams) (*models.ReferralReward, error)
+	GetRewards(p GetReferralRewardsParams) ([]models.ReferralRewardHistory, error)
+	GetInviteesCompletedFirstService(serviceUuid string) ([]int64, error)
+
+	GetRewardRules() ([]models.ReferralRewardRule, error)
+	CreateRewardRule(p CreateReferralRewardRuleParams) (*models.ReferralRewardRule, error)
+	SetRewardRuleEnabled(ID int32, enabled bool) error
+}
@@ -1,16 +1,8 @@
 package contracts
 
-import (
-	"context"
-
-	"github.com/go-redis/redis/v8"
-)
-
-type CheckUserInSMSWhiteListParams struct {
-	RedisClient *redis.Client
-	Username    string
-}
+import "context"
 
 type Registerar interface {
-	CheckUserInSMSWhiteList(ctx context.Context, p CheckUserInSMSWhiteListParams) (bool, error)
+	CheckUsernameExists(ctx context.Context, username string) (bool, error)
+	CheckReferCodeExists(ctx context.Context, referCode string) (bool, error)
 }
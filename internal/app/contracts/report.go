@@ -0,0 +1,70 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type CreateReportParams struct {
+	Uuid           string
+	ReporterID     int64
+	ReportedUserID int64
+	TargetType     models.ReportTargetType
+	TargetRef      *string
+	Category       models.ReportCategory
+	Description    *string
+}
+
+type GetReportsParams struct {
+	Status  models.ReportStatus
+	Offset  int
+	PerPage int
+}
+
+type UpdateReportStatusParams struct {
+	Uuid           string
+	FromStatus     models.ReportStatus
+	Status         models.ReportStatus
+	ResolutionNote *string
+}
+
+type CreateReportEventParams struct {
+	ReportID   int64
+	ActorID    *int64
+	Action     string
+	FromStatus *models.ReportStatus
+	ToStatus   *models.ReportStatus
+	Note       *string
+}
+
+type ReportDAOer interface {
+	WithTx(tx db.Conn) ReportDAOer
+
+	CreateReport(p CreateReportParams) (*models.Report, error)
+	GetReportByUuid(uuid string) (*models.ReportDetail, error)
+	GetReports(p GetReportsParams) ([]models.ReportDetail, error)
+	AssignReport(uuid string, assigneeID int64) (*models.Report, error)
+	UpdateReportStatus(p UpdateReportStatusParams) (*models.Report, error)
+	CreateReportEvent(p CreateReportEventParams) error
+	GetReportEvents(reportID int64) ([]models.ReportEventDetail, error)
+	CountDistinctReporters(reportedUserID int64, since time.Time) (int, error)
+}
+
+type CreateUserRestrictionParams struct {
+	UserID    int64
+	Reason    string
+	ExpiresAt time.Time
+	CreatedBy *int64
+}
+
+type UserRestrictionDAOer interface {
+	WithTx(tx db.Conn) UserRestrictionDAOer
+
+	LockUser(userID int64) error
+	CreateRestriction(p CreateUserRestrictionParams) (*models.UserRestriction, error)
+	GetActiveRestriction(userID int64) (*models.UserRestriction, error)
+	IsRestrictedByUuid(uuid string) (bool, error)
+	LiftRestrictions(userID int64, liftedBy int64) (int64, error)
+}
@@ -0,0 +1,14 @@
+package contracts
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type SigningKeyDAOer interface {
+	WithTx(tx db.Conn) SigningKeyDAOer
+	GetSigningKeys() ([]models.JwtSigningKey, error)
+	AddSigningKey(kid, secret string) (*models.JwtSigningKey, error)
+	ActivateSigningKey(kid string) error
+	RetireSigningKey(kid string) error
+}
@@ -0,0 +1,26 @@
+package contracts
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type CreateUserSuspensionParams struct {
+	UserID         int64
+	SuspensionType models.UserSuspensionType
+	Reason         string
+	ExpiresAt      *time.Time
+	CreatedBy      int64
+}
+
+type UserSuspensionDAOer interface {
+	WithTx(tx db.Conn) UserSuspensionDAOer
+
+	CreateSuspension(p CreateUserSuspensionParams) (*models.UserSuspension, error)
+	GetActiveSuspension(userID int64) (*models.UserSuspension, error)
+	GetActiveSuspensionByUserUuid(userUuid string) (*models.UserSuspension, error)
+	GetActiveSuspensions() ([]models.ActiveUserSuspension, error)
+	LiftSuspensions(userID int64, liftedBy int64) (int64, error)
+}
@@ -0,0 +1,23 @@
+package contracts
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+// AnnotateTransitionParams describes status transitions about to be made in the transaction.
+type AnnotateTransitionParams struct {
+	// Event FSM event that triggers the transition, e.g. `cancel`.
+	Event string
+
+	// ActorUuid is empty if the transition is made by the system, e.g. workers.
+	ActorUuid string
+
+	Cause string
+}
+
+type TransitionDAOer interface {
+	WithTx(tx db.Conn) TransitionDAOer
+	Annotate(p AnnotateTransitionParams) error
+	GetTransitions(entityType models.TransitionEntityType, entityID int64) ([]models.StatusTransitionWithActor, error)
+}
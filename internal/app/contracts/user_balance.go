@@ -15,6 +15,7 @@ type UserBalancer interface {
 	GetCoinBalanceByUserId(userId int) (*models.UserBalance, error)
 	DeductUserPackageCostFromBalance(userId int, pkg *models.CoinPackage) (*models.UserBalance, error)
 	DeductMatchingFee(userID int, matchingFee decimal.Decimal) (*models.UserBalance, error)
+	DeductCost(userID int, cost decimal.Decimal) (*models.UserBalance, error)
 	HasEnoughBalanceToChargePackage(userId int, pkg *models.CoinPackage) error
 	HasEnoughBalanceToCharge(userID int, cost decimal.Decimal) error
 	CreateOrTopUpBalance(params CreateOrTopUpBalanceParams) (*models.UserBalance, error)
@@ -2,6 +2,7 @@ package contracts
 
 import (
 	"context"
+	"time"
 
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	"github.com/jmoiron/sqlx"
@@ -19,13 +20,43 @@ type UpdateUserInfoParams struct {
 	Description   *string
 	BreastSize    *string
 	PhoneVerified *bool
+	Locale        *string
 }
 
+type GirlsSort string
+
+var (
+	GirlsSortNewest            GirlsSort = "newest"
+	GirlsSortRating            GirlsSort = "rating"
+	GirlsSortCompletedServices GirlsSort = "completed_services"
+)
+
+// GetGirlsParams filters are optional, nil / empty filters are not applied.
 type GetGirlsParams struct {
 	// InquirerID is used to retrieve any latest inquiry with the girl.
 	InquirerID int
 	Limit      int
 	Offset     int
+
+	AgeMin      *int
+	AgeMax      *int
+	HeightMin   *float64
+	HeightMax   *float64
+	WeightMin   *float64
+	WeightMax   *float64
+	Region      *string
+	Nationality *string
+
+	// ServiceOptions names of service options the girl should offer all of.
+	ServiceOptions []string
+	MinRating      *float64
+
+	// AvailableOn filters out girls who are occupied by services appointed on the date, who have
+	// blacked out the date or who have no availability slot on the weekday.
+	AvailableOn *time.Time
+
+	// Sort girls are ordered randomly if not specified.
+	Sort GirlsSort
 }
 
 type GetServiceOptionParams struct {
@@ -63,4 +94,10 @@ type UserDAOer interface {
 	CreateServiceOption(p CreateServiceOptionsParams) (*models.ServiceOption, error)
 	CreateUserServiceOption(p CreateServiceOptionParams) (*models.UserServiceOption, error)
 	DeleteUserServiceOption(userID int, serviceOptionID int) error
+	GetUserRolesByUuid(uuid string) ([]models.UserRoleType, error)
+	GrantUserRole(userID int64, role models.UserRoleType) error
+	RevokeUserRole(userID int64, role models.UserRoleType) error
+	HasOngoingServices(userID int64) (bool, error)
+	AnonymizeUser(userID int64) error
+	GetUserDataExport(userID int64) (*models.UserDataExport, error)
 }
@@ -0,0 +1,39 @@
+package contracts
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type CreateIdentityVerificationParams struct {
+	Uuid          string
+	UserID        int64
+	Code          string
+	SelfieObject  string
+	IDPhotoObject string
+}
+
+type GetIdentityVerificationsParams struct {
+	Status  models.IdentityVerificationStatus
+	Offset  int
+	PerPage int
+}
+
+type DecideIdentityVerificationParams struct {
+	Uuid       string
+	Status     models.IdentityVerificationStatus
+	Reason     *string
+	ReviewerID int64
+}
+
+type IdentityVerificationDAOer interface {
+	WithTx(tx db.Conn) IdentityVerificationDAOer
+
+	CreateVerification(p CreateIdentityVerificationParams) (*models.IdentityVerification, error)
+	GetLatestVerification(userID int64) (*models.IdentityVerification, error)
+	IsUserVerified(userID int64) (bool, error)
+	GetVerificationByUuid(uuid string) (*models.IdentityVerificationDetail, error)
+	GetVerifications(p GetIdentityVerificationsParams) ([]models.IdentityVerificationDetail, error)
+	DecideVerification(p DecideIdentityVerificationParams) (*models.IdentityVerification, error)
+	DeleteVerificationsOfUser(userID int64) ([]models.IdentityVerification, error)
+}
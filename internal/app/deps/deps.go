@@ -17,24 +17,37 @@ import (
 	"github.com/golobby/container"
 	cinternal "github.com/golobby/container/pkg/container"
 	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/agency"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/auth"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/availability"
 	bankAccount "github.com/huangc28/go-darkpanda-backend/internal/app/bank_account"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/block"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/chat"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/coin"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/image"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/inquiry"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/matching"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/payment"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
 	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/pubsuber"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/premium"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/rate"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/referral"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/register"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/report"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 
 	gcsenhancer "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/gcs_enhancer"
-	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/twilio"
+	smssender "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/sms_sender"
+	usernamepolicy "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/username_policy"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/service"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/verification"
 	"google.golang.org/api/option"
 )
 
@@ -60,15 +73,17 @@ func Get() *DepContainer {
 	return _depContainer
 }
 
-func (dep *DepContainer) TwilioServiceProvider(c cinternal.Container) DepRegistrar {
+func (dep *DepContainer) SMSSenderServiceProvider(c cinternal.Container) DepRegistrar {
 	return func() error {
-		c.Transient(func() twilio.TwilioServicer {
-			appConf := config.GetAppConf()
+		appConf := config.GetAppConf()
+		sender, err := smssender.New(smssender.Driver(appConf.SMSDriver), appConf)
 
-			return twilio.New(twilio.TwilioConf{
-				AccountSID:   appConf.TwilioAccountID,
-				AccountToken: appConf.TwilioAuthToken,
-			})
+		if err != nil {
+			return err
+		}
+
+		c.Singleton(func() smssender.SMSSender {
+			return sender
 		})
 
 		return nil
@@ -175,18 +190,68 @@ func (dep *DepContainer) FirestoreMessageProvider(c cinternal.Container) DepRegi
 	}
 }
 
+// JwtKeyringServiceProvider loads jwt signing keys from DB. The keyring is used by every jwt
+// signed / verified through `jwtactor`.
+func (dep *DepContainer) JwtKeyringServiceProvider(c cinternal.Container) DepRegistrar {
+	return func() error {
+		keyring := jwtactor.NewKeyring(
+			config.GetAppConf().JwtSecret,
+			auth.NewSigningKeyDAO(db.GetDB()).GetSigningKeys,
+			jwtactor.DefaultKeyringRefreshInterval,
+		)
+
+		if err := keyring.Reload(); err != nil {
+			return err
+		}
+
+		jwtactor.SetKeyring(keyring)
+
+		c.Singleton(func() *jwtactor.Keyring {
+			return keyring
+		})
+
+		return nil
+	}
+}
+
+// UsernamePolicyServiceProvider loads the username policy along with the profanity blocklist.
+func (dep *DepContainer) UsernamePolicyServiceProvider(c cinternal.Container) DepRegistrar {
+	return func() error {
+		policy, err := usernamepolicy.New(config.GetAppConf().GetUsernamePolicy())
+
+		if err != nil {
+			return fmt.Errorf("failed to load username policy %s", err.Error())
+		}
+
+		c.Singleton(func() *usernamepolicy.Policy {
+			return policy
+		})
+
+		return nil
+	}
+}
+
 func (dep *DepContainer) Run() error {
 	depRegistrars := []DepRegistrar{
-		dep.TwilioServiceProvider(dep.Container),
+		dep.SMSSenderServiceProvider(dep.Container),
 		dep.DarkFirestoreServiceProvider(dep.Container),
 		dep.GcsEnhancerServiceProvider(dep.Container),
 		dep.PubsuberServiceProvider(dep.Container),
 		dep.FirestoreMessageProvider(dep.Container),
+		dep.JwtKeyringServiceProvider(dep.Container),
+		dep.UsernamePolicyServiceProvider(dep.Container),
 
 		user.UserDaoServiceProvider(dep.Container),
+		user.UserLocationDAOServiceProvider(dep.Container),
+		user.UserFavoriteDAOServiceProvider(dep.Container),
+		user.ProfileViewDAOServiceProvider(dep.Container),
+		availability.AvailabilityDAOServiceProvider(dep.Container),
 		service.ServiceDAOServiceProvider(dep.Container),
 		service.ServiceFSMProvider(dep.Container),
 		inquiry.InquiryDaoServiceProvider(dep.Container),
+		matching.MatchingDAOServiceProvider(dep.Container),
+		matching.DispatcherServiceProvider(dep.Container),
+		transition.TransitionDAOServiceProvider(dep.Container),
 
 		chat.ChatDaoServiceProvider(dep.Container),
 		chat.ChatServiceServiceProvider(dep.Container),
@@ -195,6 +260,7 @@ func (dep *DepContainer) Run() error {
 		image.ImageDAOServiceProvider(dep.Container),
 		bankAccount.BankAccountDAOServiceProvider(dep.Container),
 		auth.AuthDaoerServiceProvider(dep.Container),
+		auth.SigningKeyDAOServiceProvider(dep.Container),
 
 		coin.CoinDAOServiceProvider(dep.Container),
 		coin.CoinPackageDaoServiceProvider(dep.Container),
@@ -202,8 +268,16 @@ func (dep *DepContainer) Run() error {
 
 		rate.RateDAOServiceProvider(dep.Container),
 		register.RegisterDaoServiceProvider(dep.Container),
+		referral.ReferralRewardDAOServiceProvider(dep.Container),
+		agency.AgencyDAOServiceProvider(dep.Container),
+		verification.IdentityVerificationDAOServiceProvider(dep.Container),
+		presence.PresenceDAOServiceProvider(dep.Container),
+		premium.PremiumDAOServiceProvider(dep.Container),
+		report.ReportDAOServiceProvider(dep.Container),
+		report.UserRestrictionDAOServiceProvider(dep.Container),
 
 		block.BlockDAOServiceProvider(dep.Container),
+		suspension.UserSuspensionDAOServiceProvider(dep.Container),
 	}
 
 	for _, depRegistrar := range depRegistrars {
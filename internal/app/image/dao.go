@@ -85,3 +85,16 @@ func (dao *ImageDAO) CreateImages(imagesParams []models.Image) error {
 
 	return nil
 }
+
+// DeleteImagesByUserID detaches every image from the user.
+func (dao *ImageDAO) DeleteImagesByUserID(ID int64) error {
+	query := `
+UPDATE images
+SET deleted_at = now()
+WHERE user_id = $1
+	AND deleted_at IS NULL;
+`
+	_, err := dao.DB.Exec(query, ID)
+
+	return err
+}
@@ -91,6 +91,20 @@ func decodeImageByMime(r io.Reader, mime string) (*DecodedImage, error) {
 	}, err
 }
 
+// DecodeHeaderFile decodes the file by the given mime. Used to make sure the file is a valid image
+// when the file is stored without going through `CompressImages`.
+func DecodeHeaderFile(h *multipart.FileHeader, mime string) (*DecodedImage, error) {
+	f, err := h.Open()
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	return decodeImageByMime(f, mime)
+}
+
 type SubImager interface {
 	SubImage(r nImage.Rectangle) nImage.Image
 }
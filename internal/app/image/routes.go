@@ -3,7 +3,6 @@ package image
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 )
@@ -14,9 +13,7 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 
 	g := r.Group(
 		"/images",
-		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{
-			Secret: config.GetAppConf().JwtSecret,
-		}, authDao),
+		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{}, authDao),
 	)
 
 	g.POST("", func(c *gin.Context) {
@@ -11,6 +11,8 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type InquiryDAO struct {
@@ -92,6 +94,14 @@ WITH blocked_users AS (
 	WHERE
 		user_id = $1 AND
 		deleted_at IS NULL
+	UNION
+	SELECT
+		user_id AS blocked_user_id
+	FROM
+		block_list
+	WHERE
+		blocked_user_id = $1 AND
+		deleted_at IS NULL
 ), ongoing_service AS (
 	SELECT
 		customer_id AS ongoing_customer_id
@@ -140,6 +150,15 @@ WITH blocked_users AS (
 		FROM
 			ongoing_service
 	)
+	AND NOT EXISTS (
+		SELECT 1
+		FROM user_suspensions AS us
+		WHERE
+			us.user_id = si.inquirer_id AND
+			us.lifted_at IS NULL AND
+			us.deleted_at IS NULL AND
+			(us.expires_at IS NULL OR us.expires_at > NOW())
+	)
 	ORDER BY si.created_at DESC
 	LIMIT $3
 	OFFSET $4
@@ -465,6 +484,25 @@ AND
 	inquiry_status=$2
 AND
 	picker_id=$3
+AND NOT EXISTS (
+	SELECT 1
+	FROM block_list AS bl
+	WHERE
+		(
+			(bl.user_id = $3 AND bl.blocked_user_id = si.inquirer_id) OR
+			(bl.user_id = si.inquirer_id AND bl.blocked_user_id = $3)
+		) AND
+		bl.deleted_at IS NULL
+)
+AND NOT EXISTS (
+	SELECT 1
+	FROM user_suspensions AS us
+	WHERE
+		us.user_id = si.inquirer_id AND
+		us.lifted_at IS NULL AND
+		us.deleted_at IS NULL AND
+		(us.expires_at IS NULL OR us.expires_at > NOW())
+)
 ORDER BY si.created_at
 OFFSET $4
 LIMIT $5;
@@ -497,3 +535,430 @@ LIMIT $5;
 
 	return irs, nil
 }
+
+// GetOpenInquiriesOfUser retrieves inquiries emitted by the user that are still waiting to be matched, along
+// with direct inquiries sent to the user that have not been answered. Inquiries are locked until the
+// transaction ends so that they can not be picked up while being cancelled.
+func (dao *InquiryDAO) GetOpenInquiriesOfUser(userID int64) ([]models.ServiceInquiry, error) {
+	query := `
+SELECT
+	*
+FROM
+	service_inquiries
+WHERE
+	(
+		inquirer_id = $1 OR
+		(inquiry_type = $2 AND picker_id = $1)
+	) AND
+	inquiry_status IN ($3, $4) AND
+	deleted_at IS NULL
+FOR UPDATE;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		userID,
+		models.InquiryTypeDirect,
+		models.InquiryStatusInquiring,
+		models.InquiryStatusAsking,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	iqs := make([]models.ServiceInquiry, 0)
+
+	for rows.Next() {
+		var m models.ServiceInquiry
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		iqs = append(iqs, m)
+	}
+
+	return iqs, nil
+}
+
+// PatchInquiriesStatus updates status of the inquiries that are still in one of the given statuses.
+// Inquiries updated are returned.
+func (dao *InquiryDAO) PatchInquiriesStatus(p contracts.PatchInquiriesStatusParams) ([]models.ServiceInquiry, error) {
+	if len(p.Uuids) == 0 {
+		return make([]models.ServiceInquiry, 0), nil
+	}
+
+	from := make([]string, 0, len(p.From))
+
+	for _, status := range p.From {
+		from = append(from, string(status))
+	}
+
+	query := `
+UPDATE service_inquiries
+SET inquiry_status = $1
+WHERE
+	uuid = ANY($2::text[])
+	AND inquiry_status = ANY($3::inquiry_status[])
+RETURNING *;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		p.To,
+		pq.Array(p.Uuids),
+		pq.Array(from),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	iqs := make([]models.ServiceInquiry, 0)
+
+	for rows.Next() {
+		var m models.ServiceInquiry
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		iqs = append(iqs, m)
+	}
+
+	return iqs, nil
+}
+
+// ApplyInquiry adds the provider to the applicants of the random inquiry. Returns `sql.ErrNoRows` if
+// the provider has applied already.
+func (dao *InquiryDAO) ApplyInquiry(inquiryID, applicantID int64) (*models.InquiryApplicant, error) {
+	query := `
+INSERT INTO inquiry_applicants (
+	inquiry_id,
+	applicant_id
+) VALUES ($1, $2)
+ON CONFLICT (inquiry_id, applicant_id) DO NOTHING
+RETURNING *;
+`
+	var applicant models.InquiryApplicant
+
+	if err := dao.db.QueryRowx(query, inquiryID, applicantID).StructScan(&applicant); err != nil {
+		return nil, err
+	}
+
+	return &applicant, nil
+}
+
+// GetInquiryApplicants retrieves profile summaries of the applicants that are still waiting for the
+// inquirer to reply. Applicants ranked higher by the matching engine come first, followed by those
+// with better rating.
+func (dao *InquiryDAO) GetInquiryApplicants(inquiryID int64) ([]models.InquiryApplicantProfile, error) {
+	query := `
+WITH ratings AS (
+	SELECT
+		ratee_id,
+		AVG(rating)::float8 AS score,
+		COUNT(*) AS number_of_services
+	FROM service_ratings
+	GROUP BY ratee_id
+)
+SELECT
+	users.uuid,
+	users.username,
+	users.avatar_url,
+	users.age,
+	users.height,
+	users.weight,
+	users.breast_size,
+	users.description,
+	EXISTS (
+		SELECT 1 FROM identity_verifications
+		WHERE
+			identity_verifications.user_id = users.id
+			AND identity_verifications.status = 'approved'
+			AND identity_verifications.deleted_at IS NULL
+	) AS verified,
+	ratings.score AS rating,
+	COALESCE(ratings.number_of_services, 0) AS number_of_services,
+	inquiry_matches.score AS match_score,
+	inquiry_applicants.status,
+	inquiry_applicants.created_at AS applied_at
+FROM inquiry_applicants
+INNER JOIN users ON users.id = inquiry_applicants.applicant_id
+LEFT JOIN ratings ON ratings.ratee_id = users.id
+LEFT JOIN inquiry_matches
+	ON inquiry_matches.inquiry_id = inquiry_applicants.inquiry_id
+	AND inquiry_matches.user_id = inquiry_applicants.applicant_id
+	AND inquiry_matches.deleted_at IS NULL
+WHERE
+	inquiry_applicants.inquiry_id = $1
+	AND inquiry_applicants.status = $2
+	AND inquiry_applicants.deleted_at IS NULL
+ORDER BY
+	match_score DESC NULLS LAST,
+	rating DESC NULLS LAST,
+	applied_at;
+`
+	rows, err := dao.db.Queryx(query, inquiryID, models.InquiryApplicantStatusApplied)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	applicants := make([]models.InquiryApplicantProfile, 0)
+
+	for rows.Next() {
+		var m models.InquiryApplicantProfile
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		applicants = append(applicants, m)
+	}
+
+	return applicants, nil
+}
+
+// GetApplicantStatus returns `sql.ErrNoRows` if the user has not applied for the inquiry.
+func (dao *InquiryDAO) GetApplicantStatus(inquiryID, applicantID int64) (models.InquiryApplicantStatus, error) {
+	query := `
+SELECT status
+FROM inquiry_applicants
+WHERE
+	inquiry_id = $1
+	AND applicant_id = $2
+	AND deleted_at IS NULL;
+`
+	var status models.InquiryApplicantStatus
+
+	if err := dao.db.QueryRow(query, inquiryID, applicantID).Scan(&status); err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+// AcceptApplicant makes the applicant the picker of the inquiry and declines the rest of the applicants.
+// Declined applicants are returned so that they can be notified. Returns `sql.ErrNoRows` if the inquiry
+// is no longer `inquiring` or the applicant is no longer `applied`, e.g. the inquirer has accepted
+// another applicant concurrently.
+func (dao *InquiryDAO) AcceptApplicant(p contracts.AcceptApplicantParams) ([]models.DeclinedApplicant, error) {
+	res, err := dao.db.Exec(`
+UPDATE service_inquiries
+SET picker_id = $1
+WHERE
+	id = $2
+	AND inquiry_status = $3;
+`,
+		p.ApplicantID,
+		p.InquiryID,
+		models.InquiryStatusInquiring,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		if err == nil {
+			err = sql.ErrNoRows
+		}
+
+		return nil, err
+	}
+
+	res, err = dao.db.Exec(`
+UPDATE inquiry_applicants
+SET status = $1
+WHERE
+	inquiry_id = $2
+	AND applicant_id = $3
+	AND status = $4
+	AND deleted_at IS NULL;
+`,
+		models.InquiryApplicantStatusAccepted,
+		p.InquiryID,
+		p.ApplicantID,
+		models.InquiryApplicantStatusApplied,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		if err == nil {
+			err = sql.ErrNoRows
+		}
+
+		return nil, err
+	}
+
+	rows, err := dao.db.Queryx(`
+UPDATE inquiry_applicants
+SET status = $1
+FROM users
+WHERE
+	users.id = inquiry_applicants.applicant_id
+	AND inquiry_applicants.inquiry_id = $2
+	AND inquiry_applicants.status = $3
+	AND inquiry_applicants.deleted_at IS NULL
+RETURNING
+	users.id,
+	users.uuid,
+	users.fcm_topic,
+	users.locale;
+`,
+		models.InquiryApplicantStatusDeclined,
+		p.InquiryID,
+		models.InquiryApplicantStatusApplied,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	declined := make([]models.DeclinedApplicant, 0)
+
+	for rows.Next() {
+		var m models.DeclinedApplicant
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		declined = append(declined, m)
+	}
+
+	return declined, nil
+}
+
+// DeclineApplicant declines the application that is still waiting for the inquirer to reply. Returns
+// `sql.ErrNoRows` if the user has no such application.
+func (dao *InquiryDAO) DeclineApplicant(inquiryID, applicantID int64) (*models.DeclinedApplicant, error) {
+	query := `
+UPDATE inquiry_applicants
+SET status = $1
+FROM users
+WHERE
+	users.id = inquiry_applicants.applicant_id
+	AND inquiry_applicants.inquiry_id = $2
+	AND inquiry_applicants.applicant_id = $3
+	AND inquiry_applicants.status = $4
+	AND inquiry_applicants.deleted_at IS NULL
+RETURNING
+	users.id,
+	users.uuid,
+	users.fcm_topic,
+	users.locale;
+`
+	var declined models.DeclinedApplicant
+
+	if err := dao.db.QueryRowx(
+		query,
+		models.InquiryApplicantStatusDeclined,
+		inquiryID,
+		applicantID,
+		models.InquiryApplicantStatusApplied,
+	).StructScan(&declined); err != nil {
+		return nil, err
+	}
+
+	return &declined, nil
+}
+
+// DeclineApplicationsOfUser declines every application of the user that is still waiting for the
+// inquirer to reply, e.g. the user is suspended and can no longer serve the inquiry.
+func (dao *InquiryDAO) DeclineApplicationsOfUser(applicantID int64) ([]models.DeclinedApplication, error) {
+	query := `
+UPDATE inquiry_applicants
+SET status = $1
+FROM service_inquiries, users
+WHERE
+	service_inquiries.id = inquiry_applicants.inquiry_id
+	AND users.id = inquiry_applicants.applicant_id
+	AND inquiry_applicants.applicant_id = $2
+	AND inquiry_applicants.status = $3
+	AND inquiry_applicants.deleted_at IS NULL
+RETURNING
+	service_inquiries.uuid AS inquiry_uuid,
+	users.uuid AS applicant_uuid,
+	users.fcm_topic,
+	users.locale;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		models.InquiryApplicantStatusDeclined,
+		applicantID,
+		models.InquiryApplicantStatusApplied,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeclinedApplications(rows)
+}
+
+// DeclineApplicantsOfInquiries declines every applicant still waiting for the inquirer to reply once
+// the inquiries are closed, e.g. cancelled by the inquirer or expired.
+func (dao *InquiryDAO) DeclineApplicantsOfInquiries(inquiryUuids []string) ([]models.DeclinedApplication, error) {
+	if len(inquiryUuids) == 0 {
+		return make([]models.DeclinedApplication, 0), nil
+	}
+
+	query := `
+UPDATE inquiry_applicants
+SET status = $1
+FROM service_inquiries, users
+WHERE
+	service_inquiries.id = inquiry_applicants.inquiry_id
+	AND users.id = inquiry_applicants.applicant_id
+	AND service_inquiries.uuid = ANY($2::text[])
+	AND inquiry_applicants.status = $3
+	AND inquiry_applicants.deleted_at IS NULL
+RETURNING
+	service_inquiries.uuid AS inquiry_uuid,
+	users.uuid AS applicant_uuid,
+	users.fcm_topic,
+	users.locale;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		models.InquiryApplicantStatusDeclined,
+		pq.Array(inquiryUuids),
+		models.InquiryApplicantStatusApplied,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeclinedApplications(rows)
+}
+
+func scanDeclinedApplications(rows *sqlx.Rows) ([]models.DeclinedApplication, error) {
+	defer rows.Close()
+
+	declined := make([]models.DeclinedApplication, 0)
+
+	for rows.Next() {
+		var m models.DeclinedApplication
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		declined = append(declined, m)
+	}
+
+	return declined, nil
+}
@@ -13,12 +13,17 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/availability"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/block"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/inquiry/util"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/matching"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
 	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
 	"github.com/jmoiron/sqlx"
 	"github.com/teris-io/shortid"
 )
@@ -36,6 +41,11 @@ type EmitInquiryBody struct {
 	AppointmentTime time.Time `form:"appointment_time" json:"appointment_time" binding:"required"`
 	ServiceDuration int       `form:"service_duration" json:"service_duration" binding:"required"`
 	Address         string    `form:"address" json:"address" binding:"required"`
+
+	// Location of the appointment, providers nearby are invited first. Inquiries without location are
+	// matched with providers regardless of where they are and distance does not affect the ranking.
+	Lat *float64 `form:"lat" json:"lat" binding:"required_with=Lng,omitempty,min=-90,max=90"`
+	Lng *float64 `form:"lng" json:"lng" binding:"required_with=Lat,omitempty,min=-180,max=180"`
 }
 
 func EmitInquiryHandler(c *gin.Context, depCon container.Container) {
@@ -87,6 +97,14 @@ func EmitInquiryHandler(c *gin.Context, depCon container.Container) {
 			return
 		}
 
+		if !block.RequireNotBlocked(c, depCon, usr.ID, picker.ID) {
+			return
+		}
+
+		if !availability.RequireAvailable(c, depCon, picker.ID, body.AppointmentTime, time.Duration(body.ServiceDuration)*time.Minute) {
+			return
+		}
+
 		iq, err := iqSrv.CreateDirectInquiry(ctx, CreateDirectInquiryParams{
 			InquirerUUID:      usr.Uuid,
 			InquirerID:        int32(usr.ID),
@@ -118,6 +136,7 @@ func EmitInquiryHandler(c *gin.Context, depCon container.Container) {
 		if err := fcm.PublishMaleSendDirectInquiryNotification(
 			ctx, dpfcm.PublishMaleSendDirectInquiryMessage{
 				Topic:          picker.FcmTopic.String,
+				Locale:         picker.Locale,
 				InquiryUUID:    iq.Uuid,
 				MaleUsername:   usr.Username,
 				Femaleusername: picker.Username,
@@ -199,9 +218,24 @@ func EmitInquiryHandler(c *gin.Context, depCon container.Container) {
 		}
 
 		// @TODO also makesure records in the firestore is marked expired.
-		if err := q.PatchInquiryStatus(ctx, models.PatchInquiryStatusParams{
-			ID:            resIq.ID,
-			InquiryStatus: models.InquiryStatusExpired,
+		var declined []models.DeclinedApplication
+
+		if err := transition.Transact(contracts.AnnotateTransitionParams{
+			Event:     Expire.ToString(),
+			ActorUuid: usr.Uuid,
+			Cause:     transition.CauseEmitNewInquiry,
+		}, func(tx *sqlx.Tx) error {
+			if err := models.New(tx).PatchInquiryStatus(ctx, models.PatchInquiryStatusParams{
+				ID:            resIq.ID,
+				InquiryStatus: models.InquiryStatusExpired,
+			}); err != nil {
+				return err
+			}
+
+			var err error
+			declined, err = NewInquiryDAO(tx).DeclineApplicantsOfInquiries([]string{resIq.Uuid})
+
+			return err
 		}); err != nil {
 			c.AbortWithError(
 				http.StatusInternalServerError,
@@ -213,9 +247,13 @@ func EmitInquiryHandler(c *gin.Context, depCon container.Container) {
 
 			return
 		}
+
+		var fm dpfcm.DPFirebaseMessenger
+		depCon.Make(&fm)
+		NotifyClosedApplications(ctx, darkfirestore.Get(), fm, declined)
 	}
 
-	riq, err := iqSrv.CreateRandomInquiry(ctx, CreateRandomInquiryParams{
+	riqParams := CreateRandomInquiryParams{
 		InquirerUUID:      usr.Uuid,
 		InquirerID:        int32(usr.ID),
 		Budget:            body.Budget,
@@ -224,7 +262,18 @@ func EmitInquiryHandler(c *gin.Context, depCon container.Container) {
 		ServiceDuration:   body.ServiceDuration,
 		Address:           body.Address,
 		Currency:          config.GetAppConf().Currency,
-	})
+	}
+
+	// Raw coordinates are never persisted.
+	if body.Lat != nil && body.Lng != nil {
+		lat := user.CoarsenCoordinate(*body.Lat, models.LocationPrecisionDistrict)
+		lng := user.CoarsenCoordinate(*body.Lng, models.LocationPrecisionDistrict)
+
+		riqParams.Lat = &lat
+		riqParams.Lng = &lng
+	}
+
+	riq, err := iqSrv.CreateRandomInquiry(ctx, riqParams)
 
 	if err != nil {
 		c.AbortWithError(
@@ -238,6 +287,12 @@ func EmitInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	// Invite providers ranked by the matching engine without holding the response on FCM. The inquiry
+	// has been emitted already, failing waves are retried by the inquiry matcher worker.
+	var dispatcher *matching.Dispatcher
+	depCon.Make(&dispatcher)
+	dispatcher.MatchEmittedInquiry(depCon, riq.ID)
+
 	trf, err := NewTransform().TransformEmitInquiry(*riq)
 
 	if err != nil {
@@ -466,14 +521,29 @@ func CancelInquiryHandler(c *gin.Context, depCon container.Container) {
 	}
 
 	var (
-		iqDao   contracts.InquiryDAOer
-		chatDao contracts.ChatDaoer
+		iqDao         contracts.InquiryDAOer
+		chatDao       contracts.ChatDaoer
+		transitionDao contracts.TransitionDAOer
 	)
 
 	depCon.Make(&iqDao)
 	depCon.Make(&chatDao)
+	depCon.Make(&transitionDao)
+
+	var declined []models.DeclinedApplication
 
 	trxResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+			Event:     Cancel.ToString(),
+			ActorUuid: c.GetString("uuid"),
+		}); err != nil {
+			return db.FormatResp{
+				HttpStatusCode: http.StatusInternalServerError,
+				Err:            err,
+				ErrCode:        apperr.FailedToAnnotateTransition,
+			}
+		}
+
 		// ------------------- Update inquiry status to cancel  -------------------
 		srvCancelStatus := models.InquiryStatus(fsm.Current())
 		uiq, err := iqDao.WithTx(tx).PatchInquiryByInquiryUUID(
@@ -500,6 +570,15 @@ func CancelInquiryHandler(c *gin.Context, depCon container.Container) {
 			}
 		}
 
+		// ------------------- Decline applicants waiting for reply -------------------
+		if declined, err = iqDao.WithTx(tx).DeclineApplicantsOfInquiries([]string{iq.Uuid}); err != nil {
+			return db.FormatResp{
+				Err:            err,
+				ErrCode:        apperr.FailedToDeclineApplicant,
+				HttpStatusCode: http.StatusInternalServerError,
+			}
+		}
+
 		return db.FormatResp{
 			Response: uiq,
 		}
@@ -540,6 +619,10 @@ func CancelInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	var fm dpfcm.DPFirebaseMessenger
+	depCon.Make(&fm)
+	NotifyClosedApplications(ctx, df, fm, declined)
+
 	c.JSON(http.StatusOK, struct {
 		InquiryUuid string `json:"inquiry_uuid"`
 	}{
@@ -612,6 +695,10 @@ func PickupInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	if !block.RequireNotBlocked(c, depCon, picker.ID, int64(iq.InquirerID.Int32)) {
+		return
+	}
+
 	// ------------------- Check inquiry status is inquiring -------------------
 	if iq.InquiryStatus != models.InquiryStatusInquiring {
 		c.AbortWithError(
@@ -622,9 +709,13 @@ func PickupInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	if !availability.RequireAvailable(c, depCon, picker.ID, iq.AppointmentTime.Time, time.Duration(iq.Duration.Int32)*time.Minute) {
+		return
+	}
+
 	fsm, _ := NewInquiryFSM(iq.InquiryStatus)
 
-	if err := fsm.Event(Pickup.ToString()); err != nil {
+	if err := FireEvent(fsm, Pickup); err != nil {
 		c.AbortWithError(
 			http.StatusBadRequest,
 			apperr.NewErr(
@@ -636,16 +727,26 @@ func PickupInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Patch inquiry status in DB to be `asking`.
+	// Add the picker to the applicants of the inquiry. The inquiry stays `inquiring` so that other
+	// providers can apply as well.
 	iqDao := NewInquiryDAO(db.GetDB())
-	if _, err := iqDao.AskingInquiry(
-		picker.ID,
+	if _, err := iqDao.ApplyInquiry(
 		iq.ID,
+		picker.ID,
 	); err != nil {
+		if err == sql.ErrNoRows {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				apperr.NewErr(apperr.AlreadyAppliedInquiry),
+			)
+
+			return
+		}
+
 		c.AbortWithError(
 			http.StatusInternalServerError,
 			apperr.NewErr(
-				apperr.FailedToUpdateInquiryContent,
+				apperr.FailedToApplyInquiry,
 				err.Error(),
 			),
 		)
@@ -653,20 +754,29 @@ func PickupInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Patch inquiry status in firestore to be `asking`
+	// Responses to invitations are measured by the matching engine.
+	var matchingDao contracts.MatchingDAOer
+	depCon.Make(&matchingDao)
+
+	if err := matchingDao.MarkResponded(iq.ID, picker.ID); err != nil {
+		log.Printf("failed to mark %s responded to inquiry %s %s", picker.Uuid, iq.Uuid, err.Error())
+	}
+
+	// Add the picker to the applicants of the inquiry document in firestore.
 	df := darkfirestore.Get()
-	if err = df.AskingInquiringUser(
+	if err = df.AddInquiryApplicant(
 		ctx,
-		darkfirestore.AskingInquiringUserParams{
-			InquiryUuid:    iq.Uuid,
-			PickerUuid:     c.GetString("uuid"),
-			PickerUsername: picker.Username,
+		darkfirestore.AddInquiryApplicantParams{
+			InquiryUuid:       iq.Uuid,
+			ApplicantUuid:     picker.Uuid,
+			ApplicantUsername: picker.Username,
+			AvatarURL:         picker.AvatarUrl.String,
 		},
 	); err != nil {
 		c.AbortWithError(
 			http.StatusInternalServerError,
 			apperr.NewErr(
-				apperr.FailedToAskInquiringUser,
+				apperr.FailedToUpdateFirestoreApplicants,
 				err.Error(),
 			),
 		)
@@ -675,7 +785,7 @@ func PickupInquiryHandler(c *gin.Context, depCon container.Container) {
 	}
 
 	// Publish FCM to notify male user that a female has picked up the inquiry.
-	inquirer, err := iqDao.GetInquirerByInquiryUUID(iq.Uuid, "fcm_topic")
+	inquirer, err := iqDao.GetInquirerByInquiryUUID(iq.Uuid, "fcm_topic", "locale")
 
 	if err != nil {
 		c.AbortWithError(
@@ -693,6 +803,7 @@ func PickupInquiryHandler(c *gin.Context, depCon container.Container) {
 	depCon.Make(&fm)
 	if err := fm.PublishPickupInquiryNotification(ctx, dpfcm.PublishPickupInquiryNotificationMessage{
 		Topic:      inquirer.FcmTopic.String,
+		Locale:     inquirer.Locale,
 		PickerName: picker.Username,
 		PickerUUID: picker.Uuid,
 	}); err != nil {
@@ -715,13 +826,83 @@ func PickupInquiryHandler(c *gin.Context, depCon container.Container) {
 
 type AgreeToChatParams struct {
 	InquiryUuid string `form:"inquiry_uuid" json:"inquiry_uuid" binding:"required,gt=0"`
+
+	// ApplicantUuid the applicant of the random inquiry that the inquirer agrees to chat with. Required
+	// when the inquiry is still `inquiring`.
+	ApplicantUuid string `form:"applicant_uuid" json:"applicant_uuid"`
+}
+
+// requireApplicant makes sure the requester is the inquirer and the applicant is still waiting for the
+// inquirer to reply. Returns the applicant if so, otherwise aborts the request.
+func requireApplicant(c *gin.Context, depCon container.Container, iq models.ServiceInquiry, inquirerUuid, applicantUuid string) (*models.User, bool) {
+	if c.GetString("uuid") != inquirerUuid {
+		c.AbortWithError(
+			http.StatusForbidden,
+			apperr.NewErr(apperr.OnlyInquirerCanManageApplicants),
+		)
+
+		return nil, false
+	}
+
+	if applicantUuid == "" {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.ApplicantUuidRequired),
+		)
+
+		return nil, false
+	}
+
+	var userDao contracts.UserDAOer
+	depCon.Make(&userDao)
+
+	applicant, err := userDao.GetUserByUuid(applicantUuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	status, err := NewInquiryDAO(db.GetDB()).GetApplicantStatus(iq.ID, applicant.ID)
+
+	if err == sql.ErrNoRows || (err == nil && status != models.InquiryApplicantStatusApplied) {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.ApplicantNotApplied),
+		)
+
+		return nil, false
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetInquiryApplicants,
+				err.Error(),
+			),
+		)
+
+		return nil, false
+	}
+
+	return applicant, true
 }
 
 // AgreePickupInquiryHandler Male user agree to have a chat with the male user.
 // Perform following operations when male user agrees to chat.
 //   - Check if inquiry status can be transitioned to `chatting`
+//   - Accept the applicant and decline the rest if the inquiry is random
 //   - Change inquiry status to `chatting` on DB
 //   - Change inquiry status to `chatting` on firestore
+//   - Notify declined applicants
 func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 	var params AgreeToChatParams
 
@@ -754,32 +935,57 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Retrieve picker info by uuid
 	var userDao contracts.UserDAOer
 	depCon.Make(&userDao)
 
-	picker, err := userDao.GetUserByID(int64(iq.PickerID.Int32))
+	inquirer, err := userDao.GetUserByID(int64(iq.InquirerID.Int32))
 
 	if err != nil {
 		c.AbortWithError(
 			http.StatusInternalServerError,
-			apperr.NewErr(apperr.InquiryHasNoPicker),
+			apperr.NewErr(apperr.FailedToGetInquiererByID),
 		)
 
 		return
 	}
 
-	inquirer, err := userDao.GetUserByID(int64(iq.InquirerID.Int32))
+	// Random inquiries stay `inquiring` while providers apply for it, the inquirer picks one of the
+	// applicants to chat with. Direct inquiries are `asking` with the picker assigned already.
+	choosingApplicant := iq.InquiryStatus == models.InquiryStatusInquiring
 
-	if err != nil {
-		c.AbortWithError(
-			http.StatusInternalServerError,
-			apperr.NewErr(apperr.FailedToGetInquiererByID),
-		)
+	var picker *models.User
+
+	if choosingApplicant {
+		applicant, ok := requireApplicant(c, depCon, iq, inquirer.Uuid, params.ApplicantUuid)
+
+		if !ok {
+			return
+		}
+
+		picker = applicant
+	} else {
+		picker, err = userDao.GetUserByID(int64(iq.PickerID.Int32))
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(apperr.InquiryHasNoPicker),
+			)
+
+			return
+		}
+	}
 
+	// Availability of the picker might have changed since the inquiry was picked.
+	if !availability.RequireAvailable(c, depCon, picker.ID, iq.AppointmentTime.Time, time.Duration(iq.Duration.Int32)*time.Minute) {
 		return
 	}
 
+	pickerID := sql.NullInt32{
+		Valid: true,
+		Int32: int32(picker.ID),
+	}
+
 	fsm, err := NewInquiryFSM(iq.InquiryStatus)
 
 	if err != nil {
@@ -794,7 +1000,7 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	if err := fsm.Event(AgreePickup.ToString()); err != nil {
+	if err := FireEvent(fsm, AgreePickup); err != nil {
 		c.AbortWithError(
 			http.StatusBadRequest,
 			apperr.NewErr(
@@ -814,10 +1020,51 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 	type TransResp struct {
 		Chatroom *models.Chatroom
 		Service  *models.Service
+		Declined []models.DeclinedApplicant
 	}
 
+	var transitionDao contracts.TransitionDAOer
+	depCon.Make(&transitionDao)
+
 	tranResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+			Event:     AgreePickup.ToString(),
+			ActorUuid: c.GetString("uuid"),
+		}); err != nil {
+			return db.FormatResp{
+				HttpStatusCode: http.StatusInternalServerError,
+				Err:            err,
+				ErrCode:        apperr.FailedToAnnotateTransition,
+			}
+		}
+
 		inquiryDao := NewInquiryDAO(tx)
+
+		declined := make([]models.DeclinedApplicant, 0)
+
+		if choosingApplicant {
+			declined, err = inquiryDao.AcceptApplicant(contracts.AcceptApplicantParams{
+				InquiryID:   iq.ID,
+				ApplicantID: picker.ID,
+			})
+
+			if err == sql.ErrNoRows {
+				return db.FormatResp{
+					HttpStatusCode: http.StatusConflict,
+					Err:            err,
+					ErrCode:        apperr.ApplicantAlreadySettled,
+				}
+			}
+
+			if err != nil {
+				return db.FormatResp{
+					HttpStatusCode: http.StatusInternalServerError,
+					Err:            err,
+					ErrCode:        apperr.FailedToAcceptApplicant,
+				}
+			}
+		}
+
 		if err := inquiryDao.PatchInquiryStatusByUUID(
 			contracts.PatchInquiryStatusByUUIDParams{
 				UUID:          iq.Uuid,
@@ -838,7 +1085,7 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 		chatroom, err := chat.WithTx(tx).CreateAndJoinChatroom(
 			iq.ID,
 			int64(iq.InquirerID.Int32),
-			int64(iq.PickerID.Int32),
+			picker.ID,
 		)
 
 		if err != nil {
@@ -870,7 +1117,7 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 					String: sid,
 				},
 				CustomerID:        iq.InquirerID,
-				ServiceProviderID: iq.PickerID,
+				ServiceProviderID: pickerID,
 				Price: sql.NullString{
 					Valid:  true,
 					String: iq.Budget,
@@ -896,6 +1143,7 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 			Response: &TransResp{
 				Chatroom: chatroom,
 				Service:  &service,
+				Declined: declined,
 			},
 		}
 	})
@@ -914,9 +1162,35 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 
 	tr := tranResp.Response.(*TransResp)
 
+	df := darkfirestore.Get()
+
+	if choosingApplicant {
+		declinedUuids := make([]string, 0, len(tr.Declined))
+
+		for _, d := range tr.Declined {
+			declinedUuids = append(declinedUuids, d.Uuid)
+		}
+
+		if err := df.SettleInquiryApplicants(ctx, darkfirestore.SettleInquiryApplicantsParams{
+			InquiryUuid:    iq.Uuid,
+			PickerUuid:     picker.Uuid,
+			PickerUsername: picker.Username,
+			DeclinedUuids:  declinedUuids,
+		}); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToUpdateFirestoreApplicants,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+	}
+
 	// If "I"(the requester) am the inquirer, the person that I'm sending message to will be picker.Username.
 	// If "I"(the requester) am not the inquirer implies I am a picker, the person that I'm sending message to will be the inquirer.Username.
-	df := darkfirestore.Get()
 	if err := df.PrepareToStartInquiryChat(
 		ctx,
 		darkfirestore.PrepareToStartInquiryChatParams{
@@ -964,6 +1238,7 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 	depCon.Make(&fm)
 	if err := fm.PublishMaleAgreeToChat(ctx, dpfcm.PublishMaleAgreeToChatMessage{
 		Topic:          picker.FcmTopic.String,
+		Locale:         picker.Locale,
 		InquiryUuid:    iq.Uuid,
 		MaleUsername:   inquirer.Username,
 		FemaleUsername: picker.Username,
@@ -979,6 +1254,18 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	// Notify the rest of the applicants that the inquirer has chosen someone else.
+	for _, d := range tr.Declined {
+		if err := fm.PublishInquiryApplicationDeclined(ctx, dpfcm.InquiryApplicationDeclinedMessage{
+			Topic:            d.FcmTopic.String,
+			Locale:           d.Locale,
+			InquiryUUID:      iq.Uuid,
+			InquirerUsername: inquirer.Username,
+		}); err != nil {
+			log.Printf("failed to notify %s of declined application of inquiry %s %s", d.Uuid, iq.Uuid, err.Error())
+		}
+	}
+
 	// Respoonse:
 	//   - service provider's info
 	//   - private chat uuid in firestore for inquirer to subscribe
@@ -994,6 +1281,10 @@ func AgreeToChatInquiryHandler(c *gin.Context, depCon container.Container) {
 
 type SkipPickupHandlerBody struct {
 	InquiryUuid string `form:"inquiry_uuid" json:"inquiry_uuid" binding:"required"`
+
+	// ApplicantUuid the applicant of the random inquiry that the inquirer declines. Required when the
+	// inquiry is still `inquiring`.
+	ApplicantUuid string `form:"applicant_uuid" json:"applicant_uuid"`
 }
 
 func SkipPickupHandler(c *gin.Context, container container.Container) {
@@ -1040,7 +1331,7 @@ func SkipPickupHandler(c *gin.Context, container container.Container) {
 		return
 	}
 
-	if err := fsm.Event(Skip.ToString()); err != nil {
+	if err := FireEvent(fsm, Skip); err != nil {
 		c.AbortWithError(
 			http.StatusInternalServerError,
 			apperr.NewErr(
@@ -1054,13 +1345,78 @@ func SkipPickupHandler(c *gin.Context, container container.Container) {
 
 	ctx := context.Background()
 
+	// Random inquiry stays `inquiring` when the inquirer declines one of the applicants, the rest of the
+	// applicants are still waiting for the inquirer to reply.
+	if iq.InquiryStatus == models.InquiryStatusInquiring {
+		applicant, ok := requireApplicant(c, container, iq.ServiceInquiry, iq.UserUuid, body.ApplicantUuid)
+
+		if !ok {
+			return
+		}
+
+		declined, err := iqDao.DeclineApplicant(iq.ID, applicant.ID)
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToDeclineApplicant,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		var df darkfirestore.DarkFireStorer
+		container.Make(&df)
+
+		if err := df.DeclineInquiryApplicants(ctx, darkfirestore.DeclineInquiryApplicantsParams{
+			InquiryUuid:    iq.Uuid,
+			ApplicantUuids: []string{declined.Uuid},
+		}); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToUpdateFirestoreApplicants,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		var fm dpfcm.DPFirebaseMessenger
+		container.Make(&fm)
+
+		if err := fm.PublishInquiryApplicationDeclined(ctx, dpfcm.InquiryApplicationDeclinedMessage{
+			Topic:            declined.FcmTopic.String,
+			Locale:           declined.Locale,
+			InquiryUUID:      iq.Uuid,
+			InquirerUsername: iq.Username,
+		}); err != nil {
+			log.Printf("failed to notify %s of declined application of inquiry %s %s", declined.Uuid, iq.Uuid, err.Error())
+		}
+
+		c.JSON(http.StatusOK, struct{}{})
+
+		return
+	}
+
 	// Change inquiry status from `asking` to `inquiring` in DB.
 	// Change inquiry status from `asking` to `inquiring` in firestore. We use
 	// inquiry uuid retrieved from DB to find the document in firestore.
 	newIqStatus := models.InquiryStatus(fsm.Current())
-	if _, err := iqDao.PatchInquiryByInquiryUUID(models.PatchInquiryParams{
-		Uuid:          iq.Uuid,
-		InquiryStatus: &newIqStatus,
+	if err := transition.Transact(contracts.AnnotateTransitionParams{
+		Event:     Skip.ToString(),
+		ActorUuid: c.GetString("uuid"),
+	}, func(tx *sqlx.Tx) error {
+		_, err := NewInquiryDAO(tx).PatchInquiryByInquiryUUID(models.PatchInquiryParams{
+			Uuid:          iq.Uuid,
+			InquiryStatus: &newIqStatus,
+		})
+
+		return err
 	}); err != nil {
 		c.AbortWithError(
 			http.StatusInternalServerError,
@@ -1102,6 +1458,50 @@ func SkipPickupHandler(c *gin.Context, container container.Container) {
 	c.JSON(http.StatusOK, struct{}{})
 }
 
+// GetInquiryApplicantsHandler inquirer retrieves providers who applied for the random inquiry, best
+// matched first, to decide which one to chat with.
+func GetInquiryApplicantsHandler(c *gin.Context, depCon container.Container) {
+	iqDao := NewInquiryDAO(db.GetDB())
+	iq, err := iqDao.GetInquiryByUuid(c.Param("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToGetInquiryByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if c.GetString("uuid") != iq.UserUuid {
+		c.AbortWithError(
+			http.StatusForbidden,
+			apperr.NewErr(apperr.OnlyInquirerCanManageApplicants),
+		)
+
+		return
+	}
+
+	applicants, err := iqDao.GetInquiryApplicants(iq.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetInquiryApplicants,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, TrfInquiryApplicants(applicants))
+}
+
 func GetServiceByInquiryUUID(c *gin.Context, depCon container.Container) {
 
 	iqUUID := c.Param("uuid")
@@ -1188,6 +1588,39 @@ func PatchInquiryHandler(c *gin.Context, depCon container.Container) {
 	}
 
 	dao := NewInquiryDAO(db.GetDB())
+
+	// Appointment should be within availability of the picker if the inquiry has been picked.
+	if body.AppointmentTime != nil || body.Duration != nil {
+		iq, err := dao.GetInquiryByUuid(body.Uuid, "picker_id", "appointment_time", "duration")
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToGetInquiryByUuid,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		appointmentTime := iq.AppointmentTime.Time
+		duration := int(iq.Duration.Int32)
+
+		if body.AppointmentTime != nil {
+			appointmentTime = *body.AppointmentTime
+		}
+
+		if body.Duration != nil {
+			duration = *body.Duration
+		}
+
+		if iq.PickerID.Valid && !availability.RequireAvailable(c, depCon, int64(iq.PickerID.Int32), appointmentTime, time.Duration(duration)*time.Minute) {
+			return
+		}
+	}
+
 	inquiry, err := dao.PatchInquiryByInquiryUUID(
 		models.PatchInquiryParams{
 			Uuid:            body.Uuid,
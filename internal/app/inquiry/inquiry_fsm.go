@@ -51,22 +51,29 @@ func NewInquiryFSM(initial models.InquiryStatus) (*fsm.FSM, error) {
 				Dst: string(models.InquiryStatusCanceled),
 			},
 			{
+				// Random inquiries stay `inquiring` while providers apply for it, so that
+				// several providers can apply at the same time.
 				Name: Pickup.ToString(),
 				Src: []string{
 					string(models.InquiryStatusInquiring),
 				},
-				Dst: string(models.InquiryStatusAsking),
+				Dst: string(models.InquiryStatusInquiring),
 			},
 			{
+				// Inquirer agrees to chat with one of the applicants of the random inquiry, or
+				// the girl agrees to chat on a direct inquiry.
 				Name: AgreePickup.ToString(),
 				Src: []string{
+					string(models.InquiryStatusInquiring),
 					string(models.InquiryStatusAsking),
 				},
 				Dst: string(models.InquiryStatusChatting),
 			},
 			{
+				// Inquirer declines one of the applicants of the random inquiry.
 				Name: Skip.ToString(),
 				Src: []string{
+					string(models.InquiryStatusInquiring),
 					string(models.InquiryStatusAsking),
 				},
 				Dst: string(models.InquiryStatusInquiring),
@@ -105,3 +112,15 @@ func NewInquiryFSM(initial models.InquiryStatus) (*fsm.FSM, error) {
 
 	return f, nil
 }
+
+// FireEvent triggers the action on the inquiry FSM. Actions that keep the inquiry in the same status,
+// e.g. `pickup`, are not treated as failures.
+func FireEvent(f *fsm.FSM, action InquiryActions) error {
+	err := f.Event(action.ToString())
+
+	if _, ok := err.(fsm.NoTransitionError); ok {
+		return nil
+	}
+
+	return err
+}
@@ -61,10 +61,6 @@ func (suite *PickupInquiryTestSuite) TestPickupInquirySuccess() {
 
 	// create an inquiry
 	iqParams, _ := util.GenTestInquiryParams(maleUser.ID)
-	iqParams.PickerID = sql.NullInt32{
-		Valid: true,
-		Int32: int32(femaleUser.ID),
-	}
 	iqParams.InquiryStatus = models.InquiryStatusInquiring
 	iqParams.ServiceType = models.ServiceTypeSex
 	iqParams.ExpiredAt = sql.NullTime{
@@ -130,7 +126,7 @@ func (suite *PickupInquiryTestSuite) TestPickupInquirySuccess() {
 	assert := assert.New(suite.T())
 	assert.Equal(http.StatusOK, w.Result().StatusCode)
 
-	// Assert that the lobby user status in DB has changed to `waiting`
+	// Assert that the inquiry remains `inquiring` so that other providers can apply as well.
 	var si models.ServiceInquiry
 	db := db.GetDB()
 	if err := db.QueryRowx(
@@ -150,20 +146,39 @@ func (suite *PickupInquiryTestSuite) TestPickupInquirySuccess() {
 	}
 
 	assert.Equal(
-		models.InquiryStatusAsking,
+		models.InquiryStatusInquiring,
 		si.InquiryStatus,
 	)
 
-	assert.Equal(
-		int64(si.PickerID.Int32),
+	assert.False(si.PickerID.Valid)
+
+	// Assert that the female user is added to the applicants of the inquiry.
+	var status models.InquiryApplicantStatus
+	if err := db.QueryRow(
+		`
+	SELECT
+		status
+	FROM
+		inquiry_applicants
+	WHERE
+		inquiry_id = $1 AND
+		applicant_id = $2;
+	`,
+		iq.ID,
 		femaleUser.ID,
-	)
+	).Scan(&status); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(models.InquiryApplicantStatusApplied, status)
 
-	// Assert that the user status in firestore has changed to `waiting`
+	// Assert that the applicant is added to the inquiry document in firestore.
 	dfClient := df.GetClient()
 	dfResp, err := dfClient.
 		Collection("inquiries").
 		Doc(respStruct.InquiryUUID).
+		Collection("applicants").
+		Doc(femaleUser.Uuid).
 		Get(ctx)
 
 	if err != nil {
@@ -172,7 +187,7 @@ func (suite *PickupInquiryTestSuite) TestPickupInquirySuccess() {
 
 	assert.Equal(
 		dfResp.Data()["status"],
-		"asking",
+		"applied",
 	)
 }
 
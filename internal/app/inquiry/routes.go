@@ -3,26 +3,26 @@ package inquiry
 import (
 	"github.com/gin-gonic/gin"
 	cintrnal "github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/middlewares"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 )
 
 func Routes(r *gin.RouterGroup, container cintrnal.Container) {
 	var (
-		userDAO contracts.UserDAOer
-		authDao contracts.AuthDaoer
+		userDAO        contracts.UserDAOer
+		authDao        contracts.AuthDaoer
+		restrictionDao contracts.UserRestrictionDAOer
 	)
 
 	container.Make(&userDAO)
 	container.Make(&authDao)
+	container.Make(&restrictionDao)
 
 	g := r.Group(
 		"/inquiries",
-		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{
-			Secret: config.GetAppConf().JwtSecret,
-		}, authDao),
+		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{}, authDao),
 	)
 
 	// Female user gets list of available inquiries.
@@ -50,6 +50,23 @@ func Routes(r *gin.RouterGroup, container cintrnal.Container) {
 		}
 	})
 
+	// Inquirer retrieves applicants of the random inquiry.
+	g.GET(
+		"/:uuid/applicants",
+		middlewares.IsMale(userDAO),
+		func(c *gin.Context) {
+			GetInquiryApplicantsHandler(c, container)
+		},
+	)
+
+	// Status transitions of the inquiry for participants and operators.
+	g.GET(
+		"/:uuid/history",
+		func(c *gin.Context) {
+			transition.GetInquiryHistoryHandler(c, container)
+		},
+	)
+
 	g.GET(
 		"/:uuid/service",
 		func(c *gin.Context) {
@@ -61,6 +78,7 @@ func Routes(r *gin.RouterGroup, container cintrnal.Container) {
 	g.POST(
 		"",
 		middlewares.IsMale(userDAO),
+		middlewares.RejectRestricted(restrictionDao),
 		func(c *gin.Context) {
 			EmitInquiryHandler(c, container)
 		},
@@ -79,6 +97,7 @@ func Routes(r *gin.RouterGroup, container cintrnal.Container) {
 	g.POST(
 		"/pickup",
 		middlewares.IsFemale(userDAO),
+		middlewares.RejectRestricted(restrictionDao),
 		func(c *gin.Context) {
 			PickupInquiryHandler(c, container)
 		},
@@ -93,9 +112,9 @@ func Routes(r *gin.RouterGroup, container cintrnal.Container) {
 		},
 	)
 
-	// A male user is not interested in chatting the the female
-	// who picked up the inquiry. He can `skip` the pickup request
-	// and proceed to the next girl.
+	// A male user is not interested in chatting with one of the females
+	// who picked up the inquiry. The inquirer can `skip` that applicant while
+	// the rest of the applicants are still waiting for the reply.
 	g.POST(
 		"/skip",
 		middlewares.IsMale(userDAO),
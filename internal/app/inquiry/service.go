@@ -4,11 +4,17 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log"
+	"strconv"
 	"time"
 
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
+	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 	"github.com/teris-io/shortid"
 )
@@ -124,6 +130,10 @@ type CreateRandomInquiryParams struct {
 	ServiceDuration   int
 	Address           string
 	Currency          string
+
+	// Location of the appointment, should have been coarsened. Optional.
+	Lat *float64
+	Lng *float64
 }
 
 func (s *InquiryService) CreateRandomInquiry(ctx context.Context, p CreateRandomInquiryParams) (*models.ServiceInquiry, error) {
@@ -140,6 +150,20 @@ func (s *InquiryService) CreateRandomInquiry(ctx context.Context, p CreateRandom
 		}
 	}
 
+	var lat, lng sql.NullString
+
+	if p.Lat != nil && p.Lng != nil {
+		lat = sql.NullString{
+			Valid:  true,
+			String: strconv.FormatFloat(*p.Lat, 'f', -1, 64),
+		}
+
+		lng = sql.NullString{
+			Valid:  true,
+			String: strconv.FormatFloat(*p.Lng, 'f', -1, 64),
+		}
+	}
+
 	iq, err := s.q.CreateInquiry(
 		ctx,
 		models.CreateInquiryParams{
@@ -169,6 +193,8 @@ func (s *InquiryService) CreateRandomInquiry(ctx context.Context, p CreateRandom
 				Valid:  true,
 				String: p.Address,
 			},
+			Lat:         lat,
+			Lng:         lng,
 			InquiryType: models.InquiryTypeRandom,
 			Currency: sql.NullString{
 				Valid:  true,
@@ -195,3 +221,155 @@ func (s *InquiryService) CreateRandomInquiry(ctx context.Context, p CreateRandom
 
 	return &iq, nil
 }
+
+// CancelOpenInquiriesOfUser cancels inquiries that are still waiting to be matched when the user is no longer
+// allowed to use the app, e.g. suspended. Every inquiry goes through the inquiry FSM just like cancelling via
+// `CancelInquiryHandler`. Transitions are recorded with the actor and the cause given. Applicants of the
+// cancelled inquiries and applications of the user still waiting for inquirers to reply are declined as well.
+// Uuids of the cancelled inquiries are returned.
+//
+// Only the database changes are made in a transaction. Firestore and FCM failures once the transaction is
+// committed are logged, the inquiries have been cancelled anyway.
+func CancelOpenInquiriesOfUser(ctx context.Context, depCon container.Container, userID int64, actorUuid, cause string) ([]string, error) {
+	var (
+		iqDao         contracts.InquiryDAOer
+		chatDao       contracts.ChatDaoer
+		transitionDao contracts.TransitionDAOer
+		fm            dpfcm.DPFirebaseMessenger
+	)
+
+	depCon.Make(&iqDao)
+	depCon.Make(&chatDao)
+	depCon.Make(&transitionDao)
+	depCon.Make(&fm)
+
+	var (
+		cancelled []models.ServiceInquiry
+		declined  []models.DeclinedApplication
+		closed    []models.DeclinedApplication
+	)
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		iqs, err := iqDao.WithTx(tx).GetOpenInquiriesOfUser(userID)
+
+		if err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		uuids := make([]string, 0, len(iqs))
+
+		for _, iq := range iqs {
+			fsm, err := NewInquiryFSM(iq.InquiryStatus)
+
+			if err != nil {
+				return db.FormatResp{Err: err}
+			}
+
+			if err := fsm.Event(Cancel.ToString()); err != nil {
+				continue
+			}
+
+			uuids = append(uuids, iq.Uuid)
+		}
+
+		if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+			Event:     Cancel.ToString(),
+			ActorUuid: actorUuid,
+			Cause:     cause,
+		}); err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		if cancelled, err = iqDao.WithTx(tx).PatchInquiriesStatus(contracts.PatchInquiriesStatusParams{
+			Uuids: uuids,
+			From: []models.InquiryStatus{
+				models.InquiryStatusInquiring,
+				models.InquiryStatusAsking,
+			},
+			To: models.InquiryStatusCanceled,
+		}); err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		cancelledUuids := make([]string, 0, len(cancelled))
+
+		for _, iq := range cancelled {
+			if err := chatDao.WithTx(tx).DeleteChatroomByInquiryId(int(iq.ID)); err != nil {
+				return db.FormatResp{Err: err}
+			}
+
+			cancelledUuids = append(cancelledUuids, iq.Uuid)
+		}
+
+		if closed, err = iqDao.WithTx(tx).DeclineApplicantsOfInquiries(cancelledUuids); err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		if declined, err = iqDao.WithTx(tx).DeclineApplicationsOfUser(userID); err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		return db.FormatResp{}
+	})
+
+	if transResp.Err != nil {
+		return nil, transResp.Err
+	}
+
+	uuids := make([]string, 0, len(cancelled))
+	df := darkfirestore.Get()
+
+	for _, iq := range cancelled {
+		if _, err := df.UpdateInquiryStatus(ctx, darkfirestore.UpdateInquiryStatusParams{
+			InquiryUuid: iq.Uuid,
+			Status:      iq.InquiryStatus,
+		}); err != nil {
+			log.Printf("failed to update status of inquiry %s on firestore %s", iq.Uuid, err.Error())
+		}
+
+		uuids = append(uuids, iq.Uuid)
+	}
+
+	for _, d := range declined {
+		if err := df.DeclineInquiryApplicants(ctx, darkfirestore.DeclineInquiryApplicantsParams{
+			InquiryUuid:    d.InquiryUuid,
+			ApplicantUuids: []string{d.ApplicantUuid},
+		}); err != nil {
+			log.Printf("failed to decline applicant %s of inquiry %s on firestore %s", d.ApplicantUuid, d.InquiryUuid, err.Error())
+		}
+	}
+
+	NotifyClosedApplications(ctx, df, fm, closed)
+
+	return uuids, nil
+}
+
+// NotifyClosedApplications marks applicants declined by `DeclineApplicantsOfInquiries` as declined on
+// firestore and tells them the inquiry has been closed. The inquiries have been closed already,
+// failures are logged only.
+func NotifyClosedApplications(ctx context.Context, df darkfirestore.DarkFireStorer, fm dpfcm.DPFirebaseMessenger, declined []models.DeclinedApplication) {
+	applicantUuids := make(map[string][]string)
+
+	for _, d := range declined {
+		applicantUuids[d.InquiryUuid] = append(applicantUuids[d.InquiryUuid], d.ApplicantUuid)
+	}
+
+	for iqUuid, aUuids := range applicantUuids {
+		if err := df.DeclineInquiryApplicants(ctx, darkfirestore.DeclineInquiryApplicantsParams{
+			InquiryUuid:    iqUuid,
+			ApplicantUuids: aUuids,
+		}); err != nil {
+			log.Printf("failed to decline applicants of inquiry %s on firestore %s", iqUuid, err.Error())
+		}
+	}
+
+	for _, d := range declined {
+		if err := fm.PublishInquiryApplicationClosed(ctx, dpfcm.InquiryApplicationClosedMessage{
+			Topic:       d.FcmTopic.String,
+			Locale:      d.Locale,
+			InquiryUUID: d.InquiryUuid,
+		}); err != nil {
+			log.Printf("failed to notify %s of closed inquiry %s %s", d.ApplicantUuid, d.InquiryUuid, err.Error())
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package inquiry
 
 import (
+	"database/sql"
 	"fmt"
 	"strconv"
 	"time"
@@ -627,3 +628,71 @@ func TrfInquiryRequests(iqrs []models.InquiryRequest) TrfedInquiryRequests {
 		InquiryRequests: iqrs,
 	}
 }
+
+type TrfedInquiryApplicant struct {
+	Uuid             string    `json:"uuid"`
+	Username         string    `json:"username"`
+	AvatarUrl        *string   `json:"avatar_url"`
+	Age              *int32    `json:"age"`
+	Height           *string   `json:"height"`
+	Weight           *string   `json:"weight"`
+	BreastSize       *string   `json:"breast_size"`
+	Description      *string   `json:"description"`
+	Verified         bool      `json:"verified"`
+	Rating           *float64  `json:"rating"`
+	NumberOfServices int       `json:"number_of_services"`
+	MatchScore       *float64  `json:"match_score"`
+	AppliedAt        time.Time `json:"applied_at"`
+}
+
+type TrfedInquiryApplicants struct {
+	Applicants []TrfedInquiryApplicant `json:"applicants"`
+}
+
+func TrfInquiryApplicants(ms []models.InquiryApplicantProfile) TrfedInquiryApplicants {
+	applicants := make([]TrfedInquiryApplicant, 0, len(ms))
+
+	for _, m := range ms {
+		a := TrfedInquiryApplicant{
+			Uuid:             m.Uuid,
+			Username:         m.Username,
+			AvatarUrl:        nullStringToPtr(m.AvatarUrl),
+			Height:           nullStringToPtr(m.Height),
+			Weight:           nullStringToPtr(m.Weight),
+			BreastSize:       nullStringToPtr(m.BreastSize),
+			Description:      nullStringToPtr(m.Description),
+			Verified:         m.Verified,
+			NumberOfServices: m.NumberOfServices,
+			AppliedAt:        m.AppliedAt,
+		}
+
+		if m.Age.Valid {
+			age := m.Age.Int32
+			a.Age = &age
+		}
+
+		if m.Rating.Valid {
+			rating := m.Rating.Float64
+			a.Rating = &rating
+		}
+
+		if m.MatchScore.Valid {
+			matchScore := m.MatchScore.Float64
+			a.MatchScore = &matchScore
+		}
+
+		applicants = append(applicants, a)
+	}
+
+	return TrfedInquiryApplicants{
+		Applicants: applicants,
+	}
+}
+
+func nullStringToPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+
+	return &s.String
+}
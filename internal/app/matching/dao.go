@@ -0,0 +1,477 @@
+package matching
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/lib/pq"
+)
+
+const timestampLayout = "2006-01-02 15:04:05"
+
+// responsivenessWindow invitations sent within this period are taken into account when measuring
+// how responsive a provider is.
+const responsivenessWindow = 30 * 24 * time.Hour
+
+type MatchingDAO struct {
+	db db.Conn
+}
+
+func NewMatchingDAO(db db.Conn) *MatchingDAO {
+	return &MatchingDAO{
+		db: db,
+	}
+}
+
+func MatchingDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.MatchingDAOer {
+			return NewMatchingDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *MatchingDAO) WithTx(tx db.Conn) contracts.MatchingDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+const matchingInquiryQuery = `
+SELECT
+	*
+FROM (
+	SELECT
+		si.id,
+		si.uuid,
+		si.inquirer_id,
+		users.username AS inquirer_username,
+		si.expect_service_type,
+		si.appointment_time,
+		si.lat::float8 AS lat,
+		si.lng::float8 AS lng,
+		si.created_at,
+		COALESCE((
+			SELECT MAX(wave)
+			FROM inquiry_match_waves
+			WHERE inquiry_match_waves.inquiry_id = si.id
+		), -1) AS last_wave
+	FROM service_inquiries AS si
+	INNER JOIN users ON users.id = si.inquirer_id
+	WHERE
+		si.inquiry_type = 'random'
+		AND si.appointment_time IS NOT NULL
+		AND si.deleted_at IS NULL
+		AND %s
+) AS matching_inquiries
+%s;
+`
+
+func (dao *MatchingDAO) queryMatchingInquiries(query string, args ...interface{}) ([]models.MatchingInquiry, error) {
+	rows, err := dao.db.Queryx(query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	iqs := make([]models.MatchingInquiry, 0)
+
+	for rows.Next() {
+		var m models.MatchingInquiry
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		iqs = append(iqs, m)
+	}
+
+	return iqs, nil
+}
+
+// GetMatchingInquiry retrieves the random inquiry to be matched.
+func (dao *MatchingDAO) GetMatchingInquiry(inquiryID int64) (*models.MatchingInquiry, error) {
+	query := fmt.Sprintf(matchingInquiryQuery, "si.id = $1", "")
+
+	iqs, err := dao.queryMatchingInquiries(query, inquiryID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(iqs) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return &iqs[0], nil
+}
+
+// GetDueMatchingInquiries retrieves inquiries still waiting to be picked up whose next wave is due.
+// Wave of an inquiry is derived from the time elapsed since the inquiry was emitted. Waves that
+// found no candidate are released, they are retried until the next wave is due.
+// Inquiries stay `inquiring` while the inquirer chooses among applicants, no more providers are
+// invited once anyone has applied.
+func (dao *MatchingDAO) GetDueMatchingInquiries(waveInterval time.Duration, maxWaves int) ([]models.MatchingInquiry, error) {
+	query := fmt.Sprintf(
+		matchingInquiryQuery,
+		`
+		si.inquiry_status = 'inquiring'
+		AND si.expired_at > NOW()
+		AND NOT EXISTS (
+			SELECT 1
+			FROM inquiry_applicants
+			WHERE
+				inquiry_applicants.inquiry_id = si.id
+				AND inquiry_applicants.status = 'applied'
+				AND inquiry_applicants.deleted_at IS NULL
+		)`,
+		`
+WHERE
+	last_wave < LEAST(FLOOR(EXTRACT(EPOCH FROM NOW() - created_at) / $1)::int, $2 - 1)
+ORDER BY id`,
+	)
+
+	return dao.queryMatchingInquiries(query, int64(waveInterval.Seconds()), maxWaves)
+}
+
+// GetMatchCandidates retrieves female users eligible for the inquiry, nearest first. Providers who
+// are suspended, block related with the inquirer, unreachable by FCM or have been invited to the
+// inquiry are excluded. Providers without published location are only matched with inquiries
+// without location.
+func (dao *MatchingDAO) GetMatchCandidates(p contracts.GetMatchCandidatesParams) ([]models.MatchCandidate, error) {
+	query := `
+WITH invitations AS (
+	SELECT
+		user_id,
+		COUNT(invited_at) AS number_of_invitations,
+		COUNT(responded_at) AS number_of_responses
+	FROM inquiry_matches
+	WHERE
+		invited_at >= NOW() - $9 * interval '1 second'
+		AND deleted_at IS NULL
+	GROUP BY user_id
+), ratings AS (
+	SELECT
+		ratee_id,
+		AVG(rating)::float8 AS score
+	FROM service_ratings
+	GROUP BY ratee_id
+)
+SELECT
+	*
+FROM (
+	SELECT
+		users.id,
+		users.uuid,
+		users.username,
+		users.fcm_topic,
+		users.locale,
+		CASE
+			WHEN $2::float8 IS NULL OR $3::float8 IS NULL THEN NULL
+			ELSE 6371 * 2 * asin(LEAST(1, sqrt(
+				power(sin(radians(user_locations.lat - $2) / 2), 2) +
+				cos(radians($2)) * cos(radians(user_locations.lat)) *
+				power(sin(radians(user_locations.lng - $3) / 2), 2)
+			)))
+		END AS distance_km,
+		(
+			NOT EXISTS (
+				SELECT 1
+				FROM availability_blackouts
+				WHERE
+					availability_blackouts.user_id = users.id
+					AND availability_blackouts.date = $5::timestamp::date
+					AND availability_blackouts.deleted_at IS NULL
+			)
+			-- Providers who have not declared any slot are considered available at any time.
+			AND (NOT EXISTS (
+				SELECT 1
+				FROM availability_slots
+				WHERE
+					availability_slots.user_id = users.id
+					AND availability_slots.deleted_at IS NULL
+			) OR EXISTS (
+				SELECT 1
+				FROM availability_slots
+				WHERE
+					availability_slots.user_id = users.id
+					AND availability_slots.weekday = EXTRACT(DOW FROM $5::timestamp)
+					AND availability_slots.start_time <= $5::timestamp::time
+					AND availability_slots.end_time > $5::timestamp::time
+					AND availability_slots.deleted_at IS NULL
+			))
+		) AS available,
+		(
+			$6::text IS NULL OR EXISTS (
+				SELECT 1
+				FROM user_service_options
+				INNER JOIN service_options ON service_options.id = user_service_options.service_option_id
+				WHERE
+					user_service_options.users_id = users.id
+					AND user_service_options.deleted_at IS NULL
+					AND service_options.deleted_at IS NULL
+					AND service_options.name = $6::text
+			)
+		) AS offers_service_type,
+		ratings.score AS rating,
+		COALESCE(invitations.number_of_invitations, 0) AS number_of_invitations,
+		COALESCE(invitations.number_of_responses, 0) AS number_of_responses
+	FROM users
+	LEFT JOIN user_locations
+		ON user_locations.user_id = users.id
+		AND user_locations.deleted_at IS NULL
+	LEFT JOIN ratings ON ratings.ratee_id = users.id
+	LEFT JOIN invitations ON invitations.user_id = users.id
+	WHERE
+		users.gender = $8
+		AND users.deleted_at IS NULL
+		AND users.fcm_topic IS NOT NULL
+		AND ($2::float8 IS NULL OR $3::float8 IS NULL OR (
+			user_locations.id IS NOT NULL
+			AND earth_box(ll_to_earth($2, $3), $4 * 1000) @> ll_to_earth(user_locations.lat, user_locations.lng)
+		))
+		-- Suspended providers are not matched until the suspension expires or is lifted.
+		AND NOT EXISTS (
+			SELECT 1
+			FROM user_suspensions
+			WHERE
+				user_suspensions.user_id = users.id
+				AND user_suspensions.lifted_at IS NULL
+				AND user_suspensions.deleted_at IS NULL
+				AND (user_suspensions.expires_at IS NULL OR user_suspensions.expires_at > NOW())
+		)
+		-- Providers who have blocked the inquirer or have been blocked by the inquirer.
+		AND NOT EXISTS (
+			SELECT 1
+			FROM block_list
+			WHERE
+				(
+					(block_list.user_id = $7 AND block_list.blocked_user_id = users.id)
+					OR (block_list.user_id = users.id AND block_list.blocked_user_id = $7)
+				)
+				AND block_list.deleted_at IS NULL
+		)
+		-- Providers invited in previous waves.
+		AND NOT EXISTS (
+			SELECT 1
+			FROM inquiry_matches
+			WHERE
+				inquiry_matches.inquiry_id = $1
+				AND inquiry_matches.user_id = users.id
+				AND inquiry_matches.invited_at IS NOT NULL
+				AND inquiry_matches.deleted_at IS NULL
+		)
+		-- Providers who have applied for the inquiry already.
+		AND NOT EXISTS (
+			SELECT 1
+			FROM inquiry_applicants
+			WHERE
+				inquiry_applicants.inquiry_id = $1
+				AND inquiry_applicants.applicant_id = users.id
+				AND inquiry_applicants.deleted_at IS NULL
+		)
+) AS candidates
+WHERE
+	distance_km IS NULL OR distance_km <= $4
+ORDER BY distance_km NULLS LAST, rating DESC NULLS LAST, id
+LIMIT $10;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		p.InquiryID,
+		p.Lat,
+		p.Lng,
+		p.RadiusKm,
+		p.AppointmentTime.Format(timestampLayout),
+		p.ExpectServiceType,
+		p.InquirerID,
+		models.GenderFemale,
+		int64(responsivenessWindow.Seconds()),
+		p.PoolSize,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	cands := make([]models.MatchCandidate, 0)
+
+	for rows.Next() {
+		var m models.MatchCandidate
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		cands = append(cands, m)
+	}
+
+	return cands, nil
+}
+
+// SaveMatches records scores of the wave. Providers scored in previous waves have their scores
+// replaced, time they were invited is kept.
+func (dao *MatchingDAO) SaveMatches(p contracts.SaveInquiryMatchesParams) error {
+	if len(p.Matches) == 0 {
+		return nil
+	}
+
+	var (
+		userIDs           = make([]int64, 0, len(p.Matches))
+		scores            = make([]float64, 0, len(p.Matches))
+		distances         = make([]sql.NullFloat64, 0, len(p.Matches))
+		availables        = make([]bool, 0, len(p.Matches))
+		offersServiceType = make([]bool, 0, len(p.Matches))
+		ratings           = make([]sql.NullFloat64, 0, len(p.Matches))
+		responsiveness    = make([]float64, 0, len(p.Matches))
+		inviteds          = make([]bool, 0, len(p.Matches))
+	)
+
+	for _, m := range p.Matches {
+		userIDs = append(userIDs, m.UserID)
+		scores = append(scores, m.Score)
+		distances = append(distances, m.DistanceKm)
+		availables = append(availables, m.Available)
+		offersServiceType = append(offersServiceType, m.OffersServiceType)
+		ratings = append(ratings, m.Rating)
+		responsiveness = append(responsiveness, m.Responsiveness)
+		inviteds = append(inviteds, m.Invited)
+	}
+
+	query := `
+INSERT INTO inquiry_matches (
+	inquiry_id,
+	user_id,
+	wave,
+	score,
+	distance_km,
+	available,
+	offers_service_type,
+	rating,
+	responsiveness,
+	invited_at
+)
+SELECT
+	$1,
+	m.user_id,
+	$2,
+	m.score,
+	m.distance_km,
+	m.available,
+	m.offers_service_type,
+	m.rating,
+	m.responsiveness,
+	CASE WHEN m.invited THEN NOW() END
+FROM unnest(
+	$3::int[],
+	$4::float8[],
+	$5::float8[],
+	$6::bool[],
+	$7::bool[],
+	$8::float8[],
+	$9::float8[],
+	$10::bool[]
+) AS m(
+	user_id,
+	score,
+	distance_km,
+	available,
+	offers_service_type,
+	rating,
+	responsiveness,
+	invited
+)
+ON CONFLICT (inquiry_id, user_id) DO UPDATE SET
+	wave = EXCLUDED.wave,
+	score = EXCLUDED.score,
+	distance_km = EXCLUDED.distance_km,
+	available = EXCLUDED.available,
+	offers_service_type = EXCLUDED.offers_service_type,
+	rating = EXCLUDED.rating,
+	responsiveness = EXCLUDED.responsiveness,
+	invited_at = COALESCE(inquiry_matches.invited_at, EXCLUDED.invited_at);
+`
+	_, err := dao.db.Exec(
+		query,
+		p.InquiryID,
+		p.Wave,
+		pq.Array(userIDs),
+		pq.Array(scores),
+		pq.Array(distances),
+		pq.Array(availables),
+		pq.Array(offersServiceType),
+		pq.Array(ratings),
+		pq.Array(responsiveness),
+		pq.Array(inviteds),
+	)
+
+	return err
+}
+
+// ClaimWave claims the wave of the inquiry before it is run. Returns false if the wave has been claimed,
+// e.g. the first wave run on emit races with the inquiry matcher worker. Claims made in concurrent
+// transactions wait for one another.
+func (dao *MatchingDAO) ClaimWave(inquiryID int64, wave int) (bool, error) {
+	query := `
+INSERT INTO inquiry_match_waves (
+	inquiry_id,
+	wave
+) VALUES ($1, $2)
+ON CONFLICT (inquiry_id, wave) DO NOTHING;
+`
+	res, err := dao.db.Exec(query, inquiryID, wave)
+
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// ReleaseWave releases the claim so that the wave is run again, e.g. no candidate has been found.
+func (dao *MatchingDAO) ReleaseWave(inquiryID int64, wave int) error {
+	query := `
+DELETE FROM inquiry_match_waves
+WHERE
+	inquiry_id = $1
+	AND wave = $2;
+`
+	_, err := dao.db.Exec(query, inquiryID, wave)
+
+	return err
+}
+
+// MarkResponded records the time the invited provider picked up the inquiry. Nothing is recorded
+// if the provider was not invited.
+func (dao *MatchingDAO) MarkResponded(inquiryID, userID int64) error {
+	query := `
+UPDATE inquiry_matches
+SET responded_at = NOW()
+WHERE
+	inquiry_id = $1
+	AND user_id = $2
+	AND invited_at IS NOT NULL
+	AND responded_at IS NULL
+	AND deleted_at IS NULL;
+`
+	_, err := dao.db.Exec(query, inquiryID, userID)
+
+	return err
+}
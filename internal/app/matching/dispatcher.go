@@ -0,0 +1,70 @@
+package matching
+
+import (
+	"context"
+	"sync"
+
+	"github.com/golobby/container/pkg/container"
+	log "github.com/sirupsen/logrus"
+)
+
+// Dispatcher runs first waves of emitted inquiries in the background so that the emit response is not
+// held on FCM. Waves still running on shutdown are waited for, and cancelled once the shutdown deadline
+// is exceeded.
+type Dispatcher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher() *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Dispatcher{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func DispatcherServiceProvider(c container.Container) func() error {
+	return func() error {
+		d := NewDispatcher()
+
+		c.Singleton(func() *Dispatcher {
+			return d
+		})
+
+		return nil
+	}
+}
+
+// MatchEmittedInquiry runs the first wave of the inquiry in the background. Failures are logged, the
+// wave is retried by the inquiry matcher worker.
+func (d *Dispatcher) MatchEmittedInquiry(depCon container.Container, inquiryID int64) {
+	d.wg.Add(1)
+
+	go func() {
+		defer d.wg.Done()
+
+		if err := MatchEmittedInquiry(d.ctx, depCon, inquiryID); err != nil {
+			log.Errorf("failed to match inquiry %d %s", inquiryID, err.Error())
+		}
+	}()
+}
+
+// Shutdown waits for waves in progress. Waves are cancelled if ctx is done before they finish.
+func (d *Dispatcher) Shutdown(ctx context.Context) {
+	done := make(chan struct{})
+
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		d.cancel()
+		<-done
+	}
+}
@@ -0,0 +1,289 @@
+// Package matching ranks female users for random inquiries and invites the best ranked ones via
+// FCM. Inquiries nobody picks up are matched again in waves, widening the search radius on each
+// wave. Scores of every wave are kept in `inquiry_matches` for later analysis.
+package matching
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	log "github.com/sirupsen/logrus"
+)
+
+// Weights of each feature in the score, should add up to 1.
+type Weights struct {
+	Distance       float64
+	Availability   float64
+	ServiceType    float64
+	Rating         float64
+	Responsiveness float64
+}
+
+var DefaultWeights = Weights{
+	Distance:       0.3,
+	Availability:   0.25,
+	ServiceType:    0.2,
+	Rating:         0.15,
+	Responsiveness: 0.1,
+}
+
+const (
+	maxRating = 5
+
+	// neutralScore score of features that can not be measured, e.g. distance of inquiries without
+	// location or rating of providers who have not been rated.
+	neutralScore = 0.5
+)
+
+// Features of the provider the score is calculated upon.
+type Features struct {
+	// DistanceKm is not valid if the inquiry has no location.
+	DistanceKm        sql.NullFloat64
+	RadiusKm          float64
+	Available         bool
+	OffersServiceType bool
+
+	// Rating is not valid if the provider has not been rated.
+	Rating         sql.NullFloat64
+	Responsiveness float64
+}
+
+// Responsiveness ratio of recent invitations the provider has responded to. The ratio is smoothed
+// so that providers who have never been invited are neither favoured nor penalised.
+func Responsiveness(invitations, responses int) float64 {
+	return float64(responses+1) / float64(invitations+2)
+}
+
+// Score weighs each feature normalised to [0, 1]. Providers nearer than others, available at the
+// appointment time, offering the expected service, higher rated and more responsive score higher.
+func Score(f Features, w Weights) float64 {
+	distance := neutralScore
+
+	if f.DistanceKm.Valid && f.RadiusKm > 0 {
+		distance = clamp(1 - f.DistanceKm.Float64/f.RadiusKm)
+	}
+
+	rating := neutralScore
+
+	if f.Rating.Valid {
+		rating = clamp(f.Rating.Float64 / maxRating)
+	}
+
+	return w.Distance*distance +
+		w.Availability*boolScore(f.Available) +
+		w.ServiceType*boolScore(f.OffersServiceType) +
+		w.Rating*rating +
+		w.Responsiveness*clamp(f.Responsiveness)
+}
+
+// Ranked candidate along with the score.
+type Ranked struct {
+	Candidate models.MatchCandidate
+	Features  Features
+	Score     float64
+}
+
+// Rank scores candidates searched within the radius, highest score first. Ties are broken by ID
+// so that ranking is deterministic.
+func Rank(cands []models.MatchCandidate, radiusKm float64, w Weights) []Ranked {
+	ranked := make([]Ranked, 0, len(cands))
+
+	for _, cand := range cands {
+		f := Features{
+			DistanceKm:        cand.DistanceKm,
+			RadiusKm:          radiusKm,
+			Available:         cand.Available,
+			OffersServiceType: cand.OffersServiceType,
+			Rating:            cand.Rating,
+			Responsiveness:    Responsiveness(cand.NumberOfInvitations, cand.NumberOfResponses),
+		}
+
+		ranked = append(ranked, Ranked{
+			Candidate: cand,
+			Features:  f,
+			Score:     Score(f, w),
+		})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+
+		return ranked[i].Candidate.ID < ranked[j].Candidate.ID
+	})
+
+	return ranked
+}
+
+// RadiusOfWave search radius doubles on each wave.
+func RadiusOfWave(conf config.MatchingConf, wave int) float64 {
+	return conf.BaseRadiusKm * math.Pow(2, float64(wave))
+}
+
+// WaveOf derives the wave the inquiry should be matched in from the time elapsed since emitted.
+func WaveOf(conf config.MatchingConf, createdAt, now time.Time) int {
+	wave := int(now.Sub(createdAt) / conf.WaveInterval)
+
+	if wave < 0 {
+		return 0
+	}
+
+	if wave >= conf.MaxWaves {
+		return conf.MaxWaves - 1
+	}
+
+	return wave
+}
+
+type Engine struct {
+	dao     contracts.MatchingDAOer
+	fcm     dpfcm.DPFirebaseMessenger
+	conf    config.MatchingConf
+	weights Weights
+}
+
+func NewEngine(dao contracts.MatchingDAOer, fcm dpfcm.DPFirebaseMessenger, conf config.MatchingConf) *Engine {
+	return &Engine{
+		dao:     dao,
+		fcm:     fcm,
+		conf:    conf,
+		weights: DefaultWeights,
+	}
+}
+
+// RunWave scores candidates within the radius of the wave and invites the top ranked ones. The wave is
+// claimed first so that it is run once even if the emit handler and the inquiry matcher worker race on
+// the first wave. The claim is released if the wave fails or finds no candidate so that it is retried.
+// Scores are recorded before invitations are sent, failing invitations are logged rather than retried.
+// Returns the number of providers invited.
+func (e *Engine) RunWave(ctx context.Context, iq models.MatchingInquiry, wave int) (int, error) {
+	claimed, err := e.dao.ClaimWave(iq.ID, wave)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !claimed {
+		return 0, nil
+	}
+
+	ranked, err := e.scoreWave(iq, wave)
+
+	if err != nil || len(ranked) == 0 {
+		if err := e.dao.ReleaseWave(iq.ID, wave); err != nil {
+			log.Errorf("failed to release wave %d of inquiry %s %s", wave, iq.Uuid, err.Error())
+		}
+
+		return 0, err
+	}
+
+	invited := 0
+
+	for idx, r := range ranked {
+		if idx >= e.conf.TopN {
+			break
+		}
+
+		invited++
+
+		if err := e.fcm.PublishRandomInquiryInvitation(ctx, dpfcm.RandomInquiryInvitationMessage{
+			Topic:            r.Candidate.FcmTopic.String,
+			Locale:           r.Candidate.Locale,
+			InquiryUUID:      iq.Uuid,
+			InquirerUsername: iq.InquirerUsername,
+		}); err != nil {
+			log.Errorf("failed to invite %s to inquiry %s %s", r.Candidate.Uuid, iq.Uuid, err.Error())
+		}
+	}
+
+	return invited, nil
+}
+
+// scoreWave ranks candidates within the radius of the wave and records the scores.
+func (e *Engine) scoreWave(iq models.MatchingInquiry, wave int) ([]Ranked, error) {
+	radiusKm := RadiusOfWave(e.conf, wave)
+
+	cands, err := e.dao.GetMatchCandidates(contracts.GetMatchCandidatesParams{
+		InquiryID:         iq.ID,
+		InquirerID:        iq.InquirerID,
+		Lat:               iq.Lat,
+		Lng:               iq.Lng,
+		RadiusKm:          radiusKm,
+		AppointmentTime:   iq.AppointmentTime.In(config.GetAppConf().GetAppLocation()),
+		ExpectServiceType: iq.ExpectServiceType,
+		PoolSize:          e.conf.CandidatePoolSize,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := Rank(cands, radiusKm, e.weights)
+	matches := make([]contracts.SaveInquiryMatchParams, 0, len(ranked))
+
+	for idx, r := range ranked {
+		matches = append(matches, contracts.SaveInquiryMatchParams{
+			UserID:            r.Candidate.ID,
+			Score:             r.Score,
+			DistanceKm:        r.Features.DistanceKm,
+			Available:         r.Features.Available,
+			OffersServiceType: r.Features.OffersServiceType,
+			Rating:            r.Features.Rating,
+			Responsiveness:    r.Features.Responsiveness,
+			Invited:           idx < e.conf.TopN,
+		})
+	}
+
+	if err := e.dao.SaveMatches(contracts.SaveInquiryMatchesParams{
+		InquiryID: iq.ID,
+		Wave:      wave,
+		Matches:   matches,
+	}); err != nil {
+		return nil, err
+	}
+
+	return ranked, nil
+}
+
+func boolScore(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func clamp(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
+
+// MatchEmittedInquiry runs the first wave of the inquiry right after emitted. Later waves are run by
+// the inquiry matcher worker.
+func MatchEmittedInquiry(ctx context.Context, depCon container.Container, inquiryID int64) error {
+	var (
+		dao contracts.MatchingDAOer
+		fcm dpfcm.DPFirebaseMessenger
+	)
+
+	depCon.Make(&dao)
+	depCon.Make(&fcm)
+
+	iq, err := dao.GetMatchingInquiry(inquiryID)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = NewEngine(dao, fcm, config.GetAppConf().GetMatchingConf()).RunWave(ctx, *iq, 0)
+
+	return err
+}
@@ -0,0 +1,190 @@
+package matchingtests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/matching"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeMatchingDAO keeps claimed waves in memory. Methods not overridden are not used by `RunWave`.
+type fakeMatchingDAO struct {
+	contracts.MatchingDAOer
+
+	cands  []models.MatchCandidate
+	claims map[int]bool
+}
+
+func (dao *fakeMatchingDAO) ClaimWave(inquiryID int64, wave int) (bool, error) {
+	if dao.claims[wave] {
+		return false, nil
+	}
+
+	dao.claims[wave] = true
+
+	return true, nil
+}
+
+func (dao *fakeMatchingDAO) ReleaseWave(inquiryID int64, wave int) error {
+	delete(dao.claims, wave)
+
+	return nil
+}
+
+func (dao *fakeMatchingDAO) GetMatchCandidates(p contracts.GetMatchCandidatesParams) ([]models.MatchCandidate, error) {
+	return dao.cands, nil
+}
+
+func (dao *fakeMatchingDAO) SaveMatches(p contracts.SaveInquiryMatchesParams) error {
+	return nil
+}
+
+// fakeMessenger counts invitations sent.
+type fakeMessenger struct {
+	dpfcm.DPFirebaseMessenger
+
+	invitations int
+}
+
+func (fm *fakeMessenger) PublishRandomInquiryInvitation(ctx context.Context, m dpfcm.RandomInquiryInvitationMessage) error {
+	fm.invitations++
+
+	return nil
+}
+
+type MatchingTestSuite struct {
+	suite.Suite
+	conf config.MatchingConf
+}
+
+func (suite *MatchingTestSuite) SetupSuite() {
+	suite.conf = config.MatchingConf{
+		TopN:         5,
+		MaxWaves:     3,
+		WaveInterval: 5 * time.Minute,
+		BaseRadiusKm: 5,
+	}
+}
+
+func (suite *MatchingTestSuite) TestNearerProviderRanksHigher() {
+	cands := []models.MatchCandidate{
+		{
+			ID:                1,
+			DistanceKm:        sql.NullFloat64{Valid: true, Float64: 4},
+			Available:         true,
+			OffersServiceType: true,
+		},
+		{
+			ID:                2,
+			DistanceKm:        sql.NullFloat64{Valid: true, Float64: 1},
+			Available:         true,
+			OffersServiceType: true,
+		},
+	}
+
+	ranked := matching.Rank(cands, 5, matching.DefaultWeights)
+
+	assert.Equal(suite.T(), int64(2), ranked[0].Candidate.ID)
+	assert.Greater(suite.T(), ranked[0].Score, ranked[1].Score)
+}
+
+func (suite *MatchingTestSuite) TestUnavailableProviderRanksLower() {
+	cands := []models.MatchCandidate{
+		{
+			ID:                1,
+			DistanceKm:        sql.NullFloat64{Valid: true, Float64: 1},
+			Available:         false,
+			OffersServiceType: true,
+			Rating:            sql.NullFloat64{Valid: true, Float64: 5},
+		},
+		{
+			ID:                2,
+			DistanceKm:        sql.NullFloat64{Valid: true, Float64: 3},
+			Available:         true,
+			OffersServiceType: true,
+		},
+	}
+
+	ranked := matching.Rank(cands, 5, matching.DefaultWeights)
+
+	assert.Equal(suite.T(), int64(2), ranked[0].Candidate.ID)
+}
+
+func (suite *MatchingTestSuite) TestTiesAreBrokenByID() {
+	cands := []models.MatchCandidate{{ID: 3}, {ID: 1}, {ID: 2}}
+
+	ranked := matching.Rank(cands, 5, matching.DefaultWeights)
+
+	assert.Equal(suite.T(), int64(1), ranked[0].Candidate.ID)
+	assert.Equal(suite.T(), int64(2), ranked[1].Candidate.ID)
+	assert.Equal(suite.T(), int64(3), ranked[2].Candidate.ID)
+}
+
+func (suite *MatchingTestSuite) TestResponsivenessIsNeutralWithoutInvitations() {
+	assert.Equal(suite.T(), 0.5, matching.Responsiveness(0, 0))
+	assert.Greater(suite.T(), matching.Responsiveness(4, 4), matching.Responsiveness(4, 0))
+}
+
+func (suite *MatchingTestSuite) TestRadiusWidensOnEachWave() {
+	assert.Equal(suite.T(), float64(5), matching.RadiusOfWave(suite.conf, 0))
+	assert.Equal(suite.T(), float64(20), matching.RadiusOfWave(suite.conf, 2))
+}
+
+func (suite *MatchingTestSuite) TestWaveIsCappedByMaxWaves() {
+	createdAt := time.Now()
+
+	assert.Equal(suite.T(), 0, matching.WaveOf(suite.conf, createdAt, createdAt.Add(time.Minute)))
+	assert.Equal(suite.T(), 1, matching.WaveOf(suite.conf, createdAt, createdAt.Add(6*time.Minute)))
+	assert.Equal(suite.T(), 2, matching.WaveOf(suite.conf, createdAt, createdAt.Add(time.Hour)))
+}
+
+func (suite *MatchingTestSuite) TestWaveIsRunOnce() {
+	dao := &fakeMatchingDAO{
+		cands:  []models.MatchCandidate{{ID: 1}, {ID: 2}},
+		claims: make(map[int]bool),
+	}
+	fm := &fakeMessenger{}
+	engine := matching.NewEngine(dao, fm, suite.conf)
+	iq := models.MatchingInquiry{ID: 1}
+
+	invited, err := engine.RunWave(context.Background(), iq, 0)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), 2, invited)
+
+	// e.g. the inquiry matcher worker runs the first wave right after the inquiry is emitted.
+	invited, err = engine.RunWave(context.Background(), iq, 0)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), 0, invited)
+	assert.Equal(suite.T(), 2, fm.invitations)
+}
+
+func (suite *MatchingTestSuite) TestWaveWithoutCandidatesIsReleased() {
+	dao := &fakeMatchingDAO{claims: make(map[int]bool)}
+	engine := matching.NewEngine(dao, &fakeMessenger{}, suite.conf)
+
+	if _, err := engine.RunWave(context.Background(), models.MatchingInquiry{ID: 1}, 0); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Empty(suite.T(), dao.claims)
+}
+
+func TestMatchingTestSuite(t *testing.T) {
+	suite.Run(t, new(MatchingTestSuite))
+}
@@ -5,10 +5,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/premium"
 )
 
 type UserDaoer interface {
@@ -126,3 +128,107 @@ func IsFemale(userDao contracts.UserDAOer) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// RequirePremium gates features that are only available to paid members.
+func RequirePremium(userDao contracts.UserDAOer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user, err := userDao.GetUserByUuid(
+			c.GetString("uuid"),
+			"premium_type",
+			"premium_expiry_date",
+		)
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToCheckPremium,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		if !premium.IsPremium(user, time.Now()) {
+			c.AbortWithError(
+				http.StatusForbidden,
+				apperr.NewErr(apperr.PremiumRequired),
+			)
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RejectRestricted blocks users restricted by moderation from reaching out to others.
+func RejectRestricted(restrictionDao contracts.UserRestrictionDAOer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		restricted, err := restrictionDao.IsRestrictedByUuid(c.GetString("uuid"))
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToCheckUserRestriction,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		if restricted {
+			c.AbortWithError(
+				http.StatusForbidden,
+				apperr.NewErr(apperr.UserRestricted),
+			)
+
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RejectBlockedUser hides the user identified by `:uuid` from the requester if either of them has
+// blocked the other one. The user is reported as not found so that the block is not revealed.
+func RejectBlockedUser(blockDao contracts.BlockDAOer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		myUuid := c.GetString("uuid")
+		targetUuid := c.Param("uuid")
+
+		if targetUuid == "me" || targetUuid == myUuid {
+			c.Next()
+
+			return
+		}
+
+		blocked, err := blockDao.IsBlockedBetweenByUuid(myUuid, targetUuid)
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToCheckHasBlockedUser,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		if blocked {
+			c.AbortWithError(
+				http.StatusNotFound,
+				apperr.NewErr(apperr.UserUnavailable),
+			)
+
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
@@ -86,8 +87,10 @@ type CancelUnpaidServices struct {
 	Service
 	CustomerFCMTopic        *string `json:"customer_fcm_topic"`
 	CustomerName            string  `json:"customer_name"`
+	CustomerLocale          string  `json:"customer_locale"`
 	ServiceProviderFCMTopic *string `json:"service_provider_fcm_topic"`
 	ServiceProviderName     string  `json:"service_provider_name"`
+	ServiceProviderLocale   string  `json:"service_provider_locale"`
 }
 
 type UserRating struct {
@@ -121,6 +124,9 @@ type RandomGirl struct {
 	ServiceUUID       *string `json:"service_uuid"`
 	ServiceStatus     *string `json:"service_status"`
 
+	// Verified indicates whether the girl has passed identity verification.
+	Verified bool `json:"verified"`
+
 	Rating UserRating
 }
 
@@ -148,6 +154,188 @@ type ServiceScannerData struct {
 	UUID                     string `json:"uuid"`
 	CustomerUsername         string `json:"customer_username"`
 	CustomerFCMTopic         string `json:"customer_fcm_topic"`
+	CustomerLocale           string `json:"customer_locale"`
 	ServiceProviderUsername  string `json:"service_providers_username"`
 	ServiceProvidersFCMTopic string `json:"service_providers_fcm_topic"`
+	ServiceProvidersLocale   string `json:"service_providers_locale"`
+}
+
+// UserDataExport records of the user encoded in JSON. Used to export personal data on user request.
+type UserDataExport struct {
+	Inquiries     json.RawMessage `json:"inquiries"`
+	Services      json.RawMessage `json:"services"`
+	Payments      json.RawMessage `json:"payments"`
+	Ratings       json.RawMessage `json:"ratings"`
+	Verifications json.RawMessage `json:"verifications"`
+}
+
+// ReferralRewardHistory referral reward credited to the recipient along with the rule and the invitee.
+type ReferralRewardHistory struct {
+	ReferralReward
+	RuleName        string                  `json:"rule_name"`
+	Event           ReferralRewardEvent     `json:"event"`
+	Recipient       ReferralRewardRecipient `json:"recipient"`
+	InviteeUuid     string                  `json:"invitee_uuid"`
+	InviteeUsername string                  `json:"invitee_username"`
+}
+
+// AgencyMemberSummary completed services and matching fees of an agency member within the settlement period.
+type AgencyMemberSummary struct {
+	MemberUuid        string         `json:"member_uuid"`
+	MemberUsername    string         `json:"member_username"`
+	MemberAvatarUrl   sql.NullString `json:"member_avatar_url"`
+	JoinedAt          time.Time      `json:"joined_at"`
+	CompletedServices int            `json:"completed_services"`
+	MatchingFeeTotal  string         `json:"matching_fee_total"`
+}
+
+// AgencyMemberService completed service provided by an agency member.
+type AgencyMemberService struct {
+	Uuid             string         `json:"uuid"`
+	CustomerUsername string         `json:"customer_username"`
+	Price            sql.NullString `json:"price"`
+	MatchingFee      sql.NullString `json:"matching_fee"`
+	StartTime        sql.NullTime   `json:"start_time"`
+	EndTime          sql.NullTime   `json:"end_time"`
+}
+
+type NearbyGirl struct {
+	User
+
+	// DistanceKm haversine distance between the girl's published location and the searching location.
+	DistanceKm float64 `json:"distance_km"`
+}
+
+type ProfileViewer struct {
+	Uuid      string         `json:"uuid"`
+	Username  string         `json:"username"`
+	AvatarUrl sql.NullString `json:"avatar_url"`
+
+	// ViewedAt latest time the viewer viewed the profile.
+	ViewedAt time.Time `json:"viewed_at"`
+}
+
+type PremiumPurchaseHistory struct {
+	PremiumPurchase
+	PlanName string `json:"plan_name"`
+}
+
+type PremiumExpiringMember struct {
+	Uuid      string         `json:"uuid"`
+	Username  string         `json:"username"`
+	FcmTopic  sql.NullString `json:"fcm_topic"`
+	Locale    string         `json:"locale"`
+	ExpiresAt time.Time      `json:"expires_at"`
+}
+
+type IdentityVerificationDetail struct {
+	IdentityVerification
+
+	UserUuid string `json:"user_uuid"`
+	Username string `json:"username"`
+}
+
+type ReportDetail struct {
+	Report
+
+	ReporterUuid     string         `json:"reporter_uuid"`
+	ReporterUsername string         `json:"reporter_username"`
+	ReportedUserUuid string         `json:"reported_user_uuid"`
+	ReportedUsername string         `json:"reported_username"`
+	AssigneeUuid     sql.NullString `json:"assignee_uuid"`
+}
+
+type ReportEventDetail struct {
+	ReportEvent
+
+	ActorUuid sql.NullString `json:"actor_uuid"`
+}
+
+type ActiveUserSuspension struct {
+	UserSuspension
+
+	UserUuid string `json:"user_uuid"`
+}
+
+// MatchingInquiry random inquiry being matched with providers. Coordinates are not valid if the
+// inquirer has not specified the location of the appointment.
+type MatchingInquiry struct {
+	ID                int64           `json:"id"`
+	Uuid              string          `json:"uuid"`
+	InquirerID        int64           `json:"inquirer_id"`
+	InquirerUsername  string          `json:"inquirer_username"`
+	ExpectServiceType sql.NullString  `json:"expect_service_type"`
+	AppointmentTime   time.Time       `json:"appointment_time"`
+	Lat               sql.NullFloat64 `json:"lat"`
+	Lng               sql.NullFloat64 `json:"lng"`
+	CreatedAt         time.Time       `json:"created_at"`
+
+	// LastWave latest wave the inquiry has been matched in, -1 if never matched.
+	LastWave int `json:"last_wave"`
+}
+
+// MatchCandidate provider eligible for a random inquiry along with the features the provider is scored by.
+type MatchCandidate struct {
+	ID       int64          `json:"id"`
+	Uuid     string         `json:"uuid"`
+	Username string         `json:"username"`
+	FcmTopic sql.NullString `json:"fcm_topic"`
+	Locale   string         `json:"locale"`
+
+	DistanceKm        sql.NullFloat64 `json:"distance_km"`
+	Available         bool            `json:"available"`
+	OffersServiceType bool            `json:"offers_service_type"`
+	Rating            sql.NullFloat64 `json:"rating"`
+
+	// Number of recent invitations the provider has received and responded to.
+	NumberOfInvitations int `json:"number_of_invitations"`
+	NumberOfResponses   int `json:"number_of_responses"`
+}
+
+// InquiryApplicantProfile profile summary of the provider who applied for a random inquiry. Inquirers
+// choose one of the applicants to chat with upon these summaries.
+type InquiryApplicantProfile struct {
+	Uuid        string         `json:"uuid"`
+	Username    string         `json:"username"`
+	AvatarUrl   sql.NullString `json:"avatar_url"`
+	Age         sql.NullInt32  `json:"age"`
+	Height      sql.NullString `json:"height"`
+	Weight      sql.NullString `json:"weight"`
+	BreastSize  sql.NullString `json:"breast_size"`
+	Description sql.NullString `json:"description"`
+	Verified    bool           `json:"verified"`
+
+	// Rating is not valid if the applicant has not been rated.
+	Rating           sql.NullFloat64 `json:"rating"`
+	NumberOfServices int             `json:"number_of_services"`
+
+	// MatchScore score given by the matching engine, not valid if the applicant was not ranked.
+	MatchScore sql.NullFloat64        `json:"match_score"`
+	Status     InquiryApplicantStatus `json:"status"`
+	AppliedAt  time.Time              `json:"applied_at"`
+}
+
+// DeclinedApplication application declined without the inquirer choosing among applicants, e.g. the
+// applicant is suspended or the inquiry has been cancelled or expired.
+type DeclinedApplication struct {
+	InquiryUuid   string         `json:"inquiry_uuid"`
+	ApplicantUuid string         `json:"applicant_uuid"`
+	FcmTopic      sql.NullString `json:"fcm_topic"`
+	Locale        string         `json:"locale"`
+}
+
+// DeclinedApplicant applicant to be notified that the inquirer has declined the application.
+type DeclinedApplicant struct {
+	ID       int64          `json:"id"`
+	Uuid     string         `json:"uuid"`
+	FcmTopic sql.NullString `json:"fcm_topic"`
+	Locale   string         `json:"locale"`
+}
+
+// StatusTransitionWithActor status transition along with the user who made it. Actor is not valid if
+// the transition is made by the system.
+type StatusTransitionWithActor struct {
+	StatusTransition
+	ActorUuid     sql.NullString `json:"actor_uuid"`
+	ActorUsername sql.NullString `json:"actor_username"`
 }
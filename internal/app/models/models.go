@@ -69,6 +69,46 @@ func (e *Gender) Scan(src interface{}) error {
 	return nil
 }
 
+type IdentityVerificationStatus string
+
+const (
+	IdentityVerificationStatusPending  IdentityVerificationStatus = "pending"
+	IdentityVerificationStatusApproved IdentityVerificationStatus = "approved"
+	IdentityVerificationStatusRejected IdentityVerificationStatus = "rejected"
+)
+
+func (e *IdentityVerificationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = IdentityVerificationStatus(s)
+	case string:
+		*e = IdentityVerificationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for IdentityVerificationStatus: %T", src)
+	}
+	return nil
+}
+
+type InquiryApplicantStatus string
+
+const (
+	InquiryApplicantStatusApplied  InquiryApplicantStatus = "applied"
+	InquiryApplicantStatusAccepted InquiryApplicantStatus = "accepted"
+	InquiryApplicantStatusDeclined InquiryApplicantStatus = "declined"
+)
+
+func (e *InquiryApplicantStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = InquiryApplicantStatus(s)
+	case string:
+		*e = InquiryApplicantStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for InquiryApplicantStatus: %T", src)
+	}
+	return nil
+}
+
 type InquiryStatus string
 
 const (
@@ -112,6 +152,26 @@ func (e *InquiryType) Scan(src interface{}) error {
 	return nil
 }
 
+type JwtKeyStatus string
+
+const (
+	JwtKeyStatusStandby JwtKeyStatus = "standby"
+	JwtKeyStatusActive  JwtKeyStatus = "active"
+	JwtKeyStatusRetired JwtKeyStatus = "retired"
+)
+
+func (e *JwtKeyStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = JwtKeyStatus(s)
+	case string:
+		*e = JwtKeyStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for JwtKeyStatus: %T", src)
+	}
+	return nil
+}
+
 type LobbyStatus string
 
 const (
@@ -134,6 +194,25 @@ func (e *LobbyStatus) Scan(src interface{}) error {
 	return nil
 }
 
+type LocationPrecision string
+
+const (
+	LocationPrecisionCity     LocationPrecision = "city"
+	LocationPrecisionDistrict LocationPrecision = "district"
+)
+
+func (e *LocationPrecision) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = LocationPrecision(s)
+	case string:
+		*e = LocationPrecision(s)
+	default:
+		return fmt.Errorf("unsupported scan type for LocationPrecision: %T", src)
+	}
+	return nil
+}
+
 type OrderStatus string
 
 const (
@@ -193,6 +272,110 @@ func (e *RefCodeType) Scan(src interface{}) error {
 	return nil
 }
 
+type ReferralRewardEvent string
+
+const (
+	ReferralRewardEventPhoneVerified         ReferralRewardEvent = "phone_verified"
+	ReferralRewardEventFirstServiceCompleted ReferralRewardEvent = "first_service_completed"
+)
+
+func (e *ReferralRewardEvent) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReferralRewardEvent(s)
+	case string:
+		*e = ReferralRewardEvent(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReferralRewardEvent: %T", src)
+	}
+	return nil
+}
+
+type ReferralRewardRecipient string
+
+const (
+	ReferralRewardRecipientInvitor ReferralRewardRecipient = "invitor"
+	ReferralRewardRecipientInvitee ReferralRewardRecipient = "invitee"
+)
+
+func (e *ReferralRewardRecipient) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReferralRewardRecipient(s)
+	case string:
+		*e = ReferralRewardRecipient(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReferralRewardRecipient: %T", src)
+	}
+	return nil
+}
+
+type ReportCategory string
+
+const (
+	ReportCategoryHarassment           ReportCategory = "harassment"
+	ReportCategorySpam                 ReportCategory = "spam"
+	ReportCategoryFakeProfile          ReportCategory = "fake_profile"
+	ReportCategoryInappropriateContent ReportCategory = "inappropriate_content"
+	ReportCategoryScam                 ReportCategory = "scam"
+	ReportCategoryUnderage             ReportCategory = "underage"
+	ReportCategoryOther                ReportCategory = "other"
+)
+
+func (e *ReportCategory) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReportCategory(s)
+	case string:
+		*e = ReportCategory(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReportCategory: %T", src)
+	}
+	return nil
+}
+
+type ReportStatus string
+
+const (
+	ReportStatusOpen      ReportStatus = "open"
+	ReportStatusInReview  ReportStatus = "in_review"
+	ReportStatusResolved  ReportStatus = "resolved"
+	ReportStatusDismissed ReportStatus = "dismissed"
+)
+
+func (e *ReportStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReportStatus(s)
+	case string:
+		*e = ReportStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReportStatus: %T", src)
+	}
+	return nil
+}
+
+type ReportTargetType string
+
+const (
+	ReportTargetTypeProfile     ReportTargetType = "profile"
+	ReportTargetTypeChatMessage ReportTargetType = "chat_message"
+	ReportTargetTypeImage       ReportTargetType = "image"
+	ReportTargetTypeService     ReportTargetType = "service"
+)
+
+func (e *ReportTargetType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ReportTargetType(s)
+	case string:
+		*e = ReportTargetType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ReportTargetType: %T", src)
+	}
+	return nil
+}
+
 type ServiceOptionsType string
 
 const (
@@ -259,6 +442,64 @@ func (e *ServiceType) Scan(src interface{}) error {
 	return nil
 }
 
+type TransitionEntityType string
+
+const (
+	TransitionEntityTypeInquiry TransitionEntityType = "inquiry"
+	TransitionEntityTypeService TransitionEntityType = "service"
+)
+
+func (e *TransitionEntityType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = TransitionEntityType(s)
+	case string:
+		*e = TransitionEntityType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for TransitionEntityType: %T", src)
+	}
+	return nil
+}
+
+type UserRoleType string
+
+const (
+	UserRoleTypeAdmin   UserRoleType = "admin"
+	UserRoleTypeSupport UserRoleType = "support"
+	UserRoleTypeFinance UserRoleType = "finance"
+)
+
+func (e *UserRoleType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserRoleType(s)
+	case string:
+		*e = UserRoleType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserRoleType: %T", src)
+	}
+	return nil
+}
+
+type UserSuspensionType string
+
+const (
+	UserSuspensionTypeSuspend UserSuspensionType = "suspend"
+	UserSuspensionTypeBan     UserSuspensionType = "ban"
+)
+
+func (e *UserSuspensionType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = UserSuspensionType(s)
+	case string:
+		*e = UserSuspensionType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for UserSuspensionType: %T", src)
+	}
+	return nil
+}
+
 type VerifyStatus string
 
 const (
@@ -280,6 +521,52 @@ func (e *VerifyStatus) Scan(src interface{}) error {
 	return nil
 }
 
+type Agency struct {
+	ID int32 `json:"id"`
+	// user that owns the manager referral codes of the agency
+	ManagerID int32  `json:"manager_id"`
+	Name      string `json:"name"`
+	// share of matching fees of member services paid to the agency, 0.3 means 30%
+	CommissionRate string       `json:"commission_rate"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+}
+
+type AgencyMember struct {
+	ID       int32 `json:"id"`
+	AgencyID int32 `json:"agency_id"`
+	MemberID int32 `json:"member_id"`
+	// manager referral code the member registered with
+	RefcodeID int32        `json:"refcode_id"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type AvailabilityBlackout struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	Date      time.Time    `json:"date"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type AvailabilitySlot struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+	// 0 is sunday, same as golang time.Weekday
+	Weekday int16 `json:"weekday"`
+	// time of day in APP_TIME_ZONE
+	StartTime time.Time `json:"start_time"`
+	// time of day in APP_TIME_ZONE, slots crossing midnight should be split into two
+	EndTime   time.Time    `json:"end_time"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
 type BankAccount struct {
 	ID            int32        `json:"id"`
 	UserID        int32        `json:"user_id"`
@@ -345,6 +632,26 @@ type CoinPackage struct {
 	Name     sql.NullString `json:"name"`
 }
 
+type IdentityVerification struct {
+	ID     int32  `json:"id"`
+	Uuid   string `json:"uuid"`
+	UserID int32  `json:"user_id"`
+	// code the user holds in the selfie
+	Code string `json:"code"`
+	// name of the private GCS object
+	SelfieObject string `json:"selfie_object"`
+	// name of the private GCS object
+	IDPhotoObject string                     `json:"id_photo_object"`
+	Status        IdentityVerificationStatus `json:"status"`
+	// reason of rejection given by the reviewer
+	Reason     sql.NullString `json:"reason"`
+	ReviewerID sql.NullInt32  `json:"reviewer_id"`
+	ReviewedAt sql.NullTime   `json:"reviewed_at"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  sql.NullTime   `json:"updated_at"`
+	DeletedAt  sql.NullTime   `json:"deleted_at"`
+}
+
 type Image struct {
 	ID        int64        `json:"id"`
 	UserID    int32        `json:"user_id"`
@@ -354,6 +661,53 @@ type Image struct {
 	DeletedAt sql.NullTime `json:"deleted_at"`
 }
 
+type InquiryApplicant struct {
+	ID          int32 `json:"id"`
+	InquiryID   int32 `json:"inquiry_id"`
+	ApplicantID int32 `json:"applicant_id"`
+	// applicants other than the accepted one are declined once the inquirer agrees to chat
+	Status    InquiryApplicantStatus `json:"status"`
+	CreatedAt time.Time              `json:"created_at"`
+	UpdatedAt sql.NullTime           `json:"updated_at"`
+	DeletedAt sql.NullTime           `json:"deleted_at"`
+}
+
+type InquiryMatch struct {
+	ID        int32 `json:"id"`
+	InquiryID int32 `json:"inquiry_id"`
+	UserID    int32 `json:"user_id"`
+	// wave of the latest scoring, search radius widens on each wave
+	Wave  int32   `json:"wave"`
+	Score float64 `json:"score"`
+	// NULL if the inquiry has no location
+	DistanceKm        sql.NullFloat64 `json:"distance_km"`
+	Available         bool            `json:"available"`
+	OffersServiceType bool            `json:"offers_service_type"`
+	// NULL if the provider has not been rated
+	Rating         sql.NullFloat64 `json:"rating"`
+	Responsiveness float64         `json:"responsiveness"`
+	// NULL if the provider was not ranked high enough to be invited
+	InvitedAt sql.NullTime `json:"invited_at"`
+	// time the invited provider picked up the inquiry
+	RespondedAt sql.NullTime `json:"responded_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
+type JwtSigningKey struct {
+	ID int32 `json:"id"`
+	// key id carried in the "kid" header of jwt signed by this key
+	Kid         string       `json:"kid"`
+	Secret      string       `json:"secret"`
+	Status      JwtKeyStatus `json:"status"`
+	ActivatedAt sql.NullTime `json:"activated_at"`
+	RetiredAt   sql.NullTime `json:"retired_at"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+	DeletedAt   sql.NullTime `json:"deleted_at"`
+}
+
 type Payment struct {
 	ID        int64        `json:"id"`
 	PayerID   int32        `json:"payer_id"`
@@ -365,6 +719,110 @@ type Payment struct {
 	Refunded  sql.NullBool `json:"refunded"`
 }
 
+type PremiumPlan struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	DurationDays int32  `json:"duration_days"`
+	// number of coins deducted from user balance
+	Cost      string       `json:"cost"`
+	Enabled   bool         `json:"enabled"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type PremiumPurchase struct {
+	ID     int32  `json:"id"`
+	Uuid   string `json:"uuid"`
+	UserID int32  `json:"user_id"`
+	PlanID int32  `json:"plan_id"`
+	Cost   string `json:"cost"`
+	// renewals purchased before expiry start from the previous expiry
+	StartsAt  time.Time `json:"starts_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// time the member is reminded that the membership is about to expire
+	ExpiryRemindedAt sql.NullTime `json:"expiry_reminded_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+	UpdatedAt        sql.NullTime `json:"updated_at"`
+	DeletedAt        sql.NullTime `json:"deleted_at"`
+}
+
+type ProfileView struct {
+	ID       int32 `json:"id"`
+	ViewerID int32 `json:"viewer_id"`
+	VieweeID int32 `json:"viewee_id"`
+	// date in app time zone, views are deduplicated per viewer per day
+	ViewedOn time.Time `json:"viewed_on"`
+	// latest time the viewer viewed the profile on the date
+	ViewedAt  time.Time    `json:"viewed_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type ReferralReward struct {
+	ID          int64 `json:"id"`
+	RuleID      int32 `json:"rule_id"`
+	RefcodeID   int32 `json:"refcode_id"`
+	InviteeID   int32 `json:"invitee_id"`
+	RecipientID int32 `json:"recipient_id"`
+	// number of coins credited, copied from the rule at the time of crediting
+	Amount    string       `json:"amount"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type ReferralRewardRule struct {
+	ID        int32                   `json:"id"`
+	Name      string                  `json:"name"`
+	Event     ReferralRewardEvent     `json:"event"`
+	Recipient ReferralRewardRecipient `json:"recipient"`
+	// number of coins credited to the recipient
+	Amount  string `json:"amount"`
+	Enabled bool   `json:"enabled"`
+	// rule applies to events occurred after this time, always applies if null
+	StartsAt sql.NullTime `json:"starts_at"`
+	// rule applies to events occurred before this time, always applies if null
+	EndsAt    sql.NullTime `json:"ends_at"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
+type Report struct {
+	ID             int32            `json:"id"`
+	Uuid           string           `json:"uuid"`
+	ReporterID     int32            `json:"reporter_id"`
+	ReportedUserID int32            `json:"reported_user_id"`
+	TargetType     ReportTargetType `json:"target_type"`
+	// reported content, service uuid, image url or <channel_uuid>/<message_id>. NULL for profile
+	TargetRef      sql.NullString `json:"target_ref"`
+	Category       ReportCategory `json:"category"`
+	Description    sql.NullString `json:"description"`
+	Status         ReportStatus   `json:"status"`
+	AssigneeID     sql.NullInt32  `json:"assignee_id"`
+	ResolutionNote sql.NullString `json:"resolution_note"`
+	ClosedAt       sql.NullTime   `json:"closed_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
+}
+
+type ReportEvent struct {
+	ID       int32 `json:"id"`
+	ReportID int32 `json:"report_id"`
+	// NULL if the action is taken by the system
+	ActorID    sql.NullInt32  `json:"actor_id"`
+	Action     string         `json:"action"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   sql.NullString `json:"to_status"`
+	Note       sql.NullString `json:"note"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  sql.NullTime   `json:"updated_at"`
+	DeletedAt  sql.NullTime   `json:"deleted_at"`
+}
+
 type Service struct {
 	ID                int64          `json:"id"`
 	Uuid              sql.NullString `json:"uuid"`
@@ -443,6 +901,20 @@ type ServiceRating struct {
 	RateeID   sql.NullInt32  `json:"ratee_id"`
 }
 
+type StatusTransition struct {
+	ID         int64                `json:"id"`
+	EntityType TransitionEntityType `json:"entity_type"`
+	EntityID   int32                `json:"entity_id"`
+	// NULL when the inquiry or service is created
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Event      sql.NullString `json:"event"`
+	// NULL if the transition is made by the system, e.g. workers
+	ActorID   sql.NullInt32  `json:"actor_id"`
+	Cause     sql.NullString `json:"cause"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
 type User struct {
 	ID                int64          `json:"id"`
 	Username          string         `json:"username"`
@@ -465,6 +937,7 @@ type User struct {
 	BreastSize        sql.NullString `json:"breast_size"`
 	Mobile            sql.NullString `json:"mobile"`
 	FcmTopic          sql.NullString `json:"fcm_topic"`
+	Locale            string         `json:"locale"`
 }
 
 type UserBalance struct {
@@ -477,6 +950,38 @@ type UserBalance struct {
 	DeletedAt sql.NullTime `json:"deleted_at"`
 }
 
+type UserFavorite struct {
+	ID             int32        `json:"id"`
+	UserID         int32        `json:"user_id"`
+	FavoriteUserID int32        `json:"favorite_user_id"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      sql.NullTime `json:"updated_at"`
+	DeletedAt      sql.NullTime `json:"deleted_at"`
+}
+
+type UserLocation struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+	// latitude rounded according to precision, raw coordinates are never stored
+	Lat float64 `json:"lat"`
+	// longitude rounded according to precision, raw coordinates are never stored
+	Lng       float64           `json:"lng"`
+	Precision LocationPrecision `json:"precision"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt sql.NullTime      `json:"updated_at"`
+	DeletedAt sql.NullTime      `json:"deleted_at"`
+}
+
+type UserPresenceSetting struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+	// hidden users always appear offline to others
+	Hidden    bool         `json:"hidden"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
 type UserRefcode struct {
 	ID          int64         `json:"id"`
 	InvitorID   int32         `json:"invitor_id"`
@@ -490,6 +995,30 @@ type UserRefcode struct {
 	ExpiredAt sql.NullTime `json:"expired_at"`
 }
 
+// restricted users can not emit inquiries, pickup inquiries or send chat messages
+type UserRestriction struct {
+	ID        int32     `json:"id"`
+	UserID    int32     `json:"user_id"`
+	Reason    string    `json:"reason"`
+	ExpiresAt time.Time `json:"expires_at"`
+	// NULL if the restriction is imposed automatically by repeated reports
+	CreatedBy sql.NullInt32 `json:"created_by"`
+	LiftedBy  sql.NullInt32 `json:"lifted_by"`
+	LiftedAt  sql.NullTime  `json:"lifted_at"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt sql.NullTime  `json:"updated_at"`
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+}
+
+type UserRole struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	Role      UserRoleType `json:"role"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	DeletedAt sql.NullTime `json:"deleted_at"`
+}
+
 type UserServiceOption struct {
 	ID              int64         `json:"id"`
 	UsersID         sql.NullInt32 `json:"users_id"`
@@ -498,3 +1027,29 @@ type UserServiceOption struct {
 	UpdatedAt       sql.NullTime  `json:"updated_at"`
 	DeletedAt       sql.NullTime  `json:"deleted_at"`
 }
+
+// suspended and banned users can not access any API
+type UserSuspension struct {
+	ID             int32              `json:"id"`
+	UserID         int32              `json:"user_id"`
+	SuspensionType UserSuspensionType `json:"suspension_type"`
+	Reason         string             `json:"reason"`
+	// NULL if the suspension never expires
+	ExpiresAt sql.NullTime  `json:"expires_at"`
+	CreatedBy int32         `json:"created_by"`
+	LiftedBy  sql.NullInt32 `json:"lifted_by"`
+	LiftedAt  sql.NullTime  `json:"lifted_at"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt sql.NullTime  `json:"updated_at"`
+	DeletedAt sql.NullTime  `json:"deleted_at"`
+}
+
+type UsernameKey struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+	// lower cased username with full-width characters folded to half-width, see usernamepolicy.Normalize
+	NormalizedUsername string       `json:"normalized_username"`
+	CreatedAt          time.Time    `json:"created_at"`
+	UpdatedAt          sql.NullTime `json:"updated_at"`
+	DeletedAt          sql.NullTime `json:"deleted_at"`
+}
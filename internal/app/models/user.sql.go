@@ -21,7 +21,7 @@ INSERT INTO users (
 	fcm_topic,
 	description
 ) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic
+RETURNING id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic, locale
 `
 
 type CreateUserParams struct {
@@ -73,12 +73,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.BreastSize,
 		&i.Mobile,
 		&i.FcmTopic,
+		&i.Locale,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic FROM users
+SELECT id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic, locale FROM users
 WHERE id = $1 LIMIT 1
 `
 
@@ -107,12 +108,13 @@ func (q *Queries) GetUserByID(ctx context.Context, id int64) (User, error) {
 		&i.BreastSize,
 		&i.Mobile,
 		&i.FcmTopic,
+		&i.Locale,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic FROM users
+SELECT id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic, locale FROM users
 WHERE username = $1 LIMIT 1
 `
 
@@ -141,12 +143,13 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (User,
 		&i.BreastSize,
 		&i.Mobile,
 		&i.FcmTopic,
+		&i.Locale,
 	)
 	return i, err
 }
 
 const getUserByUuid = `-- name: GetUserByUuid :one
-SELECT id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic FROM users
+SELECT id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic, locale FROM users
 WHERE uuid = $1 LIMIT 1
 `
 
@@ -175,6 +178,7 @@ func (q *Queries) GetUserByUuid(ctx context.Context, uuid string) (User, error)
 		&i.BreastSize,
 		&i.Mobile,
 		&i.FcmTopic,
+		&i.Locale,
 	)
 	return i, err
 }
@@ -195,7 +199,7 @@ const patchUserInfoByUuid = `-- name: PatchUserInfoByUuid :one
 UPDATE users
 SET avatar_url = $1, nationality = $2, region = $3, age = $4, height = $5, weight = $6, description = $7, breast_size = $8
 WHERE uuid = $9
-RETURNING id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic
+RETURNING id, username, phone_verified, gender, premium_type, premium_expiry_date, created_at, updated_at, deleted_at, uuid, avatar_url, nationality, region, age, height, weight, habbits, description, breast_size, mobile, fcm_topic, locale
 `
 
 type PatchUserInfoByUuidParams struct {
@@ -245,6 +249,7 @@ func (q *Queries) PatchUserInfoByUuid(ctx context.Context, arg PatchUserInfoByUu
 		&i.BreastSize,
 		&i.Mobile,
 		&i.FcmTopic,
+		&i.Locale,
 	)
 	return i, err
 }
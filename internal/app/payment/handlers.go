@@ -2,17 +2,20 @@ package payment
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/coin"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
 	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/service"
 	"github.com/jmoiron/sqlx"
 	"github.com/shopspring/decimal"
 )
@@ -45,11 +48,13 @@ func CreatePayment(c *gin.Context, depCon container.Container) {
 		srvDao         contracts.ServiceDAOer
 		userDao        contracts.UserDAOer
 		userBalanceDao contracts.UserBalancer
+		transitionDao  contracts.TransitionDAOer
 	)
 
 	depCon.Make(&srvDao)
 	depCon.Make(&userDao)
 	depCon.Make(&userBalanceDao)
+	depCon.Make(&transitionDao)
 
 	srv, err := srvDao.GetServiceByUuid(body.ServiceUuid)
 
@@ -159,6 +164,14 @@ func CreatePayment(c *gin.Context, depCon container.Container) {
 					srvPrice,
 				)
 
+			if errors.Is(err, coin.ErrInsufficientBalance) {
+				return db.FormatResp{
+					Err:            err,
+					ErrCode:        apperr.FailedToCheckHasEnoughBalance,
+					HttpStatusCode: http.StatusBadRequest,
+				}
+			}
+
 			if err != nil {
 				return db.FormatResp{
 					Err:            err,
@@ -168,6 +181,17 @@ func CreatePayment(c *gin.Context, depCon container.Container) {
 			}
 
 			// Change service status to `to_be_fulfilled`.
+			if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+				Event:     service.Paid.ToString(),
+				ActorUuid: user.Uuid,
+			}); err != nil {
+				return db.FormatResp{
+					Err:            err,
+					ErrCode:        apperr.FailedToAnnotateTransition,
+					HttpStatusCode: http.StatusInternalServerError,
+				}
+			}
+
 			srvStatus := models.ServiceStatusToBeFulfilled
 			_, err = srvDao.WithTx(tx).UpdateServiceByID(contracts.UpdateServiceByIDParams{
 				ID:            srv.ID,
@@ -280,6 +304,7 @@ func CreatePayment(c *gin.Context, depCon container.Container) {
 		ctx,
 		dpfcm.PublishServicePaidNotificationMessage{
 			Topic:       srvProvider.FcmTopic.String,
+			Locale:      srvProvider.Locale,
 			ServiceUUID: srv.Uuid.String,
 			PayerName:   user.Username,
 		},
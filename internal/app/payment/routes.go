@@ -3,7 +3,6 @@ package payment
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 )
@@ -15,9 +14,7 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 	g := r.Group(
 		"/payments",
 		jwtactor.JwtValidator(
-			jwtactor.JwtMiddlewareOptions{
-				Secret: config.GetAppConf().JwtSecret,
-			},
+			jwtactor.JwtMiddlewareOptions{},
 			authDao,
 		),
 	)
@@ -56,6 +56,14 @@ const (
 	// when male agrees to chat.
 	ServiceUuidFieldName = "service_uuid"
 
+	// Key value of the subcollection `applicants` of `inquiries` collection. Providers who applied for
+	// the random inquiry are kept in this subcollection.
+	ApplicantSubCollectionName = "applicants"
+
+	// Column name of `inquiry` document in `inquiries` collection. Number of applicants still waiting
+	// for the inquirer to reply.
+	ApplicantCountFieldName = "applicant_count"
+
 	// Key value of the `private_chats` collection in firestore.
 	PrivateChatsCollectionName = "private_chats"
 
@@ -84,6 +92,9 @@ type DarkFireStorer interface {
 
 	CreateInquiringUser(ctx context.Context, params CreateInquiringUserParams) (*firestore.WriteResult, InquiringUserInfo, error)
 	AskingInquiringUser(ctx context.Context, params AskingInquiringUserParams) error
+	AddInquiryApplicant(ctx context.Context, params AddInquiryApplicantParams) error
+	DeclineInquiryApplicants(ctx context.Context, params DeclineInquiryApplicantsParams) error
+	SettleInquiryApplicants(ctx context.Context, params SettleInquiryApplicantsParams) error
 	UpdateInquiryStatus(ctx context.Context, params UpdateInquiryStatusParams) (*firestore.WriteResult, error)
 	DisagreeInquiry(ctx context.Context, params DisagreeInquiryParams) (ChatMessage, error)
 	UpdateInquiryDetail(ctx context.Context, params UpdateInquiryDetailParams) (InquiryDetailMessage, error)
@@ -672,6 +683,9 @@ type InquiringUserInfo struct {
 	InquiryType  string `firestore:"inquiry_type,omitempty"`
 	Status       string `firestore:"status,omitempty"`
 	ServiceUuid  string `firestore:"service_uuid"`
+
+	// ApplicantCount number of applicants of the random inquiry waiting for the inquirer to reply.
+	ApplicantCount int `firestore:"applicant_count"`
 }
 
 // CreateInquiry creates new record in inquiries collection. Inquiry can
@@ -825,6 +839,122 @@ func (df *DarkFirestore) AskingInquiringUser(ctx context.Context, params AskingI
 	return err
 }
 
+type InquiryApplicantInfo struct {
+	ApplicantUuid string    `firestore:"applicant_uuid,omitempty" json:"applicant_uuid"`
+	Username      string    `firestore:"username,omitempty" json:"username"`
+	AvatarURL     string    `firestore:"avatar_url" json:"avatar_url"`
+	Status        string    `firestore:"status,omitempty" json:"status"`
+	AppliedAt     time.Time `firestore:"applied_at,omitempty" json:"applied_at"`
+}
+
+type AddInquiryApplicantParams struct {
+	InquiryUuid       string
+	ApplicantUuid     string
+	ApplicantUsername string
+	AvatarURL         string
+}
+
+// AddInquiryApplicant adds the provider to the `applicants` subcollection of the inquiry document so
+// that the inquirer sees applicants as they apply. The inquiry remains `inquiring`.
+func (df *DarkFirestore) AddInquiryApplicant(ctx context.Context, params AddInquiryApplicantParams) error {
+	iqRef := df.getInquiryRef(params.InquiryUuid)
+	applicantRef := df.getInquiryApplicantRef(params.InquiryUuid, params.ApplicantUuid)
+
+	return df.Client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Set(applicantRef, InquiryApplicantInfo{
+			ApplicantUuid: params.ApplicantUuid,
+			Username:      params.ApplicantUsername,
+			AvatarURL:     params.AvatarURL,
+			Status:        string(models.InquiryApplicantStatusApplied),
+			AppliedAt:     time.Now(),
+		}); err != nil {
+			return err
+		}
+
+		return tx.Update(iqRef, []firestore.Update{
+			{
+				Path:  ApplicantCountFieldName,
+				Value: firestore.Increment(1),
+			},
+		})
+	})
+}
+
+type DeclineInquiryApplicantsParams struct {
+	InquiryUuid    string
+	ApplicantUuids []string
+}
+
+// DeclineInquiryApplicants marks applicants skipped by the inquirer as declined.
+func (df *DarkFirestore) DeclineInquiryApplicants(ctx context.Context, params DeclineInquiryApplicantsParams) error {
+	if len(params.ApplicantUuids) == 0 {
+		return nil
+	}
+
+	batch := df.Client.Batch()
+
+	for _, aUuid := range params.ApplicantUuids {
+		batch.Set(df.getInquiryApplicantRef(params.InquiryUuid, aUuid), map[string]interface{}{
+			ServiceStatusFieldName: string(models.InquiryApplicantStatusDeclined),
+		}, firestore.MergeAll)
+	}
+
+	batch.Update(df.getInquiryRef(params.InquiryUuid), []firestore.Update{
+		{
+			Path:  ApplicantCountFieldName,
+			Value: firestore.Increment(-len(params.ApplicantUuids)),
+		},
+	})
+
+	_, err := batch.Commit(ctx)
+
+	return err
+}
+
+type SettleInquiryApplicantsParams struct {
+	InquiryUuid    string
+	PickerUuid     string
+	PickerUsername string
+
+	// DeclinedUuids applicants other than the picker.
+	DeclinedUuids []string
+}
+
+// SettleInquiryApplicants marks the applicant the inquirer agreed to chat with as accepted and the rest
+// as declined. Status of the inquiry document is updated by `PrepareToStartInquiryChat`.
+func (df *DarkFirestore) SettleInquiryApplicants(ctx context.Context, params SettleInquiryApplicantsParams) error {
+	batch := df.Client.Batch()
+
+	batch.Set(df.getInquiryApplicantRef(params.InquiryUuid, params.PickerUuid), map[string]interface{}{
+		ServiceStatusFieldName: string(models.InquiryApplicantStatusAccepted),
+	}, firestore.MergeAll)
+
+	for _, aUuid := range params.DeclinedUuids {
+		batch.Set(df.getInquiryApplicantRef(params.InquiryUuid, aUuid), map[string]interface{}{
+			ServiceStatusFieldName: string(models.InquiryApplicantStatusDeclined),
+		}, firestore.MergeAll)
+	}
+
+	batch.Update(df.getInquiryRef(params.InquiryUuid), []firestore.Update{
+		{
+			Path:  "picker_uuid",
+			Value: params.PickerUuid,
+		},
+		{
+			Path:  "picker_username",
+			Value: params.PickerUsername,
+		},
+		{
+			Path:  ApplicantCountFieldName,
+			Value: 0,
+		},
+	})
+
+	_, err := batch.Commit(ctx)
+
+	return err
+}
+
 type UpdateServiceParams struct {
 	ServiceUuid   string `firestore:"service_uuid,omitempty" json:"service_uuid"`
 	ServiceStatus string `firestore:"status,omitempty" json:"status"`
@@ -892,6 +1022,13 @@ func (df *DarkFirestore) getInquiryRef(inquiryUuid string) *firestore.DocumentRe
 		Doc(inquiryUuid)
 }
 
+func (df *DarkFirestore) getInquiryApplicantRef(inquiryUuid, applicantUuid string) *firestore.DocumentRef {
+	return df.
+		getInquiryRef(inquiryUuid).
+		Collection(ApplicantSubCollectionName).
+		Doc(applicantUuid)
+}
+
 func (df *DarkFirestore) getServiceRef(serviceUuid string) *firestore.DocumentRef {
 	return df.
 		Client.
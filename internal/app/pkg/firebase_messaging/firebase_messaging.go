@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"firebase.google.com/go/messaging"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -19,6 +20,10 @@ type DPFirebaseMessenger interface {
 	PublishMaleSendDirectInquiryNotification(ctx context.Context, m PublishMaleSendDirectInquiryMessage) error
 	PublishServiceCompletedNotification(ctx context.Context, m ServiceCompletedMessage) error
 	PublishServiceExpiredNotification(ctx context.Context, m ServiceExpiredMessage) error
+	PublishPremiumExpiryReminder(ctx context.Context, m PremiumExpiryReminderMessage) error
+	PublishRandomInquiryInvitation(ctx context.Context, m RandomInquiryInvitationMessage) error
+	PublishInquiryApplicationDeclined(ctx context.Context, m InquiryApplicationDeclinedMessage) error
+	PublishInquiryApplicationClosed(ctx context.Context, m InquiryApplicationClosedMessage) error
 }
 
 const FCMTypeFieldName = "fcm_type"
@@ -57,6 +62,11 @@ var (
 	Refunded              FCMType = "refunded"
 	MaleSendDirectInquiry FCMType = "male_send_direct_inquiry"
 	ServiceEnded          FCMType = "service_ended"
+	PremiumExpiring       FCMType = "premium_expiring"
+
+	RandomInquiryInvitation    FCMType = "random_inquiry_invitation"
+	InquiryApplicationDeclined FCMType = "inquiry_application_declined"
+	InquiryApplicationClosed   FCMType = "inquiry_application_closed"
 )
 
 type Notification struct {
@@ -69,6 +79,7 @@ const FCMImgUrl = "https://storage.googleapis.com/dark-panda-6fe35.appspot.com/f
 
 type PublishPickupInquiryNotificationMessage struct {
 	Topic      string `json:"-"`
+	Locale     string `json:"-"`
 	PickerName string `json:"picker_name"`
 	PickerUUID string `json:"picker_uuid"`
 }
@@ -82,8 +93,8 @@ func (r *DPFirebaseMessage) PublishPickupInquiryNotification(ctx context.Context
 	res, err := r.c.Send(ctx, &messaging.Message{
 		Topic: m.Topic,
 		Notification: &messaging.Notification{
-			Title:    fmt.Sprintf("%s 回覆詢問", m.PickerName),
-			Body:     fmt.Sprintf("%s 已回覆詢問", m.PickerName),
+			Title:    localize(m.Locale, pickupInquiryTitle, m.PickerName),
+			Body:     localize(m.Locale, pickupInquiryBody, m.PickerName),
 			ImageURL: FCMImgUrl,
 		},
 		Data: data,
@@ -100,6 +111,7 @@ func (r *DPFirebaseMessage) PublishPickupInquiryNotification(ctx context.Context
 
 type PublishServicePaidNotificationMessage struct {
 	Topic       string `json:"-"`
+	Locale      string `json:"-"`
 	PayerName   string `json:"payer_name"`
 	ServiceUUID string `json:"service_uuid"`
 }
@@ -113,8 +125,8 @@ func (r *DPFirebaseMessage) PublishServicePaidNotification(ctx context.Context,
 	res, err := r.c.Send(ctx, &messaging.Message{
 		Topic: m.Topic,
 		Notification: &messaging.Notification{
-			Title:    "服務付款完成",
-			Body:     fmt.Sprintf("%s 服務付款完成", m.PayerName),
+			Title:    localize(m.Locale, servicePaidTitle),
+			Body:     localize(m.Locale, servicePaidBody, m.PayerName),
 			ImageURL: FCMImgUrl,
 		},
 		Data: data,
@@ -131,6 +143,7 @@ func (r *DPFirebaseMessage) PublishServicePaidNotification(ctx context.Context,
 
 type PublishUnpaidServiceExpiredMessage struct {
 	Topic               string `json:"-"`
+	Locale              string `json:"-"`
 	ServiceUUID         string `json:"service_uuid"`
 	CustomerName        string `json:"customer_name"`
 	ServiceProviderName string `json:"service_provider_name"`
@@ -146,8 +159,8 @@ func (r *DPFirebaseMessage) PublishUnpaidServiceExpiredNotification(ctx context.
 	res, err := r.c.Send(ctx, &messaging.Message{
 		Topic: m.Topic,
 		Notification: &messaging.Notification{
-			Title:    "未付款服務已過期",
-			Body:     fmt.Sprintf("與 %s 的未付款服務已過期", m.ServiceProviderName),
+			Title:    localize(m.Locale, unpaidServiceExpiredTitle),
+			Body:     localize(m.Locale, unpaidServiceExpiredBody, m.ServiceProviderName),
 			ImageURL: FCMImgUrl,
 		},
 		Data: data,
@@ -164,6 +177,7 @@ func (r *DPFirebaseMessage) PublishUnpaidServiceExpiredNotification(ctx context.
 
 type PublishMaleAgreeToChatMessage struct {
 	Topic          string `json:"-"`
+	Locale         string `json:"-"`
 	InquiryUuid    string `json:"inquiry_uuid"`
 	MaleUsername   string `json:"male_username"`
 	FemaleUsername string `json:"female_username"`
@@ -179,8 +193,8 @@ func (r *DPFirebaseMessage) PublishMaleAgreeToChat(ctx context.Context, m Publis
 	res, err := r.c.Send(ctx, &messaging.Message{
 		Topic: m.Topic,
 		Notification: &messaging.Notification{
-			Title:    fmt.Sprintf("%s 接受聊天", m.MaleUsername),
-			Body:     fmt.Sprintf("開始與 %s 聊聊吧", m.MaleUsername),
+			Title:    localize(m.Locale, agreeToChatTitle, m.MaleUsername),
+			Body:     localize(m.Locale, agreeToChatBody, m.MaleUsername),
 			ImageURL: FCMImgUrl,
 		},
 		Data: data,
@@ -199,6 +213,9 @@ func (r *DPFirebaseMessage) PublishMaleAgreeToChat(ctx context.Context, m Publis
 type PublishServiceCancelledMessage struct {
 	Topics []string `json:"-"`
 
+	// Locales of the recipients, parallel to Topics. Missing ones use the default locale.
+	Locales []string `json:"-"`
+
 	ServiceUUID string `json:"service_uuid"`
 
 	CancellerUUID string `json:"canceller_uuid"`
@@ -215,12 +232,18 @@ func (r *DPFirebaseMessage) PublishServiceCancelled(ctx context.Context, m Publi
 	data["canceller_uuid"] = m.CancellerUUID
 	data["canceller_username"] = m.CancellerUsername
 
-	for _, topic := range m.Topics {
+	for i, topic := range m.Topics {
+		l := locale.Default
+
+		if i < len(m.Locales) {
+			l = m.Locales[i]
+		}
+
 		res, err := r.c.Send(ctx, &messaging.Message{
 			Topic: topic,
 			Notification: &messaging.Notification{
-				Title:    fmt.Sprintf("%s 取消服務", m.CancellerUsername),
-				Body:     fmt.Sprintf("%s 已取消服務", m.CancellerUsername),
+				Title:    localize(l, serviceCancelledTitle, m.CancellerUsername),
+				Body:     localize(l, serviceCancelledBody, m.CancellerUsername),
 				ImageURL: FCMImgUrl,
 			},
 			Data: data,
@@ -238,6 +261,7 @@ func (r *DPFirebaseMessage) PublishServiceCancelled(ctx context.Context, m Publi
 
 type PublishServiceRefundedMessage struct {
 	Topic       string `json:"-"`
+	Locale      string `json:"-"`
 	ServiceUUID string `json:"service_uuid"`
 }
 
@@ -249,8 +273,8 @@ func (r *DPFirebaseMessage) PublishServiceRefunded(ctx context.Context, m Publis
 	res, err := r.c.Send(ctx, &messaging.Message{
 		Topic: m.Topic,
 		Notification: &messaging.Notification{
-			Title:    "服務退款通知",
-			Body:     "服務退款通知",
+			Title:    localize(m.Locale, refundedTitle),
+			Body:     localize(m.Locale, refundedBody),
 			ImageURL: FCMImgUrl,
 		},
 
@@ -268,6 +292,7 @@ func (r *DPFirebaseMessage) PublishServiceRefunded(ctx context.Context, m Publis
 
 type PublishMaleSendDirectInquiryMessage struct {
 	Topic          string `json:"-"`
+	Locale         string `json:"-"`
 	InquiryUUID    string `json:"inquiry_uuid"`
 	Femaleusername string `json:"female_username"`
 	MaleUsername   string `json:"male_username"`
@@ -283,8 +308,8 @@ func (r *DPFirebaseMessage) PublishMaleSendDirectInquiryNotification(ctx context
 	res, err := r.c.Send(ctx, &messaging.Message{
 		Topic: m.Topic,
 		Notification: &messaging.Notification{
-			Title:    "男生詢問 ",
-			Body:     fmt.Sprintf("%s 男生向您提出詢問", m.MaleUsername),
+			Title:    localize(m.Locale, maleSendDirectInquiryTitle),
+			Body:     localize(m.Locale, maleSendDirectInquiryBody, m.MaleUsername),
 			ImageURL: FCMImgUrl,
 		},
 		Data: data,
@@ -301,6 +326,7 @@ func (r *DPFirebaseMessage) PublishMaleSendDirectInquiryNotification(ctx context
 
 type ServiceCompletedMessage struct {
 	Topic               string
+	Locale              string
 	CounterPartUsername string
 	ServiceUUID         string
 }
@@ -310,8 +336,8 @@ type ServiceCompletedMessage struct {
 func (r *DPFirebaseMessage) PublishServiceCompletedNotification(ctx context.Context, m ServiceCompletedMessage) error {
 	res, err := r.publishServiceScannedNotification(ctx, serviceScannedMessage{
 		Topic:       m.Topic,
-		Title:       "服務結束",
-		Body:        fmt.Sprintf("您與 %s 的服務已經結束", m.CounterPartUsername),
+		Title:       localize(m.Locale, serviceCompletedTitle),
+		Body:        localize(m.Locale, serviceCompletedBody, m.CounterPartUsername),
 		ServiceUUID: m.ServiceUUID,
 	})
 
@@ -325,6 +351,7 @@ func (r *DPFirebaseMessage) PublishServiceCompletedNotification(ctx context.Cont
 
 type ServiceExpiredMessage struct {
 	Topic               string
+	Locale              string
 	CounterPartUsername string
 	ServiceUUID         string
 }
@@ -332,8 +359,8 @@ type ServiceExpiredMessage struct {
 func (r *DPFirebaseMessage) PublishServiceExpiredNotification(ctx context.Context, m ServiceExpiredMessage) error {
 	res, err := r.publishServiceScannedNotification(ctx, serviceScannedMessage{
 		Topic:       m.Topic,
-		Title:       "服務過期",
-		Body:        fmt.Sprintf("您與 %s 的服務已經過期", m.CounterPartUsername),
+		Title:       localize(m.Locale, serviceExpiredTitle),
+		Body:        localize(m.Locale, serviceExpiredBody, m.CounterPartUsername),
 		ServiceUUID: m.ServiceUUID,
 	})
 
@@ -374,3 +401,134 @@ func (r *DPFirebaseMessage) publishServiceScannedNotification(ctx context.Contex
 
 	return res, nil
 }
+
+type PremiumExpiryReminderMessage struct {
+	Topic     string
+	Locale    string
+	ExpiresAt time.Time
+}
+
+// PublishPremiumExpiryReminder reminds the member to renew before the membership expires.
+func (r *DPFirebaseMessage) PublishPremiumExpiryReminder(ctx context.Context, m PremiumExpiryReminderMessage) error {
+	data := make(map[string]string)
+	data[FCMTypeFieldName] = string(PremiumExpiring)
+	data["expires_at"] = m.ExpiresAt.Format(time.RFC3339)
+
+	res, err := r.c.Send(ctx, &messaging.Message{
+		Topic: m.Topic,
+		Notification: &messaging.Notification{
+			Title:    localize(m.Locale, premiumExpiringTitle),
+			Body:     localize(m.Locale, premiumExpiringBody, m.ExpiresAt.Format("2006-01-02 15:04")),
+			ImageURL: FCMImgUrl,
+		},
+		Data: data,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("[fcm_info] premium expiry reminder sent %s", res)
+
+	return nil
+}
+
+type RandomInquiryInvitationMessage struct {
+	Topic            string `json:"-"`
+	Locale           string `json:"-"`
+	InquiryUUID      string `json:"inquiry_uuid"`
+	InquirerUsername string `json:"inquirer_username"`
+}
+
+// PublishRandomInquiryInvitation invites the provider ranked by the matching engine to pick up
+// the random inquiry.
+func (r *DPFirebaseMessage) PublishRandomInquiryInvitation(ctx context.Context, m RandomInquiryInvitationMessage) error {
+	data := make(map[string]string)
+	data[FCMTypeFieldName] = string(RandomInquiryInvitation)
+	data["inquiry_uuid"] = m.InquiryUUID
+	data["inquirer_username"] = m.InquirerUsername
+
+	res, err := r.c.Send(ctx, &messaging.Message{
+		Topic: m.Topic,
+		Notification: &messaging.Notification{
+			Title:    localize(m.Locale, randomInquiryInvitationTitle),
+			Body:     localize(m.Locale, randomInquiryInvitationBody, m.InquirerUsername),
+			ImageURL: FCMImgUrl,
+		},
+		Data: data,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("[fcm_info] random inquiry invitation sent %s", res)
+
+	return nil
+}
+
+type InquiryApplicationDeclinedMessage struct {
+	Topic            string `json:"-"`
+	Locale           string `json:"-"`
+	InquiryUUID      string `json:"inquiry_uuid"`
+	InquirerUsername string `json:"inquirer_username"`
+}
+
+// PublishInquiryApplicationDeclined notifies the applicant that the inquirer has skipped the
+// application or agreed to chat with another applicant.
+func (r *DPFirebaseMessage) PublishInquiryApplicationDeclined(ctx context.Context, m InquiryApplicationDeclinedMessage) error {
+	data := make(map[string]string)
+	data[FCMTypeFieldName] = string(InquiryApplicationDeclined)
+	data["inquiry_uuid"] = m.InquiryUUID
+	data["inquirer_username"] = m.InquirerUsername
+
+	res, err := r.c.Send(ctx, &messaging.Message{
+		Topic: m.Topic,
+		Notification: &messaging.Notification{
+			Title:    localize(m.Locale, applicationDeclinedTitle),
+			Body:     localize(m.Locale, applicationDeclinedBody, m.InquirerUsername),
+			ImageURL: FCMImgUrl,
+		},
+		Data: data,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("[fcm_info] inquiry application declined sent %s", res)
+
+	return nil
+}
+
+type InquiryApplicationClosedMessage struct {
+	Topic       string `json:"-"`
+	Locale      string `json:"-"`
+	InquiryUUID string `json:"inquiry_uuid"`
+}
+
+// PublishInquiryApplicationClosed notifies the applicant that the inquiry has been cancelled or has
+// expired before the inquirer replied.
+func (r *DPFirebaseMessage) PublishInquiryApplicationClosed(ctx context.Context, m InquiryApplicationClosedMessage) error {
+	data := make(map[string]string)
+	data[FCMTypeFieldName] = string(InquiryApplicationClosed)
+	data["inquiry_uuid"] = m.InquiryUUID
+
+	res, err := r.c.Send(ctx, &messaging.Message{
+		Topic: m.Topic,
+		Notification: &messaging.Notification{
+			Title:    localize(m.Locale, applicationClosedTitle),
+			Body:     localize(m.Locale, applicationClosedBody),
+			ImageURL: FCMImgUrl,
+		},
+		Data: data,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	log.Infof("[fcm_info] inquiry application closed sent %s", res)
+
+	return nil
+}
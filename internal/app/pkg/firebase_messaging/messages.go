@@ -0,0 +1,140 @@
+package dpfcm
+
+import (
+	"fmt"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+)
+
+const (
+	pickupInquiryTitle         = "pickup_inquiry_title"
+	pickupInquiryBody          = "pickup_inquiry_body"
+	servicePaidTitle           = "service_paid_title"
+	servicePaidBody            = "service_paid_body"
+	unpaidServiceExpiredTitle  = "unpaid_service_expired_title"
+	unpaidServiceExpiredBody   = "unpaid_service_expired_body"
+	agreeToChatTitle           = "agree_to_chat_title"
+	agreeToChatBody            = "agree_to_chat_body"
+	serviceCancelledTitle      = "service_cancelled_title"
+	serviceCancelledBody       = "service_cancelled_body"
+	refundedTitle              = "refunded_title"
+	refundedBody               = "refunded_body"
+	maleSendDirectInquiryTitle = "male_send_direct_inquiry_title"
+	maleSendDirectInquiryBody  = "male_send_direct_inquiry_body"
+	serviceCompletedTitle      = "service_completed_title"
+	serviceCompletedBody       = "service_completed_body"
+	serviceExpiredTitle        = "service_expired_title"
+	serviceExpiredBody         = "service_expired_body"
+	premiumExpiringTitle       = "premium_expiring_title"
+	premiumExpiringBody        = "premium_expiring_body"
+
+	randomInquiryInvitationTitle = "random_inquiry_invitation_title"
+	randomInquiryInvitationBody  = "random_inquiry_invitation_body"
+	applicationDeclinedTitle     = "application_declined_title"
+	applicationDeclinedBody      = "application_declined_body"
+	applicationClosedTitle       = "application_closed_title"
+	applicationClosedBody        = "application_closed_body"
+)
+
+// notificationCatalog titles and bodies of notifications. Bodies are formatted with the arguments
+// given by each publisher.
+var notificationCatalog = locale.Catalog{
+	locale.ZhTW: {
+		pickupInquiryTitle:         "%s 回覆詢問",
+		pickupInquiryBody:          "%s 已回覆詢問",
+		servicePaidTitle:           "服務付款完成",
+		servicePaidBody:            "%s 服務付款完成",
+		unpaidServiceExpiredTitle:  "未付款服務已過期",
+		unpaidServiceExpiredBody:   "與 %s 的未付款服務已過期",
+		agreeToChatTitle:           "%s 接受聊天",
+		agreeToChatBody:            "開始與 %s 聊聊吧",
+		serviceCancelledTitle:      "%s 取消服務",
+		serviceCancelledBody:       "%s 已取消服務",
+		refundedTitle:              "服務退款通知",
+		refundedBody:               "服務退款通知",
+		maleSendDirectInquiryTitle: "男生詢問",
+		maleSendDirectInquiryBody:  "%s 男生向您提出詢問",
+		serviceCompletedTitle:      "服務結束",
+		serviceCompletedBody:       "您與 %s 的服務已經結束",
+		serviceExpiredTitle:        "服務過期",
+		serviceExpiredBody:         "您與 %s 的服務已經過期",
+		premiumExpiringTitle:       "會員即將到期",
+		premiumExpiringBody:        "您的付費會員將於 %s 到期，請記得續約",
+
+		randomInquiryInvitationTitle: "附近有新的詢問",
+		randomInquiryInvitationBody:  "%s 正在尋找服務，快來回覆吧",
+		applicationDeclinedTitle:     "回覆未被接受",
+		applicationDeclinedBody:      "%s 已婉拒您的回覆",
+		applicationClosedTitle:       "詢問已關閉",
+		applicationClosedBody:        "您回覆的詢問已取消或過期",
+	},
+	locale.En: {
+		pickupInquiryTitle:         "%s replied to your inquiry",
+		pickupInquiryBody:          "%s has replied to your inquiry",
+		servicePaidTitle:           "Service paid",
+		servicePaidBody:            "%s has paid for the service",
+		unpaidServiceExpiredTitle:  "Unpaid service expired",
+		unpaidServiceExpiredBody:   "Your unpaid service with %s has expired",
+		agreeToChatTitle:           "%s accepted to chat",
+		agreeToChatBody:            "Start chatting with %s",
+		serviceCancelledTitle:      "%s cancelled the service",
+		serviceCancelledBody:       "%s has cancelled the service",
+		refundedTitle:              "Service refunded",
+		refundedBody:               "Your service has been refunded",
+		maleSendDirectInquiryTitle: "New inquiry",
+		maleSendDirectInquiryBody:  "%s sent you an inquiry",
+		serviceCompletedTitle:      "Service ended",
+		serviceCompletedBody:       "Your service with %s has ended",
+		serviceExpiredTitle:        "Service expired",
+		serviceExpiredBody:         "Your service with %s has expired",
+		premiumExpiringTitle:       "Membership expiring soon",
+		premiumExpiringBody:        "Your premium membership expires on %s, remember to renew",
+
+		randomInquiryInvitationTitle: "New inquiry nearby",
+		randomInquiryInvitationBody:  "%s is looking for a service, reply now",
+		applicationDeclinedTitle:     "Your reply was declined",
+		applicationDeclinedBody:      "%s has declined your reply",
+		applicationClosedTitle:       "Inquiry closed",
+		applicationClosedBody:        "The inquiry you replied to has been cancelled or has expired",
+	},
+	locale.Ja: {
+		pickupInquiryTitle:         "%s さんが依頼に返信しました",
+		pickupInquiryBody:          "%s さんがあなたの依頼に返信しました",
+		servicePaidTitle:           "サービスの支払いが完了しました",
+		servicePaidBody:            "%s さんがサービスの支払いを完了しました",
+		unpaidServiceExpiredTitle:  "未払いのサービスの期限が切れました",
+		unpaidServiceExpiredBody:   "%s さんとの未払いのサービスの期限が切れました",
+		agreeToChatTitle:           "%s さんがチャットを承諾しました",
+		agreeToChatBody:            "%s さんとチャットを始めましょう",
+		serviceCancelledTitle:      "%s さんがサービスをキャンセルしました",
+		serviceCancelledBody:       "%s さんによりサービスがキャンセルされました",
+		refundedTitle:              "サービスの返金のお知らせ",
+		refundedBody:               "サービスの料金が返金されました",
+		maleSendDirectInquiryTitle: "新しい依頼",
+		maleSendDirectInquiryBody:  "%s さんから依頼が届きました",
+		serviceCompletedTitle:      "サービス終了",
+		serviceCompletedBody:       "%s さんとのサービスが終了しました",
+		serviceExpiredTitle:        "サービスの期限切れ",
+		serviceExpiredBody:         "%s さんとのサービスの期限が切れました",
+		premiumExpiringTitle:       "会員の有効期限が近づいています",
+		premiumExpiringBody:        "有料会員の有効期限は %s です。更新をお忘れなく",
+
+		randomInquiryInvitationTitle: "近くで新しい依頼があります",
+		randomInquiryInvitationBody:  "%s さんがサービスを探しています。今すぐ返信しましょう",
+		applicationDeclinedTitle:     "返信は見送られました",
+		applicationDeclinedBody:      "%s さんはあなたの返信を見送りました",
+		applicationClosedTitle:       "依頼は締め切られました",
+		applicationClosedBody:        "返信した依頼はキャンセルされたか、期限が切れました",
+	},
+}
+
+// localize formats the notification message in the locale of the recipient.
+func localize(l, key string, args ...interface{}) string {
+	msg, _ := notificationCatalog.Get(l, key)
+
+	if len(args) == 0 {
+		return msg
+	}
+
+	return fmt.Sprintf(msg, args...)
+}
@@ -25,6 +25,9 @@ const GCSPublicHost = "storage.googleapis.com"
 type GCSEnhancerInterface interface {
 	ObjectLink(attr *storage.ObjectAttrs) string
 	Upload(ctx context.Context, file io.Reader, uploadFilename string) (string, error)
+	UploadPrivate(ctx context.Context, file io.Reader, uploadFilename string) error
+	NewObjectReader(ctx context.Context, filename string) (*storage.Reader, error)
+	Delete(ctx context.Context, filename string) error
 }
 
 type GCSEnhancer struct {
@@ -88,6 +91,33 @@ func (e *GCSEnhancer) Upload(ctx context.Context, file io.Reader, uploadFilename
 	return e.ObjectLink(attr), nil
 }
 
+// UploadPrivate uploads the file without making it publicly accessible. Private objects can only be
+// read through `NewObjectReader`, e.g. identity verification photos.
+func (e *GCSEnhancer) UploadPrivate(ctx context.Context, file io.Reader, uploadFilename string) error {
+	objwriter := e.NewObjectWriter(ctx, uploadFilename)
+
+	if _, err := io.Copy(objwriter, file); err != nil {
+		return err
+	}
+
+	return objwriter.Close()
+}
+
+func (e *GCSEnhancer) NewObjectReader(ctx context.Context, filename string) (*storage.Reader, error) {
+	return e.client.Bucket(e.bucketName).Object(filename).NewReader(ctx)
+}
+
+// Delete removes the object from the bucket. Deleting an object that does not exist is not an error.
+func (e *GCSEnhancer) Delete(ctx context.Context, filename string) error {
+	err := e.client.Bucket(e.bucketName).Object(filename).Delete(ctx)
+
+	if err == storage.ErrObjectNotExist {
+		return nil
+	}
+
+	return err
+}
+
 func AppendUnixTimeStampToFilename(filename string) string {
 	secs := strings.Split(filename, ".")
 	timeFactor := time.Now().Format("20060102150405")
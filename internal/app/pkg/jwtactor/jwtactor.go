@@ -1,26 +1,87 @@
 package jwtactor
 
 import (
+	"context"
 	"time"
 
 	"github.com/dgrijalva/jwt-go"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/teris-io/shortid"
+)
+
+type TokenType string
+
+var (
+	// AccessToken is presented on every API request via `Authorization` header.
+	AccessToken TokenType = "access"
+
+	// RefreshToken can only be exchanged for a new token pair via `/v1/auth/refresh`.
+	RefreshToken TokenType = "refresh"
+)
+
+const (
+	DefaultAccessTokenTTL  = time.Minute * 15
+	DefaultRefreshTokenTTL = time.Hour * 24 * 30
 )
 
 type Claim struct {
 	Uuid       string `json:"uuid"`
 	Authorized bool   `json:"authorized"`
+
+	// TokenType is empty for tokens issued before refresh token rotation is introduced.
+	// Those tokens are considered as access tokens.
+	TokenType TokenType `json:"token_type,omitempty"`
+
+	// Family groups every access / refresh token rotated from the same login. Once
+	// a refresh token is reused, every token in the family is revoked.
+	Family string `json:"family,omitempty"`
+
+	// Roles granted to operators. App users have no roles.
+	Roles []models.UserRoleType `json:"roles,omitempty"`
 	jwt.StandardClaims
 }
 
-// TODO: extend jwt token valid time. Extract the time variable to config.
+func (c *Claim) IsRefreshToken() bool {
+	return c.TokenType == RefreshToken
+}
+
+// HasAnyRole checks if the claim is granted with one of the given roles.
+func (c *Claim) HasAnyRole(roles ...models.UserRoleType) bool {
+	for _, granted := range c.Roles {
+		for _, role := range roles {
+			if granted == role {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// CreateToken issues a long-lived access token that does not belong to any token family.
+// Login flows should use `IssueTokenPair` instead. This is kept for internal tools like `gen_jwt`.
+// The token is signed by the given secret without `kid` header, use `CreateTokenWithKeyID` to sign
+// by the active key of the keyring.
 func CreateToken(uUuid string, jwtSecret string) (string, error) {
-	claim := &Claim{
+	return signClaim(newLongLivedClaim(uUuid), jwtSecret)
+}
+
+// CreateTokenWithKeyID signs the long-lived access token with the active key of the keyring.
+func CreateTokenWithKeyID(uUuid string) (string, error) {
+	return GetKeyring().Sign(newLongLivedClaim(uUuid))
+}
+
+func newLongLivedClaim(uUuid string) *Claim {
+	return &Claim{
 		Uuid: uUuid,
 		StandardClaims: jwt.StandardClaims{
 			ExpiresAt: time.Now().Add(time.Hour * 24 * 30).Unix(),
 		},
 	}
+}
 
+func signClaim(claim *Claim, jwtSecret string) (string, error) {
 	at := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
 	token, err := at.SignedString([]byte(jwtSecret))
 
@@ -31,20 +92,136 @@ func CreateToken(uUuid string, jwtSecret string) (string, error) {
 	return token, nil
 }
 
-func ParseToken(jwtToken, secret string) (*Claim, error) {
-	claims := &Claim{}
+type TokenPair struct {
+	Jwt                   string
+	JwtExpiresAt          int64
+	RefreshToken          string
+	RefreshTokenExpiresAt int64
+
+	Family         string
+	RefreshTokenID string
+}
+
+type CreateTokenPairParams struct {
+	Uuid string
+
+	// Secret signs the tokens without `kid` header. Tokens are signed by the active key of the keyring
+	// if not specified.
+	Secret          string
+	Family          string
+	Roles           []models.UserRoleType
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// CreateTokenPair signs a short-lived access token along with a refresh token. If `Family` is
+// not specified, a new token family will be generated.
+func CreateTokenPair(p CreateTokenPairParams) (*TokenPair, error) {
+	if p.AccessTokenTTL == 0 {
+		p.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+
+	if p.RefreshTokenTTL == 0 {
+		p.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	if len(p.Family) == 0 {
+		family, err := shortid.Generate()
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.Family = family
+	}
 
-	_, err := jwt.ParseWithClaims(
-		jwtToken,
-		claims,
-		func(token *jwt.Token) (interface{}, error) {
-			return []byte(secret), nil
+	refreshID, err := shortid.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	accessExp := now.Add(p.AccessTokenTTL).Unix()
+	refreshExp := now.Add(p.RefreshTokenTTL).Unix()
+
+	sign := GetKeyring().Sign
+
+	if len(p.Secret) > 0 {
+		sign = func(claim *Claim) (string, error) {
+			return signClaim(claim, p.Secret)
+		}
+	}
+
+	accessToken, err := sign(&Claim{
+		Uuid:      p.Uuid,
+		TokenType: AccessToken,
+		Family:    p.Family,
+		Roles:     p.Roles,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.Unix(),
+			ExpiresAt: accessExp,
 		},
-	)
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	refreshToken, err := sign(&Claim{
+		Uuid:      p.Uuid,
+		TokenType: RefreshToken,
+		Family:    p.Family,
+		StandardClaims: jwt.StandardClaims{
+			Id:        refreshID,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: refreshExp,
+		},
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		Jwt:                   accessToken,
+		JwtExpiresAt:          accessExp,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: refreshExp,
+		Family:                p.Family,
+		RefreshTokenID:        refreshID,
+	}, nil
+}
+
+// IssueTokenPair creates a token pair and registers the refresh token in redis so that
+// it can be rotated later on.
+func IssueTokenPair(ctx context.Context, authDaoer contracts.AuthDaoer, p CreateTokenPairParams) (*TokenPair, error) {
+	pair, err := CreateTokenPair(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authDaoer.StoreRefreshToken(ctx, contracts.StoreRefreshTokenParams{
+		ID:        pair.RefreshTokenID,
+		UserUuid:  p.Uuid,
+		Family:    pair.Family,
+		ExpiresAt: pair.RefreshTokenExpiresAt,
+	}); err != nil {
+		return nil, err
+	}
+
+	return pair, nil
+}
+
+// ParseToken parses and verifies the token against the keyring. Tokens without `kid` header are
+// verified against the given secret.
+func ParseToken(jwtToken, secret string) (*Claim, error) {
+	claims := &Claim{}
+
+	if _, err := GetKeyring().parse(jwtToken, claims, secret); err != nil {
+		return nil, err
+	}
+
 	return claims, nil
 }
@@ -0,0 +1,278 @@
+package jwtactor
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// LegacyKeyID refers to `AppConf.JwtSecret`. Tokens signed by the legacy secret do not carry `kid` header.
+	LegacyKeyID = "legacy"
+
+	DefaultKeyringRefreshInterval = time.Minute
+
+	// Failed reloads are retried after a delay doubling from base up to max.
+	reloadBackoffBase = time.Second
+	reloadBackoffMax  = time.Minute
+)
+
+var (
+	ErrUnknownSigningKey = errors.New("jwt is signed by an unknown key")
+	ErrRetiredSigningKey = errors.New("jwt is signed by a retired key")
+)
+
+// KeyLoader loads signing keys from persistent storage.
+type KeyLoader func() ([]models.JwtSigningKey, error)
+
+// Keyring holds the signing keys of jwt. New tokens are signed by the active key with the key id
+// in `kid` header. Tokens are verified against any key that is not retired. If no key is active,
+// tokens are signed by the legacy secret so that nothing changes until the first key is activated.
+//
+// Keys are reloaded periodically so that every app instance picks up changes made by `util jwtkey`.
+// Stale keys are reloaded in the background while the last loaded keys keep being served, so requests
+// never wait on storage once keys have been loaded.
+type Keyring struct {
+	legacySecret    string
+	loader          KeyLoader
+	refreshInterval time.Duration
+
+	mu         sync.RWMutex
+	keys       map[string]models.JwtSigningKey
+	loadedAt   time.Time
+	retryAt    time.Time
+	failures   int
+	refreshing bool
+
+	// reloadMu makes sure only one reload hits storage at a time.
+	reloadMu sync.Mutex
+}
+
+func NewKeyring(legacySecret string, loader KeyLoader, refreshInterval time.Duration) *Keyring {
+	if refreshInterval == 0 {
+		refreshInterval = DefaultKeyringRefreshInterval
+	}
+
+	return &Keyring{
+		legacySecret:    legacySecret,
+		loader:          loader,
+		refreshInterval: refreshInterval,
+		keys:            make(map[string]models.JwtSigningKey),
+	}
+}
+
+var (
+	keyringMu      sync.RWMutex
+	defaultKeyring *Keyring
+)
+
+// SetKeyring sets the keyring used by `CreateTokenWithKeyID`, `CreateTokenPair`, `ParseToken` and `JwtValidator`.
+func SetKeyring(k *Keyring) {
+	keyringMu.Lock()
+	defer keyringMu.Unlock()
+
+	defaultKeyring = k
+}
+
+// GetKeyring retrieves the keyring set by `SetKeyring`. If no keyring is set, a keyring that only
+// contains the legacy secret is returned.
+func GetKeyring() *Keyring {
+	keyringMu.RLock()
+	k := defaultKeyring
+	keyringMu.RUnlock()
+
+	if k != nil {
+		return k
+	}
+
+	return NewKeyring(config.GetAppConf().JwtSecret, nil, 0)
+}
+
+// Reload loads signing keys from the loader regardless of the refresh interval.
+func (k *Keyring) Reload() error {
+	if k.loader == nil {
+		return nil
+	}
+
+	keys, err := k.loader()
+
+	if err != nil {
+		return err
+	}
+
+	keyMap := make(map[string]models.JwtSigningKey)
+
+	for _, key := range keys {
+		keyMap[key.Kid] = key
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.keys = keyMap
+	k.loadedAt = time.Now()
+	k.retryAt = time.Time{}
+	k.failures = 0
+
+	return nil
+}
+
+// isDue checks if keys are stale and reloading is not backing off from a failure.
+func (k *Keyring) isDue() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	now := time.Now()
+
+	return now.Sub(k.loadedAt) > k.refreshInterval && !now.Before(k.retryAt)
+}
+
+// refresh reloads keys unless reloaded by another caller in the meantime. If storage is unavailable,
+// the keys loaded previously are kept and the next reload is delayed.
+func (k *Keyring) refresh() {
+	k.reloadMu.Lock()
+	defer k.reloadMu.Unlock()
+
+	defer func() {
+		k.mu.Lock()
+		k.refreshing = false
+		k.mu.Unlock()
+	}()
+
+	if !k.isDue() {
+		return
+	}
+
+	if err := k.Reload(); err != nil {
+		k.mu.Lock()
+		k.failures++
+
+		backoff := reloadBackoffBase
+
+		for i := 1; i < k.failures && backoff < reloadBackoffMax; i++ {
+			backoff *= 2
+		}
+
+		if backoff > reloadBackoffMax {
+			backoff = reloadBackoffMax
+		}
+
+		k.retryAt = time.Now().Add(backoff)
+		k.mu.Unlock()
+
+		log.Errorf("failed to reload jwt signing keys, retry in %s %s", backoff, err.Error())
+	}
+}
+
+func (k *Keyring) snapshot() map[string]models.JwtSigningKey {
+	if k.loader != nil && k.isDue() {
+		k.mu.Lock()
+		loaded := !k.loadedAt.IsZero()
+		start := loaded && !k.refreshing
+
+		if start {
+			k.refreshing = true
+		}
+
+		k.mu.Unlock()
+
+		if start {
+			go k.refresh()
+		} else if !loaded {
+			// Nothing to serve yet, wait for the first load.
+			k.refresh()
+		}
+	}
+
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.keys
+}
+
+// Sign signs the claim with the active key.
+func (k *Keyring) Sign(claim *Claim) (string, error) {
+	for _, key := range k.snapshot() {
+		if key.Status != models.JwtKeyStatusActive {
+			continue
+		}
+
+		at := jwt.NewWithClaims(jwt.SigningMethodHS256, claim)
+		at.Header["kid"] = key.Kid
+
+		return at.SignedString([]byte(key.Secret))
+	}
+
+	if len(k.legacySecret) == 0 {
+		return "", errors.New("no active jwt signing key")
+	}
+
+	return signClaim(claim, k.legacySecret)
+}
+
+// Keyfunc resolves the secret to verify the token by `kid` header.
+func (k *Keyring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	return k.keyfunc(k.legacySecret)(token)
+}
+
+// keyfunc resolves the secret by `kid` header, tokens without `kid` are verified against the legacy secret.
+func (k *Keyring) keyfunc(legacySecret string) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+
+		keys := k.snapshot()
+		kid, _ := token.Header["kid"].(string)
+
+		if len(kid) == 0 || kid == LegacyKeyID {
+			if key, exists := keys[LegacyKeyID]; exists && key.Status == models.JwtKeyStatusRetired {
+				return nil, ErrRetiredSigningKey
+			}
+
+			if len(legacySecret) == 0 {
+				return nil, ErrUnknownSigningKey
+			}
+
+			return []byte(legacySecret), nil
+		}
+
+		key, exists := keys[kid]
+
+		if !exists {
+			return nil, ErrUnknownSigningKey
+		}
+
+		if key.Status == models.JwtKeyStatusRetired {
+			return nil, ErrRetiredSigningKey
+		}
+
+		return []byte(key.Secret), nil
+	}
+}
+
+// Parse parses and verifies the token into the given claims.
+func (k *Keyring) Parse(token string, claims *Claim) (*jwt.Token, error) {
+	return k.parse(token, claims, k.legacySecret)
+}
+
+func (k *Keyring) parse(token string, claims *Claim, legacySecret string) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(token, claims, k.keyfunc(legacySecret))
+}
+
+// IsSigningKeyRejected checks if the parsing error is caused by an unknown or retired signing key.
+func IsSigningKeyRejected(err error) bool {
+	ve, ok := err.(*jwt.ValidationError)
+
+	if !ok {
+		return false
+	}
+
+	return ve.Inner == ErrUnknownSigningKey || ve.Inner == ErrRetiredSigningKey
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	apperr "github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 )
 
 func ExtractTokenFromRequest(c *gin.Context) (string, error) {
@@ -36,7 +37,11 @@ func ExtractTokenFromRequest(c *gin.Context) (string, error) {
 }
 
 type JwtMiddlewareOptions struct {
+	// Secret verifies tokens without `kid` header. Defaults to the legacy secret of the keyring.
 	Secret string
+
+	// Keyring to verify the jwt. Defaults to the keyring retrieved by `GetKeyring`.
+	Keyring *Keyring
 }
 
 type JwtToken struct {
@@ -72,12 +77,34 @@ func JwtValidator(opt JwtMiddlewareOptions, authDaoer contracts.AuthDaoer) gin.H
 
 		}
 
+		keyring := opt.Keyring
+
+		if keyring == nil {
+			keyring = GetKeyring()
+		}
+
+		secret := opt.Secret
+
+		if len(secret) == 0 {
+			secret = keyring.legacySecret
+		}
+
 		claims := &Claim{}
-		tkn, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
-			return []byte(opt.Secret), nil
-		})
+		tkn, err := keyring.parse(token, claims, secret)
 
 		if err != nil {
+			if IsSigningKeyRejected(err) {
+				c.AbortWithError(
+					http.StatusUnauthorized,
+					apperr.NewErr(
+						apperr.SigningKeyNotAccepted,
+						err.Error(),
+					),
+				)
+
+				return
+			}
+
 			if err == jwt.ErrSignatureInvalid {
 				c.AbortWithError(
 					http.StatusUnauthorized,
@@ -113,9 +140,23 @@ func JwtValidator(opt JwtMiddlewareOptions, authDaoer contracts.AuthDaoer) gin.H
 			return
 		}
 
+		// Refresh token can only be exchanged for a new token pair.
+		if claims.IsRefreshToken() {
+			c.AbortWithError(
+				http.StatusUnauthorized,
+				apperr.NewErr(apperr.RefreshTokenNotAllowed),
+			)
+
+			return
+		}
+
 		// Check redis to makesure the jwt token is valid.
 		ctx := context.Background()
-		isInvalid, err := authDaoer.IsTokenInvalid(ctx, token)
+		isInvalid, err := authDaoer.IsTokenInvalid(ctx, contracts.IsTokenInvalidParams{
+			Token:     token,
+			TokenType: string(claims.TokenType),
+			Family:    claims.Family,
+		})
 
 		if err != nil {
 			c.AbortWithError(
@@ -140,9 +181,71 @@ func JwtValidator(opt JwtMiddlewareOptions, authDaoer contracts.AuthDaoer) gin.H
 			return
 		}
 
+		// Suspended users are rejected even if the token has not been revoked yet.
+		mark, err := authDaoer.GetUserSuspension(ctx, claims.Uuid)
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToCheckUserSuspension,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		if AbortIfSuspended(c, mark) {
+			return
+		}
+
 		// ------------------- set uuid and jwt -------------------
 		c.Set("uuid", claims.Uuid)
 		c.Set("jwt", token)
+		c.Set("token_family", claims.Family)
+		c.Set("roles", claims.Roles)
+
+		c.Next()
+	}
+}
+
+// AbortIfSuspended rejects the request with the error code of the suspension. Banned users get a
+// different code so that the app can tell the user the account is not coming back.
+func AbortIfSuspended(c *gin.Context, mark *contracts.UserSuspensionMark) bool {
+	if mark == nil {
+		return false
+	}
+
+	code := apperr.UserSuspended
+
+	if mark.SuspensionType == models.UserSuspensionTypeBan {
+		code = apperr.UserBanned
+	}
+
+	c.AbortWithError(
+		http.StatusForbidden,
+		apperr.NewErr(code),
+	)
+
+	return true
+}
+
+// RequireRole only allows requests that carry one of the given roles in the jwt claim. It
+// must be placed after `JwtValidator`.
+func RequireRole(roles ...models.UserRoleType) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted, _ := c.MustGet("roles").([]models.UserRoleType)
+		claim := Claim{Roles: granted}
+
+		if !claim.HasAnyRole(roles...) {
+			c.AbortWithError(
+				http.StatusForbidden,
+				apperr.NewErr(apperr.RoleNotPermitted),
+			)
+
+			return
+		}
 
 		c.Next()
 	}
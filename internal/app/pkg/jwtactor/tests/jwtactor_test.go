@@ -0,0 +1,255 @@
+package tests
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+const testSecret = "jwtactor_test_secret"
+
+type JwtActorTestSuite struct {
+	suite.Suite
+	keys []models.JwtSigningKey
+}
+
+func (suite *JwtActorTestSuite) SetupTest() {
+	suite.keys = make([]models.JwtSigningKey, 0)
+
+	jwtactor.SetKeyring(
+		jwtactor.NewKeyring(
+			testSecret,
+			func() ([]models.JwtSigningKey, error) {
+				return suite.keys, nil
+			},
+			time.Hour,
+		),
+	)
+}
+
+func (suite *JwtActorTestSuite) TearDownTest() {
+	jwtactor.SetKeyring(nil)
+}
+
+// setKeyStatus changes the status of the key in storage and reloads the keyring as `util jwtkey`
+// would on the next refresh.
+func (suite *JwtActorTestSuite) setKeyStatus(kid string, status models.JwtKeyStatus) {
+	defer func() {
+		if err := jwtactor.GetKeyring().Reload(); err != nil {
+			suite.T().Fatal(err)
+		}
+	}()
+
+	for i, key := range suite.keys {
+		if key.Kid == kid {
+			suite.keys[i].Status = status
+
+			return
+		}
+	}
+
+	suite.keys = append(suite.keys, models.JwtSigningKey{
+		Kid:    kid,
+		Secret: kid + "_secret",
+		Status: status,
+	})
+}
+
+func (suite *JwtActorTestSuite) TestAccessTokenCarriesRoles() {
+	pair, err := jwtactor.CreateTokenPair(jwtactor.CreateTokenPairParams{
+		Uuid:  "some-uuid",
+		Roles: []models.UserRoleType{models.UserRoleTypeSupport},
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	claims, err := jwtactor.ParseToken(pair.Jwt, testSecret)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert := assert.New(suite.T())
+	assert.True(claims.HasAnyRole(models.UserRoleTypeAdmin, models.UserRoleTypeSupport))
+	assert.False(claims.HasAnyRole(models.UserRoleTypeFinance))
+
+	// Refresh token does not need to carry roles since roles are retrieved on every rotation.
+	refreshClaims, err := jwtactor.ParseToken(pair.RefreshToken, testSecret)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Empty(refreshClaims.Roles)
+}
+
+func (suite *JwtActorTestSuite) TestRotateSigningKey() {
+	assert := assert.New(suite.T())
+
+	// Signed by the legacy secret before any key is activated.
+	legacyToken, err := jwtactor.CreateTokenWithKeyID("some-uuid")
+	assert.NoError(err)
+
+	suite.setKeyStatus("key1", models.JwtKeyStatusStandby)
+	suite.setKeyStatus("key1", models.JwtKeyStatusActive)
+
+	key1Token, err := jwtactor.CreateTokenWithKeyID("some-uuid")
+	assert.NoError(err)
+
+	parsed, _ := jwt.Parse(key1Token, nil)
+	assert.Equal("key1", parsed.Header["kid"])
+
+	suite.setKeyStatus("key2", models.JwtKeyStatusActive)
+	suite.setKeyStatus("key1", models.JwtKeyStatusStandby)
+
+	key2Token, err := jwtactor.CreateTokenWithKeyID("some-uuid")
+	assert.NoError(err)
+
+	parsed, _ = jwt.Parse(key2Token, nil)
+	assert.Equal("key2", parsed.Header["kid"])
+
+	// Tokens signed by keys that are not retired are still valid.
+	for _, token := range []string{legacyToken, key1Token, key2Token} {
+		_, err := jwtactor.ParseToken(token, testSecret)
+		assert.NoError(err)
+	}
+
+	suite.setKeyStatus("key1", models.JwtKeyStatusRetired)
+	suite.setKeyStatus(jwtactor.LegacyKeyID, models.JwtKeyStatusRetired)
+
+	for _, token := range []string{legacyToken, key1Token} {
+		_, err := jwtactor.ParseToken(token, testSecret)
+		assert.True(jwtactor.IsSigningKeyRejected(err))
+	}
+
+	_, err = jwtactor.ParseToken(key2Token, testSecret)
+	assert.NoError(err)
+
+	// `gen_jwt --secret` signs with the given secret only.
+	withSecret, err := jwtactor.CreateToken("some-uuid", "unknown_secret")
+	assert.NoError(err)
+
+	_, err = jwtactor.ParseToken(withSecret, testSecret)
+	assert.Error(err)
+}
+
+func (suite *JwtActorTestSuite) TestRequireRole() {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		roles  []models.UserRoleType
+		status int
+	}{
+		{"app user", nil, http.StatusForbidden},
+		{"role not permitted", []models.UserRoleType{models.UserRoleTypeSupport}, http.StatusForbidden},
+		{"role permitted", []models.UserRoleType{models.UserRoleTypeFinance}, http.StatusOK},
+	}
+
+	for _, test := range tests {
+		e := gin.New()
+		e.GET(
+			"/",
+			func(c *gin.Context) {
+				c.Set("roles", test.roles)
+				c.Next()
+			},
+			jwtactor.RequireRole(models.UserRoleTypeAdmin, models.UserRoleTypeFinance),
+			func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			},
+		)
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/", nil)
+		e.ServeHTTP(rr, req)
+
+		assert.Equal(suite.T(), test.status, rr.Code, test.name)
+	}
+}
+
+func (suite *JwtActorTestSuite) TestKeepServingKeysWhenReloadFails() {
+	var (
+		numLoads int32
+		failing  int32
+	)
+
+	keys := []models.JwtSigningKey{
+		{
+			Kid:    "key1",
+			Secret: "key1_secret",
+			Status: models.JwtKeyStatusActive,
+		},
+	}
+
+	jwtactor.SetKeyring(
+		jwtactor.NewKeyring(
+			testSecret,
+			func() ([]models.JwtSigningKey, error) {
+				atomic.AddInt32(&numLoads, 1)
+
+				if atomic.LoadInt32(&failing) == 1 {
+					return nil, errors.New("storage unavailable")
+				}
+
+				return keys, nil
+			},
+			time.Nanosecond,
+		),
+	)
+
+	assert := assert.New(suite.T())
+
+	token, err := jwtactor.CreateTokenWithKeyID("some-uuid")
+	assert.NoError(err)
+	assert.Equal(int32(1), atomic.LoadInt32(&numLoads))
+
+	atomic.StoreInt32(&failing, 1)
+
+	// Stale keys are reloaded in the background, the failing reload is not retried by each request.
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := jwtactor.ParseToken(token, testSecret)
+			assert.NoError(err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Eventually(func() bool {
+		return atomic.LoadInt32(&numLoads) == 2
+	}, time.Second, time.Millisecond*10)
+
+	// Let the failing reload settle its backoff.
+	time.Sleep(time.Millisecond * 50)
+
+	for i := 0; i < 10; i++ {
+		_, err := jwtactor.ParseToken(token, testSecret)
+		assert.NoError(err)
+	}
+
+	assert.Equal(int32(2), atomic.LoadInt32(&numLoads))
+}
+
+func TestJwtActorTestSuite(t *testing.T) {
+	suite.Run(t, new(JwtActorTestSuite))
+}
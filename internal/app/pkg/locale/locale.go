@@ -0,0 +1,167 @@
+// Package locale resolves the language messages are delivered in. Locale of a request is negotiated
+// from the `Accept-Language` header, push notifications are delivered in the locale of the recipient.
+// Messages missing in the locale fall back to english, then to traditional chinese.
+package locale
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+const (
+	ZhTW = "zh-TW"
+	En   = "en"
+	Ja   = "ja"
+
+	// Default locale of users who have not picked one. Users from Taiwan are the majority.
+	Default = ZhTW
+
+	// ContextKey gin context key of the locale negotiated by `Negotiate`.
+	ContextKey = "locale"
+)
+
+// Supported locales in the order of preference when the requested one can not be matched exactly.
+var Supported = []string{ZhTW, En, Ja}
+
+var matcher = language.NewMatcher([]language.Tag{
+	language.MustParse(ZhTW),
+	language.MustParse(En),
+	language.MustParse(Ja),
+})
+
+// Match picks the supported locale closest to the given language tags. Empty string is returned if
+// none of the tags can be matched.
+func Match(tags ...language.Tag) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	_, idx, conf := matcher.Match(tags...)
+
+	if conf == language.No {
+		return ""
+	}
+
+	return Supported[idx]
+}
+
+// Normalize maps a language tag such as `zh-Hant`, `en-US` or `ja-JP` to a supported locale. Empty
+// string is returned if the tag is invalid or not supported.
+func Normalize(tag string) string {
+	t, err := language.Parse(strings.TrimSpace(tag))
+
+	if err != nil {
+		return ""
+	}
+
+	return Match(t)
+}
+
+// ParseAcceptLanguage picks the supported locale preferred by the `Accept-Language` header. Empty
+// string is returned if none of the languages is supported.
+func ParseAcceptLanguage(header string) string {
+	if len(header) == 0 {
+		return ""
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(header)
+
+	if err != nil {
+		return ""
+	}
+
+	return Match(tags...)
+}
+
+// IsSupported checks if the locale is exactly one of the supported locales.
+func IsSupported(l string) bool {
+	for _, s := range Supported {
+		if s == l {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FallbackChain lists locales to look up a message in, starting from the given locale.
+func FallbackChain(l string) []string {
+	chain := make([]string, 0, 3)
+
+	if IsSupported(l) {
+		chain = append(chain, l)
+	}
+
+	for _, f := range []string{En, ZhTW} {
+		if f != l {
+			chain = append(chain, f)
+		}
+	}
+
+	return chain
+}
+
+// Resolve picks the locale of the request if negotiated, the stored locale of the user otherwise.
+func Resolve(requested, stored string) string {
+	if IsSupported(requested) {
+		return requested
+	}
+
+	if IsSupported(stored) {
+		return stored
+	}
+
+	return Default
+}
+
+// Catalog messages of each locale identified by key, e.g. error code.
+type Catalog map[string]map[string]string
+
+// Get retrieves the message along the fallback chain of the locale.
+func (cat Catalog) Get(l, key string) (string, bool) {
+	for _, f := range FallbackChain(l) {
+		if msg, exists := cat[f][key]; exists {
+			return msg, true
+		}
+	}
+
+	return "", false
+}
+
+// MergeCatalogs merges catalogs locale by locale. Latter catalog wins on conflicting keys.
+func MergeCatalogs(cats ...Catalog) Catalog {
+	merged := make(Catalog)
+
+	for _, cat := range cats {
+		for l, msgs := range cat {
+			if _, exists := merged[l]; !exists {
+				merged[l] = make(map[string]string)
+			}
+
+			for k, v := range msgs {
+				merged[l][k] = v
+			}
+		}
+	}
+
+	return merged
+}
+
+// Negotiate stores the locale negotiated from `Accept-Language` in the context. Nothing is stored
+// if none of the languages is supported so that handlers can fall back to the locale of the user.
+func Negotiate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l := ParseAcceptLanguage(c.GetHeader("Accept-Language")); len(l) > 0 {
+			c.Set(ContextKey, l)
+		}
+
+		c.Next()
+	}
+}
+
+// FromContext retrieves the locale negotiated for the request. Empty string if not negotiated.
+func FromContext(c *gin.Context) string {
+	return c.GetString(ContextKey)
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocaleTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LocaleTestSuite) TestParseAcceptLanguage() {
+	assert := assert.New(suite.T())
+
+	assert.Equal(locale.Ja, locale.ParseAcceptLanguage("ja-JP,ja;q=0.9,en;q=0.8"))
+	assert.Equal(locale.En, locale.ParseAcceptLanguage("en-US,en;q=0.9"))
+	assert.Equal(locale.ZhTW, locale.ParseAcceptLanguage("zh-Hant-TW"))
+	assert.Equal("", locale.ParseAcceptLanguage(""))
+}
+
+func (suite *LocaleTestSuite) TestNormalize() {
+	assert := assert.New(suite.T())
+
+	assert.Equal(locale.En, locale.Normalize("en-GB"))
+	assert.Equal(locale.Ja, locale.Normalize("ja"))
+	assert.Equal("", locale.Normalize("not a tag!"))
+}
+
+func (suite *LocaleTestSuite) TestResolvePrefersRequestedLocale() {
+	assert := assert.New(suite.T())
+
+	assert.Equal(locale.Ja, locale.Resolve(locale.Ja, locale.En))
+	assert.Equal(locale.En, locale.Resolve("", locale.En))
+	assert.Equal(locale.Default, locale.Resolve("", ""))
+}
+
+func (suite *LocaleTestSuite) TestCatalogFallsBack() {
+	assert := assert.New(suite.T())
+
+	cat := locale.Catalog{
+		locale.ZhTW: {"a": "zh a", "b": "zh b"},
+		locale.En:   {"a": "en a"},
+	}
+
+	msg, _ := cat.Get(locale.Ja, "a")
+	assert.Equal("en a", msg)
+
+	msg, _ = cat.Get(locale.Ja, "b")
+	assert.Equal("zh b", msg)
+
+	_, exists := cat.Get(locale.Ja, "c")
+	assert.False(exists)
+}
+
+func TestLocaleTestSuite(t *testing.T) {
+	suite.Run(t, new(LocaleTestSuite))
+}
@@ -0,0 +1,333 @@
+// Package otpguard protects SMS OTP endpoints from brute-force attacks and SMS bombing.
+package otpguard
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+)
+
+// Failed verify attempts are counted per user and per IP. Once the number of failed attempts
+// exceeds the limit, the subject is locked out. Lockout duration doubles on each consecutive lockout:
+//
+//	otp_attempts:{{ SCOPE }}:{{ KIND }}:{{ VALUE }}       number of failed attempts
+//	otp_lockout:{{ SCOPE }}:{{ KIND }}:{{ VALUE }}        exists while the subject is locked out
+//	otp_lockout_level:{{ SCOPE }}:{{ KIND }}:{{ VALUE }}  number of consecutive lockouts
+//
+// Sending verify code SMS is throttled per user, mobile and IP:
+//
+//	otp_send_cooldown:{{ SCOPE }}:{{ KIND }}:{{ VALUE }}  exists within the cooldown interval
+//	otp_sends:{{ SCOPE }}:{{ KIND }}:{{ VALUE }}          number of SMS sent within an hour
+const (
+	AttemptsRedisKey     = "otp_attempts:%s:%s:%s"
+	LockoutRedisKey      = "otp_lockout:%s:%s:%s"
+	LockoutLevelRedisKey = "otp_lockout_level:%s:%s:%s"
+	SendCooldownRedisKey = "otp_send_cooldown:%s:%s:%s"
+	SendsRedisKey        = "otp_sends:%s:%s:%s"
+
+	// Consecutive lockouts are forgotten after a day without lockout.
+	lockoutLevelTTL = time.Hour * 24
+	sendsWindow     = time.Hour
+)
+
+type Scope string
+
+var (
+	LoginScope        Scope = "login"
+	RegisterScope     Scope = "register"
+	ChangeMobileScope Scope = "change_mobile"
+)
+
+type subjectKind string
+
+var (
+	userKind   subjectKind = "user"
+	mobileKind subjectKind = "mobile"
+	ipKind     subjectKind = "ip"
+)
+
+// Subjects identifies who is requesting the OTP. Empty fields are ignored.
+type Subjects struct {
+	UserUuid string
+	Mobile   string
+	IP       string
+}
+
+type subject struct {
+	kind  subjectKind
+	value string
+}
+
+func (s Subjects) list() []subject {
+	subs := make([]subject, 0)
+
+	if len(s.UserUuid) > 0 {
+		subs = append(subs, subject{userKind, s.UserUuid})
+	}
+
+	if len(s.Mobile) > 0 {
+		subs = append(subs, subject{mobileKind, s.Mobile})
+	}
+
+	if len(s.IP) > 0 {
+		subs = append(subs, subject{ipKind, s.IP})
+	}
+
+	return subs
+}
+
+type OTPGuard struct {
+	redis  *redis.Client
+	limits config.OTPLimits
+}
+
+func New(redis *redis.Client, limits config.OTPLimits) *OTPGuard {
+	return &OTPGuard{
+		redis:  redis,
+		limits: limits,
+	}
+}
+
+func key(tmpl string, scope Scope, sub subject) string {
+	return fmt.Sprintf(tmpl, scope, sub.kind, sub.value)
+}
+
+func (g *OTPGuard) maxAttempts(sub subject) int {
+	if sub.kind == ipKind {
+		return g.limits.MaxAttemptsPerIP
+	}
+
+	return g.limits.MaxAttempts
+}
+
+func (g *OTPGuard) maxSends(sub subject) int {
+	if sub.kind == ipKind {
+		return g.limits.MaxSendsPerHourPerIP
+	}
+
+	return g.limits.MaxSendsPerHour
+}
+
+// CheckLockout returns the remaining lockout duration if any of the subjects is locked out.
+func (g *OTPGuard) CheckLockout(ctx context.Context, scope Scope, s Subjects) (time.Duration, error) {
+	var retryAfter time.Duration
+
+	for _, sub := range s.list() {
+		ttl, err := g.redis.TTL(ctx, key(LockoutRedisKey, scope, sub)).Result()
+
+		if err != nil {
+			return 0, err
+		}
+
+		if ttl > retryAfter {
+			retryAfter = ttl
+		}
+	}
+
+	return retryAfter, nil
+}
+
+// RecordFailure increments the failed attempt counter of each subject. If any subject exceeds the limit,
+// the subject is locked out and the lockout duration is returned.
+func (g *OTPGuard) RecordFailure(ctx context.Context, scope Scope, s Subjects) (time.Duration, error) {
+	var retryAfter time.Duration
+
+	for _, sub := range s.list() {
+		attemptsKey := key(AttemptsRedisKey, scope, sub)
+
+		numAttempts, err := g.redis.Incr(ctx, attemptsKey).Result()
+
+		if err != nil {
+			return 0, err
+		}
+
+		if numAttempts == 1 {
+			if err := g.redis.Expire(ctx, attemptsKey, g.limits.LockoutMax).Err(); err != nil {
+				return 0, err
+			}
+		}
+
+		if numAttempts < int64(g.maxAttempts(sub)) {
+			continue
+		}
+
+		lockout, err := g.lockout(ctx, scope, sub)
+
+		if err != nil {
+			return 0, err
+		}
+
+		if lockout > retryAfter {
+			retryAfter = lockout
+		}
+	}
+
+	return retryAfter, nil
+}
+
+func (g *OTPGuard) lockout(ctx context.Context, scope Scope, sub subject) (time.Duration, error) {
+	levelKey := key(LockoutLevelRedisKey, scope, sub)
+
+	level, err := g.redis.Incr(ctx, levelKey).Result()
+
+	if err != nil {
+		return 0, err
+	}
+
+	lockout := g.limits.LockoutBase
+
+	for i := int64(1); i < level && lockout < g.limits.LockoutMax; i++ {
+		lockout *= 2
+	}
+
+	if lockout > g.limits.LockoutMax {
+		lockout = g.limits.LockoutMax
+	}
+
+	pipe := g.redis.TxPipeline()
+	defer pipe.Close()
+
+	pipe.Expire(ctx, levelKey, lockoutLevelTTL)
+	pipe.Set(ctx, key(LockoutRedisKey, scope, sub), level, lockout)
+	pipe.Del(ctx, key(AttemptsRedisKey, scope, sub))
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	return lockout, nil
+}
+
+// Reset clears failed attempts of the user once the OTP is verified. IP counters are kept
+// since a successful verification does not prove that other attempts from the same IP are legit.
+func (g *OTPGuard) Reset(ctx context.Context, scope Scope, s Subjects) error {
+	if len(s.UserUuid) == 0 {
+		return nil
+	}
+
+	sub := subject{userKind, s.UserUuid}
+
+	return g.redis.Del(
+		ctx,
+		key(AttemptsRedisKey, scope, sub),
+		key(LockoutLevelRedisKey, scope, sub),
+	).Err()
+}
+
+// consumeSendQuotaScript checks and consumes the send quota of all subjects atomically so that
+// concurrent sends can not pass the limit together. Quota is consumed only if none of the subjects
+// is in cooldown or has reached the limit, otherwise the longest wait in milliseconds is returned.
+//
+//	KEYS  cooldown key and sends key of each subject in pairs
+//	ARGV  cooldown in ms, sends window in ms, followed by the send limit of each subject
+var consumeSendQuotaScript = redis.NewScript(`
+local cooldown = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local retry = 0
+
+for i = 1, #KEYS, 2 do
+	local ttl = redis.call('PTTL', KEYS[i])
+
+	if ttl > retry then
+		retry = ttl
+	end
+
+	local sends = tonumber(redis.call('GET', KEYS[i + 1]) or '0')
+
+	if sends >= tonumber(ARGV[2 + (i + 1) / 2]) then
+		ttl = redis.call('PTTL', KEYS[i + 1])
+
+		if ttl < 0 then
+			redis.call('PEXPIRE', KEYS[i + 1], window)
+			ttl = window
+		end
+
+		if ttl > retry then
+			retry = ttl
+		end
+	end
+end
+
+if retry > 0 then
+	return retry
+end
+
+for i = 1, #KEYS, 2 do
+	redis.call('SET', KEYS[i], 1, 'PX', cooldown)
+
+	if redis.call('INCR', KEYS[i + 1]) == 1 then
+		redis.call('PEXPIRE', KEYS[i + 1], window)
+	end
+end
+
+return 0
+`)
+
+// ConsumeSendQuota checks whether a verify code SMS can be sent to the subjects. If so, the quota is consumed.
+// Otherwise, duration the client has to wait is returned.
+func (g *OTPGuard) ConsumeSendQuota(ctx context.Context, scope Scope, s Subjects) (time.Duration, error) {
+	subs := s.list()
+
+	if len(subs) == 0 {
+		return 0, nil
+	}
+
+	keys := make([]string, 0, len(subs)*2)
+	args := []interface{}{
+		g.limits.SendCooldown.Milliseconds(),
+		sendsWindow.Milliseconds(),
+	}
+
+	for _, sub := range subs {
+		keys = append(
+			keys,
+			key(SendCooldownRedisKey, scope, sub),
+			key(SendsRedisKey, scope, sub),
+		)
+		args = append(args, g.maxSends(sub))
+	}
+
+	retryAfterMs, err := consumeSendQuotaScript.Run(ctx, g.redis, keys, args...).Int64()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(retryAfterMs) * time.Millisecond, nil
+}
+
+// HandleRetryAfter aborts the request if error occurs when checking the guard or the client has to wait
+// before retrying. Returns true if the request is aborted.
+func HandleRetryAfter(c *gin.Context, retryAfter time.Duration, err error, errCode string) bool {
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckOTPGuard,
+				err.Error(),
+			),
+		)
+
+		return true
+	}
+
+	if retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+		c.AbortWithError(
+			http.StatusTooManyRequests,
+			apperr.NewRetryAfterErr(errCode, retryAfter),
+		)
+
+		return true
+	}
+
+	return false
+}
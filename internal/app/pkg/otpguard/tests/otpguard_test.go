@@ -0,0 +1,162 @@
+package tests
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/otpguard"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"github.com/teris-io/shortid"
+)
+
+type OTPGuardTestSuite struct {
+	suite.Suite
+	guard *otpguard.OTPGuard
+}
+
+func (suite *OTPGuardTestSuite) SetupSuite() {
+	manager.NewDefaultManager(context.Background())
+
+	suite.guard = otpguard.New(db.GetRedis(), config.OTPLimits{
+		MaxAttempts:          3,
+		MaxAttemptsPerIP:     10,
+		LockoutBase:          time.Minute,
+		LockoutMax:           time.Minute * 3,
+		SendCooldown:         time.Minute,
+		MaxSendsPerHour:      2,
+		MaxSendsPerHourPerIP: 10,
+	})
+}
+
+func (suite *OTPGuardTestSuite) genSubjects() otpguard.Subjects {
+	uuid, _ := shortid.Generate()
+
+	ip, _ := shortid.Generate()
+
+	return otpguard.Subjects{
+		UserUuid: uuid,
+		IP:       ip,
+	}
+}
+
+func (suite *OTPGuardTestSuite) TestLockoutAfterMaxAttempts() {
+	ctx := context.Background()
+	subjects := suite.genSubjects()
+
+	for i := 0; i < 2; i++ {
+		retryAfter, err := suite.guard.RecordFailure(ctx, otpguard.LoginScope, subjects)
+
+		if err != nil {
+			suite.T().Fatal(err)
+		}
+
+		assert.Equal(suite.T(), time.Duration(0), retryAfter)
+	}
+
+	retryAfter, err := suite.guard.RecordFailure(ctx, otpguard.LoginScope, subjects)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), time.Minute, retryAfter)
+
+	locked, err := suite.guard.CheckLockout(ctx, otpguard.LoginScope, subjects)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.True(suite.T(), locked > 0)
+}
+
+func (suite *OTPGuardTestSuite) TestLockoutDoublesAndCaps() {
+	ctx := context.Background()
+	subjects := otpguard.Subjects{UserUuid: suite.genSubjects().UserUuid}
+
+	expected := []time.Duration{time.Minute, time.Minute * 2, time.Minute * 3}
+
+	for _, exp := range expected {
+		var retryAfter time.Duration
+
+		for i := 0; i < 3; i++ {
+			ra, err := suite.guard.RecordFailure(ctx, otpguard.LoginScope, subjects)
+
+			if err != nil {
+				suite.T().Fatal(err)
+			}
+
+			retryAfter = ra
+		}
+
+		assert.Equal(suite.T(), exp, retryAfter)
+	}
+}
+
+func (suite *OTPGuardTestSuite) TestSendCooldown() {
+	ctx := context.Background()
+	subjects := otpguard.Subjects{Mobile: suite.genSubjects().UserUuid}
+
+	retryAfter, err := suite.guard.ConsumeSendQuota(ctx, otpguard.RegisterScope, subjects)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), time.Duration(0), retryAfter)
+
+	retryAfter, err = suite.guard.ConsumeSendQuota(ctx, otpguard.RegisterScope, subjects)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.True(suite.T(), retryAfter > 0)
+}
+
+func (suite *OTPGuardTestSuite) TestConcurrentSendsConsumeQuotaOnce() {
+	ctx := context.Background()
+	subjects := suite.genSubjects()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		sent int
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			retryAfter, err := suite.guard.ConsumeSendQuota(ctx, otpguard.RegisterScope, subjects)
+
+			if err != nil {
+				suite.T().Error(err)
+
+				return
+			}
+
+			if retryAfter == 0 {
+				mu.Lock()
+				sent++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(suite.T(), 1, sent)
+}
+
+func TestOTPGuardTestSuite(t *testing.T) {
+	suite.Run(t, new(OTPGuardTestSuite))
+}
@@ -0,0 +1,72 @@
+package smssender
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FailoverSender tries each sender in order until one of them delivers the message.
+type FailoverSender struct {
+	senders []SMSSender
+}
+
+func NewFailoverSender(senders ...SMSSender) *FailoverSender {
+	return &FailoverSender{
+		senders: senders,
+	}
+}
+
+func (s *FailoverSender) Driver() Driver {
+	return FailoverDriver
+}
+
+type FailoverError struct {
+	Errs []error
+}
+
+func (e *FailoverError) Error() string {
+	msgs := make([]string, 0)
+
+	for _, err := range e.Errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	return fmt.Sprintf("all sms drivers failed: %s", strings.Join(msgs, "; "))
+}
+
+// Unwrap returns the error of the primary driver so that callers can inspect provider specific errors.
+func (e *FailoverError) Unwrap() error {
+	if len(e.Errs) == 0 {
+		return nil
+	}
+
+	return e.Errs[0]
+}
+
+func (s *FailoverSender) Send(ctx context.Context, msg Message) (*Result, error) {
+	errs := make([]error, 0)
+
+	for _, sender := range s.senders {
+		res, err := sender.Send(ctx, msg)
+
+		if err == nil {
+			return res, nil
+		}
+
+		log.
+			WithFields(log.Fields{
+				"driver": sender.Driver(),
+				"to":     msg.To,
+			}).
+			Warnf("failed to send sms, failing over to next driver: %v", err)
+
+		errs = append(errs, err)
+	}
+
+	return nil, &FailoverError{
+		Errs: errs,
+	}
+}
@@ -0,0 +1,88 @@
+package smssender
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+// SpooledMessage is written to the spool file as a JSON line.
+type SpooledMessage struct {
+	ID      string    `json:"id"`
+	To      string    `json:"to"`
+	Content string    `json:"content"`
+	SentAt  time.Time `json:"sent_at"`
+}
+
+// FileSender appends messages to a local spool file instead of delivering them.
+type FileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSender(path string) *FileSender {
+	return &FileSender{
+		path: path,
+	}
+}
+
+func (s *FileSender) Driver() Driver {
+	return FileDriver
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) (*Result, error) {
+	ID, err := shortid.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := json.Marshal(SpooledMessage{
+		ID:      ID,
+		To:      msg.To,
+		Content: msg.Content,
+		SentAt:  time.Now(),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.path), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return nil, err
+	}
+
+	log.
+		WithFields(log.Fields{
+			"id":    ID,
+			"to":    msg.To,
+			"spool": s.path,
+		}).
+		Info("sms written to spool")
+
+	return &Result{
+		Driver:    FileDriver,
+		MessageID: ID,
+	}, nil
+}
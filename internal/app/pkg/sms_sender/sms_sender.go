@@ -0,0 +1,134 @@
+// Package smssender sends SMS messages via a provider agnostic interface. The driver in use is picked
+// through `SMS_DRIVER` in app config:
+//
+//	twilio     sends SMS via twilio live credentials.
+//	twilio_dev sends SMS via twilio test credentials. No real message is delivered.
+//	file       appends messages to a local spool file. Useful for development and tests.
+//	failover   tries drivers listed in `SMS_FAILOVER_DRIVERS` in order until one of them succeeds.
+package smssender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/twilio"
+)
+
+type Driver string
+
+var (
+	TwilioDriver    Driver = "twilio"
+	TwilioDevDriver Driver = "twilio_dev"
+	FileDriver      Driver = "file"
+	FailoverDriver  Driver = "failover"
+)
+
+type Message struct {
+	To      string
+	Content string
+}
+
+type Result struct {
+	// Driver that actually delivered the message.
+	Driver    Driver
+	MessageID string
+}
+
+type SMSSender interface {
+	Send(ctx context.Context, msg Message) (*Result, error)
+	Driver() Driver
+}
+
+// New creates SMS sender of the given driver.
+func New(driver Driver, conf *config.AppConf) (SMSSender, error) {
+	switch driver {
+	case "", TwilioDriver:
+		return NewTwilioSender(
+			TwilioDriver,
+			twilio.New(twilio.TwilioConf{
+				AccountSID:   conf.TwilioAccountID,
+				AccountToken: conf.TwilioAuthToken,
+			}),
+			conf.TwilioFrom,
+		), nil
+	case TwilioDevDriver:
+		return NewTwilioSender(
+			TwilioDevDriver,
+			twilio.New(twilio.TwilioConf{
+				AccountSID:   conf.TwilioDevAccountID,
+				AccountToken: conf.TwilioDevAuthToken,
+			}),
+			conf.TwilioDevFrom,
+		), nil
+	case FileDriver:
+		return NewFileSender(conf.GetSMSSpoolPath()), nil
+	case FailoverDriver:
+		senders := make([]SMSSender, 0)
+
+		for _, d := range strings.Split(conf.SMSFailoverDrivers, ",") {
+			d = strings.TrimSpace(d)
+
+			if len(d) == 0 {
+				continue
+			}
+
+			if Driver(d) == FailoverDriver {
+				return nil, errors.New("failover driver can not fail over to itself")
+			}
+
+			sender, err := New(Driver(d), conf)
+
+			if err != nil {
+				return nil, err
+			}
+
+			senders = append(senders, sender)
+		}
+
+		if len(senders) == 0 {
+			return nil, errors.New("no driver is specified for failover driver")
+		}
+
+		return NewFailoverSender(senders...), nil
+	}
+
+	return nil, fmt.Errorf("unknown sms driver %s", driver)
+}
+
+// HandleSendSMSError normalizes the error from sending SMS into darkpanda application
+// repliable response and send the response to the client.
+func HandleSendSMSError(c *gin.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var smsErr *twilio.SMSError
+
+	if errors.As(err, &smsErr) {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.TwilioRespErr,
+				err.Error(),
+			),
+		)
+
+		return err
+	}
+
+	c.AbortWithError(
+		http.StatusInternalServerError,
+		apperr.NewErr(
+			apperr.FailedToSendTwilioSMSErr,
+			err.Error(),
+		),
+	)
+
+	return err
+}
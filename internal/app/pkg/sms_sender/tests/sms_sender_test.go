@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	smssender "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/sms_sender"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type failingSender struct{}
+
+func (s *failingSender) Driver() smssender.Driver {
+	return smssender.TwilioDriver
+}
+
+func (s *failingSender) Send(ctx context.Context, msg smssender.Message) (*smssender.Result, error) {
+	return nil, errors.New("provider is down")
+}
+
+type SMSSenderTestSuite struct {
+	suite.Suite
+	spoolPath string
+}
+
+func (suite *SMSSenderTestSuite) SetupTest() {
+	dir, err := ioutil.TempDir("", "sms_spool")
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.spoolPath = filepath.Join(dir, "spool.log")
+}
+
+func (suite *SMSSenderTestSuite) TearDownTest() {
+	os.RemoveAll(filepath.Dir(suite.spoolPath))
+}
+
+func (suite *SMSSenderTestSuite) readSpool() []smssender.SpooledMessage {
+	f, err := os.Open(suite.spoolPath)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	defer f.Close()
+
+	msgs := make([]smssender.SpooledMessage, 0)
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		var msg smssender.SpooledMessage
+
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			suite.T().Fatal(err)
+		}
+
+		msgs = append(msgs, msg)
+	}
+
+	return msgs
+}
+
+func (suite *SMSSenderTestSuite) TestFileSenderWritesToSpool() {
+	sender := smssender.NewFileSender(suite.spoolPath)
+
+	res, err := sender.Send(context.Background(), smssender.Message{
+		To:      "+886988272727",
+		Content: "your darkpanda verify code: abc-1234",
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert := assert.New(suite.T())
+	assert.Equal(smssender.FileDriver, res.Driver)
+
+	msgs := suite.readSpool()
+	assert.Len(msgs, 1)
+	assert.Equal(res.MessageID, msgs[0].ID)
+	assert.Equal("+886988272727", msgs[0].To)
+}
+
+func (suite *SMSSenderTestSuite) TestFailoverToSecondaryDriver() {
+	sender := smssender.NewFailoverSender(
+		&failingSender{},
+		smssender.NewFileSender(suite.spoolPath),
+	)
+
+	res, err := sender.Send(context.Background(), smssender.Message{
+		To:      "+886988272727",
+		Content: "hello",
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), smssender.FileDriver, res.Driver)
+	assert.Len(suite.T(), suite.readSpool(), 1)
+}
+
+func (suite *SMSSenderTestSuite) TestFailoverAllDriversFailed() {
+	sender := smssender.NewFailoverSender(&failingSender{}, &failingSender{})
+
+	_, err := sender.Send(context.Background(), smssender.Message{
+		To:      "+886988272727",
+		Content: "hello",
+	})
+
+	var failoverErr *smssender.FailoverError
+
+	assert.True(suite.T(), errors.As(err, &failoverErr))
+	assert.Len(suite.T(), failoverErr.Errs, 2)
+}
+
+func TestSMSSenderTestSuite(t *testing.T) {
+	suite.Run(t, new(SMSSenderTestSuite))
+}
@@ -0,0 +1,38 @@
+package smssender
+
+import (
+	"context"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/twilio"
+)
+
+type TwilioSender struct {
+	driver Driver
+	client twilio.TwilioServicer
+	from   string
+}
+
+func NewTwilioSender(driver Driver, client twilio.TwilioServicer, from string) *TwilioSender {
+	return &TwilioSender{
+		driver: driver,
+		client: client,
+		from:   from,
+	}
+}
+
+func (s *TwilioSender) Driver() Driver {
+	return s.driver
+}
+
+func (s *TwilioSender) Send(ctx context.Context, msg Message) (*Result, error) {
+	resp, err := s.client.SendSMS(s.from, msg.To, msg.Content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Driver:    s.driver,
+		MessageID: resp.SID,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	usernamepolicy "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/username_policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type UsernamePolicyTestSuite struct {
+	suite.Suite
+	policy *usernamepolicy.Policy
+}
+
+func (suite *UsernamePolicyTestSuite) SetupSuite() {
+	f, err := ioutil.TempFile("", "profanity")
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	defer os.Remove(f.Name())
+
+	f.WriteString("# blocklist\n\nshit\n")
+	f.Close()
+
+	words, err := usernamepolicy.LoadWordList(f.Name())
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.policy = usernamepolicy.NewPolicy(
+		2,
+		10,
+		usernamepolicy.DefaultReservedWords,
+		words,
+	)
+}
+
+func (suite *UsernamePolicyTestSuite) assertRule(username string, rule usernamepolicy.Rule) {
+	err := suite.policy.Validate(username)
+
+	violation, ok := err.(*usernamepolicy.Violation)
+
+	if !ok {
+		suite.T().Fatalf("expected %s to violate %s, got %v", username, rule, err)
+	}
+
+	assert.Equal(suite.T(), rule, violation.Rule)
+}
+
+func (suite *UsernamePolicyTestSuite) TestValidUsername() {
+	assert.Nil(suite.T(), suite.policy.Validate("panda_01"))
+	assert.Nil(suite.T(), suite.policy.Validate("熊貓.girl"))
+}
+
+func (suite *UsernamePolicyTestSuite) TestLength() {
+	suite.assertRule("a", usernamepolicy.RuleTooShort)
+	suite.assertRule("abcdefghijk", usernamepolicy.RuleTooLong)
+}
+
+func (suite *UsernamePolicyTestSuite) TestInvalidCharacters() {
+	suite.assertRule("panda bear", usernamepolicy.RuleInvalidCharacters)
+	suite.assertRule("panda!", usernamepolicy.RuleInvalidCharacters)
+}
+
+func (suite *UsernamePolicyTestSuite) TestReservedWords() {
+	suite.assertRule("Admin_1", usernamepolicy.RuleReserved)
+	suite.assertRule("ａｄｍｉｎ", usernamepolicy.RuleReserved)
+	suite.assertRule("dark.panda", usernamepolicy.RuleReserved)
+	suite.assertRule("my_admin", usernamepolicy.RuleReserved)
+
+	// Words merely containing a reserved word are allowed.
+	assert.Nil(suite.T(), suite.policy.Validate("adminx"))
+	assert.Nil(suite.T(), suite.policy.Validate("superstaff"))
+}
+
+func (suite *UsernamePolicyTestSuite) TestProfanity() {
+	suite.assertRule("bull_shit", usernamepolicy.RuleProfane)
+	suite.assertRule("S_H_I_T", usernamepolicy.RuleProfane)
+	suite.assertRule("shit2", usernamepolicy.RuleProfane)
+
+	// Words merely containing a profane word are allowed.
+	assert.Nil(suite.T(), suite.policy.Validate("shitake"))
+}
+
+func (suite *UsernamePolicyTestSuite) TestNormalize() {
+	assert.Equal(
+		suite.T(),
+		usernamepolicy.Normalize("Panda"),
+		usernamepolicy.Normalize("ｐａｎｄａ"),
+	)
+}
+
+func TestUsernamePolicyTestSuite(t *testing.T) {
+	suite.Run(t, new(UsernamePolicyTestSuite))
+}
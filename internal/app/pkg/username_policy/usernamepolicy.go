@@ -0,0 +1,227 @@
+// Package usernamepolicy validates usernames picked at registration. Usernames are compared in
+// normalized form so that `Panda`, `panda` and `ｐａｎｄａ` are considered the same name.
+package usernamepolicy
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"golang.org/x/text/width"
+)
+
+type Rule string
+
+var (
+	RuleTooShort          Rule = "too_short"
+	RuleTooLong           Rule = "too_long"
+	RuleInvalidCharacters Rule = "invalid_characters"
+	RuleReserved          Rule = "reserved"
+	RuleProfane           Rule = "profane"
+)
+
+// Violation tells which rule the username fails.
+type Violation struct {
+	Rule    Rule
+	Message string
+}
+
+func (v *Violation) Error() string {
+	return v.Message
+}
+
+// DefaultReservedWords names that can only be used by the platform. Usernames containing any of
+// the words as a whole token are rejected, e.g. `admin_1` or `support.team`.
+var DefaultReservedWords = []string{
+	"admin",
+	"darkpanda",
+	"moderator",
+	"official",
+	"staff",
+	"support",
+	"system",
+}
+
+type Policy struct {
+	minLength int
+	maxLength int
+	reserved  map[string]struct{}
+	profanity map[string]struct{}
+}
+
+func NewPolicy(minLength, maxLength int, reserved, profanity []string) *Policy {
+	return &Policy{
+		minLength: minLength,
+		maxLength: maxLength,
+		reserved:  normalizeWords(reserved),
+		profanity: normalizeWords(profanity),
+	}
+}
+
+// New creates the policy from app config. Reserved words in config are used in addition to
+// `DefaultReservedWords`. Profanity blocklist is loaded from the file if specified.
+func New(conf config.UsernamePolicy) (*Policy, error) {
+	reserved := append([]string{}, DefaultReservedWords...)
+	reserved = append(reserved, conf.ReservedWords...)
+
+	profanity := make([]string, 0)
+
+	if len(conf.ProfanityFile) > 0 {
+		words, err := LoadWordList(conf.ProfanityFile)
+
+		if err != nil {
+			return nil, err
+		}
+
+		profanity = words
+	}
+
+	return NewPolicy(conf.MinLength, conf.MaxLength, reserved, profanity), nil
+}
+
+// LoadWordList reads words from the file, one word per line. Empty lines and lines starting
+// with `#` are ignored.
+func LoadWordList(path string) ([]string, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	words := make([]string, 0)
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		words = append(words, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+// Normalize folds full-width characters to half-width, half-width katakana to full-width, and
+// lower cases the username. Uniqueness of usernames is checked against the normalized form.
+func Normalize(username string) string {
+	return strings.ToLower(width.Fold.String(username))
+}
+
+// Validate checks the username against length, charset, reserved words and profanity rules in
+// order. The first rule failed is returned as `*Violation`.
+func (p *Policy) Validate(username string) error {
+	normalized := Normalize(username)
+	length := utf8.RuneCountInString(normalized)
+
+	if length < p.minLength {
+		return &Violation{
+			Rule:    RuleTooShort,
+			Message: fmt.Sprintf("username should be at least %d characters", p.minLength),
+		}
+	}
+
+	if length > p.maxLength {
+		return &Violation{
+			Rule:    RuleTooLong,
+			Message: fmt.Sprintf("username should be at most %d characters", p.maxLength),
+		}
+	}
+
+	for _, r := range normalized {
+		if !isAllowedRune(r) {
+			return &Violation{
+				Rule:    RuleInvalidCharacters,
+				Message: fmt.Sprintf("username contains invalid character %q, only letters, digits, \"_\" and \".\" are allowed", r),
+			}
+		}
+	}
+
+	// Usernames are split into tokens by separators and digits. Words are matched against whole tokens
+	// and runs of adjacent tokens, so `dark.panda` or `s_h_i_t` can not bypass the rules while words
+	// that merely contain a listed word, e.g. `administrator`, are allowed.
+	candidates := tokenRuns(normalized)
+
+	if containsAny(candidates, p.reserved) {
+		return &Violation{
+			Rule:    RuleReserved,
+			Message: "username is reserved",
+		}
+	}
+
+	if containsAny(candidates, p.profanity) {
+		return &Violation{
+			Rule:    RuleProfane,
+			Message: "username contains inappropriate words",
+		}
+	}
+
+	return nil
+}
+
+func isAllowedRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// tokenRuns splits the username into tokens of letters and joins every run of adjacent tokens.
+func tokenRuns(s string) []string {
+	tokens := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	runs := make([]string, 0, len(tokens)*(len(tokens)+1)/2)
+
+	for i := range tokens {
+		for j := i + 1; j <= len(tokens); j++ {
+			runs = append(runs, strings.Join(tokens[i:j], ""))
+		}
+	}
+
+	return runs
+}
+
+func containsAny(candidates []string, words map[string]struct{}) bool {
+	for _, c := range candidates {
+		if _, exists := words[c]; exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stripNonLetters(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return r
+		}
+
+		return -1
+	}, s)
+}
+
+func normalizeWords(words []string) map[string]struct{} {
+	normalized := make(map[string]struct{}, len(words))
+
+	for _, word := range words {
+		word = stripNonLetters(Normalize(word))
+
+		if len(word) > 0 {
+			normalized[word] = struct{}{}
+		}
+	}
+
+	return normalized
+}
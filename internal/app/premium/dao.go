@@ -0,0 +1,329 @@
+package premium
+
+import (
+	"database/sql"
+	"time"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type PremiumDAO struct {
+	db db.Conn
+}
+
+func NewPremiumDAO(db db.Conn) *PremiumDAO {
+	return &PremiumDAO{
+		db: db,
+	}
+}
+
+func PremiumDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.PremiumDAOer {
+			return NewPremiumDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *PremiumDAO) WithTx(tx db.Conn) contracts.PremiumDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+func (dao *PremiumDAO) GetPlans(enabledOnly bool) ([]models.PremiumPlan, error) {
+	query := `
+SELECT
+	*
+FROM
+	premium_plans
+WHERE
+	($1 = false OR enabled = true) AND
+	deleted_at IS NULL
+ORDER BY duration_days, id;
+`
+	rows, err := dao.db.Queryx(query, enabledOnly)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	plans := make([]models.PremiumPlan, 0)
+
+	for rows.Next() {
+		var m models.PremiumPlan
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		plans = append(plans, m)
+	}
+
+	return plans, nil
+}
+
+func (dao *PremiumDAO) GetEnabledPlan(ID int32) (*models.PremiumPlan, error) {
+	query := `
+SELECT
+	*
+FROM
+	premium_plans
+WHERE
+	id = $1 AND
+	enabled = true AND
+	deleted_at IS NULL;
+`
+	var m models.PremiumPlan
+
+	if err := dao.db.QueryRowx(query, ID).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PremiumDAO) CreatePlan(p contracts.CreatePremiumPlanParams) (*models.PremiumPlan, error) {
+	query := `
+INSERT INTO premium_plans (
+	name,
+	duration_days,
+	cost
+) VALUES ($1, $2, $3)
+RETURNING *;
+`
+	var m models.PremiumPlan
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Name,
+		p.DurationDays,
+		p.Cost,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PremiumDAO) SetPlanEnabled(ID int32, enabled bool) error {
+	query := `
+UPDATE premium_plans
+SET enabled = $1
+WHERE
+	id = $2 AND
+	deleted_at IS NULL;
+`
+	res, err := dao.db.Exec(query, enabled, ID)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ExtendPremium upgrades the user to a paid member for the duration. Renewals purchased before
+// expiry are stacked on top of the current expiry, otherwise the membership starts now. The user
+// row stays locked until the transaction ends so that concurrent purchases are serialized.
+func (dao *PremiumDAO) ExtendPremium(userID int64, durationDays int32) (*contracts.PremiumExtension, error) {
+	query := `
+WITH current AS (
+	SELECT
+		id,
+		CASE
+			WHEN premium_type = 'paid' AND premium_expiry_date > NOW() THEN premium_expiry_date
+			ELSE NOW()
+		END AS starts_at
+	FROM users
+	WHERE id = $1
+	FOR UPDATE
+)
+UPDATE users
+SET
+	premium_type = 'paid',
+	premium_expiry_date = current.starts_at + make_interval(days => $2)
+FROM current
+WHERE users.id = current.id
+RETURNING
+	current.starts_at,
+	users.premium_expiry_date AS expires_at;
+`
+	var m contracts.PremiumExtension
+
+	if err := dao.db.QueryRowx(query, userID, durationDays).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *PremiumDAO) CreatePurchase(p contracts.CreatePremiumPurchaseParams) (*models.PremiumPurchase, error) {
+	query := `
+INSERT INTO premium_purchases (
+	uuid,
+	user_id,
+	plan_id,
+	cost,
+	starts_at,
+	expires_at
+) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+`
+	var m models.PremiumPurchase
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Uuid,
+		p.UserID,
+		p.PlanID,
+		p.Cost,
+		p.StartsAt,
+		p.ExpiresAt,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetPurchases retrieves premium purchases of the user, latest first.
+func (dao *PremiumDAO) GetPurchases(p contracts.GetPremiumPurchasesParams) ([]models.PremiumPurchaseHistory, error) {
+	query := `
+SELECT
+	premium_purchases.*,
+	premium_plans.name AS plan_name
+FROM
+	premium_purchases
+INNER JOIN premium_plans ON premium_plans.id = premium_purchases.plan_id
+WHERE
+	premium_purchases.user_id = $1 AND
+	premium_purchases.deleted_at IS NULL
+ORDER BY premium_purchases.created_at DESC
+LIMIT $2
+OFFSET $3;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		p.UserID,
+		p.PerPage,
+		p.Offset,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	purchases := make([]models.PremiumPurchaseHistory, 0)
+
+	for rows.Next() {
+		var m models.PremiumPurchaseHistory
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		purchases = append(purchases, m)
+	}
+
+	return purchases, nil
+}
+
+// DowngradeExpiredMembers downgrades paid members whose membership has expired to normal members.
+// Uuids of downgraded users are returned. Paid members without expiry date are left untouched.
+func (dao *PremiumDAO) DowngradeExpiredMembers() ([]string, error) {
+	query := `
+UPDATE users
+SET premium_type = 'normal'
+WHERE
+	premium_type = 'paid' AND
+	premium_expiry_date <= NOW()
+RETURNING uuid;
+`
+	rows, err := dao.db.Queryx(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	uuids := make([]string, 0)
+
+	for rows.Next() {
+		var uuid string
+
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+
+		uuids = append(uuids, uuid)
+	}
+
+	return uuids, nil
+}
+
+// ClaimExpiryReminders marks members whose membership expires within the period as reminded and
+// returns them. Each expiry is claimed only once, renewing the membership makes the member
+// eligible for a reminder of the new expiry.
+func (dao *PremiumDAO) ClaimExpiryReminders(within time.Duration) ([]models.PremiumExpiringMember, error) {
+	query := `
+UPDATE premium_purchases
+SET expiry_reminded_at = NOW()
+FROM users
+WHERE
+	users.id = premium_purchases.user_id AND
+	users.premium_type = 'paid' AND
+	users.premium_expiry_date = premium_purchases.expires_at AND
+	premium_purchases.expiry_reminded_at IS NULL AND
+	premium_purchases.deleted_at IS NULL AND
+	premium_purchases.expires_at > NOW() AND
+	premium_purchases.expires_at <= NOW() + $1 * interval '1 second'
+RETURNING
+	users.uuid,
+	users.username,
+	users.fcm_topic,
+	users.locale,
+	premium_purchases.expires_at;
+`
+	rows, err := dao.db.Queryx(query, int64(within.Seconds()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	members := make([]models.PremiumExpiringMember, 0)
+
+	for rows.Next() {
+		var m models.PremiumExpiringMember
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		members = append(members, m)
+	}
+
+	return members, nil
+}
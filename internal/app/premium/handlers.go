@@ -0,0 +1,244 @@
+package premium
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/coin"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/shopspring/decimal"
+)
+
+func GetPlansHandler(c *gin.Context, depCon container.Container) {
+	var premiumDao contracts.PremiumDAOer
+	depCon.Make(&premiumDao)
+
+	plans, err := premiumDao.GetPlans(true)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetPremiumPlans,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformPlans(plans))
+}
+
+type GetMyPremiumBody struct {
+	Offset  int `form:"offset,default=0"`
+	PerPage int `form:"per_page,default=10"`
+}
+
+// GetMyPremiumHandler membership status of the user along with purchase history.
+func GetMyPremiumHandler(c *gin.Context, depCon container.Container) {
+	body := GetMyPremiumBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidatePremiumParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao    contracts.UserDAOer
+		premiumDao contracts.PremiumDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&premiumDao)
+
+	me, err := userDao.GetUserByUuid(
+		c.GetString("uuid"),
+		"id",
+		"premium_type",
+		"premium_expiry_date",
+	)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	purchases, err := premiumDao.GetPurchases(contracts.GetPremiumPurchasesParams{
+		UserID:  me.ID,
+		Offset:  body.Offset,
+		PerPage: body.PerPage,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetPremiumPurchases,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformMyPremium(me, purchases, time.Now()))
+}
+
+type PurchasePremiumBody struct {
+	PlanID int32 `form:"plan_id" json:"plan_id" binding:"required,gt=0"`
+}
+
+// PurchasePremiumHandler buys the plan with coins. Purchasing before the membership expires
+// extends the current expiry.
+func PurchasePremiumHandler(c *gin.Context, depCon container.Container) {
+	body := PurchasePremiumBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidatePremiumParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao        contracts.UserDAOer
+		premiumDao     contracts.PremiumDAOer
+		userBalanceDao contracts.UserBalancer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&premiumDao)
+	depCon.Make(&userBalanceDao)
+
+	me, err := userDao.GetUserByUuid(
+		c.GetString("uuid"),
+		"id",
+		"premium_type",
+		"premium_expiry_date",
+	)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if me.PremiumType == models.PremiumTypePaid && !me.PremiumExpiryDate.Valid {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.LifetimePremiumCanNotPurchase),
+		)
+
+		return
+	}
+
+	plan, err := premiumDao.GetEnabledPlan(body.PlanID)
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.PremiumPlanNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetPremiumPlan,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	cost, err := decimal.NewFromString(plan.Cost)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetPremiumPlan,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if err := userBalanceDao.HasEnoughBalanceToCharge(int(me.ID), cost); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToCheckHasEnoughBalance,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	purchase, err := PurchaseInTx(depCon, me.ID, plan)
+
+	if errors.Is(err, coin.ErrInsufficientBalance) {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToCheckHasEnoughBalance,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToPurchasePremium,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformPurchase(purchase, plan.Name))
+}
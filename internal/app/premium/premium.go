@@ -0,0 +1,17 @@
+package premium
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+// IsPremium checks if the user is a paid member at the given time. Paid membership without
+// expiry date never expires.
+func IsPremium(u *models.User, t time.Time) bool {
+	if u.PremiumType != models.PremiumTypePaid {
+		return false
+	}
+
+	return !u.PremiumExpiryDate.Valid || u.PremiumExpiryDate.Time.After(t)
+}
@@ -0,0 +1,35 @@
+package premiumtests
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/premium"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PremiumTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PremiumTestSuite) TestIsPremium() {
+	now := time.Now()
+
+	assert.False(suite.T(), premium.IsPremium(&models.User{PremiumType: models.PremiumTypeNormal}, now))
+	assert.True(suite.T(), premium.IsPremium(&models.User{PremiumType: models.PremiumTypePaid}, now))
+	assert.True(suite.T(), premium.IsPremium(&models.User{
+		PremiumType:       models.PremiumTypePaid,
+		PremiumExpiryDate: sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+	}, now))
+	assert.False(suite.T(), premium.IsPremium(&models.User{
+		PremiumType:       models.PremiumTypePaid,
+		PremiumExpiryDate: sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+	}, now))
+}
+
+func TestPremiumTestSuite(t *testing.T) {
+	suite.Run(t, new(PremiumTestSuite))
+}
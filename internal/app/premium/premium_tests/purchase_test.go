@@ -0,0 +1,104 @@
+package premiumtests
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/coin"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/premium"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PurchaseTestSuite struct {
+	suite.Suite
+	depCon container.Container
+}
+
+func (suite *PurchaseTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+func (suite *PurchaseTestSuite) TestConcurrentPurchasesCanNotOverdraw() {
+	user := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	plan, err := premium.NewPremiumDAO(db.GetDB()).CreatePlan(contracts.CreatePremiumPlanParams{
+		Name:         fmt.Sprintf("plan-%d", time.Now().UnixNano()),
+		DurationDays: 30,
+		Cost:         "100",
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	// Balance covers exactly one purchase.
+	if _, err := coin.NewUserBalanceDAO(db.GetDB()).CreateOrTopUpBalance(contracts.CreateOrTopUpBalanceParams{
+		UserID:      int(user.ID),
+		TopupAmount: 100,
+	}); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	var (
+		wg   sync.WaitGroup
+		errs = make(chan error, 5)
+	)
+
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := premium.PurchaseInTx(suite.depCon, user.ID, plan)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+
+	for err := range errs {
+		if err == nil {
+			succeeded++
+
+			continue
+		}
+
+		assert.Equal(suite.T(), coin.ErrInsufficientBalance, err)
+	}
+
+	assert.Equal(suite.T(), 1, succeeded)
+
+	bal, err := coin.NewUserBalanceDAO(db.GetDB()).GetCoinBalanceByUserId(int(user.ID))
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	balDeci, _ := decimal.NewFromString(bal.Balance)
+	assert.True(suite.T(), balDeci.IsZero())
+}
+
+func TestPurchaseTestSuite(t *testing.T) {
+	suite.Run(t, new(PurchaseTestSuite))
+}
@@ -0,0 +1,33 @@
+package premium
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
+
+func Routes(r *gin.RouterGroup, depCon container.Container) {
+	var authDao contracts.AuthDaoer
+	depCon.Make(&authDao)
+
+	g := r.Group(
+		"/premium",
+		jwtactor.JwtValidator(
+			jwtactor.JwtMiddlewareOptions{},
+			authDao,
+		),
+	)
+
+	g.GET("/plans", func(c *gin.Context) {
+		GetPlansHandler(c, depCon)
+	})
+
+	g.GET("/me", func(c *gin.Context) {
+		GetMyPremiumHandler(c, depCon)
+	})
+
+	g.POST("/purchases", func(c *gin.Context) {
+		PurchasePremiumHandler(c, depCon)
+	})
+}
@@ -0,0 +1,90 @@
+package premium
+
+import (
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+	"github.com/teris-io/shortid"
+)
+
+// PurchaseService upgrades users to paid members with coins. Daos should be bound to the same
+// transaction so that the deducted balance and the extended membership are committed together.
+type PurchaseService struct {
+	premiumDao     contracts.PremiumDAOer
+	userBalanceDao contracts.UserBalancer
+}
+
+func NewPurchaseService(pd contracts.PremiumDAOer, ub contracts.UserBalancer) *PurchaseService {
+	return &PurchaseService{
+		premiumDao:     pd,
+		userBalanceDao: ub,
+	}
+}
+
+// Purchase deducts cost of the plan from user balance and extends the membership by the duration
+// of the plan. The deduction fails with `coin.ErrInsufficientBalance` if the balance can not
+// cover the cost.
+func (s *PurchaseService) Purchase(userID int64, plan *models.PremiumPlan) (*models.PremiumPurchase, error) {
+	cost, err := decimal.NewFromString(plan.Cost)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ext, err := s.premiumDao.ExtendPremium(userID, plan.DurationDays)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.userBalanceDao.DeductCost(int(userID), cost); err != nil {
+		return nil, err
+	}
+
+	sid, err := shortid.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.premiumDao.CreatePurchase(contracts.CreatePremiumPurchaseParams{
+		Uuid:      sid,
+		UserID:    userID,
+		PlanID:    plan.ID,
+		Cost:      plan.Cost,
+		StartsAt:  ext.StartsAt,
+		ExpiresAt: ext.ExpiresAt,
+	})
+}
+
+// PurchaseInTx purchases the plan for the user in a transaction.
+func PurchaseInTx(depCon container.Container, userID int64, plan *models.PremiumPlan) (*models.PremiumPurchase, error) {
+	var (
+		premiumDao     contracts.PremiumDAOer
+		userBalanceDao contracts.UserBalancer
+		purchase       *models.PremiumPurchase
+	)
+
+	depCon.Make(&premiumDao)
+	depCon.Make(&userBalanceDao)
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		var err error
+
+		purchase, err = NewPurchaseService(
+			premiumDao.WithTx(tx),
+			userBalanceDao.WithTx(tx),
+		).Purchase(userID, plan)
+
+		return db.FormatResp{Err: err}
+	})
+
+	if transResp.Err != nil {
+		return nil, transResp.Err
+	}
+
+	return purchase, nil
+}
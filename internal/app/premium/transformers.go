@@ -0,0 +1,87 @@
+package premium
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type PremiumTransform struct{}
+
+func NewTransform() *PremiumTransform {
+	return &PremiumTransform{}
+}
+
+type TrfedPlan struct {
+	ID           int32  `json:"id"`
+	Name         string `json:"name"`
+	DurationDays int32  `json:"duration_days"`
+	Cost         string `json:"cost"`
+}
+
+type TrfedPlans struct {
+	Plans []TrfedPlan `json:"plans"`
+}
+
+func (t *PremiumTransform) TransformPlans(plans []models.PremiumPlan) TrfedPlans {
+	trf := TrfedPlans{
+		Plans: make([]TrfedPlan, 0),
+	}
+
+	for _, plan := range plans {
+		trf.Plans = append(trf.Plans, TrfedPlan{
+			ID:           plan.ID,
+			Name:         plan.Name,
+			DurationDays: plan.DurationDays,
+			Cost:         plan.Cost,
+		})
+	}
+
+	return trf
+}
+
+type TrfedPurchase struct {
+	Uuid      string    `json:"uuid"`
+	PlanName  string    `json:"plan_name"`
+	Cost      string    `json:"cost"`
+	StartsAt  time.Time `json:"starts_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (t *PremiumTransform) TransformPurchase(m *models.PremiumPurchase, planName string) TrfedPurchase {
+	return TrfedPurchase{
+		Uuid:      m.Uuid,
+		PlanName:  planName,
+		Cost:      m.Cost,
+		StartsAt:  m.StartsAt,
+		ExpiresAt: m.ExpiresAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+type TrfedMyPremium struct {
+	IsPremium         bool               `json:"is_premium"`
+	PremiumType       models.PremiumType `json:"premium_type"`
+	PremiumExpiryDate *time.Time         `json:"premium_expiry_date"`
+	Purchases         []TrfedPurchase    `json:"purchases"`
+}
+
+func (t *PremiumTransform) TransformMyPremium(u *models.User, purchases []models.PremiumPurchaseHistory, now time.Time) TrfedMyPremium {
+	trf := TrfedMyPremium{
+		IsPremium:   IsPremium(u, now),
+		PremiumType: u.PremiumType,
+		Purchases:   make([]TrfedPurchase, 0),
+	}
+
+	if u.PremiumExpiryDate.Valid {
+		expiry := u.PremiumExpiryDate.Time
+		trf.PremiumExpiryDate = &expiry
+	}
+
+	for _, p := range purchases {
+		trf.Purchases = append(trf.Purchases, t.TransformPurchase(&p.PremiumPurchase, p.PlanName))
+	}
+
+	return trf
+}
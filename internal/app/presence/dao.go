@@ -0,0 +1,153 @@
+package presence
+
+import (
+	"database/sql"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/lib/pq"
+)
+
+type PresenceDAO struct {
+	db db.Conn
+}
+
+func NewPresenceDAO(db db.Conn) *PresenceDAO {
+	return &PresenceDAO{
+		db: db,
+	}
+}
+
+func PresenceDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.PresenceDAOer {
+			return NewPresenceDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *PresenceDAO) WithTx(tx db.Conn) contracts.PresenceDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+func (dao *PresenceDAO) SetHidden(userID int64, hidden bool) (*models.UserPresenceSetting, error) {
+	query := `
+INSERT INTO user_presence_settings (
+	user_id,
+	hidden
+) VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET
+	hidden = EXCLUDED.hidden,
+	deleted_at = NULL
+RETURNING *;
+`
+	var m models.UserPresenceSetting
+
+	if err := dao.db.QueryRowx(query, userID, hidden).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// IsHidden checks if the user has hidden the presence. Presence is visible by default.
+func (dao *PresenceDAO) IsHidden(userID int64) (bool, error) {
+	query := `
+SELECT
+	hidden
+FROM
+	user_presence_settings
+WHERE
+	user_id = $1 AND
+	deleted_at IS NULL;
+`
+	var hidden bool
+
+	err := dao.db.QueryRowx(query, userID).Scan(&hidden)
+
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return hidden, nil
+}
+
+// GetHiddenUserUuids retrieves uuids of the given users who have hidden their presence.
+func (dao *PresenceDAO) GetHiddenUserUuids(uuids []string) ([]string, error) {
+	query := `
+SELECT
+	users.uuid
+FROM
+	user_presence_settings
+INNER JOIN users ON users.id = user_presence_settings.user_id
+WHERE
+	users.uuid = ANY($1) AND
+	user_presence_settings.hidden = true AND
+	user_presence_settings.deleted_at IS NULL;
+`
+	rows, err := dao.db.Queryx(query, pq.Array(uuids))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	hiddenUuids := make([]string, 0)
+
+	for rows.Next() {
+		var uuid string
+
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+
+		hiddenUuids = append(hiddenUuids, uuid)
+	}
+
+	return hiddenUuids, nil
+}
+
+// GetUuidsOfUserIDs retrieves uuids among the given ones that belong to the given user IDs.
+func (dao *PresenceDAO) GetUuidsOfUserIDs(uuids []string, userIDs []int64) ([]string, error) {
+	query := `
+SELECT
+	uuid
+FROM
+	users
+WHERE
+	uuid = ANY($1) AND
+	id = ANY($2);
+`
+	rows, err := dao.db.Queryx(query, pq.Array(uuids), pq.Array(userIDs))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	matched := make([]string, 0)
+
+	for rows.Next() {
+		var uuid string
+
+		if err := rows.Scan(&uuid); err != nil {
+			return nil, err
+		}
+
+		matched = append(matched, uuid)
+	}
+
+	return matched, nil
+}
@@ -0,0 +1,246 @@
+package presence
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+// HeartbeatHandler client sends heartbeat periodically while the app is in foreground.
+func HeartbeatHandler(c *gin.Context) {
+	if err := Touch(
+		context.Background(),
+		db.GetRedis(),
+		c.GetString("uuid"),
+		time.Now(),
+	); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToUpdatePresence,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+func GetVisibilityHandler(c *gin.Context, depCon container.Container) {
+	var (
+		userDao     contracts.UserDAOer
+		presenceDao contracts.PresenceDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&presenceDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	hidden, err := presenceDao.IsHidden(me.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetPresenceSetting,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformVisibility(hidden))
+}
+
+type PutVisibilityBody struct {
+	Hidden *bool `form:"hidden" json:"hidden" binding:"required"`
+}
+
+// PutVisibilityHandler hides / reveals presence of the user. Last seen time is removed once
+// the presence is hidden.
+func PutVisibilityHandler(c *gin.Context, depCon container.Container) {
+	body := PutVisibilityBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidatePresenceParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao     contracts.UserDAOer
+		presenceDao contracts.PresenceDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&presenceDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id", "uuid")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	setting, err := presenceDao.SetHidden(me.ID, *body.Hidden)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToSetPresenceVisibility,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if setting.Hidden {
+		if err := Clear(context.Background(), db.GetRedis(), me.Uuid); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToUpdatePresence,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformVisibility(setting.Hidden))
+}
+
+type GetPresencesBody struct {
+	Uuids []string `form:"uuids" json:"uuids" binding:"required,min=1,max=100"`
+}
+
+// GetPresencesHandler bulk presence lookup, e.g. for users listed in chatrooms. Users blocked by or
+// blocking the requester are always offline.
+func GetPresencesHandler(c *gin.Context, depCon container.Container) {
+	body := GetPresencesBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidatePresenceParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao     contracts.UserDAOer
+		blockDao    contracts.BlockDAOer
+		presenceDao contracts.PresenceDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&blockDao)
+	depCon.Make(&presenceDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	blockRelatedIDs, err := blockDao.GetBlockRelatedUserIDs(int(me.ID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetBlockRelatedUsers,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	statuses, err := LookupStatuses(depCon, body.Uuids)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetPresences,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if len(blockRelatedIDs) > 0 {
+		blockedUuids, err := presenceDao.GetUuidsOfUserIDs(body.Uuids, blockRelatedIDs)
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToGetPresences,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		for _, blockedUuid := range blockedUuids {
+			statuses[blockedUuid] = StatusOffline
+		}
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformPresences(body.Uuids, statuses))
+}
@@ -0,0 +1,149 @@
+package presence
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	log "github.com/sirupsen/logrus"
+)
+
+type Status string
+
+const (
+	StatusOnline         Status = "online"
+	StatusRecentlyActive Status = "recently_active"
+	StatusOffline        Status = "offline"
+)
+
+const (
+	lastSeenKey = "user_last_seen:%s"
+
+	// OnlineWindow users who have sent a heartbeat within this period are online. Clients should
+	// send heartbeats more frequently than this.
+	OnlineWindow = 2 * time.Minute
+
+	// RecentlyActiveWindow users who have sent a heartbeat within this period are recently active.
+	// Last seen timestamps expire after this period, users are offline afterwards.
+	RecentlyActiveWindow = 24 * time.Hour
+)
+
+// StatusOf derives presence status from the last seen time.
+func StatusOf(lastSeen time.Time, now time.Time) Status {
+	elapsed := now.Sub(lastSeen)
+
+	if elapsed <= OnlineWindow {
+		return StatusOnline
+	}
+
+	if elapsed <= RecentlyActiveWindow {
+		return StatusRecentlyActive
+	}
+
+	return StatusOffline
+}
+
+// Touch records the time the user is last seen.
+func Touch(ctx context.Context, rds *redis.Client, userUuid string, t time.Time) error {
+	return rds.Set(
+		ctx,
+		fmt.Sprintf(lastSeenKey, userUuid),
+		t.Unix(),
+		RecentlyActiveWindow,
+	).Err()
+}
+
+// Clear removes the last seen time of the user.
+func Clear(ctx context.Context, rds *redis.Client, userUuid string) error {
+	return rds.Del(ctx, fmt.Sprintf(lastSeenKey, userUuid)).Err()
+}
+
+// GetStatuses retrieves presence status of each user. Users who have hidden their presence
+// are always offline.
+func GetStatuses(ctx context.Context, rds *redis.Client, dao contracts.PresenceDAOer, userUuids []string) (map[string]Status, error) {
+	statuses := make(map[string]Status)
+
+	if len(userUuids) == 0 {
+		return statuses, nil
+	}
+
+	keys := make([]string, 0, len(userUuids))
+
+	for _, userUuid := range userUuids {
+		keys = append(keys, fmt.Sprintf(lastSeenKey, userUuid))
+	}
+
+	vals, err := rds.MGet(ctx, keys...).Result()
+
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	for i, userUuid := range userUuids {
+		statuses[userUuid] = StatusOffline
+
+		v, ok := vals[i].(string)
+
+		if !ok {
+			continue
+		}
+
+		ts, err := strconv.ParseInt(v, 10, 64)
+
+		if err != nil {
+			continue
+		}
+
+		statuses[userUuid] = StatusOf(time.Unix(ts, 0), now)
+	}
+
+	hiddenUuids, err := dao.GetHiddenUserUuids(userUuids)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hiddenUuid := range hiddenUuids {
+		statuses[hiddenUuid] = StatusOffline
+	}
+
+	return statuses, nil
+}
+
+// LookupStatuses retrieves presence status of each user with dependencies resolved from the container.
+func LookupStatuses(depCon container.Container, userUuids []string) (map[string]Status, error) {
+	var dao contracts.PresenceDAOer
+	depCon.Make(&dao)
+
+	return GetStatuses(
+		context.Background(),
+		db.GetRedis(),
+		dao,
+		userUuids,
+	)
+}
+
+// LookupStatusesOrOffline is like `LookupStatuses` but every user is considered offline if presence
+// can not be retrieved. Presence should not prevent the rest of the payload from being served.
+func LookupStatusesOrOffline(depCon container.Container, userUuids []string) map[string]Status {
+	statuses, err := LookupStatuses(depCon, userUuids)
+
+	if err != nil {
+		log.Errorf("failed to lookup presence statuses: %s", err.Error())
+
+		statuses = make(map[string]Status)
+
+		for _, userUuid := range userUuids {
+			statuses[userUuid] = StatusOffline
+		}
+	}
+
+	return statuses
+}
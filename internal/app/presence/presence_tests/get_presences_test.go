@@ -0,0 +1,100 @@
+package presencetests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/block"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type GetPresencesTestSuite struct {
+	suite.Suite
+	depCon container.Container
+}
+
+func (suite *GetPresencesTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+func (suite *GetPresencesTestSuite) TestBlockRelatedUsersAreOffline() {
+	ctx := context.Background()
+
+	me := util.CreateTestUser(suite.T(), models.GenderMale)
+	blockedByMe := util.CreateTestUser(suite.T(), models.GenderFemale)
+	blockingMe := util.CreateTestUser(suite.T(), models.GenderFemale)
+	unrelated := util.CreateTestUser(suite.T(), models.GenderFemale)
+
+	for _, u := range []models.User{blockedByMe, blockingMe, unrelated} {
+		if err := presence.Touch(ctx, db.GetRedis(), u.Uuid, time.Now()); err != nil {
+			suite.T().Fatal(err)
+		}
+	}
+
+	blockDao := block.NewBlockDAO(db.GetDB())
+
+	for _, p := range []block.BlockUserParams{
+		{BlockerId: int(me.ID), BlockeeId: int(blockedByMe.ID)},
+		{BlockerId: int(blockingMe.ID), BlockeeId: int(me.ID)},
+	} {
+		if err := blockDao.BlockUser(p); err != nil {
+			suite.T().Fatal(err)
+		}
+	}
+
+	q := url.Values{}
+	q.Add("uuids", blockedByMe.Uuid)
+	q.Add("uuids", blockingMe.Uuid)
+	q.Add("uuids", unrelated.Uuid)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/presence?"+q.Encode(), nil)
+	c.Set("uuid", me.Uuid)
+
+	presence.GetPresencesHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	assert := assert.New(suite.T())
+	assert.Equal(http.StatusOK, w.Code)
+
+	var resp presence.TrfedPresences
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	statuses := make(map[string]presence.Status)
+
+	for _, p := range resp.Presences {
+		statuses[p.Uuid] = p.Status
+	}
+
+	assert.Equal(presence.StatusOffline, statuses[blockedByMe.Uuid])
+	assert.Equal(presence.StatusOffline, statuses[blockingMe.Uuid])
+	assert.Equal(presence.StatusOnline, statuses[unrelated.Uuid])
+}
+
+func TestGetPresencesTestSuite(t *testing.T) {
+	suite.Run(t, new(GetPresencesTestSuite))
+}
@@ -0,0 +1,40 @@
+package presencetests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PresenceTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PresenceTestSuite) TestStatusOf() {
+	now := time.Now()
+
+	assert.Equal(suite.T(), presence.StatusOnline, presence.StatusOf(now, now))
+	assert.Equal(suite.T(), presence.StatusOnline, presence.StatusOf(now.Add(-presence.OnlineWindow), now))
+	assert.Equal(suite.T(), presence.StatusRecentlyActive, presence.StatusOf(now.Add(-time.Hour), now))
+	assert.Equal(suite.T(), presence.StatusOffline, presence.StatusOf(now.Add(-presence.RecentlyActiveWindow-time.Second), now))
+}
+
+func (suite *PresenceTestSuite) TestTransformPresencesKeepsRequestedOrder() {
+	trf := presence.NewTransform().TransformPresences(
+		[]string{"b", "a"},
+		map[string]presence.Status{
+			"a": presence.StatusOnline,
+			"b": presence.StatusOffline,
+		},
+	)
+
+	assert.Equal(suite.T(), "b", trf.Presences[0].Uuid)
+	assert.Equal(suite.T(), presence.StatusOnline, trf.Presences[1].Status)
+}
+
+func TestPresenceTestSuite(t *testing.T) {
+	suite.Run(t, new(PresenceTestSuite))
+}
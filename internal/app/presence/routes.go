@@ -0,0 +1,35 @@
+package presence
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
+
+func Routes(r *gin.RouterGroup, depCon container.Container) {
+	var authDao contracts.AuthDaoer
+	depCon.Make(&authDao)
+
+	g := r.Group(
+		"/presence",
+		jwtactor.JwtValidator(
+			jwtactor.JwtMiddlewareOptions{},
+			authDao,
+		),
+	)
+
+	g.GET("", func(c *gin.Context) {
+		GetPresencesHandler(c, depCon)
+	})
+
+	g.POST("/heartbeat", HeartbeatHandler)
+
+	g.GET("/visibility", func(c *gin.Context) {
+		GetVisibilityHandler(c, depCon)
+	})
+
+	g.PUT("/visibility", func(c *gin.Context) {
+		PutVisibilityHandler(c, depCon)
+	})
+}
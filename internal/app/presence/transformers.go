@@ -0,0 +1,42 @@
+package presence
+
+type PresenceTransform struct{}
+
+func NewTransform() *PresenceTransform {
+	return &PresenceTransform{}
+}
+
+type TrfedVisibility struct {
+	Hidden bool `json:"hidden"`
+}
+
+func (t *PresenceTransform) TransformVisibility(hidden bool) TrfedVisibility {
+	return TrfedVisibility{
+		Hidden: hidden,
+	}
+}
+
+type TrfedPresence struct {
+	Uuid   string `json:"uuid"`
+	Status Status `json:"status"`
+}
+
+type TrfedPresences struct {
+	Presences []TrfedPresence `json:"presences"`
+}
+
+// TransformPresences presences are listed in the order of requested uuids.
+func (t *PresenceTransform) TransformPresences(userUuids []string, statuses map[string]Status) TrfedPresences {
+	trf := TrfedPresences{
+		Presences: make([]TrfedPresence, 0),
+	}
+
+	for _, userUuid := range userUuids {
+		trf.Presences = append(trf.Presences, TrfedPresence{
+			Uuid:   userUuid,
+			Status: statuses[userUuid],
+		})
+	}
+
+	return trf
+}
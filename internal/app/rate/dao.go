@@ -8,6 +8,7 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/lib/pq"
 )
 
 type RateDAO struct {
@@ -295,15 +296,23 @@ FROM
 INNER JOIN users AS raters ON raters.id = service_ratings.rater_id
 WHERE
 	service_ratings.ratee_id = $1
+	AND raters.id <> ALL($4::int[])
 ORDER BY service_ratings.created_at desc
 LIMIT $2
 OFFSET $3;
 `
+	excludeUserIDs := p.ExcludeUserIDs
+
+	if excludeUserIDs == nil {
+		excludeUserIDs = make([]int64, 0)
+	}
+
 	rows, err := dao.db.Queryx(
 		query,
 		p.UserID,
 		p.PerPage,
 		p.Offset,
+		pq.Array(excludeUserIDs),
 	)
 
 	ms := make([]models.UserRatings, 0)
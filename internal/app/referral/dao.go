@@ -83,6 +83,7 @@ func (dao *ReferralCodeDAO) GetUnoccupiedReferralCode(userUuid string) (*models.
 		INNER JOIN users ON users.id = user_refcodes.invitor_id
 		WHERE
 			users.uuid = $1 AND		  
+			user_refcodes.ref_code_type = $2 AND
 			user_refcodes.invitee_id IS NULL
 		ORDER BY user_refcodes.created_at DESC 
 		LIMIT 1;
@@ -90,7 +91,8 @@ func (dao *ReferralCodeDAO) GetUnoccupiedReferralCode(userUuid string) (*models.
 
 	var m models.UserRefcode
 
-	if err := dao.db.QueryRowx(query, userUuid).StructScan(&m); err != nil {
+	// Manager referral codes are never occupied, they should not be mistaken as unoccupied invitor codes.
+	if err := dao.db.QueryRowx(query, userUuid, models.RefCodeTypeInvitor).StructScan(&m); err != nil {
 		return nil, err
 	}
 
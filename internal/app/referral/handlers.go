@@ -11,6 +11,7 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	genverifycode "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/generate_verify_code"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
 	"github.com/jmoiron/sqlx"
@@ -39,7 +40,7 @@ func HandleVerifyReferralCode(c *gin.Context, depCon container.Container) {
 	var userSrv contracts.UserDAOer
 	depCon.Make(&userSrv)
 
-	invitee, err := userSrv.GetUserByUuid(body.InviteeUUID, "id")
+	invitee, err := userSrv.GetUserByUuid(body.InviteeUUID, "id", "gender")
 
 	if err != nil {
 		c.AbortWithError(
@@ -53,6 +54,9 @@ func HandleVerifyReferralCode(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	var agencyDao contracts.AgencyDAOer
+	depCon.Make(&agencyDao)
+
 	err, errCode := db.Transact(
 		db.GetDB(),
 		func(tx *sqlx.Tx) (error, interface{}) {
@@ -68,6 +72,12 @@ func HandleVerifyReferralCode(c *gin.Context, depCon container.Container) {
 				return err, apperr.FailedToGetReferralCode
 			}
 
+			// Manager referral codes are shared by every member of the agency, thus are never
+			// occupied nor expired.
+			if refCode.RefCodeType == models.RefCodeTypeManager {
+				return joinAgency(agencyDao.WithTx(tx), refCode, invitee)
+			}
+
 			// If referral code is occupied returns error.
 			if refCode.InviteeID.Valid {
 				return errors.New("Referral code is occupied"), apperr.ReferralCodeIsOccupied
@@ -76,7 +86,7 @@ func HandleVerifyReferralCode(c *gin.Context, depCon container.Container) {
 			// If referral code is expired, return error.
 			if time.Now().After(refCode.ExpiredAt.Time) {
 				return errors.New(
-					apperr.ReferralErrorMessageMap[apperr.ReferralCodeExpired],
+					apperr.GetErrorMessage(apperr.ReferralCodeExpired),
 				), apperr.ReferralCodeExpired
 			}
 
@@ -110,6 +120,42 @@ func HandleVerifyReferralCode(c *gin.Context, depCon container.Container) {
 	c.JSON(http.StatusOK, struct{}{})
 }
 
+// joinAgency adds the invitee to the agency owning the manager referral code. Only female users
+// can join an agency.
+func joinAgency(agencyDao contracts.AgencyDAOer, refCode *models.UserRefcode, invitee *models.User) (error, interface{}) {
+	if invitee.Gender != models.GenderFemale {
+		return errors.New(
+			apperr.GetErrorMessage(apperr.AgencyOnlyAcceptsFemale),
+		), apperr.AgencyOnlyAcceptsFemale
+	}
+
+	agency, err := agencyDao.GetAgencyByRefcodeID(refCode.ID)
+
+	if err == sql.ErrNoRows {
+		return err, apperr.AgencyNotFound
+	}
+
+	if err != nil {
+		return err, apperr.FailedToGetAgency
+	}
+
+	if _, err := agencyDao.AddMember(contracts.AddAgencyMemberParams{
+		AgencyID:  agency.ID,
+		MemberID:  invitee.ID,
+		RefcodeID: refCode.ID,
+	}); err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New(
+				apperr.GetErrorMessage(apperr.AlreadyAgencyMember),
+			), apperr.AlreadyAgencyMember
+		}
+
+		return err, apperr.FailedToJoinAgency
+	}
+
+	return nil, nil
+}
+
 func GetReferralCodeHandler(c *gin.Context, depCon container.Container) {
 	var userDao contracts.UserDAOer
 	depCon.Make(&userDao)
@@ -170,3 +216,67 @@ func GetReferralCodeHandler(c *gin.Context, depCon container.Container) {
 		ReferralCode: refCode.RefCode,
 	})
 }
+
+type GetReferralRewardsBody struct {
+	Offset  int `form:"offset,default=0"`
+	PerPage int `form:"per_page,default=10"`
+}
+
+// GetReferralRewardsHandler lists referral rewards credited to the requester, latest first.
+func GetReferralRewardsHandler(c *gin.Context, depCon container.Container) {
+	body := GetReferralRewardsBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateGetReferralRewardsParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao   contracts.UserDAOer
+		rewardDao contracts.ReferralRewardDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&rewardDao)
+
+	user, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	rewards, err := rewardDao.GetRewards(contracts.GetReferralRewardsParams{
+		RecipientID: user.ID,
+		Offset:      body.Offset,
+		PerPage:     body.PerPage,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetReferralRewards,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformRewards(rewards))
+}
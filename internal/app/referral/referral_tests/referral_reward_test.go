@@ -0,0 +1,163 @@
+package referral_tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/referral"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReferralRewardTestSuite struct {
+	suite.Suite
+	depCon container.Container
+	assert *assert.Assertions
+}
+
+func (suite *ReferralRewardTestSuite) SetupSuite() {
+	suite.assert = assert.New(suite.T())
+
+	manager.
+		NewDefaultManager(context.Background()).Run(func() {
+		deps.Get().Run()
+		suite.depCon = deps.Get().Container
+	})
+}
+
+func (suite *ReferralRewardTestSuite) TestGrantRewardsOnlyOnce() {
+	ctx := context.Background()
+	q := models.New(db.GetDB())
+
+	users := make([]models.User, 0)
+
+	for i := 0; i < 2; i++ {
+		params, err := util.GenTestUserParams()
+
+		if err != nil {
+			suite.T().Fatal(err)
+		}
+
+		params.Gender = models.GenderMale
+		user, err := q.CreateUser(ctx, *params)
+
+		if err != nil {
+			suite.T().Fatal(err)
+		}
+
+		users = append(users, user)
+	}
+
+	invitor := users[0]
+	invitee := users[1]
+
+	if _, err := q.CreateRefcode(ctx, models.CreateRefcodeParams{
+		InvitorID: int32(invitor.ID),
+		InviteeID: sql.NullInt32{
+			Valid: true,
+			Int32: int32(invitee.ID),
+		},
+		RefCode:     util.GenRandStringRune(10),
+		RefCodeType: models.RefCodeTypeInvitor,
+	}); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	rewardDao := referral.NewReferralRewardDAO(db.GetDB())
+
+	invitorRule, err := rewardDao.CreateRewardRule(contracts.CreateReferralRewardRuleParams{
+		Name:      "invitor reward",
+		Event:     models.ReferralRewardEventPhoneVerified,
+		Recipient: models.ReferralRewardRecipientInvitor,
+		Amount:    "50",
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	// Rules apply to every invitee, disable them so that other tests are not affected.
+	defer rewardDao.SetRewardRuleEnabled(invitorRule.ID, false)
+
+	inviteeRule, err := rewardDao.CreateRewardRule(contracts.CreateReferralRewardRuleParams{
+		Name:      "invitee reward",
+		Event:     models.ReferralRewardEventPhoneVerified,
+		Recipient: models.ReferralRewardRecipientInvitee,
+		Amount:    "20",
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	defer rewardDao.SetRewardRuleEnabled(inviteeRule.ID, false)
+
+	rewards, err := referral.GrantRewardsInTx(
+		suite.depCon,
+		invitee.ID,
+		models.ReferralRewardEventPhoneVerified,
+	)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.assert.Len(rewards, 2)
+
+	// Granting the same event again should not credit the balance.
+	rewards, err = referral.GrantRewardsInTx(
+		suite.depCon,
+		invitee.ID,
+		models.ReferralRewardEventPhoneVerified,
+	)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.assert.Len(rewards, 0)
+
+	var userBalanceDao contracts.UserBalancer
+	suite.depCon.Make(&userBalanceDao)
+
+	invitorBal, err := userBalanceDao.GetCoinBalanceByUserId(int(invitor.ID))
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.assert.True(decimal.RequireFromString(invitorBal.Balance).Equal(decimal.NewFromInt(50)))
+
+	inviteeBal, err := userBalanceDao.GetCoinBalanceByUserId(int(invitee.ID))
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.assert.True(decimal.RequireFromString(inviteeBal.Balance).Equal(decimal.NewFromInt(20)))
+
+	history, err := rewardDao.GetRewards(contracts.GetReferralRewardsParams{
+		RecipientID: invitor.ID,
+		PerPage:     10,
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.assert.Len(history, 1)
+	suite.assert.Equal(invitee.Uuid, history[0].InviteeUuid)
+}
+
+func TestReferralRewardTestSuite(t *testing.T) {
+	suite.Run(t, new(ReferralRewardTestSuite))
+}
@@ -0,0 +1,335 @@
+package referral
+
+import (
+	"database/sql"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type ReferralRewardDAO struct {
+	db db.Conn
+}
+
+func NewReferralRewardDAO(db db.Conn) *ReferralRewardDAO {
+	return &ReferralRewardDAO{
+		db: db,
+	}
+}
+
+func ReferralRewardDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.ReferralRewardDAOer {
+			return NewReferralRewardDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *ReferralRewardDAO) WithTx(tx db.Conn) contracts.ReferralRewardDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+// GetInvitorRefcodeByInviteeID retrieves the invitor referral code the invitee signed up with.
+// Manager referral codes are not rewarded.
+func (dao *ReferralRewardDAO) GetInvitorRefcodeByInviteeID(inviteeID int64) (*models.UserRefcode, error) {
+	query := `
+SELECT
+	user_refcodes.*
+FROM
+	user_refcodes
+WHERE
+	invitee_id = $1 AND
+	ref_code_type = $2 AND
+	deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+`
+	var m models.UserRefcode
+
+	if err := dao.db.QueryRowx(
+		query,
+		inviteeID,
+		models.RefCodeTypeInvitor,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetApplicableRewardRules retrieves enabled rules of the event that are within the campaign period.
+func (dao *ReferralRewardDAO) GetApplicableRewardRules(event models.ReferralRewardEvent) ([]models.ReferralRewardRule, error) {
+	query := `
+SELECT
+	*
+FROM
+	referral_reward_rules
+WHERE
+	event = $1 AND
+	enabled = true AND
+	(starts_at IS NULL OR starts_at <= NOW()) AND
+	(ends_at IS NULL OR ends_at > NOW()) AND
+	deleted_at IS NULL
+ORDER BY id;
+`
+	rows, err := dao.db.Queryx(query, event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rules := make([]models.ReferralRewardRule, 0)
+
+	for rows.Next() {
+		var rule models.ReferralRewardRule
+
+		if err := rows.StructScan(&rule); err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// CreateReward records the reward of the rule. Each rule rewards an invitee at most once,
+// `sql.ErrNoRows` is returned if the invitee has already been rewarded by the rule.
+func (dao *ReferralRewardDAO) CreateReward(p contracts.CreateReferralRewardParams) (*models.ReferralReward, error) {
+	query := `
+INSERT INTO referral_rewards (
+	rule_id,
+	refcode_id,
+	invitee_id,
+	recipient_id,
+	amount
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (rule_id, invitee_id) DO NOTHING
+RETURNING *;
+`
+	var m models.ReferralReward
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.RuleID,
+		p.RefcodeID,
+		p.InviteeID,
+		p.RecipientID,
+		p.Amount,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetRewards retrieves rewards credited to the recipient, latest first.
+func (dao *ReferralRewardDAO) GetRewards(p contracts.GetReferralRewardsParams) ([]models.ReferralRewardHistory, error) {
+	query := `
+SELECT
+	referral_rewards.*,
+	referral_reward_rules.name AS rule_name,
+	referral_reward_rules.event,
+	referral_reward_rules.recipient,
+	invitees.uuid AS invitee_uuid,
+	invitees.username AS invitee_username
+FROM
+	referral_rewards
+INNER JOIN referral_reward_rules ON referral_reward_rules.id = referral_rewards.rule_id
+INNER JOIN users AS invitees ON invitees.id = referral_rewards.invitee_id
+WHERE
+	referral_rewards.recipient_id = $1 AND
+	referral_rewards.deleted_at IS NULL
+ORDER BY referral_rewards.created_at DESC
+LIMIT $2
+OFFSET $3;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		p.RecipientID,
+		p.PerPage,
+		p.Offset,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rewards := make([]models.ReferralRewardHistory, 0)
+
+	for rows.Next() {
+		var reward models.ReferralRewardHistory
+
+		if err := rows.StructScan(&reward); err != nil {
+			return nil, err
+		}
+
+		rewards = append(rewards, reward)
+	}
+
+	return rewards, nil
+}
+
+// GetInviteesCompletedFirstService retrieves participants of the service who signed up via referral
+// code and have not completed any service before the given one.
+func (dao *ReferralRewardDAO) GetInviteesCompletedFirstService(serviceUuid string) ([]int64, error) {
+	query := `
+WITH participants AS (
+	SELECT
+		services.id AS service_id,
+		UNNEST(ARRAY[services.customer_id, services.service_provider_id]) AS user_id
+	FROM
+		services
+	WHERE
+		services.uuid = $1 AND
+		services.service_status = $2
+)
+SELECT
+	participants.user_id
+FROM
+	participants
+WHERE
+	participants.user_id IS NOT NULL AND
+	EXISTS (
+		SELECT 1 FROM user_refcodes
+		WHERE
+			user_refcodes.invitee_id = participants.user_id AND
+			user_refcodes.ref_code_type = $3
+	) AND
+	NOT EXISTS (
+		SELECT 1 FROM services
+		WHERE
+			services.id <> participants.service_id AND
+			services.service_status = $2 AND
+			participants.user_id IN (services.customer_id, services.service_provider_id) AND
+			(services.end_time, services.id) < (
+				SELECT end_time, id FROM services WHERE id = participants.service_id
+			)
+	);
+`
+	rows, err := dao.db.Queryx(
+		query,
+		serviceUuid,
+		models.ServiceStatusCompleted,
+		models.RefCodeTypeInvitor,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	userIDs := make([]int64, 0)
+
+	for rows.Next() {
+		var userID int64
+
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+
+		userIDs = append(userIDs, userID)
+	}
+
+	return userIDs, nil
+}
+
+func (dao *ReferralRewardDAO) GetRewardRules() ([]models.ReferralRewardRule, error) {
+	query := `
+SELECT
+	*
+FROM
+	referral_reward_rules
+WHERE
+	deleted_at IS NULL
+ORDER BY id;
+`
+	rows, err := dao.db.Queryx(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rules := make([]models.ReferralRewardRule, 0)
+
+	for rows.Next() {
+		var rule models.ReferralRewardRule
+
+		if err := rows.StructScan(&rule); err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func (dao *ReferralRewardDAO) CreateRewardRule(p contracts.CreateReferralRewardRuleParams) (*models.ReferralRewardRule, error) {
+	query := `
+INSERT INTO referral_reward_rules (
+	name,
+	event,
+	recipient,
+	amount,
+	starts_at,
+	ends_at
+) VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+`
+	var m models.ReferralRewardRule
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Name,
+		p.Event,
+		p.Recipient,
+		p.Amount,
+		p.StartsAt,
+		p.EndsAt,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ReferralRewardDAO) SetRewardRuleEnabled(ID int32, enabled bool) error {
+	query := `
+UPDATE referral_reward_rules
+SET enabled = $1
+WHERE
+	id = $2 AND
+	deleted_at IS NULL;
+`
+	res, err := dao.db.Exec(query, enabled, ID)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
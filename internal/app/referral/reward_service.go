@@ -0,0 +1,128 @@
+package referral
+
+import (
+	"database/sql"
+
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+// RewardService credits coins to the invitor / invitee according to referral reward rules. Rules are
+// managed via `util referralrule` so that campaigns can be run without deploying.
+//
+// Daos should be bound to the same transaction so that the reward record and the credited balance
+// are committed together. Each rule rewards an invitee at most once, thus granting rewards of the
+// same event again is a no-op.
+type RewardService struct {
+	rewardDao      contracts.ReferralRewardDAOer
+	userBalanceDao contracts.UserBalancer
+}
+
+func NewRewardService(rd contracts.ReferralRewardDAOer, ub contracts.UserBalancer) *RewardService {
+	return &RewardService{
+		rewardDao:      rd,
+		userBalanceDao: ub,
+	}
+}
+
+// GrantRewards credits rewards of every applicable rule of the event to the invitee signed up via
+// the invitor referral code. Rewards credited by this call are returned.
+func (s *RewardService) GrantRewards(inviteeID int64, event models.ReferralRewardEvent) ([]*models.ReferralReward, error) {
+	rewards := make([]*models.ReferralReward, 0)
+
+	refcode, err := s.rewardDao.GetInvitorRefcodeByInviteeID(inviteeID)
+
+	if err == sql.ErrNoRows {
+		return rewards, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := s.rewardDao.GetApplicableRewardRules(event)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		recipientID := inviteeID
+
+		if rule.Recipient == models.ReferralRewardRecipientInvitor {
+			recipientID = int64(refcode.InvitorID)
+		}
+
+		amount, err := decimal.NewFromString(rule.Amount)
+
+		if err != nil {
+			return nil, err
+		}
+
+		reward, err := s.rewardDao.CreateReward(contracts.CreateReferralRewardParams{
+			RuleID:      rule.ID,
+			RefcodeID:   refcode.ID,
+			InviteeID:   inviteeID,
+			RecipientID: recipientID,
+			Amount:      rule.Amount,
+		})
+
+		// The invitee has been rewarded by the rule.
+		if err == sql.ErrNoRows {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// `AddBalance` does not create balance record for users who have never topped up.
+		if _, err := s.userBalanceDao.CreateOrTopUpBalance(contracts.CreateOrTopUpBalanceParams{
+			UserID:      int(recipientID),
+			TopupAmount: 0,
+		}); err != nil {
+			return nil, err
+		}
+
+		if err := s.userBalanceDao.AddBalance(int(recipientID), amount); err != nil {
+			return nil, err
+		}
+
+		rewards = append(rewards, reward)
+	}
+
+	return rewards, nil
+}
+
+// GrantRewardsInTx grants rewards of the event to the invitee in a transaction.
+func GrantRewardsInTx(depCon container.Container, inviteeID int64, event models.ReferralRewardEvent) ([]*models.ReferralReward, error) {
+	var (
+		rewardDao      contracts.ReferralRewardDAOer
+		userBalanceDao contracts.UserBalancer
+		rewards        []*models.ReferralReward
+	)
+
+	depCon.Make(&rewardDao)
+	depCon.Make(&userBalanceDao)
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		var err error
+
+		rewards, err = NewRewardService(
+			rewardDao.WithTx(tx),
+			userBalanceDao.WithTx(tx),
+		).GrantRewards(inviteeID, event)
+
+		return db.FormatResp{Err: err}
+	})
+
+	if transResp.Err != nil {
+		return nil, transResp.Err
+	}
+
+	return rewards, nil
+}
@@ -3,7 +3,6 @@ package referral
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 )
@@ -14,9 +13,7 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 
 	g := r.Group(
 		"/referral_code",
-		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{
-			Secret: config.GetAppConf().JwtSecret,
-		},
+		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{},
 			authDaoer,
 		),
 	)
@@ -31,4 +28,16 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 	r.POST("/verify", func(c *gin.Context) {
 		HandleVerifyReferralCode(c, depCon)
 	})
+
+	rg := r.Group(
+		"/referral",
+		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{},
+			authDaoer,
+		),
+	)
+
+	// Lists referral rewards credited to the user.
+	rg.GET("/rewards", func(c *gin.Context) {
+		GetReferralRewardsHandler(c, depCon)
+	})
 }
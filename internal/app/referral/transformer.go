@@ -0,0 +1,45 @@
+package referral
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type ReferralTransform struct{}
+
+func NewTransform() *ReferralTransform {
+	return &ReferralTransform{}
+}
+
+type TransformedReward struct {
+	RuleName        string    `json:"rule_name"`
+	Event           string    `json:"event"`
+	Recipient       string    `json:"recipient"`
+	Amount          string    `json:"amount"`
+	InviteeUuid     string    `json:"invitee_uuid"`
+	InviteeUsername string    `json:"invitee_username"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+type TransformedRewards struct {
+	Rewards []TransformedReward `json:"rewards"`
+}
+
+func (t *ReferralTransform) TransformRewards(rewards []models.ReferralRewardHistory) *TransformedRewards {
+	trfRewards := make([]TransformedReward, 0)
+
+	for _, reward := range rewards {
+		trfRewards = append(trfRewards, TransformedReward{
+			RuleName:        reward.RuleName,
+			Event:           string(reward.Event),
+			Recipient:       string(reward.Recipient),
+			Amount:          reward.Amount,
+			InviteeUuid:     reward.InviteeUuid,
+			InviteeUsername: reward.InviteeUsername,
+			CreatedAt:       reward.CreatedAt,
+		})
+	}
+
+	return &TransformedRewards{trfRewards}
+}
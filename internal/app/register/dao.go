@@ -11,6 +11,7 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	usernamepolicy "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/username_policy"
 )
 
 type RegisterDAO struct {
@@ -33,17 +34,50 @@ func RegisterDaoServiceProvider(c cintrnal.Container) func() error {
 	}
 }
 
+// CheckUsernameExists checks if the username or any username with the same normalized form is taken.
 func (dao *RegisterDAO) CheckUsernameExists(ctx context.Context, username string) (bool, error) {
-	query := `SELECT EXISTS(SELECT 1 FROM users WHERE username = $1) AS "exists"`
+	query := `
+SELECT
+	EXISTS(SELECT 1 FROM users WHERE username = $1) OR
+	EXISTS(SELECT 1 FROM username_keys WHERE normalized_username = $2 AND deleted_at IS NULL) AS "exists"
+`
 	var exists bool
 
-	if err := dao.db.QueryRow(query, username).Scan(&exists); err != nil {
+	if err := dao.db.QueryRow(
+		query,
+		username,
+		usernamepolicy.Normalize(username),
+	).Scan(&exists); err != nil {
 		return false, err
 	}
 
 	return exists, nil
 }
 
+// CreateUsernameKey claims the normalized username for the user. `sql.ErrNoRows` is returned if
+// the normalized username has been claimed by another user.
+func (dao *RegisterDAO) CreateUsernameKey(userID int64, username string) (*models.UsernameKey, error) {
+	query := `
+INSERT INTO username_keys (
+	user_id,
+	normalized_username
+) VALUES ($1, $2)
+ON CONFLICT (normalized_username) DO NOTHING
+RETURNING *;
+`
+	var m models.UsernameKey
+
+	if err := dao.db.QueryRowx(
+		query,
+		userID,
+		usernamepolicy.Normalize(username),
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
 func (dao *RegisterDAO) CheckReferCodeExists(ctx context.Context, referCode string) (bool, error) {
 	query := `SELECT EXISTS(SELECT 1 FROM user_refcodes WHERE ref_code = $1) AS "exists"`
 	var exists bool
@@ -78,27 +112,6 @@ const (
 	RegisterMobileNumberFieldKey     = "mobile"
 )
 
-const SmsWhiteListKey = "sms_white_list"
-
-// We don't send real sms message to users in this list.
-// We want to save some money. Users in this list are
-// developers.
-func (dao *RegisterDAO) CheckUserInSMSWhiteList(ctx context.Context, p contracts.CheckUserInSMSWhiteListParams) (bool, error) {
-	l, err := p.RedisClient.LRange(ctx, SmsWhiteListKey, 0, -1).Result()
-
-	if err != nil {
-		return false, err
-	}
-
-	for _, username := range l {
-		if username == p.Username {
-			return true, nil
-		}
-	}
-
-	return false, nil
-}
-
 type CreateRegisterMobileVerifyCodeParams struct {
 	RedisCli   *redis.Client
 	UserUuid   string
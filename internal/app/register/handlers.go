@@ -20,8 +20,11 @@ import (
 	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
 	genverifycode "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/generate_verify_code"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/otpguard"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
-	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/twilio"
+	smssender "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/sms_sender"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/referral"
 	"github.com/jmoiron/sqlx"
 	"github.com/teris-io/shortid"
 )
@@ -49,27 +52,7 @@ func RegisterHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	dao := NewRegisterDAO(db.GetDB())
-	exists, err := dao.CheckUsernameExists(ctx, body.Username)
-
-	if err != nil {
-		c.AbortWithError(
-			http.StatusInternalServerError,
-			apperr.NewErr(
-				apperr.FailedToCheckUsernameExistence,
-				err.Error(),
-			),
-		)
-
-		return
-	}
-
-	if exists {
-		c.AbortWithError(
-			http.StatusBadRequest,
-			apperr.NewErr(apperr.UsernameNotAvailable),
-		)
-
+	if ok := validateUsername(c, depCon, body.Username); !ok {
 		return
 	}
 
@@ -111,6 +94,43 @@ func RegisterHandler(c *gin.Context, depCon container.Container) {
 			}
 		}
 
+		// Notifications are delivered in the language the user registered with.
+		if l := locale.FromContext(c); len(l) > 0 {
+			var userDao contracts.UserDAOer
+			depCon.Make(&userDao)
+
+			if _, err := userDao.WithTx(tx).UpdateUserInfoByUuid(contracts.UpdateUserInfoParams{
+				Uuid:   newUser.Uuid,
+				Locale: &l,
+			}); err != nil {
+				return db.FormatResp{
+					Err:            err,
+					ErrCode:        apperr.FailedToCreateUser,
+					HttpStatusCode: http.StatusInternalServerError,
+				}
+			}
+
+			newUser.Locale = l
+		}
+
+		// Normalized username is claimed in the same transaction so that concurrent registrations
+		// of `panda` and `ｐａｎｄａ` can not both succeed.
+		if _, err := NewRegisterDAO(tx).CreateUsernameKey(newUser.ID, newUser.Username); err != nil {
+			if err == sql.ErrNoRows {
+				return db.FormatResp{
+					Err:            errors.New(apperr.GetErrorMessage(apperr.UsernameNotAvailable)),
+					ErrCode:        apperr.UsernameNotAvailable,
+					HttpStatusCode: http.StatusBadRequest,
+				}
+			}
+
+			return db.FormatResp{
+				Err:            err,
+				ErrCode:        apperr.FailedToClaimUsername,
+				HttpStatusCode: http.StatusInternalServerError,
+			}
+		}
+
 		return db.FormatResp{
 			Response: &newUser,
 		}
@@ -121,7 +141,7 @@ func RegisterHandler(c *gin.Context, depCon container.Container) {
 			txResp.HttpStatusCode,
 			apperr.NewErr(
 				txResp.ErrCode,
-				err.Error(),
+				txResp.Err.Error(),
 			),
 		)
 
@@ -154,29 +174,7 @@ func VerifyUsernameHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	dao := NewRegisterDAO(db.GetDB())
-	ctx := context.Background()
-
-	exists, err := dao.CheckUsernameExists(ctx, body.Username)
-
-	if err != nil {
-		c.AbortWithError(
-			http.StatusInternalServerError,
-			apperr.NewErr(
-				apperr.FailedToCheckUsernameExistence,
-				err.Error(),
-			),
-		)
-
-		return
-	}
-
-	if exists {
-		c.AbortWithError(
-			http.StatusBadRequest,
-			apperr.NewErr(apperr.UsernameNotAvailable),
-		)
-
+	if ok := validateUsername(c, depCon, body.Username); !ok {
 		return
 	}
 
@@ -233,8 +231,21 @@ func SendMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Generate phone verify code and update user record.
+	// Throttle sending verify code SMS so that one number can not be SMS bombed.
 	ctx := context.Background()
+	retryAfter, err := otpguard.
+		New(db.GetRedis(), config.GetAppConf().GetOTPLimits()).
+		ConsumeSendQuota(ctx, otpguard.RegisterScope, otpguard.Subjects{
+			UserUuid: body.Uuid,
+			Mobile:   body.Mobile,
+			IP:       c.ClientIP(),
+		})
+
+	if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPRequests) {
+		return
+	}
+
+	// Generate phone verify code and update user record.
 	vs := genverifycode.GenVerifyCode()
 
 	if err := CreateRegisterMobileVerifyCode(
@@ -256,59 +267,16 @@ func SendMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Send mobile verify code by twilio. If uuid is in the white list,
-	// we don't send real SMS message because it's too expensive
-	regDao := NewRegisterDAO(db.GetDB())
-	exists, err := regDao.CheckUserInSMSWhiteList(ctx, contracts.CheckUserInSMSWhiteListParams{
-		RedisClient: db.GetRedis(),
-		Username:    user.Username,
-	})
+	// Send mobile verify code via configured SMS driver.
+	var smsSender smssender.SMSSender
+	depCon.Make(&smsSender)
 
-	if err != nil && !errors.Is(err, redis.Nil) {
-		c.AbortWithError(
-			http.StatusInternalServerError,
-			apperr.NewErr(
-				apperr.FailedToCheckUserExistsInSMSWhiteList,
-				err.Error(),
-			),
-		)
-
-		return
-	}
-
-	var (
-		tc   twilio.TwilioServicer
-		from string = config.GetAppConf().TwilioFrom
-	)
-	depCon.Make(&tc)
-
-	if exists {
-		log.Info("DEV account, bypassing real sms sending")
-
-		// Set twilio config to use DEV.
-		tc.SetConfig(twilio.TwilioConf{
-			AccountSID:   config.GetAppConf().TwilioDevAccountID,
-			AccountToken: config.GetAppConf().TwilioDevAuthToken,
-		})
-
-		from = config.GetAppConf().TwilioDevFrom
-	}
-
-	smsResp, err := tc.SendSMS(
-		from,
-		body.Mobile,
-		fmt.Sprintf("your darkpanda verify code: \n\n %s", vs.BuildCode()),
-	)
-
-	if twilio.HandleSendTwilioError(c, err) != nil {
-		c.AbortWithError(
-			http.StatusInternalServerError,
-			apperr.NewErr(
-				apperr.FailedToSendTwilioSMS,
-				err.Error(),
-			),
-		)
+	smsResp, err := smsSender.Send(ctx, smssender.Message{
+		To:      body.Mobile,
+		Content: fmt.Sprintf("your darkpanda verify code: \n\n %s", vs.BuildCode()),
+	})
 
+	if smssender.HandleSendSMSError(c, err) != nil {
 		return
 	}
 
@@ -317,7 +285,7 @@ func SendMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 			"user_uuid": user.Uuid,
 			"mobile":    user.Mobile.String,
 		}).
-		Infof("sends twilio SMS success, login verify code created ! %v", smsResp.SID)
+		Infof("sends %s SMS success, login verify code created ! %v", smsResp.Driver, smsResp.MessageID)
 
 	c.JSON(
 		http.StatusOK,
@@ -395,8 +363,20 @@ func VerifyMobileHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Retrieve verify code from redis.
 	ctx := context.Background()
+	guard := otpguard.New(db.GetRedis(), config.GetAppConf().GetOTPLimits())
+	subjects := otpguard.Subjects{
+		UserUuid: user.Uuid,
+		IP:       c.ClientIP(),
+	}
+
+	retryAfter, err := guard.CheckLockout(ctx, otpguard.RegisterScope, subjects)
+
+	if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPAttempts) {
+		return
+	}
+
+	// Retrieve verify code from redis.
 	vc, err := GetRegisterMobileVerifyCode(ctx, GetRegisterMobileVerifyCodeParams{
 		RedisCli: db.GetRedis(),
 		UserUuid: user.Uuid,
@@ -424,6 +404,12 @@ func VerifyMobileHandler(c *gin.Context, depCon container.Container) {
 	}
 
 	if vc.VerifyCode != body.VerifyCode {
+		retryAfter, err := guard.RecordFailure(ctx, otpguard.RegisterScope, subjects)
+
+		if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPAttempts) {
+			return
+		}
+
 		c.AbortWithError(
 			http.StatusBadRequest,
 			apperr.NewErr(
@@ -434,6 +420,18 @@ func VerifyMobileHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	if err := guard.Reset(ctx, otpguard.RegisterScope, subjects); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRecordOTPAttempt,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
 	phoneVerified := true
 	if _, err := userDao.UpdateUserInfoByUuid(contracts.UpdateUserInfoParams{
 		Uuid:          body.UUID,
@@ -451,6 +449,12 @@ func VerifyMobileHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	// Referral campaigns should not block the registration. Rewards failed to be credited
+	// are logged so that they can be compensated manually.
+	if _, err := referral.GrantRewardsInTx(depCon, user.ID, models.ReferralRewardEventPhoneVerified); err != nil {
+		log.Errorf("failed to grant referral rewards to user %s %s", user.Uuid, err.Error())
+	}
+
 	// Retreive verifier by user uuid
 	// If user already mobile verified, respond with ok status
 	// If user is not mobile verified, try compare the verify code
@@ -458,9 +462,17 @@ func VerifyMobileHandler(c *gin.Context, depCon container.Container) {
 	// If verify code isn't match, respond bad request.
 	// Response with auth jwt token.
 
-	jwt, err := jwtactor.CreateToken(
-		user.Uuid,
-		config.GetAppConf().JwtSecret,
+	var authDaoer contracts.AuthDaoer
+	depCon.Make(&authDaoer)
+
+	tokenPair, err := jwtactor.IssueTokenPair(
+		context.Background(),
+		authDaoer,
+		jwtactor.CreateTokenPairParams{
+			Uuid:            user.Uuid,
+			AccessTokenTTL:  config.GetAppConf().GetAccessTokenTTL(),
+			RefreshTokenTTL: config.GetAppConf().GetRefreshTokenTTL(),
+		},
 	)
 
 	if err != nil {
@@ -475,7 +487,5 @@ func VerifyMobileHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	c.JSON(http.StatusOK, struct {
-		Jwt string `json:"jwt"`
-	}{jwt})
+	c.JSON(http.StatusOK, NewTransform().TransformTokenPair(tokenPair))
 }
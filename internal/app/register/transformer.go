@@ -1,6 +1,9 @@
 package register
 
-import "github.com/huangc28/go-darkpanda-backend/internal/app/models"
+import (
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
 
 type RegisterTransform struct{}
 
@@ -39,3 +42,19 @@ func (at *RegisterTransform) TransformSendMobileVerifyCode(uuid string, verifyPr
 		VerifyPrefix: verifyPrefix,
 	}
 }
+
+type TransformedTokenPair struct {
+	Jwt                   string `json:"jwt"`
+	JwtExpiresAt          int64  `json:"jwt_expires_at"`
+	RefreshToken          string `json:"refresh_token"`
+	RefreshTokenExpiresAt int64  `json:"refresh_token_expires_at"`
+}
+
+func (at *RegisterTransform) TransformTokenPair(pair *jwtactor.TokenPair) TransformedTokenPair {
+	return TransformedTokenPair{
+		Jwt:                   pair.Jwt,
+		JwtExpiresAt:          pair.JwtExpiresAt,
+		RefreshToken:          pair.RefreshToken,
+		RefreshTokenExpiresAt: pair.RefreshTokenExpiresAt,
+	}
+}
@@ -0,0 +1,66 @@
+package register
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	container "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	usernamepolicy "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/username_policy"
+)
+
+var usernameRuleErrCodes = map[usernamepolicy.Rule]string{
+	usernamepolicy.RuleTooShort:          apperr.UsernameTooShort,
+	usernamepolicy.RuleTooLong:           apperr.UsernameTooLong,
+	usernamepolicy.RuleInvalidCharacters: apperr.UsernameHasInvalidCharacters,
+	usernamepolicy.RuleReserved:          apperr.UsernameReserved,
+	usernamepolicy.RuleProfane:           apperr.UsernameProfane,
+}
+
+// validateUsername checks the username against the username policy and whether the username is taken.
+// The request is aborted with the error code of the failed rule.
+func validateUsername(c *gin.Context, depCon container.Container, username string) bool {
+	var policy *usernamepolicy.Policy
+	depCon.Make(&policy)
+
+	if err := policy.Validate(username); err != nil {
+		violation := err.(*usernamepolicy.Violation)
+
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				usernameRuleErrCodes[violation.Rule],
+				violation.Message,
+			),
+		)
+
+		return false
+	}
+
+	exists, err := NewRegisterDAO(db.GetDB()).CheckUsernameExists(context.Background(), username)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckUsernameExistence,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	if exists {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.UsernameNotAvailable),
+		)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,288 @@
+package report
+
+import (
+	"time"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type ReportDAO struct {
+	db db.Conn
+}
+
+func NewReportDAO(db db.Conn) *ReportDAO {
+	return &ReportDAO{
+		db: db,
+	}
+}
+
+func ReportDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.ReportDAOer {
+			return NewReportDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *ReportDAO) WithTx(tx db.Conn) contracts.ReportDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+func (dao *ReportDAO) CreateReport(p contracts.CreateReportParams) (*models.Report, error) {
+	query := `
+INSERT INTO reports (
+	uuid,
+	reporter_id,
+	reported_user_id,
+	target_type,
+	target_ref,
+	category,
+	description
+) VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+`
+	var m models.Report
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Uuid,
+		p.ReporterID,
+		p.ReportedUserID,
+		p.TargetType,
+		p.TargetRef,
+		p.Category,
+		p.Description,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+const reportDetailColumns = `
+	reports.*,
+	reporters.uuid AS reporter_uuid,
+	reporters.username AS reporter_username,
+	reported_users.uuid AS reported_user_uuid,
+	reported_users.username AS reported_username,
+	assignees.uuid AS assignee_uuid
+`
+
+const reportDetailJoins = `
+INNER JOIN users AS reporters ON reporters.id = reports.reporter_id
+INNER JOIN users AS reported_users ON reported_users.id = reports.reported_user_id
+LEFT JOIN users AS assignees ON assignees.id = reports.assignee_id
+`
+
+func (dao *ReportDAO) GetReportByUuid(uuid string) (*models.ReportDetail, error) {
+	query := `
+SELECT` + reportDetailColumns + `
+FROM
+	reports` + reportDetailJoins + `
+WHERE
+	reports.uuid = $1 AND
+	reports.deleted_at IS NULL;
+`
+	var m models.ReportDetail
+
+	if err := dao.db.QueryRowx(query, uuid).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetReports retrieves reports of the status, oldest first so that moderators work through the queue in order.
+func (dao *ReportDAO) GetReports(p contracts.GetReportsParams) ([]models.ReportDetail, error) {
+	query := `
+SELECT` + reportDetailColumns + `
+FROM
+	reports` + reportDetailJoins + `
+WHERE
+	reports.status = $1 AND
+	reports.deleted_at IS NULL
+ORDER BY reports.created_at
+LIMIT $2
+OFFSET $3;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		p.Status,
+		p.PerPage,
+		p.Offset,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rs := make([]models.ReportDetail, 0)
+
+	for rows.Next() {
+		var m models.ReportDetail
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		rs = append(rs, m)
+	}
+
+	return rs, nil
+}
+
+// AssignReport assigns the report to the moderator. An open report is moved to `in_review` once assigned.
+func (dao *ReportDAO) AssignReport(uuid string, assigneeID int64) (*models.Report, error) {
+	query := `
+UPDATE reports
+SET
+	assignee_id = $1,
+	status = CASE WHEN status = $2 THEN $3 ELSE status END
+WHERE
+	uuid = $4 AND
+	deleted_at IS NULL
+RETURNING *;
+`
+	var m models.Report
+
+	if err := dao.db.QueryRowx(
+		query,
+		assigneeID,
+		models.ReportStatusOpen,
+		models.ReportStatusInReview,
+		uuid,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// UpdateReportStatus changes status of the report only if it is still in `FromStatus`. `sql.ErrNoRows` is
+// returned if the report has been changed by another moderator in the meantime.
+func (dao *ReportDAO) UpdateReportStatus(p contracts.UpdateReportStatusParams) (*models.Report, error) {
+	query := `
+UPDATE reports
+SET
+	status = $1,
+	resolution_note = COALESCE($2, resolution_note),
+	closed_at = CASE WHEN $1 IN ($3::report_status, $4::report_status) THEN NOW() ELSE NULL END
+WHERE
+	uuid = $5 AND
+	status = $6 AND
+	deleted_at IS NULL
+RETURNING *;
+`
+	var m models.Report
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Status,
+		p.ResolutionNote,
+		models.ReportStatusResolved,
+		models.ReportStatusDismissed,
+		p.Uuid,
+		p.FromStatus,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *ReportDAO) CreateReportEvent(p contracts.CreateReportEventParams) error {
+	query := `
+INSERT INTO report_events (
+	report_id,
+	actor_id,
+	action,
+	from_status,
+	to_status,
+	note
+) VALUES ($1, $2, $3, $4, $5, $6);
+`
+	_, err := dao.db.Exec(
+		query,
+		p.ReportID,
+		p.ActorID,
+		p.Action,
+		p.FromStatus,
+		p.ToStatus,
+		p.Note,
+	)
+
+	return err
+}
+
+func (dao *ReportDAO) GetReportEvents(reportID int64) ([]models.ReportEventDetail, error) {
+	query := `
+SELECT
+	report_events.*,
+	users.uuid AS actor_uuid
+FROM
+	report_events
+LEFT JOIN users ON users.id = report_events.actor_id
+WHERE
+	report_events.report_id = $1 AND
+	report_events.deleted_at IS NULL
+ORDER BY report_events.created_at, report_events.id;
+`
+	rows, err := dao.db.Queryx(query, reportID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	es := make([]models.ReportEventDetail, 0)
+
+	for rows.Next() {
+		var m models.ReportEventDetail
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		es = append(es, m)
+	}
+
+	return es, nil
+}
+
+// CountDistinctReporters counts users who reported the user since the given time. Dismissed reports
+// are not counted.
+func (dao *ReportDAO) CountDistinctReporters(reportedUserID int64, since time.Time) (int, error) {
+	query := `
+SELECT
+	COUNT(DISTINCT reporter_id)
+FROM
+	reports
+WHERE
+	reported_user_id = $1 AND
+	created_at >= $2 AND
+	status != $3 AND
+	deleted_at IS NULL;
+`
+	var count int
+
+	if err := dao.db.QueryRowx(
+		query,
+		reportedUserID,
+		since,
+		models.ReportStatusDismissed,
+	).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
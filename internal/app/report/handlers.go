@@ -0,0 +1,225 @@
+package report
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+type CreateReportBody struct {
+	ReportedUuid string  `form:"reported_uuid" json:"reported_uuid" binding:"required"`
+	TargetType   string  `form:"target_type" json:"target_type" binding:"required,oneof=profile chat_message image service"`
+	TargetRef    *string `form:"target_ref" json:"target_ref"`
+	Category     string  `form:"category" json:"category" binding:"required,oneof=harassment spam fake_profile inappropriate_content scam underage other"`
+	Description  *string `form:"description" json:"description" binding:"omitempty,max=1000"`
+}
+
+// validateTarget makes sure the reported content exists and involves the reported user. Reporter has to be
+// part of the service or the chatroom being reported.
+func validateTarget(depCon container.Container, targetType models.ReportTargetType, ref string, reporter, reported *models.User) (bool, error) {
+	switch targetType {
+	case models.ReportTargetTypeService:
+		var srvDao contracts.ServiceDAOer
+		depCon.Make(&srvDao)
+
+		srv, err := srvDao.GetServiceByUuid(ref, "customer_id", "service_provider_id")
+
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		parties := map[int64]bool{
+			int64(srv.CustomerID.Int32):        true,
+			int64(srv.ServiceProviderID.Int32): true,
+		}
+
+		return parties[reporter.ID] && parties[reported.ID], nil
+
+	case models.ReportTargetTypeChatMessage:
+		// Chat messages live in firestore, reference is composed of `<channel_uuid>/<message_id>`.
+		parts := strings.SplitN(ref, "/", 2)
+
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return false, nil
+		}
+
+		var chatDao contracts.ChatDaoer
+		depCon.Make(&chatDao)
+
+		for _, uuid := range []string{reporter.Uuid, reported.Uuid} {
+			in, err := chatDao.IsUserInChatroom(uuid, parts[0])
+
+			if err != nil || !in {
+				return false, err
+			}
+		}
+
+		return true, nil
+
+	case models.ReportTargetTypeImage:
+		if reported.AvatarUrl.Valid && reported.AvatarUrl.String == ref {
+			return true, nil
+		}
+
+		var imageDao contracts.ImageDAOer
+		depCon.Make(&imageDao)
+
+		imgs, err := imageDao.GetImagesByUserID(int(reported.ID))
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, img := range imgs {
+			if img.Url == ref {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// CreateReportHandler files a report against a user's profile, chat message, image or service. The report
+// enters the moderation queue as `open`.
+func CreateReportHandler(c *gin.Context, depCon container.Container) {
+	body := CreateReportBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateReportParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	myUuid := c.GetString("uuid")
+
+	if body.ReportedUuid == myUuid {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.CanNotReportYourself),
+		)
+
+		return
+	}
+
+	targetType := models.ReportTargetType(body.TargetType)
+
+	// Profile is identified by the reported user, any reference given is ignored.
+	if targetType == models.ReportTargetTypeProfile {
+		body.TargetRef = nil
+	} else if body.TargetRef == nil || len(*body.TargetRef) == 0 {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.ReportTargetRefRequired),
+		)
+
+		return
+	}
+
+	var userDao contracts.UserDAOer
+	depCon.Make(&userDao)
+
+	me, err := userDao.GetUserByUuid(myUuid, "id", "uuid")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	reported, err := userDao.GetUserByUuid(body.ReportedUuid, "id", "uuid", "avatar_url")
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.ReportedUserNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if body.TargetRef != nil {
+		valid, err := validateTarget(depCon, targetType, *body.TargetRef, me, reported)
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToValidateReportTarget,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		if !valid {
+			c.AbortWithError(
+				http.StatusNotFound,
+				apperr.NewErr(apperr.ReportTargetNotFound),
+			)
+
+			return
+		}
+	}
+
+	r, err := CreateReportInTx(depCon, contracts.CreateReportParams{
+		ReporterID:     me.ID,
+		ReportedUserID: reported.ID,
+		TargetType:     targetType,
+		TargetRef:      body.TargetRef,
+		Category:       models.ReportCategory(body.Category),
+		Description:    body.Description,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCreateReport,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformCreatedReport(r))
+}
@@ -0,0 +1,188 @@
+package report
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/jmoiron/sqlx"
+	"github.com/teris-io/shortid"
+)
+
+const (
+	// AutoRestrictReporters number of distinct reporters within AutoRestrictWindow that restricts
+	// the reported user automatically.
+	AutoRestrictReporters = 3
+	AutoRestrictWindow    = 7 * 24 * time.Hour
+
+	// AutoRestrictDuration how long the automatic restriction lasts before moderators look into the reports.
+	AutoRestrictDuration = 24 * time.Hour
+)
+
+// Actions recorded in report events.
+const (
+	ActionCreated        = "created"
+	ActionAssigned       = "assigned"
+	ActionStatusChanged  = "status_changed"
+	ActionAutoRestricted = "auto_restricted"
+)
+
+var transitions = map[models.ReportStatus][]models.ReportStatus{
+	models.ReportStatusOpen: {
+		models.ReportStatusInReview,
+		models.ReportStatusResolved,
+		models.ReportStatusDismissed,
+	},
+	models.ReportStatusInReview: {
+		models.ReportStatusOpen,
+		models.ReportStatusResolved,
+		models.ReportStatusDismissed,
+	},
+
+	// Closed reports can only be reopened.
+	models.ReportStatusResolved: {
+		models.ReportStatusOpen,
+	},
+	models.ReportStatusDismissed: {
+		models.ReportStatusOpen,
+	},
+}
+
+// CanTransit tells if the report is allowed to move from one status to the other.
+func CanTransit(from, to models.ReportStatus) bool {
+	for _, s := range transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ShouldAutoRestrict tells if number of distinct reporters is enough to restrict the reported user.
+func ShouldAutoRestrict(reporterCount int) bool {
+	return reporterCount >= AutoRestrictReporters
+}
+
+// ReportService files reports. Daos should be bound to the same transaction so that the report, its
+// events and the automatic restriction are committed together.
+type ReportService struct {
+	reportDao      contracts.ReportDAOer
+	restrictionDao contracts.UserRestrictionDAOer
+}
+
+func NewReportService(rd contracts.ReportDAOer, ud contracts.UserRestrictionDAOer) *ReportService {
+	return &ReportService{
+		reportDao:      rd,
+		restrictionDao: ud,
+	}
+}
+
+// CreateReport files the report and restricts the reported user if enough users have reported
+// the user recently. The restriction is only imposed if the user is not restricted yet. Reports of
+// the same user are serialized so that concurrent reports neither miss the threshold nor impose
+// the restriction twice.
+func (s *ReportService) CreateReport(p contracts.CreateReportParams) (*models.Report, error) {
+	sid, err := shortid.Generate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.restrictionDao.LockUser(p.ReportedUserID); err != nil {
+		return nil, err
+	}
+
+	p.Uuid = sid
+
+	r, err := s.reportDao.CreateReport(p)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.reportDao.CreateReportEvent(contracts.CreateReportEventParams{
+		ReportID: int64(r.ID),
+		ActorID:  &p.ReporterID,
+		Action:   ActionCreated,
+		ToStatus: &r.Status,
+	}); err != nil {
+		return nil, err
+	}
+
+	count, err := s.reportDao.CountDistinctReporters(
+		p.ReportedUserID,
+		time.Now().Add(-AutoRestrictWindow),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !ShouldAutoRestrict(count) {
+		return r, nil
+	}
+
+	_, err = s.restrictionDao.GetActiveRestriction(p.ReportedUserID)
+
+	if err == nil {
+		return r, nil
+	}
+
+	if err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	reason := fmt.Sprintf("reported by %d users within %s", count, AutoRestrictWindow)
+
+	if _, err := s.restrictionDao.CreateRestriction(contracts.CreateUserRestrictionParams{
+		UserID:    p.ReportedUserID,
+		Reason:    reason,
+		ExpiresAt: time.Now().Add(AutoRestrictDuration),
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := s.reportDao.CreateReportEvent(contracts.CreateReportEventParams{
+		ReportID: int64(r.ID),
+		Action:   ActionAutoRestricted,
+		Note:     &reason,
+	}); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// CreateReportInTx files the report in a transaction.
+func CreateReportInTx(depCon container.Container, p contracts.CreateReportParams) (*models.Report, error) {
+	var (
+		reportDao      contracts.ReportDAOer
+		restrictionDao contracts.UserRestrictionDAOer
+		r              *models.Report
+	)
+
+	depCon.Make(&reportDao)
+	depCon.Make(&restrictionDao)
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		var err error
+
+		r, err = NewReportService(
+			reportDao.WithTx(tx),
+			restrictionDao.WithTx(tx),
+		).CreateReport(p)
+
+		return db.FormatResp{Err: err}
+	})
+
+	if transResp.Err != nil {
+		return nil, transResp.Err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,137 @@
+package reporttests
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/report"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CreateReportTestSuite struct {
+	suite.Suite
+	depCon container.Container
+}
+
+func (suite *CreateReportTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+func (suite *CreateReportTestSuite) reportProfile(reporter, reported models.User) {
+	body := url.Values{}
+	body.Set("reported_uuid", reported.Uuid)
+	body.Set("target_type", string(models.ReportTargetTypeProfile))
+	body.Set("category", string(models.ReportCategoryHarassment))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, err := util.ComposeTestRequest("POST", "/v1/reports", &body, map[string]string{})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	c.Request = req
+	c.Set("uuid", reporter.Uuid)
+
+	report.CreateReportHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code, w.Body.String())
+}
+
+func (suite *CreateReportTestSuite) countActiveRestrictions(userID int64) int {
+	var count int
+
+	if err := db.GetDB().QueryRow(`
+SELECT COUNT(*)
+FROM user_restrictions
+WHERE
+	user_id = $1 AND
+	expires_at > NOW() AND
+	lifted_at IS NULL AND
+	deleted_at IS NULL;
+`, userID).Scan(&count); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return count
+}
+
+func (suite *CreateReportTestSuite) TestAutoRestrictAfterDistinctReporters() {
+	reported := util.CreateTestUser(suite.T(), models.GenderFemale)
+	reporter := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	// Reporting the same user repeatedly does not count as more reporters.
+	for i := 0; i < report.AutoRestrictReporters; i++ {
+		suite.reportProfile(reporter, reported)
+	}
+
+	assert.Equal(suite.T(), 0, suite.countActiveRestrictions(reported.ID))
+
+	for i := 1; i < report.AutoRestrictReporters; i++ {
+		suite.reportProfile(util.CreateTestUser(suite.T(), models.GenderMale), reported)
+	}
+
+	assert.Equal(suite.T(), 1, suite.countActiveRestrictions(reported.ID))
+
+	// Further reports do not stack restrictions.
+	suite.reportProfile(util.CreateTestUser(suite.T(), models.GenderMale), reported)
+	assert.Equal(suite.T(), 1, suite.countActiveRestrictions(reported.ID))
+}
+
+func (suite *CreateReportTestSuite) TestConcurrentReportsRestrictOnce() {
+	reported := util.CreateTestUser(suite.T(), models.GenderFemale)
+	reporters := make([]models.User, report.AutoRestrictReporters*2)
+
+	for i := range reporters {
+		reporters[i] = util.CreateTestUser(suite.T(), models.GenderMale)
+	}
+
+	var wg sync.WaitGroup
+
+	for _, reporter := range reporters {
+		reporter := reporter
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := report.CreateReportInTx(suite.depCon, contracts.CreateReportParams{
+				ReporterID:     reporter.ID,
+				ReportedUserID: reported.ID,
+				TargetType:     models.ReportTargetTypeProfile,
+				Category:       models.ReportCategorySpam,
+			})
+
+			assert.NoError(suite.T(), err)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(suite.T(), 1, suite.countActiveRestrictions(reported.ID))
+}
+
+func TestCreateReportTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateReportTestSuite))
+}
@@ -0,0 +1,31 @@
+package reporttests
+
+import (
+	"testing"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ReportTestSuite) TestCanTransit() {
+	assert.True(suite.T(), report.CanTransit(models.ReportStatusOpen, models.ReportStatusInReview))
+	assert.True(suite.T(), report.CanTransit(models.ReportStatusInReview, models.ReportStatusResolved))
+	assert.True(suite.T(), report.CanTransit(models.ReportStatusDismissed, models.ReportStatusOpen))
+	assert.False(suite.T(), report.CanTransit(models.ReportStatusOpen, models.ReportStatusOpen))
+	assert.False(suite.T(), report.CanTransit(models.ReportStatusResolved, models.ReportStatusDismissed))
+}
+
+func (suite *ReportTestSuite) TestShouldAutoRestrict() {
+	assert.False(suite.T(), report.ShouldAutoRestrict(report.AutoRestrictReporters-1))
+	assert.True(suite.T(), report.ShouldAutoRestrict(report.AutoRestrictReporters))
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
@@ -0,0 +1,143 @@
+package report
+
+import (
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type UserRestrictionDAO struct {
+	db db.Conn
+}
+
+func NewUserRestrictionDAO(db db.Conn) *UserRestrictionDAO {
+	return &UserRestrictionDAO{
+		db: db,
+	}
+}
+
+func UserRestrictionDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.UserRestrictionDAOer {
+			return NewUserRestrictionDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *UserRestrictionDAO) WithTx(tx db.Conn) contracts.UserRestrictionDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+// LockUser locks the row of the user until the transaction ends. Reports against the user are counted
+// and restrictions are imposed by one transaction at a time. Restrictions expire with time, thus an
+// active restriction can not be made unique by an index.
+func (dao *UserRestrictionDAO) LockUser(userID int64) error {
+	query := `
+SELECT id
+FROM users
+WHERE id = $1
+FOR UPDATE;
+`
+	var id int64
+
+	return dao.db.QueryRowx(query, userID).Scan(&id)
+}
+
+func (dao *UserRestrictionDAO) CreateRestriction(p contracts.CreateUserRestrictionParams) (*models.UserRestriction, error) {
+	query := `
+INSERT INTO user_restrictions (
+	user_id,
+	reason,
+	expires_at,
+	created_by
+) VALUES ($1, $2, $3, $4)
+RETURNING *;
+`
+	var m models.UserRestriction
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.UserID,
+		p.Reason,
+		p.ExpiresAt,
+		p.CreatedBy,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetActiveRestriction retrieves the restriction of the user that expires last. `sql.ErrNoRows` is returned
+// if the user is not restricted.
+func (dao *UserRestrictionDAO) GetActiveRestriction(userID int64) (*models.UserRestriction, error) {
+	query := `
+SELECT
+	*
+FROM
+	user_restrictions
+WHERE
+	user_id = $1 AND
+	expires_at > NOW() AND
+	lifted_at IS NULL AND
+	deleted_at IS NULL
+ORDER BY expires_at DESC
+LIMIT 1;
+`
+	var m models.UserRestriction
+
+	if err := dao.db.QueryRowx(query, userID).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *UserRestrictionDAO) IsRestrictedByUuid(uuid string) (bool, error) {
+	query := `
+SELECT EXISTS(
+	SELECT 1
+	FROM user_restrictions
+	INNER JOIN users ON users.id = user_restrictions.user_id
+	WHERE
+		users.uuid = $1 AND
+		user_restrictions.expires_at > NOW() AND
+		user_restrictions.lifted_at IS NULL AND
+		user_restrictions.deleted_at IS NULL
+);
+`
+	var restricted bool
+
+	if err := dao.db.QueryRowx(query, uuid).Scan(&restricted); err != nil {
+		return false, err
+	}
+
+	return restricted, nil
+}
+
+// LiftRestrictions lifts all active restrictions of the user. Number of lifted restrictions is returned.
+func (dao *UserRestrictionDAO) LiftRestrictions(userID int64, liftedBy int64) (int64, error) {
+	query := `
+UPDATE user_restrictions
+SET
+	lifted_at = NOW(),
+	lifted_by = $1
+WHERE
+	user_id = $2 AND
+	expires_at > NOW() AND
+	lifted_at IS NULL AND
+	deleted_at IS NULL;
+`
+	res, err := dao.db.Exec(query, liftedBy, userID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,26 @@
+package report
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
+
+// Routes APIs for users to report others. Moderation of reports is done via admin APIs.
+func Routes(r *gin.RouterGroup, depCon container.Container) {
+	var authDao contracts.AuthDaoer
+	depCon.Make(&authDao)
+
+	g := r.Group(
+		"/reports",
+		jwtactor.JwtValidator(
+			jwtactor.JwtMiddlewareOptions{},
+			authDao,
+		),
+	)
+
+	g.POST("", func(c *gin.Context) {
+		CreateReportHandler(c, depCon)
+	})
+}
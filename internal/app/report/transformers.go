@@ -0,0 +1,33 @@
+package report
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type ReportTransform struct{}
+
+func NewTransform() *ReportTransform {
+	return &ReportTransform{}
+}
+
+type TrfedCreatedReport struct {
+	Uuid       string                  `json:"uuid"`
+	TargetType models.ReportTargetType `json:"target_type"`
+	Category   models.ReportCategory   `json:"category"`
+	Status     models.ReportStatus     `json:"status"`
+	CreatedAt  time.Time               `json:"created_at"`
+}
+
+// TransformCreatedReport reporter only needs to know the report is received. Identity of the
+// reported user and moderation details are kept to operators.
+func (t *ReportTransform) TransformCreatedReport(r *models.Report) TrfedCreatedReport {
+	return TrfedCreatedReport{
+		Uuid:       r.Uuid,
+		TargetType: r.TargetType,
+		Category:   r.Category,
+		Status:     r.Status,
+		CreatedAt:  r.CreatedAt,
+	}
+}
@@ -17,7 +17,9 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
 	dpfcm "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/firebase_messaging"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/locale"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 	"github.com/jmoiron/sqlx"
 	log "github.com/sirupsen/logrus"
 )
@@ -291,10 +293,20 @@ func ScanServiceQrCode(c *gin.Context, depCon container.Container) {
 		)
 
 		// Change service type to expired.
-		srvDao.UpdateServiceByID(contracts.UpdateServiceByIDParams{
-			ID:            srv.ID,
-			ServiceStatus: &srvStatusExp,
-		})
+		transition.Transact(
+			contracts.AnnotateTransitionParams{
+				Event:     Expired.ToString(),
+				ActorUuid: c.GetString("uuid"),
+			},
+			func(tx *sqlx.Tx) error {
+				_, err := srvDao.WithTx(tx).UpdateServiceByID(contracts.UpdateServiceByIDParams{
+					ID:            srv.ID,
+					ServiceStatus: &srvStatusExp,
+				})
+
+				return err
+			},
+		)
 	}
 
 	if err != nil {
@@ -374,8 +386,13 @@ func ScanServiceQrCode(c *gin.Context, depCon container.Container) {
 		time.Duration(srv.Duration.Int32) * time.Minute,
 	)
 
-	var chatDao contracts.ChatDaoer
+	var (
+		chatDao       contracts.ChatDaoer
+		transitionDao contracts.TransitionDAOer
+	)
+
 	depCon.Make(&chatDao)
+	depCon.Make(&transitionDao)
 	chat, err := chatDao.GetChatroomByServiceId(int(srv.ID))
 
 	if err != nil {
@@ -393,6 +410,17 @@ func ScanServiceQrCode(c *gin.Context, depCon container.Container) {
 	transResp := db.TransactWithFormatStruct(
 		db.GetDB(),
 		func(tx *sqlx.Tx) db.FormatResp {
+			if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+				Event:     StartService.ToString(),
+				ActorUuid: c.GetString("uuid"),
+			}); err != nil {
+				return db.FormatResp{
+					Err:            err,
+					ErrCode:        apperr.FailedToAnnotateTransition,
+					HttpStatusCode: http.StatusInternalServerError,
+				}
+			}
+
 			srvDao.WithTx(tx)
 			usrv, err := srvDao.UpdateServiceByID(contracts.UpdateServiceByIDParams{
 				ID:            srv.ID,
@@ -1050,8 +1078,13 @@ func CancelService(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	var chatDao contracts.ChatDaoer
+	var (
+		chatDao       contracts.ChatDaoer
+		transitionDao contracts.TransitionDAOer
+	)
+
 	depCon.Make(&chatDao)
+	depCon.Make(&transitionDao)
 
 	type TxResp struct {
 		*models.Service
@@ -1072,6 +1105,18 @@ func CancelService(c *gin.Context, depCon container.Container) {
 			log.Infof("%s cancel cause: %s", user.Uuid, string(cancelCause))
 
 			// Change service status to cancel.
+			if err := transitionDao.WithTx(tx).Annotate(contracts.AnnotateTransitionParams{
+				Event:     Cancel.ToString(),
+				ActorUuid: user.Uuid,
+				Cause:     string(cancelCause),
+			}); err != nil {
+				return db.FormatResp{
+					Err:            err,
+					ErrCode:        apperr.FailedToAnnotateTransition,
+					HttpStatusCode: http.StatusInternalServerError,
+				}
+			}
+
 			srvStatus := models.ServiceStatus(srvFsm.Current())
 			usrv, err := srvDao.WithTx(tx).UpdateServiceByID(contracts.UpdateServiceByIDParams{
 				ID:            srv.ID,
@@ -1193,7 +1238,7 @@ func CancelService(c *gin.Context, depCon container.Container) {
 	depCon.Make(&dpfcmer)
 
 	partnerID := usrvInfo.GetPartnerId(user.ID)
-	partner, err := userDao.GetUserByID(int64(partnerID), "fcm_topic")
+	partner, err := userDao.GetUserByID(int64(partnerID), "fcm_topic", "locale")
 
 	if err != nil {
 		c.AbortWithError(
@@ -1212,6 +1257,10 @@ func CancelService(c *gin.Context, depCon container.Container) {
 			user.FcmTopic.String,
 			partner.FcmTopic.String,
 		},
+		Locales: []string{
+			locale.Resolve(locale.FromContext(c), user.Locale),
+			partner.Locale,
+		},
 		ServiceUUID:       usrvInfo.Uuid.String,
 		CancellerUUID:     user.Uuid,
 		CancellerUsername: user.Username,
@@ -1239,6 +1288,7 @@ func CancelService(c *gin.Context, depCon container.Container) {
 
 		if err := dpfcmer.PublishServiceRefunded(ctx, dpfcm.PublishServiceRefundedMessage{
 			Topic:       maleParty.FcmTopic.String,
+			Locale:      maleParty.Locale,
 			ServiceUUID: usrvInfo.Uuid.String,
 		}); err != nil {
 			c.AbortWithError(
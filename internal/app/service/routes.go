@@ -3,9 +3,9 @@ package service
 import (
 	"github.com/gin-gonic/gin"
 	cintrnal "github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
 )
 
 func Routes(r *gin.RouterGroup, container cintrnal.Container) {
@@ -17,9 +17,7 @@ func Routes(r *gin.RouterGroup, container cintrnal.Container) {
 	container.Make(&authDao)
 	g := r.Group(
 		"/services",
-		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{
-			Secret: config.GetAppConf().JwtSecret,
-		}, authDao),
+		jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{}, authDao),
 	)
 
 	g.GET("/:seg", func(c *gin.Context) {
@@ -69,6 +67,14 @@ func Routes(r *gin.RouterGroup, container cintrnal.Container) {
 		},
 	)
 
+	// Status transitions of the service for participants and operators.
+	g.GET(
+		"/:seg/history",
+		func(c *gin.Context) {
+			transition.GetServiceHistoryHandler(c, container)
+		},
+	)
+
 	g.GET(
 		"/:seg/rating",
 		func(c *gin.Context) {
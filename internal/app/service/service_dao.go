@@ -374,8 +374,10 @@ WITH found_services AS (
 		services.uuid,
 		customers.username AS customer_username,
 		customers.fcm_topic AS customer_fcm_topic,
+		customers.locale AS customer_locale,
 		service_providers.username AS service_providers_username,
-		service_providers.fcm_topic AS service_providers_fcm_topic
+		service_providers.fcm_topic AS service_providers_fcm_topic,
+		service_providers.locale AS service_providers_locale
 	FROM
 		services
 	INNER JOIN users AS customers ON services.customer_id = customers.id
@@ -400,8 +402,10 @@ SELECT
 	uuid,
 	customer_username,
 	customer_fcm_topic,
+	customer_locale,
 	service_providers_username,
-	service_providers_fcm_topic
+	service_providers_fcm_topic,
+	service_providers_locale
 FROM
 	found_services;
 	`, 30,
@@ -444,8 +448,10 @@ WITH found_services AS (
 		services.uuid,
 		customers.username AS customer_username,
 		customers.fcm_topic AS customer_fcm_topic,
+		customers.locale AS customer_locale,
 		service_providers.username AS service_providers_username,
-		service_providers.fcm_topic AS service_providers_fcm_topic
+		service_providers.fcm_topic AS service_providers_fcm_topic,
+		service_providers.locale AS service_providers_locale
 	FROM
 		services
 	INNER JOIN users AS customers ON services.customer_id = customers.id
@@ -468,8 +474,10 @@ SELECT
 	uuid,
 	customer_username,
 	customer_fcm_topic,
+	customer_locale,
 	service_providers_username,
-	service_providers_fcm_topic
+	service_providers_fcm_topic,
+	service_providers_locale
 FROM
 	found_services;
 `
@@ -746,8 +754,10 @@ SELECT
 		services.uuid,
 		customers.fcm_topic AS customer_fcm_topic,
 		customers.username AS customer_name,
+		COALESCE(customers.locale, '') AS customer_locale,
 		service_providers.fcm_topic AS service_provider_fcm_topic,
-		service_providers.username AS service_provider_name
+		service_providers.username AS service_provider_name,
+		COALESCE(service_providers.locale, '') AS service_provider_locale
 	FROM
 		services
 	LEFT JOIN users AS customers ON customers.id = services.customer_id
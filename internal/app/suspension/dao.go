@@ -0,0 +1,171 @@
+package suspension
+
+import (
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type UserSuspensionDAO struct {
+	db db.Conn
+}
+
+func NewUserSuspensionDAO(db db.Conn) *UserSuspensionDAO {
+	return &UserSuspensionDAO{
+		db: db,
+	}
+}
+
+func UserSuspensionDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.UserSuspensionDAOer {
+			return NewUserSuspensionDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *UserSuspensionDAO) WithTx(tx db.Conn) contracts.UserSuspensionDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+func (dao *UserSuspensionDAO) CreateSuspension(p contracts.CreateUserSuspensionParams) (*models.UserSuspension, error) {
+	query := `
+INSERT INTO user_suspensions (
+	user_id,
+	suspension_type,
+	reason,
+	expires_at,
+	created_by
+) VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+`
+	var m models.UserSuspension
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.UserID,
+		p.SuspensionType,
+		p.Reason,
+		p.ExpiresAt,
+		p.CreatedBy,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetActiveSuspension retrieves the latest suspension of the user in effect. `sql.ErrNoRows` is returned
+// if the user is not suspended.
+func (dao *UserSuspensionDAO) GetActiveSuspension(userID int64) (*models.UserSuspension, error) {
+	query := `
+SELECT
+	*
+FROM
+	user_suspensions
+WHERE
+	user_id = $1 AND
+	lifted_at IS NULL AND
+	deleted_at IS NULL AND
+	(expires_at IS NULL OR expires_at > NOW())
+ORDER BY created_at DESC
+LIMIT 1;
+`
+	var m models.UserSuspension
+
+	if err := dao.db.QueryRowx(query, userID).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetActiveSuspensionByUserUuid same as `GetActiveSuspension`. It is used when the suspension cache misses
+// since the jwt claim only carries the uuid of the user.
+func (dao *UserSuspensionDAO) GetActiveSuspensionByUserUuid(userUuid string) (*models.UserSuspension, error) {
+	query := `
+SELECT
+	user_suspensions.*
+FROM
+	user_suspensions
+INNER JOIN users ON users.id = user_suspensions.user_id
+WHERE
+	users.uuid = $1 AND
+	user_suspensions.lifted_at IS NULL AND
+	user_suspensions.deleted_at IS NULL AND
+	(user_suspensions.expires_at IS NULL OR user_suspensions.expires_at > NOW())
+ORDER BY user_suspensions.created_at DESC
+LIMIT 1;
+`
+	var m models.UserSuspension
+
+	if err := dao.db.QueryRowx(query, userUuid).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetActiveSuspensions retrieves every suspension in effect. It is used to rebuild the redis cache.
+func (dao *UserSuspensionDAO) GetActiveSuspensions() ([]models.ActiveUserSuspension, error) {
+	query := `
+SELECT
+	user_suspensions.*,
+	users.uuid AS user_uuid
+FROM
+	user_suspensions
+INNER JOIN users ON users.id = user_suspensions.user_id
+WHERE
+	user_suspensions.lifted_at IS NULL AND
+	user_suspensions.deleted_at IS NULL AND
+	(user_suspensions.expires_at IS NULL OR user_suspensions.expires_at > NOW());
+`
+	rows, err := dao.db.Queryx(query)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ss := make([]models.ActiveUserSuspension, 0)
+
+	for rows.Next() {
+		var m models.ActiveUserSuspension
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		ss = append(ss, m)
+	}
+
+	return ss, nil
+}
+
+// LiftSuspensions lifts every suspension of the user in effect. Number of lifted suspensions is returned.
+func (dao *UserSuspensionDAO) LiftSuspensions(userID int64, liftedBy int64) (int64, error) {
+	query := `
+UPDATE user_suspensions
+SET
+	lifted_at = NOW(),
+	lifted_by = $1
+WHERE
+	user_id = $2 AND
+	lifted_at IS NULL AND
+	deleted_at IS NULL AND
+	(expires_at IS NULL OR expires_at > NOW());
+`
+	res, err := dao.db.Exec(query, liftedBy, userID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
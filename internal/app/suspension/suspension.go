@@ -0,0 +1,193 @@
+package suspension
+
+import (
+	"context"
+	"time"
+
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/inquiry"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+// Redis writes are made after the suspension is committed. They are retried so that a transient redis
+// failure does not leave the suspended user able to use the app until the cache is rebuilt.
+const (
+	cacheRetryAttempts = 3
+	cacheRetryBackoff  = 200 * time.Millisecond
+)
+
+type SuspendParams struct {
+	UserID         int64
+	UserUuid       string
+	SuspensionType models.UserSuspensionType
+	Reason         string
+
+	// ExpiresAt nil if the suspension never expires.
+	ExpiresAt *time.Time
+	ActorID   int64
+	ActorUuid string
+}
+
+func MarkOf(s *models.UserSuspension) contracts.UserSuspensionMark {
+	mark := contracts.UserSuspensionMark{
+		SuspensionType: s.SuspensionType,
+		Reason:         s.Reason,
+	}
+
+	if s.ExpiresAt.Valid {
+		expiresAt := s.ExpiresAt.Time
+		mark.ExpiresAt = &expiresAt
+	}
+
+	return mark
+}
+
+// Suspend suspends or bans the user. Suspension in effect is replaced by the new one so that operators
+// can escalate a suspension to a ban. Once the suspension is stored:
+//
+//   - the suspension is cached for `JwtValidator` to reject the user.
+//   - every session of the user is revoked.
+//   - open inquiries of the user are cancelled.
+func Suspend(ctx context.Context, depCon container.Container, p SuspendParams) (*models.UserSuspension, error) {
+	var (
+		dao     contracts.UserSuspensionDAOer
+		authDao contracts.AuthDaoer
+		s       *models.UserSuspension
+	)
+
+	depCon.Make(&dao)
+	depCon.Make(&authDao)
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		txDao := dao.WithTx(tx)
+
+		if _, err := txDao.LiftSuspensions(p.UserID, p.ActorID); err != nil {
+			return db.FormatResp{Err: err}
+		}
+
+		var err error
+
+		s, err = txDao.CreateSuspension(contracts.CreateUserSuspensionParams{
+			UserID:         p.UserID,
+			SuspensionType: p.SuspensionType,
+			Reason:         p.Reason,
+			ExpiresAt:      p.ExpiresAt,
+			CreatedBy:      p.ActorID,
+		})
+
+		return db.FormatResp{Err: err}
+	})
+
+	if transResp.Err != nil {
+		return nil, transResp.Err
+	}
+
+	// The suspension is in effect once committed. Failures below are logged rather than returned so that
+	// the operator is not told the suspension failed. Suspensions missing in the cache are looked up from
+	// the database by `JwtValidator`.
+	if err := retryCacheWrite(ctx, func() error {
+		return authDao.SetUserSuspension(ctx, p.UserUuid, MarkOf(s))
+	}); err != nil {
+		log.Errorf("failed to cache suspension of user %s %s", p.UserUuid, err.Error())
+	}
+
+	if err := retryCacheWrite(ctx, func() error {
+		return authDao.RevokeAllSessions(ctx, p.UserUuid)
+	}); err != nil {
+		log.Errorf("failed to revoke sessions of user %s %s", p.UserUuid, err.Error())
+	}
+
+	uuids, err := inquiry.CancelOpenInquiriesOfUser(
+		ctx,
+		depCon,
+		p.UserID,
+		p.ActorUuid,
+		transition.CauseUserSuspended,
+	)
+
+	if err != nil {
+		log.Errorf("failed to cancel open inquiries of user %s %s", p.UserUuid, err.Error())
+	}
+
+	log.
+		WithFields(log.Fields{
+			"user_uuid":           p.UserUuid,
+			"suspension_type":     p.SuspensionType,
+			"cancelled_inquiries": uuids,
+		}).
+		Info("user suspended")
+
+	return s, nil
+}
+
+// Lift lifts suspensions of the user in effect. The user has to login again since sessions have been
+// revoked on suspension.
+func Lift(ctx context.Context, depCon container.Container, userID int64, userUuid string, actorID int64) (int64, error) {
+	var (
+		dao     contracts.UserSuspensionDAOer
+		authDao contracts.AuthDaoer
+	)
+
+	depCon.Make(&dao)
+	depCon.Make(&authDao)
+
+	lifted, err := dao.LiftSuspensions(userID, actorID)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err := retryCacheWrite(ctx, func() error {
+		return authDao.ClearUserSuspension(ctx, userUuid)
+	}); err != nil {
+		return 0, err
+	}
+
+	return lifted, nil
+}
+
+// retryCacheWrite retries fn with exponential backoff. The last error is returned if every attempt fails.
+func retryCacheWrite(ctx context.Context, fn func() error) error {
+	var (
+		err     error
+		backoff = cacheRetryBackoff
+	)
+
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt == cacheRetryAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// SyncCache caches every suspension in effect. Postgres is the source of truth, the cache is rebuilt on
+// API boot in case redis loses the data. Users missing in the cache are looked up by `GetUserSuspension`
+// of the auth dao, syncing only saves those lookups.
+func SyncCache(ctx context.Context, dao contracts.UserSuspensionDAOer, authDao contracts.AuthDaoer) error {
+	ss, err := dao.GetActiveSuspensions()
+
+	if err != nil {
+		return err
+	}
+
+	for _, s := range ss {
+		if err := authDao.SetUserSuspension(ctx, s.UserUuid, MarkOf(&s.UserSuspension)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,227 @@
+package suspensiontests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/inquiry"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuspendTestSuite struct {
+	suite.Suite
+	depCon container.Container
+}
+
+func (suite *SuspendTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+// createRandomInquiry creates an `inquiring` random inquiry of the inquirer both in DB and firestore.
+func (suite *SuspendTestSuite) createRandomInquiry(inquirer models.User) models.ServiceInquiry {
+	ctx := context.Background()
+	params, err := util.GenTestInquiryParams(inquirer.ID)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	params.InquiryStatus = models.InquiryStatusInquiring
+	params.InquiryType = models.InquiryTypeRandom
+
+	iq, err := models.New(db.GetDB()).CreateInquiry(ctx, *params)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	if _, _, err := darkfirestore.Get().CreateInquiringUser(ctx, darkfirestore.CreateInquiringUserParams{
+		InquiryUuid:  iq.Uuid,
+		InquirerUuid: inquirer.Uuid,
+		InquiryType:  string(models.InquiryTypeRandom),
+	}); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return iq
+}
+
+func (suite *SuspendTestSuite) suspend(user models.User) {
+	admin := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	if _, err := suspension.Suspend(context.Background(), suite.depCon, suspension.SuspendParams{
+		UserID:         user.ID,
+		UserUuid:       user.Uuid,
+		SuspensionType: models.UserSuspensionTypeSuspend,
+		Reason:         "harassment",
+		ActorID:        admin.ID,
+		ActorUuid:      admin.Uuid,
+	}); err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+func (suite *SuspendTestSuite) TestSuspendedUserIsRejected() {
+	user := util.CreateTestUser(suite.T(), models.GenderMale)
+	token, err := jwtactor.CreateTokenWithKeyID(user.Uuid)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.suspend(user)
+
+	var authDao contracts.AuthDaoer
+	suite.depCon.Make(&authDao)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/users/me", nil)
+	c.Request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	jwtactor.JwtValidator(jwtactor.JwtMiddlewareOptions{}, authDao)(c)
+	apperr.HandleError()(c)
+
+	respStruct := struct {
+		ErrCode string `json:"err_code"`
+	}{}
+
+	json.Unmarshal(w.Body.Bytes(), &respStruct)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.Equal(suite.T(), apperr.UserSuspended, respStruct.ErrCode)
+}
+
+func (suite *SuspendTestSuite) TestCacheMissFallsBackToDatabase() {
+	ctx := context.Background()
+	user := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	var authDao contracts.AuthDaoer
+	suite.depCon.Make(&authDao)
+
+	// Not suspended users are cached as well.
+	mark, err := authDao.GetUserSuspension(ctx, user.Uuid)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Nil(suite.T(), mark)
+
+	suite.suspend(user)
+
+	// Redis loses the suspension.
+	if err := authDao.ClearUserSuspension(ctx, user.Uuid); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	mark, err = authDao.GetUserSuspension(ctx, user.Uuid)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	if assert.NotNil(suite.T(), mark) {
+		assert.Equal(suite.T(), models.UserSuspensionTypeSuspend, mark.SuspensionType)
+	}
+}
+
+func (suite *SuspendTestSuite) TestSuspendCancelsOpenInquiries() {
+	inquirer := util.CreateTestUser(suite.T(), models.GenderMale)
+	iq := suite.createRandomInquiry(inquirer)
+
+	suite.suspend(inquirer)
+
+	var status models.InquiryStatus
+
+	if err := db.GetDB().QueryRow(
+		`SELECT inquiry_status FROM service_inquiries WHERE id = $1;`,
+		iq.ID,
+	).Scan(&status); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), models.InquiryStatusCanceled, status)
+}
+
+func (suite *SuspendTestSuite) TestSuspendDeclinesPendingApplications() {
+	inquirer := util.CreateTestUser(suite.T(), models.GenderMale)
+	applicant := util.CreateTestUser(suite.T(), models.GenderFemale)
+	iq := suite.createRandomInquiry(inquirer)
+
+	iqDao := inquiry.NewInquiryDAO(db.GetDB())
+
+	if _, err := iqDao.ApplyInquiry(iq.ID, applicant.ID); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.suspend(applicant)
+
+	status, err := iqDao.GetApplicantStatus(iq.ID, applicant.ID)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), models.InquiryApplicantStatusDeclined, status)
+
+	// The inquiry of someone else stays open for other applicants.
+	var iqStatus models.InquiryStatus
+
+	if err := db.GetDB().QueryRow(
+		`SELECT inquiry_status FROM service_inquiries WHERE id = $1;`,
+		iq.ID,
+	).Scan(&iqStatus); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), models.InquiryStatusInquiring, iqStatus)
+}
+
+func (suite *SuspendTestSuite) TestSuspendDeclinesApplicantsOfCancelledInquiries() {
+	inquirer := util.CreateTestUser(suite.T(), models.GenderMale)
+	applicant := util.CreateTestUser(suite.T(), models.GenderFemale)
+	iq := suite.createRandomInquiry(inquirer)
+
+	iqDao := inquiry.NewInquiryDAO(db.GetDB())
+
+	if _, err := iqDao.ApplyInquiry(iq.ID, applicant.ID); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	suite.suspend(inquirer)
+
+	status, err := iqDao.GetApplicantStatus(iq.ID, applicant.ID)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), models.InquiryApplicantStatusDeclined, status)
+}
+
+func TestSuspendTestSuite(t *testing.T) {
+	suite.Run(t, new(SuspendTestSuite))
+}
@@ -0,0 +1,41 @@
+package suspensiontests
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SuspensionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SuspensionTestSuite) TestMarkOf() {
+	expiresAt := time.Now().Add(time.Hour)
+
+	mark := suspension.MarkOf(&models.UserSuspension{
+		SuspensionType: models.UserSuspensionTypeSuspend,
+		Reason:         "harassment",
+		ExpiresAt:      sql.NullTime{Time: expiresAt, Valid: true},
+	})
+
+	assert.Equal(suite.T(), models.UserSuspensionTypeSuspend, mark.SuspensionType)
+	assert.Equal(suite.T(), "harassment", mark.Reason)
+	assert.True(suite.T(), mark.ExpiresAt.Equal(expiresAt))
+
+	mark = suspension.MarkOf(&models.UserSuspension{
+		SuspensionType: models.UserSuspensionTypeBan,
+		Reason:         "scam",
+	})
+
+	assert.Nil(suite.T(), mark.ExpiresAt)
+}
+
+func TestSuspensionTestSuite(t *testing.T) {
+	suite.Run(t, new(SuspensionTestSuite))
+}
@@ -0,0 +1,88 @@
+package transition
+
+import (
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type TransitionDAO struct {
+	db db.Conn
+}
+
+func NewTransitionDAO(db db.Conn) *TransitionDAO {
+	return &TransitionDAO{
+		db: db,
+	}
+}
+
+func TransitionDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.TransitionDAOer {
+			return NewTransitionDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *TransitionDAO) WithTx(tx db.Conn) contracts.TransitionDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+// Annotate sets actor, event and cause local to the transaction. Transitions recorded by the
+// `record_status_transition` trigger afterwards in the same transaction carry the annotation. It has
+// no effect outside of a transaction.
+func (dao *TransitionDAO) Annotate(p contracts.AnnotateTransitionParams) error {
+	query := `
+SELECT
+	set_config('darkpanda.transition_actor_uuid', $1, true),
+	set_config('darkpanda.transition_event', $2, true),
+	set_config('darkpanda.transition_cause', $3, true);
+`
+	_, err := dao.db.Exec(query, p.ActorUuid, p.Event, p.Cause)
+
+	return err
+}
+
+// GetTransitions retrieves the timeline of the inquiry or the service, earliest first.
+func (dao *TransitionDAO) GetTransitions(entityType models.TransitionEntityType, entityID int64) ([]models.StatusTransitionWithActor, error) {
+	query := `
+SELECT
+	status_transitions.*,
+	users.uuid AS actor_uuid,
+	users.username AS actor_username
+FROM status_transitions
+LEFT JOIN users ON users.id = status_transitions.actor_id
+WHERE
+	status_transitions.entity_type = $1
+	AND status_transitions.entity_id = $2
+ORDER BY
+	status_transitions.created_at,
+	status_transitions.id;
+`
+	rows, err := dao.db.Queryx(query, entityType, entityID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ts := make([]models.StatusTransitionWithActor, 0)
+
+	for rows.Next() {
+		var m models.StatusTransitionWithActor
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		ts = append(ts, m)
+	}
+
+	return ts, nil
+}
@@ -0,0 +1,122 @@
+package transition
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+// GetInquiryHistoryHandler retrieves status transitions of the inquiry. Only the inquirer, the picker
+// and operators can view the history.
+func GetInquiryHistoryHandler(c *gin.Context, depCon container.Container) {
+	var iqDao contracts.InquiryDAOer
+	depCon.Make(&iqDao)
+
+	iq, err := iqDao.GetInquiryByUuid(c.Param("uuid"), "id", "inquirer_id", "picker_id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToGetInquiryByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if !requireParticipant(c, depCon, iq.InquirerID.Int32, iq.PickerID.Int32) {
+		return
+	}
+
+	respondHistory(c, depCon, models.TransitionEntityTypeInquiry, iq.ID)
+}
+
+// GetServiceHistoryHandler retrieves status transitions of the service. Only the customer, the service
+// provider and operators can view the history.
+func GetServiceHistoryHandler(c *gin.Context, depCon container.Container) {
+	var srvDao contracts.ServiceDAOer
+	depCon.Make(&srvDao)
+
+	srv, err := srvDao.GetServiceByUuid(c.Param("seg"), "id", "customer_id", "service_provider_id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToGetServiceByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if !requireParticipant(c, depCon, srv.CustomerID.Int32, srv.ServiceProviderID.Int32) {
+		return
+	}
+
+	respondHistory(c, depCon, models.TransitionEntityTypeService, srv.ID)
+}
+
+func requireParticipant(c *gin.Context, depCon container.Container, participantIDs ...int32) bool {
+	if isOperator(c) {
+		return true
+	}
+
+	var userDao contracts.UserDAOer
+	depCon.Make(&userDao)
+
+	requester, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	for _, id := range participantIDs {
+		if id != 0 && int64(id) == requester.ID {
+			return true
+		}
+	}
+
+	c.AbortWithError(
+		http.StatusForbidden,
+		apperr.NewErr(apperr.NotParticipantOfHistory),
+	)
+
+	return false
+}
+
+func respondHistory(c *gin.Context, depCon container.Container, entityType models.TransitionEntityType, entityID int64) {
+	var dao contracts.TransitionDAOer
+	depCon.Make(&dao)
+
+	ts, err := dao.GetTransitions(entityType, entityID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetTransitionHistory,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, TrfHistory(ts))
+}
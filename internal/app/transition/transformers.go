@@ -0,0 +1,58 @@
+package transition
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type TrfedTransitionActor struct {
+	Uuid     string `json:"uuid"`
+	Username string `json:"username"`
+}
+
+type TrfedTransition struct {
+	FromStatus *string `json:"from_status"`
+	ToStatus   string  `json:"to_status"`
+	Event      string  `json:"event"`
+	Cause      string  `json:"cause"`
+
+	// Actor is nil if the transition is made by the system.
+	Actor     *TrfedTransitionActor `json:"actor"`
+	CreatedAt time.Time             `json:"created_at"`
+}
+
+type TrfedHistory struct {
+	Transitions []TrfedTransition `json:"transitions"`
+}
+
+func TrfHistory(ms []models.StatusTransitionWithActor) TrfedHistory {
+	ts := make([]TrfedTransition, 0, len(ms))
+
+	for _, m := range ms {
+		t := TrfedTransition{
+			ToStatus:  m.ToStatus,
+			Event:     m.Event.String,
+			Cause:     m.Cause.String,
+			CreatedAt: m.CreatedAt,
+		}
+
+		if m.FromStatus.Valid {
+			fromStatus := m.FromStatus.String
+			t.FromStatus = &fromStatus
+		}
+
+		if m.ActorUuid.Valid {
+			t.Actor = &TrfedTransitionActor{
+				Uuid:     m.ActorUuid.String,
+				Username: m.ActorUsername.String,
+			}
+		}
+
+		ts = append(ts, t)
+	}
+
+	return TrfedHistory{
+		Transitions: ts,
+	}
+}
@@ -0,0 +1,62 @@
+// Package transition keeps the audit trail of inquiry and service status. Every status update is
+// recorded by the `record_status_transition` trigger in the same transaction as the update. Callers
+// annotate the transaction with the actor, event and cause beforehand so that support can tell who
+// made the transition and why when resolving disputes.
+package transition
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/jmoiron/sqlx"
+)
+
+// Transact runs status updates of `fn` in a transaction annotated with `p`. Used by status updates
+// that are not wrapped in a transaction of their own.
+func Transact(p contracts.AnnotateTransitionParams, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.GetDB().Beginx()
+
+	if err != nil {
+		return err
+	}
+
+	if err := NewTransitionDAO(tx).Annotate(p); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// isOperator operators can view the history of any inquiry or service to resolve disputes.
+func isOperator(c *gin.Context) bool {
+	granted, _ := c.Get("roles")
+	roles, _ := granted.([]models.UserRoleType)
+	claim := jwtactor.Claim{Roles: roles}
+
+	return claim.HasAnyRole(
+		models.UserRoleTypeAdmin,
+		models.UserRoleTypeSupport,
+	)
+}
+
+// Causes of transitions made on behalf of the actor rather than requested by the actor.
+const (
+	// CauseEmitNewInquiry stale inquiry is expired when the inquirer emits a new one.
+	CauseEmitNewInquiry = "emit_new_inquiry"
+
+	// CauseUserSuspended open inquiries are cancelled when the user is suspended.
+	CauseUserSuspended = "user_suspended"
+
+	// CauseInquiryTimeout inquiries still inquiring 5 hours after created are cancelled by the worker.
+	CauseInquiryTimeout = "inquiry_timeout"
+)
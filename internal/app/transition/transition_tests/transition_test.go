@@ -0,0 +1,64 @@
+package transitiontests
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/transition"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransitionTestSuite struct {
+	suite.Suite
+}
+
+func (suite *TransitionTestSuite) TestCreationHasNoFromStatus() {
+	h := transition.TrfHistory([]models.StatusTransitionWithActor{
+		{
+			StatusTransition: models.StatusTransition{
+				ToStatus:  string(models.InquiryStatusInquiring),
+				Event:     sql.NullString{Valid: true, String: "create"},
+				CreatedAt: time.Now(),
+			},
+			ActorUuid:     sql.NullString{Valid: true, String: "inquirer"},
+			ActorUsername: sql.NullString{Valid: true, String: "someone"},
+		},
+	})
+
+	assert.Len(suite.T(), h.Transitions, 1)
+	assert.Nil(suite.T(), h.Transitions[0].FromStatus)
+	assert.Equal(suite.T(), "inquirer", h.Transitions[0].Actor.Uuid)
+}
+
+func (suite *TransitionTestSuite) TestSystemTransitionHasNoActor() {
+	h := transition.TrfHistory([]models.StatusTransitionWithActor{
+		{
+			StatusTransition: models.StatusTransition{
+				FromStatus: sql.NullString{Valid: true, String: string(models.InquiryStatusInquiring)},
+				ToStatus:   string(models.InquiryStatusCanceled),
+				Event:      sql.NullString{Valid: true, String: "cancel"},
+				Cause:      sql.NullString{Valid: true, String: transition.CauseInquiryTimeout},
+			},
+		},
+		{
+			StatusTransition: models.StatusTransition{
+				FromStatus: sql.NullString{Valid: true, String: string(models.InquiryStatusChatting)},
+				ToStatus:   string(models.InquiryStatusInquiring),
+			},
+		},
+	})
+
+	assert.Nil(suite.T(), h.Transitions[0].Actor)
+	assert.Equal(suite.T(), transition.CauseInquiryTimeout, h.Transitions[0].Cause)
+
+	// From status of each transition is copied rather than shared.
+	assert.Equal(suite.T(), string(models.InquiryStatusInquiring), *h.Transitions[0].FromStatus)
+	assert.Equal(suite.T(), string(models.InquiryStatusChatting), *h.Transitions[1].FromStatus)
+}
+
+func TestTransitionTestSuite(t *testing.T) {
+	suite.Run(t, new(TransitionTestSuite))
+}
@@ -0,0 +1,374 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	darkfirestore "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/dark_firestore"
+	gcsenhancer "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/gcs_enhancer"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
+)
+
+// DeleteMyAccountHandler deletes account of the requester. Personal information is removed from
+// the user record while inquiries, services and payments are kept for the other participants.
+// Account can not be deleted while any paid service has not been completed yet.
+func DeleteMyAccountHandler(c *gin.Context, depCon container.Container) {
+	ctx := context.Background()
+
+	var (
+		userDao        contracts.UserDAOer
+		chatDao        contracts.ChatDaoer
+		imageDao       contracts.ImageDAOer
+		bankAccountDao contracts.BankAccountDAOer
+		authDao        contracts.AuthDaoer
+		verifyDao      contracts.IdentityVerificationDAOer
+		gcsEnhancer    gcsenhancer.GCSEnhancerInterface
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&chatDao)
+	depCon.Make(&imageDao)
+	depCon.Make(&bankAccountDao)
+	depCon.Make(&authDao)
+	depCon.Make(&verifyDao)
+	depCon.Make(&gcsEnhancer)
+
+	user, err := userDao.GetUserByUuid(c.GetString("uuid"), "id", "uuid")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	hasOngoing, err := userDao.HasOngoingServices(user.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckOngoingServices,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if hasOngoing {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.HasOngoingServices),
+		)
+
+		return
+	}
+
+	chatrooms, err := chatDao.GetChatroomsByUserID(user.ID, false)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserChatrooms,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var verifications []models.IdentityVerification
+
+	transResp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+		for _, chatroom := range chatrooms {
+			if err := chatDao.WithTx(tx).LeaveChat(chatroom.ID, user.ID); err != nil {
+				return db.FormatResp{
+					Err:            err,
+					ErrCode:        apperr.FailedToLeaveChatrooms,
+					HttpStatusCode: http.StatusInternalServerError,
+				}
+			}
+		}
+
+		if err := imageDao.WithTx(tx).DeleteImagesByUserID(user.ID); err != nil {
+			return db.FormatResp{
+				Err:            err,
+				ErrCode:        apperr.FailedToDeleteUserImages,
+				HttpStatusCode: http.StatusInternalServerError,
+			}
+		}
+
+		if err := bankAccountDao.WithTx(tx).DeleteUserBankAccounts(user.ID); err != nil {
+			return db.FormatResp{
+				Err:            err,
+				ErrCode:        apperr.FailedToDeleteUserBankAccounts,
+				HttpStatusCode: http.StatusInternalServerError,
+			}
+		}
+
+		if verifications, err = verifyDao.WithTx(tx).DeleteVerificationsOfUser(user.ID); err != nil {
+			return db.FormatResp{
+				Err:            err,
+				ErrCode:        apperr.FailedToDeleteIdentityVerifications,
+				HttpStatusCode: http.StatusInternalServerError,
+			}
+		}
+
+		if err := userDao.WithTx(tx).AnonymizeUser(user.ID); err != nil {
+			return db.FormatResp{
+				Err:            err,
+				ErrCode:        apperr.FailedToAnonymizeUser,
+				HttpStatusCode: http.StatusInternalServerError,
+			}
+		}
+
+		return db.FormatResp{}
+	})
+
+	if transResp.Err != nil {
+		c.AbortWithError(
+			transResp.HttpStatusCode,
+			apperr.NewErr(
+				transResp.ErrCode,
+				transResp.Err.Error(),
+			),
+		)
+
+		return
+	}
+
+	// Identity verification photos are private objects which are not reachable once the requests are deleted.
+	// Failing to remove them should not fail the deletion since the account has been anonymized.
+	for _, v := range verifications {
+		for _, objName := range []string{v.SelfieObject, v.IDPhotoObject} {
+			if err := gcsEnhancer.Delete(ctx, objName); err != nil {
+				log.Errorf("failed to delete verification photo %s of user %s %s", objName, user.Uuid, err.Error())
+			}
+		}
+	}
+
+	// Revoke every session of the user including the one performing the request.
+	if err := authDao.RevokeAllSessions(ctx, user.Uuid); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRevokeSession,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	// Tokens issued by `gen_jwt` do not belong to any session.
+	claims, err := jwtactor.ParseToken(c.GetString("jwt"), config.GetAppConf().JwtSecret)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToParseJwtToken,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if err := authDao.RevokeJwt(ctx, c.GetString("jwt"), claims.ExpiresAt); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToInvalidateSignature,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+type ExportMyDataBody struct {
+	Format string `form:"format,default=zip" json:"format" binding:"oneof=zip json"`
+}
+
+// Number of chat messages retrieved from firestore per request when exporting chatrooms.
+const exportMessageBatchSize = 500
+
+// ExportMyDataHandler exports personal data of the requester, including profile, inquiries, services,
+// payments, ratings, identity verifications and messages of every chatroom the requester has joined.
+func ExportMyDataHandler(c *gin.Context, depCon container.Container) {
+	// See the comment in routes.go on why the path is registered as a wildcard.
+	if c.Param("uuid") != "me" {
+		c.AbortWithError(http.StatusNotFound, apperr.NewErr(apperr.APINotFound))
+
+		return
+	}
+
+	body := ExportMyDataBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateExportParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	ctx := context.Background()
+
+	var (
+		userDao contracts.UserDAOer
+		chatDao contracts.ChatDaoer
+		df      darkfirestore.DarkFireStorer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&chatDao)
+	depCon.Make(&df)
+
+	user, err := userDao.GetUserByUuid(c.GetString("uuid"))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	records, err := userDao.GetUserDataExport(user.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToExportUserData,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	chatrooms, err := chatDao.GetChatroomsByUserID(user.ID, true)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserChatrooms,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	chatroomMessages := make(map[string][]interface{})
+
+	for _, chatroom := range chatrooms {
+		if !chatroom.ChannelUuid.Valid {
+			continue
+		}
+
+		msgs := make([]interface{}, 0)
+
+		for offset := 0; ; offset += exportMessageBatchSize {
+			batch, err := df.GetHistoricalMessages(ctx, darkfirestore.GetHistoricalMessagesParams{
+				ChannelUUID: chatroom.ChannelUuid.String,
+				Offset:      offset,
+				Limit:       exportMessageBatchSize,
+			})
+
+			if err != nil {
+				c.AbortWithError(
+					http.StatusInternalServerError,
+					apperr.NewErr(
+						apperr.FailedToExportUserData,
+						err.Error(),
+					),
+				)
+
+				return
+			}
+
+			msgs = append(msgs, batch...)
+
+			if len(batch) < exportMessageBatchSize {
+				break
+			}
+		}
+
+		chatroomMessages[chatroom.ChannelUuid.String] = msgs
+	}
+
+	export := NewTransform().TransformDataExport(
+		user,
+		records,
+		chatrooms,
+		chatroomMessages,
+	)
+
+	if body.Format == "json" {
+		c.JSON(http.StatusOK, export)
+
+		return
+	}
+
+	buf := new(bytes.Buffer)
+
+	if err := WriteDataExportZip(buf, export); err != nil {
+		log.Errorf("failed to write data export of user %s %s", user.Uuid, err.Error())
+
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToExportUserData,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.Header(
+		"Content-Disposition",
+		fmt.Sprintf(
+			"attachment; filename=\"darkpanda_%s_%s.zip\"",
+			user.Uuid,
+			time.Now().Format("20060102"),
+		),
+	)
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
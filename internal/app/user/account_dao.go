@@ -0,0 +1,168 @@
+package user
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+// HasOngoingServices checks if the user is a participant of any service that has been paid but not yet
+// completed. Account can not be deleted in this case.
+func (dao *UserDAO) HasOngoingServices(userID int64) (bool, error) {
+	query := `
+SELECT EXISTS(
+	SELECT 1
+	FROM services
+	WHERE (customer_id = $1 OR service_provider_id = $1)
+		AND service_status IN (
+			'to_be_fulfilled',
+			'fulfilling'
+		)
+) AS "exists";
+`
+	var exists bool
+
+	if err := dao.db.QueryRow(query, userID).Scan(&exists); err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// AnonymizeUser removes personal information of the user and marks the user as deleted. The row is kept
+// so that inquiries, services and payments the user participated in remain consistent for the other party.
+func (dao *UserDAO) AnonymizeUser(userID int64) error {
+	query := `
+UPDATE users
+SET
+	username = 'deleted_' || uuid,
+	phone_verified = false,
+	premium_type = 'normal',
+	premium_expiry_date = NULL,
+	avatar_url = NULL,
+	nationality = NULL,
+	region = NULL,
+	age = NULL,
+	height = NULL,
+	weight = NULL,
+	habbits = NULL,
+	description = NULL,
+	breast_size = NULL,
+	mobile = NULL,
+	fcm_topic = NULL,
+	deleted_at = now()
+WHERE id = $1;
+`
+	if _, err := dao.db.Exec(query, userID); err != nil {
+		return err
+	}
+
+	// Release the username so that it can be registered again.
+	if _, err := dao.db.Exec(`DELETE FROM username_keys WHERE user_id = $1;`, userID); err != nil {
+		return err
+	}
+
+	_, err := dao.db.Exec(`DELETE FROM user_locations WHERE user_id = $1;`, userID)
+
+	return err
+}
+
+// GetUserDataExport retrieves inquiries, services, payments and ratings the user participated in along with
+// the identity verification requests of the user.
+// Records are aggregated into JSON arrays by postgres. Internal IDs are left out.
+func (dao *UserDAO) GetUserDataExport(userID int64) (*models.UserDataExport, error) {
+	query := `
+SELECT
+	(
+		SELECT COALESCE(json_agg(t ORDER BY t.created_at), '[]'::json)
+		FROM (
+			SELECT
+				uuid,
+				inquiry_type,
+				inquiry_status,
+				expect_service_type,
+				budget,
+				currency,
+				duration,
+				appointment_time,
+				address,
+				created_at
+			FROM service_inquiries
+			WHERE inquirer_id = $1
+		) AS t
+	) AS inquiries,
+	(
+		SELECT COALESCE(json_agg(t ORDER BY t.created_at), '[]'::json)
+		FROM (
+			SELECT
+				uuid,
+				CASE WHEN customer_id = $1 THEN 'customer' ELSE 'service_provider' END AS participant_role,
+				service_type,
+				service_status,
+				price,
+				matching_fee,
+				currency,
+				duration,
+				appointment_time,
+				start_time,
+				end_time,
+				address,
+				cancel_cause,
+				created_at
+			FROM services
+			WHERE customer_id = $1 OR service_provider_id = $1
+		) AS t
+	) AS services,
+	(
+		SELECT COALESCE(json_agg(t ORDER BY t.created_at), '[]'::json)
+		FROM (
+			SELECT
+				services.uuid AS service_uuid,
+				payments.price,
+				payments.refunded,
+				payments.created_at
+			FROM payments
+			INNER JOIN services ON services.id = payments.service_id
+			WHERE payments.payer_id = $1
+		) AS t
+	) AS payments,
+	(
+		SELECT COALESCE(json_agg(t ORDER BY t.created_at), '[]'::json)
+		FROM (
+			SELECT
+				services.uuid AS service_uuid,
+				CASE WHEN service_ratings.rater_id = $1 THEN 'given' ELSE 'received' END AS direction,
+				service_ratings.rating,
+				service_ratings.comments,
+				service_ratings.created_at
+			FROM service_ratings
+			INNER JOIN services ON services.id = service_ratings.service_id
+			WHERE service_ratings.rater_id = $1 OR service_ratings.ratee_id = $1
+		) AS t
+	) AS ratings,
+	(
+		SELECT COALESCE(json_agg(t ORDER BY t.created_at), '[]'::json)
+		FROM (
+			SELECT
+				uuid,
+				status,
+				reason,
+				reviewed_at,
+				created_at
+			FROM identity_verifications
+			WHERE user_id = $1 AND deleted_at IS NULL
+		) AS t
+	) AS verifications;
+`
+	var m models.UserDataExport
+
+	if err := dao.db.QueryRow(query, userID).Scan(
+		&m.Inquiries,
+		&m.Services,
+		&m.Payments,
+		&m.Ratings,
+		&m.Verifications,
+	); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
@@ -3,9 +3,11 @@ package user
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
@@ -16,8 +18,10 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	genverifycode "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/generate_verify_code"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/otpguard"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
-	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/twilio"
+	smssender "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/sms_sender"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -46,7 +50,24 @@ func (h *UserHandlers) GetMyProfileHandler(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, NewTransform().TransformUser(&usr))
+	var verificationDao contracts.IdentityVerificationDAOer
+	h.Container.Make(&verificationDao)
+
+	verified, err := verificationDao.IsUserVerified(usr.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformUser(&usr, verified))
 }
 
 type GetUserProfileBody struct {
@@ -92,7 +113,54 @@ func (h *UserHandlers) GetUserProfileHandler(c *gin.Context, depCon container.Co
 		return
 	}
 
-	tResp, err := NewTransform().TransformViewableUserProfile(user, *userRating)
+	var verificationDao contracts.IdentityVerificationDAOer
+	depCon.Make(&verificationDao)
+
+	verified, err := verificationDao.IsUserVerified(user.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	// Failing to record the view should not prevent the profile from being viewed.
+	if err := recordProfileView(depCon, c.GetString("uuid"), &user); err != nil {
+		log.WithFields(log.Fields{
+			"viewee_uuid": user.Uuid,
+		}).Errorf("failed to record profile view: %s", err.Error())
+	}
+
+	var profileViewDao contracts.ProfileViewDAOer
+	depCon.Make(&profileViewDao)
+
+	viewCount, err := profileViewDao.GetViewCount(user.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetProfileViewCount,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	tResp, err := NewTransform().TransformViewableUserProfile(
+		user,
+		*userRating,
+		verified,
+		viewCount,
+		presence.LookupStatusesOrOffline(depCon, []string{user.Uuid})[user.Uuid],
+	)
 
 	if err != nil {
 		log.Fatal(err)
@@ -117,6 +185,9 @@ type PutUserInfoBody struct {
 	Description  *string             `form:"description" json:"description"`
 	Images       []CreateImageParams `form:"imageList" json:"imageList"`
 	RemoveImages []CreateImageParams `form:"removeImageList" json:"removeImageList"`
+
+	// Locale push notifications are delivered in.
+	Locale *string `form:"locale" json:"locale" binding:"omitempty,oneof=zh-TW en ja"`
 }
 
 func (h *UserHandlers) PutUserInfo(c *gin.Context) {
@@ -146,6 +217,7 @@ func (h *UserHandlers) PutUserInfo(c *gin.Context) {
 		Height:      &body.Height,
 		Weight:      &body.Weight,
 		Description: body.Description,
+		Locale:      body.Locale,
 	})
 
 	if err != nil {
@@ -400,14 +472,49 @@ func (h *UserHandlers) GetUserRatings(c *gin.Context, depCon container.Container
 		return
 	}
 
-	// Retrieve all rating of services that I have participated in.
-	var rateDao contracts.RateDAOer
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		rateDao  contracts.RateDAOer
+		blockDao contracts.BlockDAOer
+	)
+
 	depCon.Make(&rateDao)
+	depCon.Make(&blockDao)
+
+	// Ratings left by users blocked by or blocking the viewer are hidden.
+	blockRelatedIDs, err := blockDao.GetBlockRelatedUserIDs(int(me.ID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetBlockRelatedUsers,
+				err.Error(),
+			),
+		)
 
+		return
+	}
+
+	// Retrieve all rating of services that I have participated in.
 	rs, err := rateDao.GetUserRatings(contracts.GetUserRatingsParams{
-		UserID:  int(targetUser.ID),
-		PerPage: body.PerPage,
-		Offset:  body.Offset,
+		UserID:         int(targetUser.ID),
+		PerPage:        body.PerPage,
+		Offset:         body.Offset,
+		ExcludeUserIDs: blockRelatedIDs,
 	})
 
 	if err != nil {
@@ -450,6 +557,19 @@ func ChangeMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 
 	ctx := context.Background()
 
+	// Throttle sending verify code SMS so that one number can not be SMS bombed.
+	retryAfter, err := otpguard.
+		New(db.GetRedis(), config.GetAppConf().GetOTPLimits()).
+		ConsumeSendQuota(ctx, otpguard.ChangeMobileScope, otpguard.Subjects{
+			UserUuid: c.GetString("uuid"),
+			Mobile:   body.Mobile,
+			IP:       c.ClientIP(),
+		})
+
+	if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPRequests) {
+		return
+	}
+
 	// Generate verify code.
 	verifyCode := genverifycode.GenVerifyCode()
 
@@ -474,25 +594,16 @@ func ChangeMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Send verify code via twilio.
-	var tc twilio.TwilioServicer
-	depCon.Make(&tc)
-
-	smsResp, err := tc.SendSMS(
-		config.GetAppConf().TwilioFrom,
-		body.Mobile,
-		fmt.Sprintf("[Darkpanda] Here is your change mobile verify code: \n\n %s", verifyCode.BuildCode()),
-	)
+	// Send verify code via configured SMS driver.
+	var smsSender smssender.SMSSender
+	depCon.Make(&smsSender)
 
-	if twilio.HandleSendTwilioError(c, err) != nil {
-		c.AbortWithError(
-			http.StatusInternalServerError,
-			apperr.NewErr(
-				apperr.FailedToSendTwilioMessage,
-				err.Error(),
-			),
-		)
+	smsResp, err := smsSender.Send(ctx, smssender.Message{
+		To:      body.Mobile,
+		Content: fmt.Sprintf("[Darkpanda] Here is your change mobile verify code: \n\n %s", verifyCode.BuildCode()),
+	})
 
+	if smssender.HandleSendSMSError(c, err) != nil {
 		return
 	}
 
@@ -501,7 +612,7 @@ func ChangeMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 			"user_uuid": c.GetString("uuid"),
 			"mobile":    body.Mobile,
 		}).
-		Infof("sends twilio SMS success, login verify code created ! %v", smsResp.SID)
+		Infof("sends %s SMS success, change mobile verify code created ! %v", smsResp.Driver, smsResp.MessageID)
 
 	c.JSON(
 		http.StatusOK,
@@ -534,8 +645,20 @@ func VerifyMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	// Get change mobile verify record from redis, if it exists.
 	ctx := context.Background()
+	guard := otpguard.New(db.GetRedis(), config.GetAppConf().GetOTPLimits())
+	subjects := otpguard.Subjects{
+		UserUuid: c.GetString("uuid"),
+		IP:       c.ClientIP(),
+	}
+
+	retryAfter, err := guard.CheckLockout(ctx, otpguard.ChangeMobileScope, subjects)
+
+	if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPAttempts) {
+		return
+	}
+
+	// Get change mobile verify record from redis, if it exists.
 	m, err := GetChangeMobileVerifyCode(ctx, GetChangeMobileVerifyCodeParams{
 		RedisCli: db.GetRedis(),
 		UserUuid: c.GetString("uuid"),
@@ -563,6 +686,12 @@ func VerifyMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 	}
 
 	if body.VerifyCode != m.VerifyCode {
+		retryAfter, err := guard.RecordFailure(ctx, otpguard.ChangeMobileScope, subjects)
+
+		if otpguard.HandleRetryAfter(c, retryAfter, err, apperr.TooManyOTPAttempts) {
+			return
+		}
+
 		c.AbortWithError(
 			http.StatusInternalServerError,
 			apperr.NewErr(apperr.ChangeMobileVerifyCodeNotMatching),
@@ -571,6 +700,18 @@ func VerifyMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	if err := guard.Reset(ctx, otpguard.ChangeMobileScope, subjects); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRecordOTPAttempt,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
 	var userDao contracts.UserDAOer
 	depCon.Make(&userDao)
 
@@ -603,6 +744,37 @@ func VerifyMobileVerifyCodeHandler(c *gin.Context, depCon container.Container) {
 type GetGirlsBody struct {
 	PerPage int `form:"per_page,default=6"`
 	Offset  int `form:"offset,default=0"`
+
+	AgeMin         *int     `form:"age_min" binding:"omitempty,min=0"`
+	AgeMax         *int     `form:"age_max" binding:"omitempty,min=0"`
+	HeightMin      *float64 `form:"height_min" binding:"omitempty,min=0"`
+	HeightMax      *float64 `form:"height_max" binding:"omitempty,min=0"`
+	WeightMin      *float64 `form:"weight_min" binding:"omitempty,min=0"`
+	WeightMax      *float64 `form:"weight_max" binding:"omitempty,min=0"`
+	Region         *string  `form:"region"`
+	Nationality    *string  `form:"nationality"`
+	ServiceOptions []string `form:"service_options"`
+	MinRating      *float64 `form:"min_rating" binding:"omitempty,min=0,max=5"`
+
+	// AvailableOn date in the format of `YYYY-MM-DD`.
+	AvailableOn *time.Time `form:"available_on" time_format:"2006-01-02"`
+	Sort        string     `form:"sort" binding:"omitempty,oneof=newest rating completed_services"`
+}
+
+func (b *GetGirlsBody) validateRanges() error {
+	if b.AgeMin != nil && b.AgeMax != nil && *b.AgeMin > *b.AgeMax {
+		return errors.New("age_min should not be greater than age_max")
+	}
+
+	if b.HeightMin != nil && b.HeightMax != nil && *b.HeightMin > *b.HeightMax {
+		return errors.New("height_min should not be greater than height_max")
+	}
+
+	if b.WeightMin != nil && b.WeightMax != nil && *b.WeightMin > *b.WeightMax {
+		return errors.New("weight_min should not be greater than weight_max")
+	}
+
+	return nil
 }
 
 func GetGirls(c *gin.Context, depCon container.Container) {
@@ -620,6 +792,18 @@ func GetGirls(c *gin.Context, depCon container.Container) {
 		return
 	}
 
+	if err := body.validateRanges(); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateGetGirlsParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
 	var userDao contracts.UserDAOer
 	depCon.Make(&userDao)
 
@@ -638,9 +822,21 @@ func GetGirls(c *gin.Context, depCon container.Container) {
 	}
 
 	girls, err := userDao.GetGirls(contracts.GetGirlsParams{
-		InquirerID: int(me.ID),
-		Limit:      body.PerPage,
-		Offset:     body.Offset,
+		InquirerID:     int(me.ID),
+		Limit:          body.PerPage,
+		Offset:         body.Offset,
+		AgeMin:         body.AgeMin,
+		AgeMax:         body.AgeMax,
+		HeightMin:      body.HeightMin,
+		HeightMax:      body.HeightMax,
+		WeightMin:      body.WeightMin,
+		WeightMax:      body.WeightMax,
+		Region:         body.Region,
+		Nationality:    body.Nationality,
+		ServiceOptions: body.ServiceOptions,
+		MinRating:      body.MinRating,
+		AvailableOn:    body.AvailableOn,
+		Sort:           contracts.GirlsSort(body.Sort),
 	})
 
 	if err != nil {
@@ -655,7 +851,16 @@ func GetGirls(c *gin.Context, depCon container.Container) {
 		return
 	}
 
-	trf, err := TrfRandomGirls(girls)
+	girlUuids := make([]string, 0)
+
+	for _, girl := range girls {
+		girlUuids = append(girlUuids, girl.Uuid)
+	}
+
+	trf, err := TrfRandomGirls(
+		girls,
+		presence.LookupStatusesOrOffline(depCon, girlUuids),
+	)
 
 	if err != nil {
 		c.AbortWithError(
@@ -12,6 +12,7 @@ import (
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/net/context"
 )
@@ -118,7 +119,8 @@ UPDATE users SET
 	description = COALESCE($7, description),
 	breast_size = COALESCE($8, breast_size),
 	phone_verified = COALESCE($9, phone_verified),
-	mobile = COALESCE($10, mobile)
+	mobile = COALESCE($10, mobile),
+	locale = COALESCE($12, locale)
 WHERE uuid = $11
 RETURNING
 	id,
@@ -137,7 +139,8 @@ RETURNING
 	habbits,
 	description,
 	breast_size,
-	mobile;
+	mobile,
+	locale;
 `
 	u := &models.User{}
 
@@ -154,6 +157,7 @@ RETURNING
 		p.PhoneVerified,
 		p.Mobile,
 		p.Uuid,
+		p.Locale,
 	).Scan(
 		&u.ID,
 		&u.Username,
@@ -172,6 +176,7 @@ RETURNING
 		&u.Description,
 		&u.BreastSize,
 		&u.Mobile,
+		&u.Locale,
 	); err != nil {
 		log.Errorf("Failed to update user info %s", err.Error())
 
@@ -479,12 +484,11 @@ ORDER BY users.uuid, chatrooms.created_at DESC;
 	return channelResults, nil
 }
 
-// GetGirls retrieve list of girl profile who wants their profile to be viewed publically.
-// It also retrieve latest inquiry made between each girl with the male user. If no inquiry
-// has ever existed,
-func (dao *UserDAO) GetGirls(p contracts.GetGirlsParams) ([]*models.RandomGirl, error) {
-	query := `
+// girlsSelect select list of girls listed by `GetGirls` alike queries. It has to be followed by
+// `girlsOngoingJoins`.
+const girlsSelect = `
 SELECT
+	users.id,
 	username,
 	users.uuid,
 	avatar_url,
@@ -493,24 +497,24 @@ SELECT
 	weight,
 	breast_size,
 	description,
-    CASE WHEN si.id IS NOT NULL
-    THEN true
-    ELSE false
-    END has_inquiry,
-
-    CASE WHEN services.uuid IS NOT NULL
-    THEN true
-    ELSE false
-    END has_service,
-
-    si.uuid AS inquiry_uuid,
+	si.id IS NOT NULL AS has_inquiry,
+	services.uuid IS NOT NULL AS has_service,
+	si.uuid AS inquiry_uuid,
 	si.inquiry_status,
 	si.expect_service_type,
-
 	services.uuid AS service_uuid,
-	services.service_status
-FROM users
-
+	services.service_status,
+	EXISTS (
+		SELECT 1 FROM identity_verifications
+		WHERE
+			identity_verifications.user_id = users.id
+			AND identity_verifications.status = 'approved'
+			AND identity_verifications.deleted_at IS NULL
+	) AS verified`
+
+// girlsOngoingJoins joins latest ongoing inquiry and service between each girl and the male user
+// bound to `$1`.
+const girlsOngoingJoins = `
 -- Retrieve related inquiries if any
 LEFT JOIN service_inquiries AS si
 	ON si.inquirer_id = $1
@@ -519,11 +523,11 @@ LEFT JOIN service_inquiries AS si
 		'canceled',
 		'booked'
 	)
-	AND si.created_at=(
-	 	SELECT max(created_at)
+	AND si.created_at = (
+		SELECT max(created_at)
 		FROM service_inquiries
-        	WHERE inquirer_id = $1
-        	AND picker_id = users.id
+		WHERE inquirer_id = $1
+		AND picker_id = users.id
 	)
 
 -- Retrieve related services if any
@@ -535,29 +539,171 @@ LEFT JOIN services
 		'completed',
 		'expired'
 	)
-	AND services.created_at=(
-	 	SELECT max(services.created_at)
+	AND services.created_at = (
+		SELECT max(services.created_at)
 		FROM services
-        	WHERE services.customer_id=$1
-        	AND services.service_provider_id = users.id
-	)
+		WHERE services.customer_id = $1
+		AND services.service_provider_id = users.id
+	)`
+
+// girlsSortClauses ORDER BY clauses of each sorting option. Girls are ordered
+// in a pseudo random manner if no sorting option is specified.
+var girlsSortClauses = map[contracts.GirlsSort]string{
+	contracts.GirlsSortNewest:            "users.created_at DESC, users.id DESC",
+	contracts.GirlsSortRating:            "COALESCE(ratings.score, 0) DESC, users.id",
+	contracts.GirlsSortCompletedServices: "COALESCE(completions.number_of_services, 0) DESC, users.id",
+}
+
+const defaultGirlsSortClause = "users.id % 4, users.id"
+
+// GetGirls retrieve list of girl profile who wants their profile to be viewed publically.
+// It also retrieve latest inquiry made between each girl with the male user. If no inquiry
+// has ever existed,
+//
+// Filters in `contracts.GetGirlsParams` are applied only when specified.
+func (dao *UserDAO) GetGirls(p contracts.GetGirlsParams) ([]*models.RandomGirl, error) {
+	sortClause, ok := girlsSortClauses[p.Sort]
+
+	if !ok {
+		sortClause = defaultGirlsSortClause
+	}
+
+	var serviceOptions interface{}
+
+	if len(p.ServiceOptions) > 0 {
+		serviceOptions = pq.Array(p.ServiceOptions)
+	}
+
+	query := fmt.Sprintf(`
+%s
+FROM users
+%s
+
+-- Average rating of each girl
+LEFT JOIN (
+	SELECT
+		ratee_id,
+		AVG(rating) AS score
+	FROM service_ratings
+	GROUP BY ratee_id
+) AS ratings ON ratings.ratee_id = users.id
+
+-- Number of services each girl has completed
+LEFT JOIN (
+	SELECT
+		service_provider_id,
+		COUNT(1) AS number_of_services
+	FROM services
+	WHERE service_status = 'completed'
+	GROUP BY service_provider_id
+) AS completions ON completions.service_provider_id = users.id
 WHERE
 	gender='female'
-ORDER BY users.id % 4, users.id
+	AND users.deleted_at IS NULL
+	AND ($4::int IS NULL OR users.age >= $4)
+	AND ($5::int IS NULL OR users.age <= $5)
+	AND ($6::numeric IS NULL OR users.height >= $6)
+	AND ($7::numeric IS NULL OR users.height <= $7)
+	AND ($8::numeric IS NULL OR users.weight >= $8)
+	AND ($9::numeric IS NULL OR users.weight <= $9)
+	AND ($10::varchar IS NULL OR users.region = $10)
+	AND ($11::varchar IS NULL OR users.nationality = $11)
+	AND ($12::text[] IS NULL OR (
+		SELECT COUNT(DISTINCT service_options.name)
+		FROM user_service_options
+		INNER JOIN service_options ON service_options.id = user_service_options.service_option_id
+		WHERE
+			user_service_options.users_id = users.id
+			AND user_service_options.deleted_at IS NULL
+			AND service_options.name = ANY($12::text[])
+	) = cardinality($12::text[]))
+	AND ($13::numeric IS NULL OR ratings.score >= $13)
+	AND ($14::date IS NULL OR NOT EXISTS (
+		SELECT 1
+		FROM services AS occupied
+		WHERE
+			occupied.service_provider_id = users.id
+			AND occupied.service_status IN (
+				'unpaid',
+				'to_be_fulfilled',
+				'fulfilling'
+			)
+			AND occupied.appointment_time >= $14::date
+			AND occupied.appointment_time < $14::date + interval '1 day'
+	))
+	AND ($14::date IS NULL OR NOT EXISTS (
+		SELECT 1
+		FROM availability_blackouts
+		WHERE
+			availability_blackouts.user_id = users.id
+			AND availability_blackouts.date = $14::date
+			AND availability_blackouts.deleted_at IS NULL
+	))
+	-- Girls who have not declared any slot are considered available at any time.
+	AND ($14::date IS NULL OR NOT EXISTS (
+		SELECT 1
+		FROM availability_slots
+		WHERE
+			availability_slots.user_id = users.id
+			AND availability_slots.deleted_at IS NULL
+	) OR EXISTS (
+		SELECT 1
+		FROM availability_slots
+		WHERE
+			availability_slots.user_id = users.id
+			AND availability_slots.weekday = EXTRACT(DOW FROM $14::date)
+			AND availability_slots.deleted_at IS NULL
+	))
+	-- Suspended girls are hidden until the suspension expires or is lifted.
+	AND NOT EXISTS (
+		SELECT 1
+		FROM user_suspensions
+		WHERE
+			user_suspensions.user_id = users.id
+			AND user_suspensions.lifted_at IS NULL
+			AND user_suspensions.deleted_at IS NULL
+			AND (user_suspensions.expires_at IS NULL OR user_suspensions.expires_at > NOW())
+	)
+	-- Girls who have blocked the inquirer or have been blocked by the inquirer.
+	AND NOT EXISTS (
+		SELECT 1
+		FROM block_list
+		WHERE
+			(
+				(block_list.user_id = $1 AND block_list.blocked_user_id = users.id)
+				OR (block_list.user_id = users.id AND block_list.blocked_user_id = $1)
+			)
+			AND block_list.deleted_at IS NULL
+	)
+ORDER BY %s
 LIMIT $2
 OFFSET $3;
-	`
+	`, girlsSelect, girlsOngoingJoins, sortClause)
 
 	gs := make([]*models.RandomGirl, 0)
 
-	rows, err := dao.db.Queryx(query, p.InquirerID, p.Limit, p.Offset)
+	rows, err := dao.db.Queryx(
+		query,
+		p.InquirerID,
+		p.Limit,
+		p.Offset,
+		p.AgeMin,
+		p.AgeMax,
+		p.HeightMin,
+		p.HeightMax,
+		p.WeightMin,
+		p.WeightMax,
+		p.Region,
+		p.Nationality,
+		serviceOptions,
+		p.MinRating,
+		p.AvailableOn,
+	)
 
 	if err != nil {
 		return gs, err
 	}
 
-	girlIDs := make([]int64, 0)
-
 	for rows.Next() {
 		var g models.RandomGirl
 
@@ -566,12 +712,27 @@ OFFSET $3;
 		}
 
 		gs = append(gs, &g)
-		girlIDs = append(girlIDs, g.ID)
 	}
 
+	if err := dao.attachGirlsDetail(gs); err != nil {
+		return nil, err
+	}
+
+	return gs, nil
+}
+
+// attachGirlsDetail attaches rating and channel uuid of ongoing inquiry or service to
+// girls retrieved by `GetGirls` alike queries.
+func (dao *UserDAO) attachGirlsDetail(gs []*models.RandomGirl) error {
 	// If no girls are loaded, we don't have to fetch rating for the girls.
 	if len(gs) == 0 {
-		return gs, nil
+		return nil
+	}
+
+	girlIDs := make([]int64, 0)
+
+	for _, g := range gs {
+		girlIDs = append(girlIDs, g.ID)
 	}
 
 	// Compose a query to retrieve girls rating.
@@ -593,7 +754,7 @@ GROUP BY ratee_id;
 	ratingRows, err := dao.db.Queryx(ratingQuery)
 
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// Map to record ratee ID with rating info, we will be
@@ -610,7 +771,7 @@ GROUP BY ratee_id;
 		ur := models.UserRating{}
 
 		if err := ratingRows.StructScan(&ur); err != nil {
-			return nil, err
+			return err
 		}
 
 		ratingGirlIDMap[ur.RateeID] = &ur
@@ -625,11 +786,7 @@ GROUP BY ratee_id;
 	// Compose a query to retrieve chatroom uuid for those inquiry and service that are still ongoing.
 	// Iterate through all girls, collect those girls that has ongoing inquiry or service with me. Retrieve channel uuid of those.
 
-	if err := dao.attachOngoingGirlsChannelUUID(gs); err != nil {
-		return nil, err
-	}
-
-	return gs, nil
+	return dao.attachOngoingGirlsChannelUUID(gs)
 }
 
 const (
@@ -0,0 +1,153 @@
+package user
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type TransformedExportProfile struct {
+	Uuid              string             `json:"uuid"`
+	Username          string             `json:"username"`
+	Gender            models.Gender      `json:"gender"`
+	Mobile            string             `json:"mobile"`
+	PhoneVerified     bool               `json:"phone_verified"`
+	PremiumType       models.PremiumType `json:"premium_type"`
+	PremiumExpiryDate *time.Time         `json:"premium_expiry_date"`
+	AvatarUrl         string             `json:"avatar_url"`
+	Nationality       string             `json:"nationality"`
+	Region            string             `json:"region"`
+	Age               int32              `json:"age"`
+	Height            string             `json:"height"`
+	Weight            string             `json:"weight"`
+	Habbits           string             `json:"habbits"`
+	Description       string             `json:"description"`
+	BreastSize        string             `json:"breast_size"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+type TransformedExportChatroom struct {
+	ChannelUuid  string              `json:"channel_uuid"`
+	ChatroomType models.ChatroomType `json:"chatroom_type"`
+	CreatedAt    time.Time           `json:"created_at"`
+	Messages     []interface{}       `json:"messages"`
+}
+
+type TransformedDataExport struct {
+	ExportedAt    time.Time                   `json:"exported_at"`
+	Profile       TransformedExportProfile    `json:"profile"`
+	Inquiries     json.RawMessage             `json:"inquiries"`
+	Services      json.RawMessage             `json:"services"`
+	Payments      json.RawMessage             `json:"payments"`
+	Ratings       json.RawMessage             `json:"ratings"`
+	Verifications json.RawMessage             `json:"verifications"`
+	Chatrooms     []TransformedExportChatroom `json:"chatrooms"`
+}
+
+func (ut *UserTransform) TransformDataExport(user *models.User, records *models.UserDataExport, chatrooms []models.Chatroom, msgs map[string][]interface{}) TransformedDataExport {
+	profile := TransformedExportProfile{
+		Uuid:          user.Uuid,
+		Username:      user.Username,
+		Gender:        user.Gender,
+		Mobile:        user.Mobile.String,
+		PhoneVerified: user.PhoneVerified,
+		PremiumType:   user.PremiumType,
+		AvatarUrl:     user.AvatarUrl.String,
+		Nationality:   user.Nationality.String,
+		Region:        user.Region.String,
+		Age:           user.Age.Int32,
+		Height:        user.Height.String,
+		Weight:        user.Weight.String,
+		Habbits:       user.Habbits.String,
+		Description:   user.Description.String,
+		BreastSize:    user.BreastSize.String,
+		CreatedAt:     user.CreatedAt,
+	}
+
+	if user.PremiumExpiryDate.Valid {
+		profile.PremiumExpiryDate = &user.PremiumExpiryDate.Time
+	}
+
+	trfChatrooms := make([]TransformedExportChatroom, 0)
+
+	for _, chatroom := range chatrooms {
+		if !chatroom.ChannelUuid.Valid {
+			continue
+		}
+
+		chatroomMsgs := msgs[chatroom.ChannelUuid.String]
+
+		if chatroomMsgs == nil {
+			chatroomMsgs = make([]interface{}, 0)
+		}
+
+		trfChatrooms = append(trfChatrooms, TransformedExportChatroom{
+			ChannelUuid:  chatroom.ChannelUuid.String,
+			ChatroomType: chatroom.ChatroomType,
+			CreatedAt:    chatroom.CreatedAt,
+			Messages:     chatroomMsgs,
+		})
+	}
+
+	return TransformedDataExport{
+		ExportedAt:    time.Now(),
+		Profile:       profile,
+		Inquiries:     records.Inquiries,
+		Services:      records.Services,
+		Payments:      records.Payments,
+		Ratings:       records.Ratings,
+		Verifications: records.Verifications,
+		Chatrooms:     trfChatrooms,
+	}
+}
+
+// WriteDataExportZip writes the export into a ZIP archive. Each section is written in a separate JSON file
+// and messages of each chatroom are written under `chatrooms/`.
+func WriteDataExportZip(w io.Writer, export TransformedDataExport) error {
+	zw := zip.NewWriter(w)
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", export.Profile},
+		{"inquiries.json", export.Inquiries},
+		{"services.json", export.Services},
+		{"payments.json", export.Payments},
+		{"ratings.json", export.Ratings},
+		{"verifications.json", export.Verifications},
+	}
+
+	for _, chatroom := range export.Chatrooms {
+		files = append(files, struct {
+			name string
+			data interface{}
+		}{
+			// Channel uuid contains colon, e.g. "private_chat:xxxx", which is not allowed in file names on some platforms.
+			fmt.Sprintf("chatrooms/%s.json", strings.ReplaceAll(chatroom.ChannelUuid, ":", "_")),
+			chatroom,
+		})
+	}
+
+	for _, file := range files {
+		fw, err := zw.Create(file.name)
+
+		if err != nil {
+			return err
+		}
+
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+
+		if err := enc.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
@@ -0,0 +1,271 @@
+package user
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
+)
+
+// getFavoriteTarget retrieves the user and the girl to add to / remove from favorites.
+// Error response is written if either of them can not be retrieved.
+func getFavoriteTarget(c *gin.Context, userDao contracts.UserDAOer) (*models.User, *models.User, bool) {
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return nil, nil, false
+	}
+
+	target, err := userDao.GetUserByUuid(c.Param("uuid"), "id", "gender")
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.FavoriteUserNotFound),
+		)
+
+		return nil, nil, false
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return nil, nil, false
+	}
+
+	return me, target, true
+}
+
+// AddFavoriteHandler male user adds the girl to his favorites. Girls blocked by or blocking the
+// male user can not be added.
+func AddFavoriteHandler(c *gin.Context, depCon container.Container) {
+	var (
+		userDao     contracts.UserDAOer
+		blockDao    contracts.BlockDAOer
+		favoriteDao contracts.UserFavoriteDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&blockDao)
+	depCon.Make(&favoriteDao)
+
+	me, target, ok := getFavoriteTarget(c, userDao)
+
+	if !ok {
+		return
+	}
+
+	if target.Gender != models.GenderFemale {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.CanOnlyFavoriteFemale),
+		)
+
+		return
+	}
+
+	blockRelatedIDs, err := blockDao.GetBlockRelatedUserIDs(int(me.ID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetBlockRelatedUsers,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	for _, blockRelatedID := range blockRelatedIDs {
+		if blockRelatedID == target.ID {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				apperr.NewErr(apperr.UserBlockedCanNotFavorite),
+			)
+
+			return
+		}
+	}
+
+	if _, err := favoriteDao.AddFavorite(me.ID, target.ID); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToAddFavorite,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+func RemoveFavoriteHandler(c *gin.Context, depCon container.Container) {
+	var (
+		userDao     contracts.UserDAOer
+		favoriteDao contracts.UserFavoriteDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&favoriteDao)
+
+	me, target, ok := getFavoriteTarget(c, userDao)
+
+	if !ok {
+		return
+	}
+
+	err := favoriteDao.RemoveFavorite(me.ID, target.ID)
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusNotFound,
+			apperr.NewErr(apperr.FavoriteNotFound),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToRemoveFavorite,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+type GetFavoritesBody struct {
+	Offset  int `form:"offset,default=0"`
+	PerPage int `form:"per_page,default=10"`
+}
+
+// GetFavoritesHandler lists favorite girls of the male user in the same shape as `GetGirls`.
+// Girls blocked by or blocking the male user are excluded.
+func GetFavoritesHandler(c *gin.Context, depCon container.Container) {
+	body := GetFavoritesBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToBindApiBodyParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao     contracts.UserDAOer
+		blockDao    contracts.BlockDAOer
+		favoriteDao contracts.UserFavoriteDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&blockDao)
+	depCon.Make(&favoriteDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	blockRelatedIDs, err := blockDao.GetBlockRelatedUserIDs(int(me.ID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetBlockRelatedUsers,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	girls, err := favoriteDao.GetFavoriteGirls(contracts.GetFavoriteGirlsParams{
+		UserID:         me.ID,
+		ExcludeUserIDs: blockRelatedIDs,
+		Offset:         body.Offset,
+		PerPage:        body.PerPage,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetFavoriteGirls,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	girlUuids := make([]string, 0)
+
+	for _, girl := range girls {
+		girlUuids = append(girlUuids, girl.Uuid)
+	}
+
+	trf, err := TrfRandomGirls(
+		girls,
+		presence.LookupStatusesOrOffline(depCon, girlUuids),
+	)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToTransformGirlProfile,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, trf)
+}
@@ -0,0 +1,151 @@
+package user
+
+import (
+	"database/sql"
+	"fmt"
+
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/lib/pq"
+)
+
+type UserFavoriteDAO struct {
+	db db.Conn
+}
+
+func NewUserFavoriteDAO(db db.Conn) *UserFavoriteDAO {
+	return &UserFavoriteDAO{
+		db: db,
+	}
+}
+
+func UserFavoriteDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.UserFavoriteDAOer {
+			return NewUserFavoriteDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *UserFavoriteDAO) WithTx(tx db.Conn) contracts.UserFavoriteDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+// AddFavorite adds the girl to favorites of the user. Adding the same girl again is a no-op.
+func (dao *UserFavoriteDAO) AddFavorite(userID, favoriteUserID int64) (*models.UserFavorite, error) {
+	query := `
+INSERT INTO user_favorites (
+	user_id,
+	favorite_user_id
+) VALUES ($1, $2)
+ON CONFLICT (user_id, favorite_user_id) DO UPDATE SET
+	deleted_at = NULL
+RETURNING *;
+`
+	var m models.UserFavorite
+
+	if err := dao.db.QueryRowx(
+		query,
+		userID,
+		favoriteUserID,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// RemoveFavorite removes the girl from favorites of the user. `sql.ErrNoRows` is returned
+// if the girl is not in the favorites.
+func (dao *UserFavoriteDAO) RemoveFavorite(userID, favoriteUserID int64) error {
+	query := `
+UPDATE user_favorites
+SET deleted_at = NOW()
+WHERE
+	user_id = $1 AND
+	favorite_user_id = $2 AND
+	deleted_at IS NULL;
+`
+	res, err := dao.db.Exec(query, userID, favoriteUserID)
+
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// GetFavoriteGirls retrieves favorite girls of the user, latest favorited first. Like `GetGirls`,
+// latest ongoing inquiry or service between each girl and the user is attached.
+func (dao *UserFavoriteDAO) GetFavoriteGirls(p contracts.GetFavoriteGirlsParams) ([]*models.RandomGirl, error) {
+	query := fmt.Sprintf(`
+%s
+FROM user_favorites
+INNER JOIN users ON users.id = user_favorites.favorite_user_id
+%s
+WHERE
+	user_favorites.user_id = $1
+	AND user_favorites.deleted_at IS NULL
+	AND users.deleted_at IS NULL
+	AND users.id <> ALL($2::int[])
+ORDER BY user_favorites.updated_at DESC, user_favorites.id DESC
+LIMIT $3
+OFFSET $4;
+`, girlsSelect, girlsOngoingJoins)
+
+	excludeUserIDs := p.ExcludeUserIDs
+
+	if excludeUserIDs == nil {
+		excludeUserIDs = make([]int64, 0)
+	}
+
+	rows, err := dao.db.Queryx(
+		query,
+		p.UserID,
+		pq.Array(excludeUserIDs),
+		p.PerPage,
+		p.Offset,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	gs := make([]*models.RandomGirl, 0)
+
+	for rows.Next() {
+		var g models.RandomGirl
+
+		if err := rows.StructScan(&g); err != nil {
+			return nil, err
+		}
+
+		gs = append(gs, &g)
+	}
+
+	userDao := &UserDAO{db: dao.db}
+
+	if err := userDao.attachGirlsDetail(gs); err != nil {
+		return nil, err
+	}
+
+	return gs, nil
+}
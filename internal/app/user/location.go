@@ -0,0 +1,40 @@
+package user
+
+import (
+	"math"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+// coordinateDecimals number of decimals coordinates are rounded to for each precision.
+// 1 decimal of a degree is about 11km, 2 decimals is about 1.1km.
+var coordinateDecimals = map[models.LocationPrecision]int{
+	models.LocationPrecisionCity:     1,
+	models.LocationPrecisionDistrict: 2,
+}
+
+// CoarsenCoordinate rounds the coordinate according to the precision chosen by the user.
+// Raw coordinates should never be persisted.
+func CoarsenCoordinate(v float64, precision models.LocationPrecision) float64 {
+	decimals, ok := coordinateDecimals[precision]
+
+	if !ok {
+		decimals = coordinateDecimals[models.LocationPrecisionCity]
+	}
+
+	pow := math.Pow(10, float64(decimals))
+
+	return math.Round(v*pow) / pow
+}
+
+// RoundDistanceKm rounds the distance up to whole kilometers so that the location of the girl
+// can not be triangulated from the distance. Distances under 1km are reported as 1km.
+func RoundDistanceKm(distanceKm float64) int {
+	rounded := int(math.Ceil(distanceKm))
+
+	if rounded < 1 {
+		return 1
+	}
+
+	return rounded
+}
@@ -0,0 +1,231 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+type PutMyLocationBody struct {
+	Lat       *float64 `form:"lat" json:"lat" binding:"required,min=-90,max=90"`
+	Lng       *float64 `form:"lng" json:"lng" binding:"required,min=-180,max=180"`
+	Precision string   `form:"precision,default=district" json:"precision" binding:"oneof=city district"`
+}
+
+// PutMyLocationHandler female user opts in to be discovered nearby. Coordinates are rounded
+// according to the precision before persisted.
+func PutMyLocationHandler(c *gin.Context, depCon container.Container) {
+	body := PutMyLocationBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateUserLocationParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao     contracts.UserDAOer
+		locationDao contracts.UserLocationDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&locationDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	precision := models.LocationPrecision(body.Precision)
+
+	loc, err := locationDao.UpsertLocation(contracts.UpsertUserLocationParams{
+		UserID:    me.ID,
+		Lat:       CoarsenCoordinate(*body.Lat, precision),
+		Lng:       CoarsenCoordinate(*body.Lng, precision),
+		Precision: precision,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToUpsertUserLocation,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		Precision models.LocationPrecision `json:"precision"`
+	}{
+		loc.Precision,
+	})
+}
+
+// DeleteMyLocationHandler withdraws the published location.
+func DeleteMyLocationHandler(c *gin.Context, depCon container.Container) {
+	var (
+		userDao     contracts.UserDAOer
+		locationDao contracts.UserLocationDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&locationDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if err := locationDao.DeleteLocation(me.ID); err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToDeleteUserLocation,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct{}{})
+}
+
+type GetNearbyGirlsBody struct {
+	Lat      *float64 `form:"lat" binding:"required,min=-90,max=90"`
+	Lng      *float64 `form:"lng" binding:"required,min=-180,max=180"`
+	RadiusKm float64  `form:"radius_km,default=10" binding:"gt=0,max=50"`
+	PerPage  int      `form:"per_page,default=6"`
+	Offset   int      `form:"offset,default=0"`
+}
+
+// GetNearbyGirlsHandler lists girls who published their location within the radius, nearest first.
+func GetNearbyGirlsHandler(c *gin.Context, depCon container.Container) {
+	// Registered as wildcard since static path `/girls/nearby` conflicts with `/:uuid/*` routes.
+	if c.Param("uuid") != "girls" {
+		c.AbortWithError(http.StatusNotFound, apperr.NewErr(apperr.APINotFound))
+
+		return
+	}
+
+	body := GetNearbyGirlsBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToValidateNearbyGirlsParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao     contracts.UserDAOer
+		blockDao    contracts.BlockDAOer
+		locationDao contracts.UserLocationDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&blockDao)
+	depCon.Make(&locationDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	blockRelatedIDs, err := blockDao.GetBlockRelatedUserIDs(int(me.ID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetBlockRelatedUsers,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	girls, err := locationDao.GetNearbyGirls(contracts.GetNearbyGirlsParams{
+		Lat:            *body.Lat,
+		Lng:            *body.Lng,
+		RadiusKm:       body.RadiusKm,
+		Offset:         body.Offset,
+		PerPage:        body.PerPage,
+		ExcludeUserIDs: blockRelatedIDs,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetNearbyGirls,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	trf, err := TrfNearbyGirls(girls)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToTransformGirlProfile,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, trf)
+}
@@ -0,0 +1,164 @@
+package user
+
+import (
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/lib/pq"
+)
+
+type UserLocationDAO struct {
+	db db.Conn
+}
+
+func NewUserLocationDAO(db db.Conn) *UserLocationDAO {
+	return &UserLocationDAO{
+		db: db,
+	}
+}
+
+func UserLocationDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.UserLocationDAOer {
+			return NewUserLocationDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *UserLocationDAO) WithTx(tx db.Conn) contracts.UserLocationDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+// UpsertLocation publishes the location of the user. Coordinates should have been coarsened
+// by `CoarsenCoordinate` before hand.
+func (dao *UserLocationDAO) UpsertLocation(p contracts.UpsertUserLocationParams) (*models.UserLocation, error) {
+	query := `
+INSERT INTO user_locations (
+	user_id,
+	lat,
+	lng,
+	precision
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE SET
+	lat = EXCLUDED.lat,
+	lng = EXCLUDED.lng,
+	precision = EXCLUDED.precision,
+	deleted_at = NULL
+RETURNING *;
+`
+	var m models.UserLocation
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.UserID,
+		p.Lat,
+		p.Lng,
+		p.Precision,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// DeleteLocation removes the published location, the user will no longer be discovered nearby.
+func (dao *UserLocationDAO) DeleteLocation(userID int64) error {
+	query := `
+DELETE FROM user_locations
+WHERE user_id = $1;
+`
+	_, err := dao.db.Exec(query, userID)
+
+	return err
+}
+
+// GetNearbyGirls retrieves girls who published their location within the radius, nearest first.
+// Candidates are narrowed down by the `ll_to_earth` gist index before haversine distance is calculated.
+func (dao *UserLocationDAO) GetNearbyGirls(p contracts.GetNearbyGirlsParams) ([]models.NearbyGirl, error) {
+	query := `
+SELECT
+	*
+FROM (
+	SELECT
+		users.id,
+		users.uuid,
+		users.username,
+		users.avatar_url,
+		users.age,
+		users.height,
+		users.weight,
+		users.breast_size,
+		users.description,
+		6371 * 2 * asin(LEAST(1, sqrt(
+			power(sin(radians(user_locations.lat - $1) / 2), 2) +
+			cos(radians($1)) * cos(radians(user_locations.lat)) *
+			power(sin(radians(user_locations.lng - $2) / 2), 2)
+		))) AS distance_km
+	FROM
+		user_locations
+	INNER JOIN users ON users.id = user_locations.user_id
+	WHERE
+		earth_box(ll_to_earth($1, $2), $3 * 1000) @> ll_to_earth(user_locations.lat, user_locations.lng) AND
+		users.gender = $4 AND
+		users.deleted_at IS NULL AND
+		users.id <> ALL($7::int[]) AND
+		user_locations.deleted_at IS NULL AND
+		-- Suspended girls are hidden until the suspension expires or is lifted.
+		NOT EXISTS (
+			SELECT 1
+			FROM user_suspensions
+			WHERE
+				user_suspensions.user_id = users.id AND
+				user_suspensions.lifted_at IS NULL AND
+				user_suspensions.deleted_at IS NULL AND
+				(user_suspensions.expires_at IS NULL OR user_suspensions.expires_at > NOW())
+		)
+) AS nearby
+WHERE
+	distance_km <= $3
+ORDER BY distance_km, id
+LIMIT $5
+OFFSET $6;
+`
+	excludeUserIDs := p.ExcludeUserIDs
+
+	if excludeUserIDs == nil {
+		excludeUserIDs = make([]int64, 0)
+	}
+
+	rows, err := dao.db.Queryx(
+		query,
+		p.Lat,
+		p.Lng,
+		p.RadiusKm,
+		models.GenderFemale,
+		p.PerPage,
+		p.Offset,
+		pq.Array(excludeUserIDs),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	girls := make([]models.NearbyGirl, 0)
+
+	for rows.Next() {
+		var m models.NearbyGirl
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		girls = append(girls, m)
+	}
+
+	return girls, nil
+}
@@ -0,0 +1,149 @@
+package user
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/requestbinder"
+)
+
+// recordProfileView records the view of the viewee profile. Self views and views between
+// users blocked by either side are not recorded.
+func recordProfileView(depCon container.Container, viewerUuid string, viewee *models.User) error {
+	var (
+		userDao        contracts.UserDAOer
+		blockDao       contracts.BlockDAOer
+		profileViewDao contracts.ProfileViewDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&blockDao)
+	depCon.Make(&profileViewDao)
+
+	viewer, err := userDao.GetUserByUuid(viewerUuid, "id")
+
+	if err != nil {
+		return err
+	}
+
+	if viewer.ID == viewee.ID {
+		return nil
+	}
+
+	blockRelatedIDs, err := blockDao.GetBlockRelatedUserIDs(int(viewer.ID))
+
+	if err != nil {
+		return err
+	}
+
+	for _, blockRelatedID := range blockRelatedIDs {
+		if blockRelatedID == viewee.ID {
+			return nil
+		}
+	}
+
+	now := time.Now()
+
+	return profileViewDao.RecordView(contracts.RecordProfileViewParams{
+		ViewerID: viewer.ID,
+		VieweeID: viewee.ID,
+		ViewedAt: now,
+		ViewedOn: now.In(config.GetAppConf().GetAppLocation()).Format("2006-01-02"),
+	})
+}
+
+type GetMyProfileViewersBody struct {
+	Offset  int `form:"offset,default=0"`
+	PerPage int `form:"per_page,default=10"`
+}
+
+// GetMyProfileViewersHandler lists users who have viewed my profile. Only paid members are able
+// to see who the viewers are, see `middlewares.RequirePremium`. The view count is available to
+// everyone on the profile.
+func GetMyProfileViewersHandler(c *gin.Context, depCon container.Container) {
+	// Registered as wildcard since static path `/me/viewers` conflicts with `/:uuid/*` routes.
+	if c.Param("uuid") != "me" {
+		c.AbortWithError(http.StatusNotFound, apperr.NewErr(apperr.APINotFound))
+
+		return
+	}
+
+	body := GetMyProfileViewersBody{}
+
+	if err := requestbinder.Bind(c, &body); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToBindApiBodyParams,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	var (
+		userDao        contracts.UserDAOer
+		blockDao       contracts.BlockDAOer
+		profileViewDao contracts.ProfileViewDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&blockDao)
+	depCon.Make(&profileViewDao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	blockRelatedIDs, err := blockDao.GetBlockRelatedUserIDs(int(me.ID))
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetBlockRelatedUsers,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	viewers, err := profileViewDao.GetViewers(contracts.GetProfileViewersParams{
+		VieweeID:       me.ID,
+		ExcludeUserIDs: blockRelatedIDs,
+		Offset:         body.Offset,
+		PerPage:        body.PerPage,
+	})
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetProfileViewers,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, TrfProfileViewers(viewers))
+}
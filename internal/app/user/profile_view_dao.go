@@ -0,0 +1,135 @@
+package user
+
+import (
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/lib/pq"
+)
+
+type ProfileViewDAO struct {
+	db db.Conn
+}
+
+func NewProfileViewDAO(db db.Conn) *ProfileViewDAO {
+	return &ProfileViewDAO{
+		db: db,
+	}
+}
+
+func ProfileViewDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.ProfileViewDAOer {
+			return NewProfileViewDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *ProfileViewDAO) WithTx(tx db.Conn) contracts.ProfileViewDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+// RecordView records the view of the profile. Views of the same viewer are counted once
+// per day, viewing again on the same day only refreshes `viewed_at`.
+func (dao *ProfileViewDAO) RecordView(p contracts.RecordProfileViewParams) error {
+	query := `
+INSERT INTO profile_views (
+	viewer_id,
+	viewee_id,
+	viewed_on,
+	viewed_at
+) VALUES ($1, $2, $3, $4)
+ON CONFLICT (viewer_id, viewee_id, viewed_on) DO UPDATE SET
+	viewed_at = EXCLUDED.viewed_at;
+`
+	_, err := dao.db.Exec(
+		query,
+		p.ViewerID,
+		p.VieweeID,
+		p.ViewedOn,
+		p.ViewedAt,
+	)
+
+	return err
+}
+
+// GetViewCount counts views of the profile. Each viewer is counted at most once per day.
+func (dao *ProfileViewDAO) GetViewCount(vieweeID int64) (int, error) {
+	query := `
+SELECT
+	COUNT(1)
+FROM
+	profile_views
+WHERE
+	viewee_id = $1 AND
+	deleted_at IS NULL;
+`
+	var count int
+
+	if err := dao.db.QueryRowx(query, vieweeID).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// GetViewers retrieves users who have viewed the profile, latest viewer first.
+func (dao *ProfileViewDAO) GetViewers(p contracts.GetProfileViewersParams) ([]models.ProfileViewer, error) {
+	query := `
+SELECT
+	users.uuid,
+	users.username,
+	users.avatar_url,
+	MAX(profile_views.viewed_at) AS viewed_at
+FROM
+	profile_views
+INNER JOIN users ON users.id = profile_views.viewer_id
+WHERE
+	profile_views.viewee_id = $1 AND
+	profile_views.deleted_at IS NULL AND
+	users.deleted_at IS NULL AND
+	users.id <> ALL($2::int[])
+GROUP BY users.id
+ORDER BY viewed_at DESC
+LIMIT $3
+OFFSET $4;
+`
+	excludeUserIDs := p.ExcludeUserIDs
+
+	if excludeUserIDs == nil {
+		excludeUserIDs = make([]int64, 0)
+	}
+
+	rows, err := dao.db.Queryx(
+		query,
+		p.VieweeID,
+		pq.Array(excludeUserIDs),
+		p.PerPage,
+		p.Offset,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	viewers := make([]models.ProfileViewer, 0)
+
+	for rows.Next() {
+		var m models.ProfileViewer
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		viewers = append(viewers, m)
+	}
+
+	return viewers, nil
+}
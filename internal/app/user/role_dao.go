@@ -0,0 +1,64 @@
+package user
+
+import (
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+// GetUserRolesByUuid retrieves operator roles granted to the user. App users have no roles.
+func (dao *UserDAO) GetUserRolesByUuid(uuid string) ([]models.UserRoleType, error) {
+	query := `
+SELECT user_roles.role
+FROM user_roles
+INNER JOIN users ON users.id = user_roles.user_id
+WHERE users.uuid = $1
+	AND user_roles.deleted_at IS NULL
+ORDER BY user_roles.role;
+`
+	rows, err := dao.db.Query(query, uuid)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	roles := make([]models.UserRoleType, 0)
+
+	for rows.Next() {
+		var role models.UserRoleType
+
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, role)
+	}
+
+	return roles, rows.Err()
+}
+
+// GrantUserRole grants the role to the user. Granting a role that has been revoked before restores it.
+func (dao *UserDAO) GrantUserRole(userID int64, role models.UserRoleType) error {
+	query := `
+INSERT INTO user_roles (user_id, role)
+VALUES ($1, $2)
+ON CONFLICT (user_id, role)
+DO UPDATE SET deleted_at = NULL;
+`
+	_, err := dao.db.Exec(query, userID, role)
+
+	return err
+}
+
+func (dao *UserDAO) RevokeUserRole(userID int64, role models.UserRoleType) error {
+	query := `
+UPDATE user_roles
+SET deleted_at = now()
+WHERE user_id = $1
+	AND role = $2
+	AND deleted_at IS NULL;
+`
+	_, err := dao.db.Exec(query, userID, role)
+
+	return err
+}
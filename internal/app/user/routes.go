@@ -3,26 +3,27 @@ package user
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/golobby/container/pkg/container"
-	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/availability"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/middlewares"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 )
 
 func Routes(r *gin.RouterGroup, depCon container.Container) {
 	var (
-		authDao contracts.AuthDaoer
-		userDao contracts.UserDAOer
+		authDao  contracts.AuthDaoer
+		userDao  contracts.UserDAOer
+		blockDao contracts.BlockDAOer
 	)
 
 	depCon.Make(&authDao)
 	depCon.Make(&userDao)
+	depCon.Make(&blockDao)
 
 	g := r.Group(
 		"/users",
 		jwtactor.JwtValidator(
-			jwtactor.JwtMiddlewareOptions{
-				Secret: config.GetAppConf().JwtSecret,
-			},
+			jwtactor.JwtMiddlewareOptions{},
 			authDao,
 		),
 	)
@@ -31,17 +32,23 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 		Container: depCon,
 	}
 
-	g.GET("/:uuid/services", handlers.GetUserServiceHistory)
+	// Users who have blocked one another do not see each other's profile.
+	rejectBlocked := middlewares.RejectBlockedUser(blockDao)
+
+	// Favorites are kept by male users only, same as `/favorites` group below.
+	isMale := middlewares.IsMale(userDao)
+
+	g.GET("/:uuid/services", rejectBlocked, handlers.GetUserServiceHistory)
 
 	g.GET("/:uuid/payments", handlers.GetUserPayments)
 
-	g.GET("/:uuid/images", handlers.GetUserImagesHandler)
+	g.GET("/:uuid/images", rejectBlocked, handlers.GetUserImagesHandler)
 
-	g.GET("/:uuid/ratings", func(c *gin.Context) {
+	g.GET("/:uuid/ratings", rejectBlocked, func(c *gin.Context) {
 		handlers.GetUserRatings(c, depCon)
 	})
 
-	g.GET("/:uuid/service-option", func(c *gin.Context) {
+	g.GET("/:uuid/service-option", rejectBlocked, func(c *gin.Context) {
 		handlers.GetUserServiceOption(c, depCon)
 	})
 
@@ -62,12 +69,98 @@ func Routes(r *gin.RouterGroup, depCon container.Container) {
 		case "girls":
 			// Get list of girls profile. These girls are available for male user to pick.
 			GetGirls(c, depCon)
+		case "availability":
+			availability.GetMyAvailabilityHandler(c, depCon)
+		case "favorites":
+			if isMale(c); c.IsAborted() {
+				return
+			}
+
+			GetFavoritesHandler(c, depCon)
 		default:
+			if rejectBlocked(c); c.IsAborted() {
+				return
+			}
+
 			handlers.GetUserProfileHandler(c, depCon)
 		}
 
 	})
 
+	// Registered as wildcard since static path `/me/export` conflicts with `/:uuid/*` routes.
+	// Only `/me/export` is served.
+	g.GET("/:uuid/export", func(c *gin.Context) {
+		ExportMyDataHandler(c, depCon)
+	})
+
+	// Only `/me/viewers` is served, see `GetMyProfileViewersHandler`.
+	g.GET(
+		"/:uuid/viewers",
+		middlewares.RequirePremium(userDao),
+		func(c *gin.Context) {
+			GetMyProfileViewersHandler(c, depCon)
+		},
+	)
+
+	g.DELETE("/me", func(c *gin.Context) {
+		DeleteMyAccountHandler(c, depCon)
+	})
+
+	// Only `/girls/nearby` is served, see `GetNearbyGirlsHandler`.
+	g.GET("/:uuid/nearby", func(c *gin.Context) {
+		GetNearbyGirlsHandler(c, depCon)
+	})
+
+	g.PUT(
+		"/me/location",
+		middlewares.IsFemale(userDao),
+		func(c *gin.Context) {
+			PutMyLocationHandler(c, depCon)
+		},
+	)
+
+	g.DELETE("/me/location", func(c *gin.Context) {
+		DeleteMyLocationHandler(c, depCon)
+	})
+
+	g.GET("/:uuid/availability", rejectBlocked, func(c *gin.Context) {
+		availability.GetUserAvailabilityHandler(c, depCon)
+	})
+
+	// Weekly availability slots and blackout dates of the female user. Listing is served by `/:uuid`.
+	ag := g.Group("/availability", middlewares.IsFemale(userDao))
+
+	ag.POST("/slots", func(c *gin.Context) {
+		availability.CreateSlotHandler(c, depCon)
+	})
+
+	ag.PUT("/slots/:id", func(c *gin.Context) {
+		availability.UpdateSlotHandler(c, depCon)
+	})
+
+	ag.DELETE("/slots/:id", func(c *gin.Context) {
+		availability.DeleteSlotHandler(c, depCon)
+	})
+
+	ag.POST("/blackouts", func(c *gin.Context) {
+		availability.CreateBlackoutHandler(c, depCon)
+	})
+
+	ag.DELETE("/blackouts/:id", func(c *gin.Context) {
+		availability.DeleteBlackoutHandler(c, depCon)
+	})
+
+	// Favorite girls of the male user. Listing is served by `/:uuid`.
+	fg := g.Group("/favorites", isMale)
+
+	fg.POST("/:uuid", func(c *gin.Context) {
+		AddFavoriteHandler(c, depCon)
+	})
+
+	fg.DELETE("/:uuid", func(c *gin.Context) {
+		RemoveFavoriteHandler(c, depCon)
+	})
+
 	g.PUT("/", handlers.PutUserInfo)
 
 	g.POST(
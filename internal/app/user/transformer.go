@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/presence"
 
 	convertnullsql "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/convert_null_sql"
 )
@@ -30,15 +31,17 @@ type TransformedUser struct {
 	Weight      float32       `json:"weight"`
 	Description string        `json:"description"`
 	FCMTopic    string        `json:"fcm_topic"`
+	Verified    bool          `json:"verified"`
 }
 
-func (ut *UserTransform) TransformUser(m *models.User) *TransformedUser {
+func (ut *UserTransform) TransformUser(m *models.User, verified bool) *TransformedUser {
 	return &TransformedUser{
 		Username:  m.Username,
 		Gender:    m.Gender,
 		Uuid:      m.Uuid,
 		AvatarUrl: m.AvatarUrl.String,
 		FCMTopic:  m.FcmTopic.String,
+		Verified:  verified,
 	}
 }
 
@@ -171,9 +174,12 @@ type TransformedViewableUserProfile struct {
 	Description string            `json:"description"`
 	Traits      []Trait           `json:"traits"`
 	Rating      models.UserRating `json:"rating"`
+	Verified    bool              `json:"verified"`
+	ViewCount   int               `json:"view_count"`
+	Presence    presence.Status   `json:"presence"`
 }
 
-func (ut *UserTransform) TransformViewableUserProfile(user models.User, rating models.UserRating) (*TransformedViewableUserProfile, error) {
+func (ut *UserTransform) TransformViewableUserProfile(user models.User, rating models.UserRating, verified bool, viewCount int, status presence.Status) (*TransformedViewableUserProfile, error) {
 	traits, err := formatUserTraits(user)
 
 	if err != nil {
@@ -190,6 +196,9 @@ func (ut *UserTransform) TransformViewableUserProfile(user models.User, rating m
 		Traits:      traits,
 		Description: user.Description.String,
 		Rating:      rating,
+		Verified:    verified,
+		ViewCount:   viewCount,
+		Presence:    status,
 	}, nil
 }
 
@@ -363,18 +372,20 @@ type TrfedRandomGirl struct {
 	InquiryUUID   *string           `json:"inquiry_uuid"`
 	InquiryStatus *string           `json:"inquiry_status"`
 
-	HasService        bool    `json:"has_service"`
-	ExpectServiceType *string `json:"expect_service_type"`
-	ChannelUUID       *string `json:"channel_uuid"`
-	ServiceUUID       *string `json:"service_uuid"`
-	ServiceStatus     *string `json:"service_status"`
+	HasService        bool            `json:"has_service"`
+	ExpectServiceType *string         `json:"expect_service_type"`
+	ChannelUUID       *string         `json:"channel_uuid"`
+	ServiceUUID       *string         `json:"service_uuid"`
+	ServiceStatus     *string         `json:"service_status"`
+	Verified          bool            `json:"verified"`
+	Presence          presence.Status `json:"presence"`
 }
 
 type TrfedRandomGirls struct {
 	Girls []*TrfedRandomGirl `json:"girls"`
 }
 
-func TrfRandomGirls(rgs []*models.RandomGirl) (*TrfedRandomGirls, error) {
+func TrfRandomGirls(rgs []*models.RandomGirl, presences map[string]presence.Status) (*TrfedRandomGirls, error) {
 	trfgs := make([]*TrfedRandomGirl, 0)
 
 	for _, rg := range rgs {
@@ -408,6 +419,8 @@ func TrfRandomGirls(rgs []*models.RandomGirl) (*TrfedRandomGirls, error) {
 			ChannelUUID:       rg.ChannelUUID,
 			ServiceUUID:       rg.ServiceUUID,
 			ServiceStatus:     rg.ServiceStatus,
+			Verified:          rg.Verified,
+			Presence:          presences[rg.Uuid],
 		}
 
 		trfgs = append(trfgs, trfg)
@@ -447,3 +460,90 @@ func TransformViewableUserServiceOption(so []models.UserServiceOptionData) []Trf
 }
 
 //TransformViewableUserServiceOption
+
+type TrfedNearbyGirl struct {
+	Uuid        string   `json:"uuid"`
+	Username    string   `json:"username"`
+	AvatarURL   string   `json:"avatar_url"`
+	Age         *int32   `json:"age"`
+	Height      *float32 `json:"height"`
+	Weight      *float32 `json:"weight"`
+	BreastSize  string   `json:"breast_size"`
+	Description string   `json:"description"`
+
+	// DistanceKm is rounded up to whole kilometers, coordinates of the girl are never exposed.
+	DistanceKm int `json:"distance_km"`
+}
+
+type TrfedNearbyGirls struct {
+	Girls []TrfedNearbyGirl `json:"girls"`
+}
+
+func TrfNearbyGirls(ngs []models.NearbyGirl) (*TrfedNearbyGirls, error) {
+	trfgs := make([]TrfedNearbyGirl, 0)
+
+	for _, ng := range ngs {
+		height, err := convertnullsql.ConvertSqlNullStringToFloat32(ng.Height)
+
+		if err != nil {
+			return nil, err
+		}
+
+		weight, err := convertnullsql.ConvertSqlNullStringToFloat32(ng.Weight)
+
+		if err != nil {
+			return nil, err
+		}
+
+		var age *int32
+
+		if ng.Age.Valid {
+			a := ng.Age.Int32
+			age = &a
+		}
+
+		trfgs = append(trfgs, TrfedNearbyGirl{
+			Uuid:        ng.Uuid,
+			Username:    ng.Username,
+			AvatarURL:   ng.AvatarUrl.String,
+			Age:         age,
+			Height:      height,
+			Weight:      weight,
+			BreastSize:  ng.BreastSize.String,
+			Description: ng.Description.String,
+			DistanceKm:  RoundDistanceKm(ng.DistanceKm),
+		})
+	}
+
+	return &TrfedNearbyGirls{
+		Girls: trfgs,
+	}, nil
+}
+
+type TrfedProfileViewer struct {
+	Uuid      string    `json:"uuid"`
+	Username  string    `json:"username"`
+	AvatarURL string    `json:"avatar_url"`
+	ViewedAt  time.Time `json:"viewed_at"`
+}
+
+type TrfedProfileViewers struct {
+	Viewers []TrfedProfileViewer `json:"viewers"`
+}
+
+func TrfProfileViewers(vs []models.ProfileViewer) TrfedProfileViewers {
+	trfvs := make([]TrfedProfileViewer, 0)
+
+	for _, v := range vs {
+		trfvs = append(trfvs, TrfedProfileViewer{
+			Uuid:      v.Uuid,
+			Username:  v.Username,
+			AvatarURL: v.AvatarUrl.String,
+			ViewedAt:  v.ViewedAt,
+		})
+	}
+
+	return TrfedProfileViewers{
+		Viewers: trfvs,
+	}
+}
@@ -0,0 +1,421 @@
+package usertests
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// GetGirlsTestSuite each test places girls in a region of its own so that girls created by
+// other tests are filtered out.
+type GetGirlsTestSuite struct {
+	suite.Suite
+	depCon container.Container
+
+	region string
+	male   models.User
+}
+
+func (suite *GetGirlsTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+func (suite *GetGirlsTestSuite) SetupTest() {
+	suite.region = util.GenRandStringRune(12)
+	suite.male = util.CreateTestUser(suite.T(), models.GenderMale)
+}
+
+func (suite *GetGirlsTestSuite) exec(query string, args ...interface{}) {
+	if _, err := db.GetDB().Exec(query, args...); err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+// createGirl creates a girl in the region of the test.
+func (suite *GetGirlsTestSuite) createGirl() models.User {
+	girl := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.exec(`UPDATE users SET region = $1 WHERE id = $2;`, suite.region, girl.ID)
+
+	return girl
+}
+
+func (suite *GetGirlsTestSuite) createGirlWithTraits(age int, height, weight float64) models.User {
+	girl := suite.createGirl()
+	suite.exec(
+		`UPDATE users SET age = $1, height = $2, weight = $3 WHERE id = $4;`,
+		age,
+		height,
+		weight,
+		girl.ID,
+	)
+
+	return girl
+}
+
+func (suite *GetGirlsTestSuite) rate(girl models.User, ratings ...int) {
+	for _, rating := range ratings {
+		suite.exec(
+			`INSERT INTO service_ratings (rater_id, ratee_id, rating) VALUES ($1, $2, $3);`,
+			suite.male.ID,
+			girl.ID,
+			rating,
+		)
+	}
+}
+
+func (suite *GetGirlsTestSuite) createService(girl models.User, status models.ServiceStatus, appointmentTime time.Time) {
+	ctx := context.Background()
+	q := models.New(db.GetDB())
+
+	iqParams, err := util.GenTestInquiryParams(suite.male.ID)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	iq, err := q.CreateInquiry(ctx, *iqParams)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	srvParams, err := util.GenTestServiceParams(suite.male.ID, girl.ID, iq.ID)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	srvParams.ServiceStatus = status
+	srvParams.AppointmentTime = sql.NullTime{
+		Valid: true,
+		Time:  appointmentTime,
+	}
+
+	if _, err := q.CreateService(ctx, *srvParams); err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+func (suite *GetGirlsTestSuite) offer(girl models.User, options ...*models.ServiceOption) {
+	dao := user.NewUserDAO(db.GetDB())
+
+	for _, option := range options {
+		if _, err := dao.CreateUserServiceOption(contracts.CreateServiceOptionParams{
+			UserID:          int(girl.ID),
+			ServiceOptionID: int(option.ID),
+		}); err != nil {
+			suite.T().Fatal(err)
+		}
+	}
+}
+
+func (suite *GetGirlsTestSuite) createServiceOption() *models.ServiceOption {
+	option, err := user.NewUserDAO(db.GetDB()).CreateServiceOption(contracts.CreateServiceOptionsParams{
+		Name:               util.GenRandStringRune(12),
+		Price:              100,
+		Duration:           60,
+		ServiceOptionsType: "custom",
+	})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return option
+}
+
+func (suite *GetGirlsTestSuite) getGirls(query url.Values) *httptest.ResponseRecorder {
+	if query.Get("region") == "" {
+		query.Set("region", suite.region)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/users/girls?"+query.Encode(), nil)
+	c.Set("uuid", suite.male.Uuid)
+
+	user.GetGirls(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	return w
+}
+
+func errCodeOf(w *httptest.ResponseRecorder) string {
+	resp := struct {
+		ErrCode string `json:"err_code"`
+	}{}
+
+	json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return resp.ErrCode
+}
+
+// listGirls lists uuids of girls in the order of the response.
+func (suite *GetGirlsTestSuite) listGirls(query url.Values) []string {
+	w := suite.getGirls(query)
+
+	if w.Code != http.StatusOK {
+		suite.T().Fatalf("failed to get girls %s", w.Body.String())
+	}
+
+	var resp user.TrfedRandomGirls
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	uuids := make([]string, 0)
+
+	for _, g := range resp.Girls {
+		uuids = append(uuids, g.Uuid)
+	}
+
+	return uuids
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByAge() {
+	younger := suite.createGirlWithTraits(20, 160, 50)
+	older := suite.createGirlWithTraits(30, 160, 50)
+
+	assert := assert.New(suite.T())
+	assert.Equal([]string{older.Uuid}, suite.listGirls(url.Values{"age_min": {"25"}}))
+	assert.Equal([]string{younger.Uuid}, suite.listGirls(url.Values{"age_max": {"25"}}))
+	assert.ElementsMatch(
+		[]string{younger.Uuid, older.Uuid},
+		suite.listGirls(url.Values{"age_min": {"20"}, "age_max": {"30"}}),
+	)
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByHeight() {
+	shorter := suite.createGirlWithTraits(25, 155, 50)
+	taller := suite.createGirlWithTraits(25, 172.5, 50)
+
+	assert := assert.New(suite.T())
+	assert.Equal([]string{taller.Uuid}, suite.listGirls(url.Values{"height_min": {"170"}}))
+	assert.Equal([]string{shorter.Uuid}, suite.listGirls(url.Values{"height_max": {"170"}}))
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByWeight() {
+	lighter := suite.createGirlWithTraits(25, 160, 45)
+	heavier := suite.createGirlWithTraits(25, 160, 58.5)
+
+	assert := assert.New(suite.T())
+	assert.Equal([]string{heavier.Uuid}, suite.listGirls(url.Values{"weight_min": {"50"}}))
+	assert.Equal([]string{lighter.Uuid}, suite.listGirls(url.Values{"weight_max": {"50"}}))
+}
+
+func (suite *GetGirlsTestSuite) TestInvalidRangeIsRejected() {
+	w := suite.getGirls(url.Values{"age_min": {"30"}, "age_max": {"20"}})
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), apperr.FailedToValidateGetGirlsParams, errCodeOf(w))
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByRegion() {
+	girl := suite.createGirl()
+
+	otherRegion := util.GenRandStringRune(12)
+	other := suite.createGirl()
+	suite.exec(`UPDATE users SET region = $1 WHERE id = $2;`, otherRegion, other.ID)
+
+	assert := assert.New(suite.T())
+	assert.Equal([]string{girl.Uuid}, suite.listGirls(url.Values{}))
+	assert.Equal([]string{other.Uuid}, suite.listGirls(url.Values{"region": {otherRegion}}))
+}
+
+func (suite *GetGirlsTestSuite) TestDeletedGirlsAreExcluded() {
+	girl := suite.createGirl()
+	deleted := suite.createGirl()
+
+	if err := user.NewUserDAO(db.GetDB()).AnonymizeUser(deleted.ID); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	// Region is cleared by anonymization, put it back so that only `deleted_at` tells them apart.
+	suite.exec(`UPDATE users SET region = $1 WHERE id = $2;`, suite.region, deleted.ID)
+
+	assert.Equal(suite.T(), []string{girl.Uuid}, suite.listGirls(url.Values{}))
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByNationality() {
+	nationality := util.GenRandStringRune(12)
+
+	girl := suite.createGirl()
+	suite.exec(`UPDATE users SET nationality = $1 WHERE id = $2;`, nationality, girl.ID)
+
+	other := suite.createGirl()
+	suite.exec(`UPDATE users SET nationality = $1 WHERE id = $2;`, util.GenRandStringRune(12), other.ID)
+
+	assert.Equal(
+		suite.T(),
+		[]string{girl.Uuid},
+		suite.listGirls(url.Values{"nationality": {nationality}}),
+	)
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByServiceOptions() {
+	massage := suite.createServiceOption()
+	dinner := suite.createServiceOption()
+
+	both := suite.createGirl()
+	suite.offer(both, massage, dinner)
+
+	massageOnly := suite.createGirl()
+	suite.offer(massageOnly, massage)
+
+	suite.createGirl()
+
+	assert := assert.New(suite.T())
+	assert.ElementsMatch(
+		[]string{both.Uuid, massageOnly.Uuid},
+		suite.listGirls(url.Values{"service_options": {massage.Name}}),
+	)
+
+	// Girls have to offer all of the options.
+	assert.Equal(
+		[]string{both.Uuid},
+		suite.listGirls(url.Values{"service_options": {massage.Name, dinner.Name}}),
+	)
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByMinRating() {
+	good := suite.createGirl()
+	suite.rate(good, 5, 4)
+
+	poor := suite.createGirl()
+	suite.rate(poor, 2)
+
+	// Girls who have never been rated are filtered out.
+	suite.createGirl()
+
+	assert := assert.New(suite.T())
+	assert.Equal([]string{good.Uuid}, suite.listGirls(url.Values{"min_rating": {"4.5"}}))
+	assert.ElementsMatch(
+		[]string{good.Uuid, poor.Uuid},
+		suite.listGirls(url.Values{"min_rating": {"2"}}),
+	)
+}
+
+func (suite *GetGirlsTestSuite) TestFilterByAvailableOn() {
+	date := time.Now().AddDate(0, 0, 7)
+	date = time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+
+	// Girls who have not declared any slot are available at any time.
+	free := suite.createGirl()
+
+	occupied := suite.createGirl()
+	suite.createService(occupied, models.ServiceStatusToBeFulfilled, date.Add(12*time.Hour))
+
+	// Canceled services do not occupy the girl.
+	canceled := suite.createGirl()
+	suite.createService(canceled, models.ServiceStatusCanceled, date.Add(12*time.Hour))
+
+	blackedOut := suite.createGirl()
+	suite.exec(`INSERT INTO availability_blackouts (user_id, date) VALUES ($1, $2);`, blackedOut.ID, date)
+
+	slotted := suite.createGirl()
+	suite.exec(
+		`INSERT INTO availability_slots (user_id, weekday, start_time, end_time) VALUES ($1, $2, '10:00', '18:00');`,
+		slotted.ID,
+		int(date.Weekday()),
+	)
+
+	slottedOtherDay := suite.createGirl()
+	suite.exec(
+		`INSERT INTO availability_slots (user_id, weekday, start_time, end_time) VALUES ($1, $2, '10:00', '18:00');`,
+		slottedOtherDay.ID,
+		(int(date.Weekday())+1)%7,
+	)
+
+	assert := assert.New(suite.T())
+	assert.ElementsMatch(
+		[]string{free.Uuid, canceled.Uuid, slotted.Uuid},
+		suite.listGirls(url.Values{"available_on": {date.Format("2006-01-02")}}),
+	)
+
+	// Every girl is listed without the filter.
+	assert.Len(suite.listGirls(url.Values{}), 6)
+}
+
+func (suite *GetGirlsTestSuite) TestSortByNewest() {
+	first := suite.createGirl()
+	second := suite.createGirl()
+	third := suite.createGirl()
+
+	assert.Equal(
+		suite.T(),
+		[]string{third.Uuid, second.Uuid, first.Uuid},
+		suite.listGirls(url.Values{"sort": {string(contracts.GirlsSortNewest)}}),
+	)
+}
+
+func (suite *GetGirlsTestSuite) TestSortByRating() {
+	average := suite.createGirl()
+	suite.rate(average, 3)
+
+	unrated := suite.createGirl()
+
+	best := suite.createGirl()
+	suite.rate(best, 5, 5)
+
+	assert.Equal(
+		suite.T(),
+		[]string{best.Uuid, average.Uuid, unrated.Uuid},
+		suite.listGirls(url.Values{"sort": {string(contracts.GirlsSortRating)}}),
+	)
+}
+
+func (suite *GetGirlsTestSuite) TestSortByCompletedServices() {
+	once := suite.createGirl()
+	suite.createService(once, models.ServiceStatusCompleted, time.Now().AddDate(0, 0, -2))
+
+	// Only completed services are counted.
+	never := suite.createGirl()
+	suite.createService(never, models.ServiceStatusCanceled, time.Now().AddDate(0, 0, -2))
+
+	twice := suite.createGirl()
+	suite.createService(twice, models.ServiceStatusCompleted, time.Now().AddDate(0, 0, -2))
+	suite.createService(twice, models.ServiceStatusCompleted, time.Now().AddDate(0, 0, -1))
+
+	assert.Equal(
+		suite.T(),
+		[]string{twice.Uuid, once.Uuid, never.Uuid},
+		suite.listGirls(url.Values{"sort": {string(contracts.GirlsSortCompletedServices)}}),
+	)
+}
+
+func (suite *GetGirlsTestSuite) TestInvalidSortIsRejected() {
+	suite.createGirl()
+
+	w := suite.getGirls(url.Values{"sort": {"popular"}})
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), apperr.FailedToBindApiBodyParams, errCodeOf(w))
+}
+
+func TestGetGirlsTestSuite(t *testing.T) {
+	suite.Run(t, new(GetGirlsTestSuite))
+}
@@ -0,0 +1,30 @@
+package usertests
+
+import (
+	"testing"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocationTestSuite struct {
+	suite.Suite
+}
+
+func (suite *LocationTestSuite) TestCoarsenCoordinate() {
+	assert.Equal(suite.T(), 25.03, user.CoarsenCoordinate(25.033964, models.LocationPrecisionDistrict))
+	assert.Equal(suite.T(), 121.6, user.CoarsenCoordinate(121.564468, models.LocationPrecisionCity))
+	assert.Equal(suite.T(), -33.9, user.CoarsenCoordinate(-33.868820, models.LocationPrecisionCity))
+}
+
+func (suite *LocationTestSuite) TestRoundDistanceKm() {
+	assert.Equal(suite.T(), 1, user.RoundDistanceKm(0))
+	assert.Equal(suite.T(), 1, user.RoundDistanceKm(0.3))
+	assert.Equal(suite.T(), 3, user.RoundDistanceKm(2.01))
+}
+
+func TestLocationTestSuite(t *testing.T) {
+	suite.Run(t, new(LocationTestSuite))
+}
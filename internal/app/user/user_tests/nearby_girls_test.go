@@ -0,0 +1,239 @@
+package usertests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/block"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/suspension"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type NearbyGirlsTestSuite struct {
+	suite.Suite
+	depCon container.Container
+
+	// lat, lng origin of the test. Picked randomly in the southern ocean so that
+	// locations published by other tests are not in range.
+	lat, lng float64
+}
+
+func (suite *NearbyGirlsTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+func (suite *NearbyGirlsTestSuite) SetupTest() {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	suite.lat = user.CoarsenCoordinate(-60+r.Float64()*10, models.LocationPrecisionDistrict)
+	suite.lng = user.CoarsenCoordinate(-150+r.Float64()*50, models.LocationPrecisionDistrict)
+}
+
+// publishLocation publishes the location of the user by district precision. The location is
+// withdrawn once the test finishes.
+func (suite *NearbyGirlsTestSuite) publishLocation(u models.User, lat, lng float64) {
+	body := url.Values{}
+	body.Set("lat", fmt.Sprintf("%f", lat))
+	body.Set("lng", fmt.Sprintf("%f", lng))
+	body.Set("precision", string(models.LocationPrecisionDistrict))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	req, err := util.ComposeTestRequest("PUT", "/v1/users/me/location", &body, map[string]string{})
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	c.Request = req
+	c.Set("uuid", u.Uuid)
+
+	user.PutMyLocationHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	if w.Code != http.StatusOK {
+		suite.T().Fatalf("failed to publish location %s", w.Body.String())
+	}
+
+	suite.T().Cleanup(func() {
+		user.NewUserLocationDAO(db.GetDB()).DeleteLocation(u.ID)
+	})
+}
+
+func (suite *NearbyGirlsTestSuite) getNearbyGirls(me models.User, query url.Values) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/users/girls/nearby?"+query.Encode(), nil)
+	c.Params = gin.Params{{Key: "uuid", Value: "girls"}}
+	c.Set("uuid", me.Uuid)
+
+	user.GetNearbyGirlsHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	return w
+}
+
+func (suite *NearbyGirlsTestSuite) originQuery() url.Values {
+	query := url.Values{}
+	query.Set("lat", fmt.Sprintf("%f", suite.lat))
+	query.Set("lng", fmt.Sprintf("%f", suite.lng))
+
+	return query
+}
+
+func (suite *NearbyGirlsTestSuite) TestNearestFirstWithRoundedDistance() {
+	// 0.01 degree of latitude is about 1.11km, 0.05 degree is about 5.56km.
+	mid := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.publishLocation(mid, suite.lat+0.05, suite.lng)
+
+	near := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.publishLocation(near, suite.lat+0.01, suite.lng)
+
+	same := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.publishLocation(same, suite.lat, suite.lng)
+
+	// Out of the default radius of 10km.
+	far := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.publishLocation(far, suite.lat+0.2, suite.lng)
+
+	// Only girls are listed.
+	male := util.CreateTestUser(suite.T(), models.GenderMale)
+	suite.publishLocation(male, suite.lat, suite.lng)
+
+	me := util.CreateTestUser(suite.T(), models.GenderMale)
+	w := suite.getNearbyGirls(me, suite.originQuery())
+
+	assert := assert.New(suite.T())
+	assert.Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var resp user.TrfedNearbyGirls
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	uuids := make([]string, 0)
+	distances := make([]int, 0)
+
+	for _, g := range resp.Girls {
+		uuids = append(uuids, g.Uuid)
+		distances = append(distances, g.DistanceKm)
+	}
+
+	assert.Equal([]string{same.Uuid, near.Uuid, mid.Uuid}, uuids)
+	assert.Equal([]int{1, 2, 6}, distances)
+
+	// Coordinates of girls are never exposed.
+	raw := struct {
+		Girls []map[string]interface{} `json:"girls"`
+	}{}
+
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	for _, g := range raw.Girls {
+		assert.NotContains(g, "lat")
+		assert.NotContains(g, "lng")
+	}
+
+	// Radius narrows down the result.
+	query := suite.originQuery()
+	query.Set("radius_km", "2")
+	w = suite.getNearbyGirls(me, query)
+
+	resp = user.TrfedNearbyGirls{}
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Len(resp.Girls, 2)
+}
+
+func (suite *NearbyGirlsTestSuite) TestBlockRelatedGirlsAreExcluded() {
+	girl := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.publishLocation(girl, suite.lat, suite.lng)
+
+	me := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	if err := block.NewBlockDAO(db.GetDB()).BlockUser(block.BlockUserParams{
+		BlockerId: int(girl.ID),
+		BlockeeId: int(me.ID),
+	}); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	w := suite.getNearbyGirls(me, suite.originQuery())
+
+	var resp user.TrfedNearbyGirls
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Empty(suite.T(), resp.Girls)
+}
+
+func (suite *NearbyGirlsTestSuite) TestSuspendedGirlsAreExcluded() {
+	girl := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.publishLocation(girl, suite.lat, suite.lng)
+
+	me := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	if _, err := suspension.NewUserSuspensionDAO(db.GetDB()).CreateSuspension(contracts.CreateUserSuspensionParams{
+		UserID:         girl.ID,
+		SuspensionType: models.UserSuspensionTypeSuspend,
+		Reason:         "harassment",
+		CreatedBy:      me.ID,
+	}); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	w := suite.getNearbyGirls(me, suite.originQuery())
+
+	var resp user.TrfedNearbyGirls
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Empty(suite.T(), resp.Girls)
+}
+
+func (suite *NearbyGirlsTestSuite) TestRadiusIsBounded() {
+	me := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	query := suite.originQuery()
+	query.Set("radius_km", "51")
+	w := suite.getNearbyGirls(me, query)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func TestNearbyGirlsTestSuite(t *testing.T) {
+	suite.Run(t, new(NearbyGirlsTestSuite))
+}
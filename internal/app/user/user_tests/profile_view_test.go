@@ -0,0 +1,160 @@
+package usertests
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/block"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/middlewares"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfileViewTestSuite struct {
+	suite.Suite
+	depCon container.Container
+}
+
+func (suite *ProfileViewTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+}
+
+// viewProfile views the profile of the viewee and returns the view count on the profile.
+func (suite *ProfileViewTestSuite) viewProfile(viewer, viewee models.User) int {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/users/"+viewee.Uuid, nil)
+	c.Params = gin.Params{{Key: "uuid", Value: viewee.Uuid}}
+	c.Set("uuid", viewer.Uuid)
+
+	(&user.UserHandlers{Container: suite.depCon}).GetUserProfileHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	if w.Code != http.StatusOK {
+		suite.T().Fatalf("failed to view profile %s", w.Body.String())
+	}
+
+	var resp user.TransformedViewableUserProfile
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return resp.ViewCount
+}
+
+// getViewers requests the viewers of my profile through the premium gate.
+func (suite *ProfileViewTestSuite) getViewers(me models.User) *httptest.ResponseRecorder {
+	var userDao contracts.UserDAOer
+	suite.depCon.Make(&userDao)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("GET", "/v1/users/me/viewers", nil)
+	c.Params = gin.Params{{Key: "uuid", Value: "me"}}
+	c.Set("uuid", me.Uuid)
+
+	middlewares.RequirePremium(userDao)(c)
+
+	if !c.IsAborted() {
+		user.GetMyProfileViewersHandler(c, suite.depCon)
+	}
+
+	apperr.HandleError()(c)
+
+	return w
+}
+
+func (suite *ProfileViewTestSuite) setPremium(u models.User, premiumType models.PremiumType, expiresAt time.Time) {
+	if _, err := db.GetDB().Exec(
+		`UPDATE users SET premium_type = $1, premium_expiry_date = $2 WHERE id = $3;`,
+		premiumType,
+		expiresAt,
+		u.ID,
+	); err != nil {
+		suite.T().Fatal(err)
+	}
+}
+
+func (suite *ProfileViewTestSuite) TestViewsAreCountedOncePerViewerPerDay() {
+	viewee := util.CreateTestUser(suite.T(), models.GenderFemale)
+	viewer := util.CreateTestUser(suite.T(), models.GenderMale)
+	anotherViewer := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	assert := assert.New(suite.T())
+	assert.Equal(1, suite.viewProfile(viewer, viewee))
+	assert.Equal(1, suite.viewProfile(viewer, viewee))
+	assert.Equal(2, suite.viewProfile(anotherViewer, viewee))
+
+	// Viewing my own profile is not counted.
+	assert.Equal(2, suite.viewProfile(viewee, viewee))
+}
+
+func (suite *ProfileViewTestSuite) TestBlockRelatedViewsAreNotCounted() {
+	viewee := util.CreateTestUser(suite.T(), models.GenderFemale)
+	blocked := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	if err := block.NewBlockDAO(db.GetDB()).BlockUser(block.BlockUserParams{
+		BlockerId: int(viewee.ID),
+		BlockeeId: int(blocked.ID),
+	}); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(suite.T(), 0, suite.viewProfile(blocked, viewee))
+}
+
+func (suite *ProfileViewTestSuite) TestViewersAreOnlyListedToPremiumMembers() {
+	viewee := util.CreateTestUser(suite.T(), models.GenderFemale)
+	viewer := util.CreateTestUser(suite.T(), models.GenderMale)
+
+	suite.viewProfile(viewer, viewee)
+	suite.viewProfile(viewer, viewee)
+
+	assert := assert.New(suite.T())
+
+	w := suite.getViewers(viewee)
+	assert.Equal(http.StatusForbidden, w.Code)
+
+	// Expired membership is no longer premium.
+	suite.setPremium(viewee, models.PremiumTypePaid, time.Now().Add(-time.Hour))
+	w = suite.getViewers(viewee)
+	assert.Equal(http.StatusForbidden, w.Code)
+
+	suite.setPremium(viewee, models.PremiumTypePaid, time.Now().Add(24*time.Hour))
+	w = suite.getViewers(viewee)
+	assert.Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var resp user.TrfedProfileViewers
+
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	if assert.Len(resp.Viewers, 1) {
+		assert.Equal(viewer.Uuid, resp.Viewers[0].Uuid)
+	}
+}
+
+func TestProfileViewTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfileViewTestSuite))
+}
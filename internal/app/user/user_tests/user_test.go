@@ -322,7 +322,7 @@ func (suite *UserAPITestsSuite) TestGetUserProfileByUuid() {
 	}
 
 	// ------------------- test cases -------------------
-	respStruct := &user.TransformedMaleUser{}
+	respStruct := &user.TransformedViewableUserProfile{}
 	dec := json.NewDecoder(resp.Result().Body)
 	if err := dec.Decode(respStruct); err != nil {
 		suite.T().Fatal(err)
@@ -355,11 +355,11 @@ func (suite *UserAPITestsSuite) TestGetUserImagesByUuid() {
 	}
 
 	// create images and relate those images to that user
-	imagesParams := make([]image.CreateImageParams, 0)
+	imagesParams := make([]models.Image, 0)
 	for i := 0; i < 12; i++ {
-		imagesParams = append(imagesParams, image.CreateImageParams{
-			UserID: maleUser.ID,
-			URL:    fmt.Sprintf("https://foo.com/bar%d.png", i),
+		imagesParams = append(imagesParams, models.Image{
+			UserID: int32(maleUser.ID),
+			Url:    fmt.Sprintf("https://foo.com/bar%d.png", i),
 		})
 	}
 
@@ -442,7 +442,7 @@ func (suite *UserAPITestsSuite) TestGetUserPaymentSuccess() {
 		suite.T().Fatal(err)
 	}
 
-	newPayment, err := q.CreatePayment(ctx, *paymentParams)
+	_, err = q.CreatePayment(ctx, *paymentParams)
 
 	if err != nil {
 		suite.T().Fatal(err)
@@ -472,7 +472,7 @@ func (suite *UserAPITestsSuite) TestGetUserPaymentSuccess() {
 	}
 	assert := assert.New(suite.T())
 	assert.Equal(http.StatusOK, resp.Result().StatusCode)
-	assert.Equal(newPayment.RecTradeID.String, respStruct.Payments[0].RecTradeID)
+	assert.Equal(service.Uuid.String, respStruct.Payments[0].Service.Uuid)
 }
 
 func (suite *UserAPITestsSuite) TestGetUserHistoricalServices() {
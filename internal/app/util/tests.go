@@ -10,10 +10,12 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"strings"
+	"testing"
 	"time"
 
 	faker "github.com/bxcodec/faker/v3"
 	"github.com/gin-gonic/gin"
+	"github.com/huangc28/go-darkpanda-backend/db"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
 	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 	"github.com/ventu-io/go-shortid"
@@ -73,6 +75,28 @@ func GenTestUserParams() (*models.CreateUserParams, error) {
 	return p, nil
 }
 
+// CreateTestUser creates a randomized user of the given gender. The test is failed right away if the
+// user can not be created.
+func CreateTestUser(t testing.TB, gender models.Gender) models.User {
+	t.Helper()
+
+	params, err := GenTestUserParams()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params.Gender = gender
+
+	user, err := models.New(db.GetDB()).CreateUser(context.Background(), *params)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return user
+}
+
 type TestServiceInfo struct {
 	Male    *models.User
 	Female  *models.User
@@ -0,0 +1,45 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	genverifycode "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/generate_verify_code"
+)
+
+const (
+	verificationCodeKey = "identity_verification_code:%s"
+
+	// VerificationCodeTTL the selfie should be taken and uploaded within this period after the code is generated.
+	VerificationCodeTTL = 30 * time.Minute
+)
+
+// CreateVerificationCode generates the code the user holds in the selfie. Previous code of the user is replaced.
+func CreateVerificationCode(ctx context.Context, rds *redis.Client, userUuid string) (string, error) {
+	vc := genverifycode.GenVerifyCode()
+	code := vc.BuildCode()
+
+	if err := rds.Set(
+		ctx,
+		fmt.Sprintf(verificationCodeKey, userUuid),
+		code,
+		VerificationCodeTTL,
+	).Err(); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// GetVerificationCode retrieves the code generated for the user, `redis.Nil` is returned if the code
+// has expired or has never been generated.
+func GetVerificationCode(ctx context.Context, rds *redis.Client, userUuid string) (string, error) {
+	return rds.Get(ctx, fmt.Sprintf(verificationCodeKey, userUuid)).Result()
+}
+
+// DeleteVerificationCode code is used only once.
+func DeleteVerificationCode(ctx context.Context, rds *redis.Client, userUuid string) error {
+	return rds.Del(ctx, fmt.Sprintf(verificationCodeKey, userUuid)).Err()
+}
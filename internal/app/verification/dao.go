@@ -0,0 +1,243 @@
+package verification
+
+import (
+	cintrnal "github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type IdentityVerificationDAO struct {
+	db db.Conn
+}
+
+func NewIdentityVerificationDAO(db db.Conn) *IdentityVerificationDAO {
+	return &IdentityVerificationDAO{
+		db: db,
+	}
+}
+
+func IdentityVerificationDAOServiceProvider(c cintrnal.Container) func() error {
+	return func() error {
+		c.Transient(func() contracts.IdentityVerificationDAOer {
+			return NewIdentityVerificationDAO(db.GetDB())
+		})
+
+		return nil
+	}
+}
+
+func (dao *IdentityVerificationDAO) WithTx(tx db.Conn) contracts.IdentityVerificationDAOer {
+	dao.db = tx
+
+	return dao
+}
+
+// CreateVerification submits the verification request for review. A user has at most one pending request,
+// `sql.ErrNoRows` is returned if the user has a request waiting for review.
+func (dao *IdentityVerificationDAO) CreateVerification(p contracts.CreateIdentityVerificationParams) (*models.IdentityVerification, error) {
+	query := `
+INSERT INTO identity_verifications (
+	uuid,
+	user_id,
+	code,
+	selfie_object,
+	id_photo_object
+) VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) WHERE status = 'pending' DO NOTHING
+RETURNING *;
+`
+	var m models.IdentityVerification
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Uuid,
+		p.UserID,
+		p.Code,
+		p.SelfieObject,
+		p.IDPhotoObject,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+func (dao *IdentityVerificationDAO) GetLatestVerification(userID int64) (*models.IdentityVerification, error) {
+	query := `
+SELECT
+	*
+FROM
+	identity_verifications
+WHERE
+	user_id = $1 AND
+	deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+`
+	var m models.IdentityVerification
+
+	if err := dao.db.QueryRowx(query, userID).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// IsUserVerified user is verified once any of the requests is approved.
+func (dao *IdentityVerificationDAO) IsUserVerified(userID int64) (bool, error) {
+	query := `
+SELECT EXISTS(
+	SELECT 1
+	FROM identity_verifications
+	WHERE
+		user_id = $1 AND
+		status = $2 AND
+		deleted_at IS NULL
+);
+`
+	var verified bool
+
+	if err := dao.db.QueryRowx(
+		query,
+		userID,
+		models.IdentityVerificationStatusApproved,
+	).Scan(&verified); err != nil {
+		return false, err
+	}
+
+	return verified, nil
+}
+
+func (dao *IdentityVerificationDAO) GetVerificationByUuid(uuid string) (*models.IdentityVerificationDetail, error) {
+	query := `
+SELECT
+	identity_verifications.*,
+	users.uuid AS user_uuid,
+	users.username
+FROM
+	identity_verifications
+INNER JOIN users ON users.id = identity_verifications.user_id
+WHERE
+	identity_verifications.uuid = $1 AND
+	identity_verifications.deleted_at IS NULL;
+`
+	var m models.IdentityVerificationDetail
+
+	if err := dao.db.QueryRowx(query, uuid).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// GetVerifications retrieves requests of the status, oldest first so that reviewers work through the queue in order.
+func (dao *IdentityVerificationDAO) GetVerifications(p contracts.GetIdentityVerificationsParams) ([]models.IdentityVerificationDetail, error) {
+	query := `
+SELECT
+	identity_verifications.*,
+	users.uuid AS user_uuid,
+	users.username
+FROM
+	identity_verifications
+INNER JOIN users ON users.id = identity_verifications.user_id
+WHERE
+	identity_verifications.status = $1 AND
+	identity_verifications.deleted_at IS NULL
+ORDER BY identity_verifications.created_at
+LIMIT $2
+OFFSET $3;
+`
+	rows, err := dao.db.Queryx(
+		query,
+		p.Status,
+		p.PerPage,
+		p.Offset,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	vs := make([]models.IdentityVerificationDetail, 0)
+
+	for rows.Next() {
+		var m models.IdentityVerificationDetail
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		vs = append(vs, m)
+	}
+
+	return vs, nil
+}
+
+// DecideVerification approves or rejects the pending request. `sql.ErrNoRows` is returned if the
+// request does not exist or has been decided.
+func (dao *IdentityVerificationDAO) DecideVerification(p contracts.DecideIdentityVerificationParams) (*models.IdentityVerification, error) {
+	query := `
+UPDATE identity_verifications
+SET
+	status = $1,
+	reason = $2,
+	reviewer_id = $3,
+	reviewed_at = NOW()
+WHERE
+	uuid = $4 AND
+	status = $5 AND
+	deleted_at IS NULL
+RETURNING *;
+`
+	var m models.IdentityVerification
+
+	if err := dao.db.QueryRowx(
+		query,
+		p.Status,
+		p.Reason,
+		p.ReviewerID,
+		p.Uuid,
+		models.IdentityVerificationStatusPending,
+	).StructScan(&m); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// DeleteVerificationsOfUser soft deletes every verification request of the user. Deleted requests are returned
+// so that the caller can remove the photos from GCS once the transaction is committed.
+func (dao *IdentityVerificationDAO) DeleteVerificationsOfUser(userID int64) ([]models.IdentityVerification, error) {
+	query := `
+UPDATE identity_verifications
+SET deleted_at = NOW()
+WHERE
+	user_id = $1 AND
+	deleted_at IS NULL
+RETURNING *;
+`
+	rows, err := dao.db.Queryx(query, userID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ms := make([]models.IdentityVerification, 0)
+
+	for rows.Next() {
+		var m models.IdentityVerification
+
+		if err := rows.StructScan(&m); err != nil {
+			return nil, err
+		}
+
+		ms = append(ms, m)
+	}
+
+	return ms, nil
+}
@@ -0,0 +1,405 @@
+package verification
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/image"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	gcsenhancer "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/gcs_enhancer"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+)
+
+const (
+	SelfieField  = "selfie"
+	IDPhotoField = "id_photo"
+)
+
+var photoExtensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+}
+
+// requireSubmittable aborts the request if the user has been verified or has a request waiting for review.
+func requireSubmittable(c *gin.Context, dao contracts.IdentityVerificationDAOer, userID int64) bool {
+	verified, err := dao.IsUserVerified(userID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckUserVerified,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	if verified {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.UserAlreadyVerified),
+		)
+
+		return false
+	}
+
+	latest, err := dao.GetLatestVerification(userID)
+
+	if err != nil && err != sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return false
+	}
+
+	if latest != nil && latest.Status == models.IdentityVerificationStatusPending {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.IdentityVerificationPending),
+		)
+
+		return false
+	}
+
+	return true
+}
+
+// CreateVerificationCodeHandler generates the code the female user should hold in the selfie.
+func CreateVerificationCodeHandler(c *gin.Context, depCon container.Container) {
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.IdentityVerificationDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id", "uuid")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if !requireSubmittable(c, dao, me.ID) {
+		return
+	}
+
+	code, err := CreateVerificationCode(context.Background(), db.GetRedis(), me.Uuid)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCreateVerificationCode,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, struct {
+		Code      string    `json:"code"`
+		ExpiredAt time.Time `json:"expired_at"`
+	}{
+		code,
+		time.Now().Add(VerificationCodeTTL),
+	})
+}
+
+// CreateVerificationHandler submits the selfie holding the verification code along with the ID photo
+// for review. Photos are stored as private objects that only operators can read.
+func CreateVerificationHandler(c *gin.Context, depCon container.Container) {
+	// ------------------- Limit upload size to 20 MB -------------------
+	if err := c.Request.ParseMultipartForm(20e6); err != nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(
+				apperr.FailedToParseMultipartForm,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	fileHeaders := make(map[string]*multipart.FileHeader)
+
+	for _, field := range []string{SelfieField, IDPhotoField} {
+		fhs := c.Request.MultipartForm.File[field]
+
+		if len(fhs) == 0 {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				apperr.NewErr(apperr.VerificationPhotoNotFound),
+			)
+
+			return
+		}
+
+		fileHeaders[field] = fhs[0]
+	}
+
+	ctx := context.Background()
+
+	var (
+		userDao     contracts.UserDAOer
+		dao         contracts.IdentityVerificationDAOer
+		gcsEnhancer gcsenhancer.GCSEnhancerInterface
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+	depCon.Make(&gcsEnhancer)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id", "uuid")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if !requireSubmittable(c, dao, me.ID) {
+		return
+	}
+
+	code, err := GetVerificationCode(ctx, db.GetRedis(), me.Uuid)
+
+	if err == redis.Nil {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.VerificationCodeExpired),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetVerificationCode,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	objects := make(map[string]string)
+
+	for field, fh := range fileHeaders {
+		hfs, err := image.DetectMimeOfHeaderFiles([]*multipart.FileHeader{fh})
+
+		if err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				apperr.NewErr(
+					apperr.FailedToOpenMultipartFile,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		ext, ok := photoExtensions[hfs[0].Mime]
+
+		if !ok {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				apperr.NewErr(apperr.VerificationPhotoNotImage),
+			)
+
+			return
+		}
+
+		// Verification photos skip the compression, see `uploadPrivate`. Decode the photo instead to reject
+		// files that merely look like an image.
+		if _, err := image.DecodeHeaderFile(fh, hfs[0].Mime); err != nil {
+			c.AbortWithError(
+				http.StatusBadRequest,
+				apperr.NewErr(
+					apperr.VerificationPhotoNotImage,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		objName := path.Join(
+			"identity_verifications",
+			me.Uuid,
+			gcsenhancer.AppendUnixTimeStampToFilename(fmt.Sprintf("%s.%s", field, ext)),
+		)
+
+		if err := uploadPrivate(ctx, gcsEnhancer, fh, objName); err != nil {
+			c.AbortWithError(
+				http.StatusInternalServerError,
+				apperr.NewErr(
+					apperr.FailedToUploadVerificationPhoto,
+					err.Error(),
+				),
+			)
+
+			return
+		}
+
+		objects[field] = objName
+	}
+
+	sid, err := shortid.Generate()
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCreateIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	v, err := dao.CreateVerification(contracts.CreateIdentityVerificationParams{
+		Uuid:          sid,
+		UserID:        me.ID,
+		Code:          code,
+		SelfieObject:  objects[SelfieField],
+		IDPhotoObject: objects[IDPhotoField],
+	})
+
+	if err == sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusBadRequest,
+			apperr.NewErr(apperr.IdentityVerificationPending),
+		)
+
+		return
+	}
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCreateIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	if err := DeleteVerificationCode(ctx, db.GetRedis(), me.Uuid); err != nil {
+		log.Errorf("failed to delete identity verification code of %s: %s", me.Uuid, err.Error())
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformVerification(v))
+}
+
+// uploadPrivate uploads the photo as is. Unlike profile images, verification photos do not go through
+// `image.CompressImages` and `GCSEnhancer.UploadImages`: the lossy compression may blur the code and the
+// ID that reviewers have to read, and the pipeline publishes every object via public ACL. The photo is
+// checked by `image.DetectMimeOfHeaderFiles` and `image.DecodeHeaderFile` before being uploaded.
+func uploadPrivate(ctx context.Context, enhancer gcsenhancer.GCSEnhancerInterface, fh *multipart.FileHeader, objName string) error {
+	f, err := fh.Open()
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return enhancer.UploadPrivate(ctx, f, objName)
+}
+
+// GetMyVerificationHandler tells whether the requester has been verified along with the latest request.
+func GetMyVerificationHandler(c *gin.Context, depCon container.Container) {
+	var (
+		userDao contracts.UserDAOer
+		dao     contracts.IdentityVerificationDAOer
+	)
+
+	depCon.Make(&userDao)
+	depCon.Make(&dao)
+
+	me, err := userDao.GetUserByUuid(c.GetString("uuid"), "id")
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetUserByUuid,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	verified, err := dao.IsUserVerified(me.ID)
+
+	if err != nil {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToCheckUserVerified,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	latest, err := dao.GetLatestVerification(me.ID)
+
+	if err != nil && err != sql.ErrNoRows {
+		c.AbortWithError(
+			http.StatusInternalServerError,
+			apperr.NewErr(
+				apperr.FailedToGetIdentityVerification,
+				err.Error(),
+			),
+		)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, NewTransform().TransformMyVerification(verified, latest))
+}
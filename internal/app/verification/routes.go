@@ -0,0 +1,41 @@
+package verification
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/middlewares"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+)
+
+func Routes(r *gin.RouterGroup, depCon container.Container) {
+	var (
+		authDao contracts.AuthDaoer
+		userDao contracts.UserDAOer
+	)
+
+	depCon.Make(&authDao)
+	depCon.Make(&userDao)
+
+	g := r.Group(
+		"/verifications",
+		jwtactor.JwtValidator(
+			jwtactor.JwtMiddlewareOptions{},
+			authDao,
+		),
+		middlewares.IsFemale(userDao),
+	)
+
+	g.GET("/me", func(c *gin.Context) {
+		GetMyVerificationHandler(c, depCon)
+	})
+
+	// Generate the code to be held in the selfie.
+	g.POST("/code", func(c *gin.Context) {
+		CreateVerificationCodeHandler(c, depCon)
+	})
+
+	g.POST("", func(c *gin.Context) {
+		CreateVerificationHandler(c, depCon)
+	})
+}
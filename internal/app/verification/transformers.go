@@ -0,0 +1,58 @@
+package verification
+
+import (
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+)
+
+type VerificationTransform struct{}
+
+func NewTransform() *VerificationTransform {
+	return &VerificationTransform{}
+}
+
+type TrfedVerification struct {
+	Uuid       string                            `json:"uuid"`
+	Status     models.IdentityVerificationStatus `json:"status"`
+	Reason     *string                           `json:"reason"`
+	CreatedAt  time.Time                         `json:"created_at"`
+	ReviewedAt *time.Time                        `json:"reviewed_at"`
+}
+
+func (t *VerificationTransform) TransformVerification(m *models.IdentityVerification) *TrfedVerification {
+	trf := &TrfedVerification{
+		Uuid:      m.Uuid,
+		Status:    m.Status,
+		CreatedAt: m.CreatedAt,
+	}
+
+	if m.Reason.Valid {
+		reason := m.Reason.String
+		trf.Reason = &reason
+	}
+
+	if m.ReviewedAt.Valid {
+		reviewedAt := m.ReviewedAt.Time
+		trf.ReviewedAt = &reviewedAt
+	}
+
+	return trf
+}
+
+type TrfedMyVerification struct {
+	Verified bool               `json:"verified"`
+	Latest   *TrfedVerification `json:"latest"`
+}
+
+func (t *VerificationTransform) TransformMyVerification(verified bool, latest *models.IdentityVerification) TrfedMyVerification {
+	trf := TrfedMyVerification{
+		Verified: verified,
+	}
+
+	if latest != nil {
+		trf.Latest = t.TransformVerification(latest)
+	}
+
+	return trf
+}
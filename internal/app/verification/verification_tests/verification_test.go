@@ -0,0 +1,345 @@
+package verificationtests
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/storage"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/golobby/container/pkg/container"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/apperr"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/deps"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	gcsenhancer "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/gcs_enhancer"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/util"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/verification"
+	"github.com/huangc28/go-darkpanda-backend/manager"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeEnhancer keeps private uploads in memory instead of uploading to GCS.
+type fakeEnhancer struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (e *fakeEnhancer) ObjectLink(attr *storage.ObjectAttrs) string {
+	return attr.Name
+}
+
+func (e *fakeEnhancer) Upload(ctx context.Context, file io.Reader, uploadFilename string) (string, error) {
+	return "", errors.New("verification photos should not be public")
+}
+
+func (e *fakeEnhancer) UploadPrivate(ctx context.Context, file io.Reader, uploadFilename string) error {
+	b, err := ioutil.ReadAll(file)
+
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.objects[uploadFilename] = b
+
+	return nil
+}
+
+func (e *fakeEnhancer) NewObjectReader(ctx context.Context, filename string) (*storage.Reader, error) {
+	return nil, errors.New("not supported")
+}
+
+func (e *fakeEnhancer) Delete(ctx context.Context, filename string) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.objects, filename)
+
+	return nil
+}
+
+type VerificationTestSuite struct {
+	suite.Suite
+	depCon container.Container
+	gcs    *fakeEnhancer
+}
+
+func (suite *VerificationTestSuite) SetupSuite() {
+	manager.
+		NewDefaultManager(context.Background()).
+		Run(func() {
+			deps.Get().Run()
+			suite.depCon = deps.Get().Container
+		})
+
+	suite.gcs = &fakeEnhancer{objects: make(map[string][]byte)}
+	suite.depCon.Singleton(func() gcsenhancer.GCSEnhancerInterface {
+		return suite.gcs
+	})
+}
+
+func (suite *VerificationTestSuite) pngBytes() []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	img.Set(1, 1, color.White)
+
+	var buf bytes.Buffer
+
+	if err := png.Encode(&buf, img); err != nil {
+		suite.T().Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func (suite *VerificationTestSuite) newPhotosRequest(fields ...string) *http.Request {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	for _, field := range fields {
+		fw, err := mw.CreateFormFile(field, field+".png")
+
+		if err != nil {
+			suite.T().Fatal(err)
+		}
+
+		fw.Write(suite.pngBytes())
+	}
+
+	mw.Close()
+
+	req, _ := http.NewRequest("POST", "/v1/verifications", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	return req
+}
+
+func (suite *VerificationTestSuite) createCode(user models.User) string {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/v1/verifications/code", nil)
+	c.Set("uuid", user.Uuid)
+
+	verification.CreateVerificationCodeHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	if w.Code != http.StatusOK {
+		suite.T().Fatalf("failed to create verification code %s", w.Body.String())
+	}
+
+	resp := struct {
+		Code string `json:"code"`
+	}{}
+
+	json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return resp.Code
+}
+
+func (suite *VerificationTestSuite) submit(user models.User) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = suite.newPhotosRequest(verification.SelfieField, verification.IDPhotoField)
+	c.Set("uuid", user.Uuid)
+
+	verification.CreateVerificationHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	return w
+}
+
+func errCodeOf(w *httptest.ResponseRecorder) string {
+	resp := struct {
+		ErrCode string `json:"err_code"`
+	}{}
+
+	json.Unmarshal(w.Body.Bytes(), &resp)
+
+	return resp.ErrCode
+}
+
+func (suite *VerificationTestSuite) TestSubmitVerification() {
+	user := util.CreateTestUser(suite.T(), models.GenderFemale)
+	code := suite.createCode(user)
+
+	w := suite.submit(user)
+
+	assert := assert.New(suite.T())
+	assert.Equal(http.StatusOK, w.Code, w.Body.String())
+
+	var dao contracts.IdentityVerificationDAOer
+	suite.depCon.Make(&dao)
+
+	v, err := dao.GetLatestVerification(user.ID)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	assert.Equal(models.IdentityVerificationStatusPending, v.Status)
+	assert.Equal(code, v.Code)
+
+	// Photos are uploaded privately under the directory of the user.
+	for _, obj := range []string{v.SelfieObject, v.IDPhotoObject} {
+		assert.True(strings.HasPrefix(obj, "identity_verifications/"+user.Uuid+"/"))
+		assert.Contains(suite.gcs.objects, obj)
+	}
+
+	// Code is used only once.
+	_, err = verification.GetVerificationCode(context.Background(), db.GetRedis(), user.Uuid)
+	assert.Equal(redis.Nil, err)
+}
+
+func (suite *VerificationTestSuite) TestSubmitWithoutCode() {
+	user := util.CreateTestUser(suite.T(), models.GenderFemale)
+	w := suite.submit(user)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), apperr.VerificationCodeExpired, errCodeOf(w))
+}
+
+func (suite *VerificationTestSuite) TestSubmitMissingPhoto() {
+	user := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.createCode(user)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = suite.newPhotosRequest(verification.SelfieField)
+	c.Set("uuid", user.Uuid)
+
+	verification.CreateVerificationHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), apperr.VerificationPhotoNotFound, errCodeOf(w))
+}
+
+func (suite *VerificationTestSuite) TestSubmitCorruptedPhoto() {
+	user := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.createCode(user)
+
+	// Truncated png is detected as png by the header but can not be decoded.
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	for _, field := range []string{verification.SelfieField, verification.IDPhotoField} {
+		fw, err := mw.CreateFormFile(field, field+".png")
+
+		if err != nil {
+			suite.T().Fatal(err)
+		}
+
+		fw.Write(suite.pngBytes()[:40])
+	}
+
+	mw.Close()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/v1/verifications", &body)
+	c.Request.Header.Set("Content-Type", mw.FormDataContentType())
+	c.Set("uuid", user.Uuid)
+
+	verification.CreateVerificationHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), apperr.VerificationPhotoNotImage, errCodeOf(w))
+}
+
+func (suite *VerificationTestSuite) TestSecondPendingVerificationIsRejected() {
+	user := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.createCode(user)
+
+	if w := suite.submit(user); w.Code != http.StatusOK {
+		suite.T().Fatal(w.Body.String())
+	}
+
+	// Code can not be requested while the request is waiting for review.
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("POST", "/v1/verifications/code", nil)
+	c.Set("uuid", user.Uuid)
+
+	verification.CreateVerificationCodeHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Equal(suite.T(), apperr.IdentityVerificationPending, errCodeOf(w))
+
+	// The partial unique index guards concurrent submissions that passed the check.
+	_, err := verification.NewIdentityVerificationDAO(db.GetDB()).CreateVerification(contracts.CreateIdentityVerificationParams{
+		Uuid:          util.GenRandStringRune(10),
+		UserID:        user.ID,
+		Code:          "0000",
+		SelfieObject:  "selfie.png",
+		IDPhotoObject: "id_photo.png",
+	})
+
+	assert.Equal(suite.T(), sql.ErrNoRows, err)
+}
+
+func (suite *VerificationTestSuite) TestAccountDeletionPurgesVerifications() {
+	u := util.CreateTestUser(suite.T(), models.GenderFemale)
+	suite.createCode(u)
+
+	if w := suite.submit(u); w.Code != http.StatusOK {
+		suite.T().Fatal(w.Body.String())
+	}
+
+	dao := verification.NewIdentityVerificationDAO(db.GetDB())
+	v, err := dao.GetLatestVerification(u.ID)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	jwt, err := jwtactor.CreateTokenWithKeyID(u.Uuid)
+
+	if err != nil {
+		suite.T().Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request, _ = http.NewRequest("DELETE", "/v1/users/me", nil)
+	c.Set("uuid", u.Uuid)
+	c.Set("jwt", jwt)
+
+	user.DeleteMyAccountHandler(c, suite.depCon)
+	apperr.HandleError()(c)
+
+	assert := assert.New(suite.T())
+	assert.Equal(http.StatusOK, w.Code, w.Body.String())
+
+	_, err = dao.GetLatestVerification(u.ID)
+	assert.Equal(sql.ErrNoRows, err)
+
+	for _, obj := range []string{v.SelfieObject, v.IDPhotoObject} {
+		assert.NotContains(suite.gcs.objects, obj)
+	}
+}
+
+func TestVerificationTestSuite(t *testing.T) {
+	suite.Run(t, new(VerificationTestSuite))
+}
@@ -0,0 +1,153 @@
+package utilcmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/agency"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	genverifycode "github.com/huangc28/go-darkpanda-backend/internal/app/pkg/generate_verify_code"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Onboarding an agency:
+//
+//	util agency create <manager username> "Panda Agency" --commission-rate 0.3
+//	util agency code <manager username>
+//	# female users registered with the manager referral code become members of the agency.
+var Agency = &cobra.Command{
+	Use:   "agency",
+	Short: "Manage agencies that onboard service providers via manager referral codes.",
+}
+
+var commissionRateF string
+
+var CreateAgency = &cobra.Command{
+	Use:   "create <manager username> <name>",
+	Short: "Create an agency managed by the user.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rate, err := parseCommissionRate(commissionRateF)
+
+		if err != nil {
+			return err
+		}
+
+		manager, err := user.NewUserDAO(db.GetDB()).GetUserByUsername(args[0], "id")
+
+		if err != nil {
+			return err
+		}
+
+		a, err := agency.NewAgencyDAO(db.GetDB()).CreateAgency(contracts.CreateAgencyParams{
+			ManagerID:      manager.ID,
+			Name:           args[1],
+			CommissionRate: rate,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		log.Printf("created agency %s managed by %s with commission rate %s", a.Name, args[0], a.CommissionRate)
+
+		return nil
+	},
+}
+
+var SetAgencyCommissionRate = &cobra.Command{
+	Use:   "rate <manager username> <rate>",
+	Short: "Set share of matching fees paid to the agency.",
+	Long:  "Set share of matching fees paid to the agency, 0.3 means 30%. The rate applies to every unsettled month.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rate, err := parseCommissionRate(args[1])
+
+		if err != nil {
+			return err
+		}
+
+		agencyDao := agency.NewAgencyDAO(db.GetDB())
+		a, err := getAgencyByManagerUsername(agencyDao, args[0])
+
+		if err != nil {
+			return err
+		}
+
+		if err := agencyDao.SetCommissionRate(a.ID, rate); err != nil {
+			return err
+		}
+
+		log.Printf("set commission rate of agency %s to %s", a.Name, rate)
+
+		return nil
+	},
+}
+
+var CreateManagerRefcode = &cobra.Command{
+	Use:   "code <manager username> [code]",
+	Short: "Create a manager referral code of the agency.",
+	Long:  "Create a manager referral code of the agency. A random code is generated if not specified. Manager referral codes never expire.",
+	Args:  cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agencyDao := agency.NewAgencyDAO(db.GetDB())
+		a, err := getAgencyByManagerUsername(agencyDao, args[0])
+
+		if err != nil {
+			return err
+		}
+
+		// Invitor referral codes are numeric, use letters so that codes never collide.
+		code := genverifycode.GenRandStringRune(8)
+
+		if len(args) == 2 {
+			code = args[1]
+		}
+
+		refcode, err := agencyDao.CreateManagerRefcode(int64(a.ManagerID), code)
+
+		if err != nil {
+			return err
+		}
+
+		log.Printf("created manager referral code %s of agency %s", refcode.RefCode, a.Name)
+
+		return nil
+	},
+}
+
+func init() {
+	CreateAgency.Flags().StringVar(&commissionRateF, "commission-rate", "0", "share of matching fees paid to the agency, 0.3 means 30%")
+
+	Agency.AddCommand(CreateAgency)
+	Agency.AddCommand(SetAgencyCommissionRate)
+	Agency.AddCommand(CreateManagerRefcode)
+}
+
+func parseCommissionRate(s string) (string, error) {
+	rate, err := decimal.NewFromString(s)
+
+	if err != nil {
+		return "", err
+	}
+
+	if rate.IsNegative() || rate.GreaterThan(decimal.NewFromInt(1)) {
+		return "", fmt.Errorf("commission rate should be between 0 and 1")
+	}
+
+	return rate.String(), nil
+}
+
+func getAgencyByManagerUsername(agencyDao *agency.AgencyDAO, username string) (*models.Agency, error) {
+	manager, err := user.NewUserDAO(db.GetDB()).GetUserByUsername(username, "id")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return agencyDao.GetAgencyByManagerID(manager.ID)
+}
@@ -4,8 +4,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/huangc28/go-darkpanda-backend/config"
-	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 	"github.com/spf13/cobra"
 )
 
@@ -33,13 +31,7 @@ var GenJwtTokenByUuid = &cobra.Command{
 func GenJwtTokenByUuidFunc(cmd *cobra.Command, args []string) error {
 	uuid := args[0]
 
-	secret := config.GetAppConf().JwtSecret
-
-	if SecretF != "" {
-		secret = SecretF
-	}
-
-	jwtToken, err := jwtactor.CreateToken(uuid, secret)
+	jwtToken, err := createToken(uuid)
 
 	if err != nil {
 		return err
@@ -6,7 +6,6 @@ import (
 
 	"github.com/huangc28/go-darkpanda-backend/config"
 	"github.com/huangc28/go-darkpanda-backend/db"
-	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 	"github.com/spf13/cobra"
 )
 
@@ -51,17 +50,10 @@ func init() {
 
 func GenJwtTokenByNameFunc(cmd *cobra.Command, args []string) error {
 	// retrieve user uuid by username
-	var (
-		uuid   string
-		secret string = config.GetAppConf().JwtSecret
-	)
+	var uuid string
 
 	username := args[0]
 
-	if SecretF != "" {
-		secret = SecretF
-	}
-
 	db := db.GetDB()
 	if err := db.
 		QueryRow("SELECT uuid FROM users WHERE username = $1", username).
@@ -69,7 +61,7 @@ func GenJwtTokenByNameFunc(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	jwtToken, err := jwtactor.CreateToken(uuid, secret)
+	jwtToken, err := createToken(uuid)
 
 	if err != nil {
 		return err
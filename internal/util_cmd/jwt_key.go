@@ -0,0 +1,147 @@
+package utilcmd
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/auth"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
+	"github.com/jmoiron/sqlx"
+	"github.com/spf13/cobra"
+)
+
+// Rotating the signing key:
+//
+//	util jwtkey add 2021-06
+//	# wait for every app instance to reload the keyring, see `jwtactor.DefaultKeyringRefreshInterval`.
+//	util jwtkey activate 2021-06
+//	# wait for tokens signed by the previous key to expire.
+//	util jwtkey retire 2021-01
+var JwtKey = &cobra.Command{
+	Use:   "jwtkey",
+	Short: "Manage keys that sign / verify jwt.",
+}
+
+var keySecretF string
+
+var ListJwtKeys = &cobra.Command{
+	Use:   "list",
+	Short: "List jwt signing keys.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keys, err := auth.NewSigningKeyDAO(db.GetDB()).GetSigningKeys()
+
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "KID\tSTATUS\tCREATED AT\tACTIVATED AT\tRETIRED AT")
+
+		for _, key := range keys {
+			fmt.Fprintf(
+				w,
+				"%s\t%s\t%s\t%s\t%s\n",
+				key.Kid,
+				key.Status,
+				key.CreatedAt.Format("2006-01-02 15:04:05"),
+				formatNullTime(key.ActivatedAt.Valid, key.ActivatedAt.Time.Format("2006-01-02 15:04:05")),
+				formatNullTime(key.RetiredAt.Valid, key.RetiredAt.Time.Format("2006-01-02 15:04:05")),
+			)
+		}
+
+		return w.Flush()
+	},
+}
+
+var AddJwtKey = &cobra.Command{
+	Use:   "add <kid>",
+	Short: "Add a signing key in standby.",
+	Long:  "Add a signing key in standby. Standby keys verify tokens but do not sign new tokens until activated. A random secret is generated unless --key-secret is specified.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		secret := keySecretF
+
+		if secret == "" {
+			b := make([]byte, 32)
+
+			if _, err := rand.Read(b); err != nil {
+				return err
+			}
+
+			secret = base64.RawURLEncoding.EncodeToString(b)
+		}
+
+		key, err := auth.NewSigningKeyDAO(db.GetDB()).AddSigningKey(args[0], secret)
+
+		if err != nil {
+			return err
+		}
+
+		log.Printf("added signing key %s in %s", key.Kid, key.Status)
+
+		return nil
+	},
+}
+
+var ActivateJwtKey = &cobra.Command{
+	Use:   "activate <kid>",
+	Short: "Sign new tokens with the given standby key.",
+	Long:  "Sign new tokens with the given standby key. The current active key is put back to standby and keeps verifying tokens it signed.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		resp := db.TransactWithFormatStruct(db.GetDB(), func(tx *sqlx.Tx) db.FormatResp {
+			if err := auth.NewSigningKeyDAO(db.GetDB()).WithTx(tx).ActivateSigningKey(args[0]); err != nil {
+				return db.FormatResp{Err: err}
+			}
+
+			return db.FormatResp{}
+		})
+
+		if resp.Err != nil {
+			return resp.Err
+		}
+
+		log.Printf("activated signing key %s", args[0])
+
+		return nil
+	},
+}
+
+var RetireJwtKey = &cobra.Command{
+	Use:   "retire <kid>",
+	Short: "Reject tokens signed by the given standby key.",
+	Long:  fmt.Sprintf("Reject tokens signed by the given standby key. Use \"%s\" to reject tokens signed by the secret in app config.", jwtactor.LegacyKeyID),
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := auth.NewSigningKeyDAO(db.GetDB()).RetireSigningKey(args[0]); err != nil {
+			return err
+		}
+
+		log.Printf("retired signing key %s", args[0])
+
+		return nil
+	},
+}
+
+func init() {
+	AddJwtKey.Flags().StringVar(&keySecretF, "key-secret", "", "secret of the signing key, randomly generated if not specified")
+
+	JwtKey.AddCommand(ListJwtKeys)
+	JwtKey.AddCommand(AddJwtKey)
+	JwtKey.AddCommand(ActivateJwtKey)
+	JwtKey.AddCommand(RetireJwtKey)
+}
+
+func formatNullTime(valid bool, formatted string) string {
+	if !valid {
+		return "-"
+	}
+
+	return formatted
+}
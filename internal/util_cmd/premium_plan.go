@@ -0,0 +1,142 @@
+package utilcmd
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/premium"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Selling premium membership:
+//
+//	util premiumplan add "30 days" 30 300
+//	util premiumplan disable 1
+var PremiumPlan = &cobra.Command{
+	Use:   "premiumplan",
+	Short: "Manage premium membership plans purchasable with coins.",
+}
+
+var ListPremiumPlans = &cobra.Command{
+	Use:   "list",
+	Short: "List premium plans.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		plans, err := premium.NewPremiumDAO(db.GetDB()).GetPlans(false)
+
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tDAYS\tCOST\tENABLED")
+
+		for _, plan := range plans {
+			fmt.Fprintf(
+				w,
+				"%d\t%s\t%d\t%s\t%t\n",
+				plan.ID,
+				plan.Name,
+				plan.DurationDays,
+				plan.Cost,
+				plan.Enabled,
+			)
+		}
+
+		return w.Flush()
+	},
+}
+
+var AddPremiumPlan = &cobra.Command{
+	Use:   "add <name> <duration_days> <cost>",
+	Short: "Add a premium plan that extends membership by the duration for the cost in coins.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		days, err := strconv.Atoi(args[1])
+
+		if err != nil {
+			return err
+		}
+
+		if days <= 0 {
+			return fmt.Errorf("duration days should be positive")
+		}
+
+		cost, err := decimal.NewFromString(args[2])
+
+		if err != nil {
+			return err
+		}
+
+		if !cost.IsPositive() {
+			return fmt.Errorf("cost should be positive")
+		}
+
+		plan, err := premium.NewPremiumDAO(db.GetDB()).CreatePlan(contracts.CreatePremiumPlanParams{
+			Name:         args[0],
+			DurationDays: days,
+			Cost:         cost.String(),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		log.Printf("added premium plan %d of %d days for %s coins", plan.ID, plan.DurationDays, plan.Cost)
+
+		return nil
+	},
+}
+
+var EnablePremiumPlan = &cobra.Command{
+	Use:   "enable <id>",
+	Short: "Enable the premium plan.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPremiumPlanEnabled(args[0], true)
+	},
+}
+
+var DisablePremiumPlan = &cobra.Command{
+	Use:   "disable <id>",
+	Short: "Disable the premium plan.",
+	Long:  "Disable the premium plan. Memberships purchased with the plan are kept.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setPremiumPlanEnabled(args[0], false)
+	},
+}
+
+func init() {
+	PremiumPlan.AddCommand(ListPremiumPlans)
+	PremiumPlan.AddCommand(AddPremiumPlan)
+	PremiumPlan.AddCommand(EnablePremiumPlan)
+	PremiumPlan.AddCommand(DisablePremiumPlan)
+}
+
+func setPremiumPlanEnabled(idStr string, enabled bool) error {
+	ID, err := strconv.Atoi(idStr)
+
+	if err != nil {
+		return err
+	}
+
+	if err := premium.NewPremiumDAO(db.GetDB()).SetPlanEnabled(int32(ID), enabled); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("premium plan %d not found", ID)
+		}
+
+		return err
+	}
+
+	log.Printf("set premium plan %d enabled to %t", ID, enabled)
+
+	return nil
+}
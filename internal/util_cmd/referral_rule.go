@@ -0,0 +1,202 @@
+package utilcmd
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/contracts"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/referral"
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Running a referral campaign:
+//
+//	util referralrule add "2021 spring invitor" phone_verified 50 --starts-at 2021-03-01 --ends-at 2021-04-01
+//	util referralrule add "2021 spring invitee" first_service_completed 100 --recipient invitee
+//	util referralrule disable 2
+var ReferralRule = &cobra.Command{
+	Use:   "referralrule",
+	Short: "Manage rules that reward coins to invitors / invitees.",
+}
+
+var availableRewardEvents = map[models.ReferralRewardEvent]struct{}{
+	models.ReferralRewardEventPhoneVerified:         {},
+	models.ReferralRewardEventFirstServiceCompleted: {},
+}
+
+var availableRewardRecipients = map[models.ReferralRewardRecipient]struct{}{
+	models.ReferralRewardRecipientInvitor: {},
+	models.ReferralRewardRecipientInvitee: {},
+}
+
+const ruleTimeLayout = "2006-01-02"
+
+var (
+	recipientF string
+	startsAtF  string
+	endsAtF    string
+)
+
+var ListReferralRules = &cobra.Command{
+	Use:   "list",
+	Short: "List referral reward rules.",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rules, err := referral.NewReferralRewardDAO(db.GetDB()).GetRewardRules()
+
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tNAME\tEVENT\tRECIPIENT\tAMOUNT\tENABLED\tSTARTS AT\tENDS AT")
+
+		for _, rule := range rules {
+			fmt.Fprintf(
+				w,
+				"%d\t%s\t%s\t%s\t%s\t%t\t%s\t%s\n",
+				rule.ID,
+				rule.Name,
+				rule.Event,
+				rule.Recipient,
+				rule.Amount,
+				rule.Enabled,
+				formatNullTime(rule.StartsAt.Valid, rule.StartsAt.Time.Format(ruleTimeLayout)),
+				formatNullTime(rule.EndsAt.Valid, rule.EndsAt.Time.Format(ruleTimeLayout)),
+			)
+		}
+
+		return w.Flush()
+	},
+}
+
+var AddReferralRule = &cobra.Command{
+	Use:   "add <name> <phone_verified|first_service_completed> <amount>",
+	Short: "Add a rule that rewards coins on the event of the invitee.",
+	Long:  "Add a rule that rewards coins on the event of the invitee. Each rule rewards an invitee at most once. Rule applies immediately unless --starts-at is specified.",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		event := models.ReferralRewardEvent(args[1])
+
+		if _, ok := availableRewardEvents[event]; !ok {
+			return fmt.Errorf("unknown event %s", args[1])
+		}
+
+		recipient := models.ReferralRewardRecipient(recipientF)
+
+		if _, ok := availableRewardRecipients[recipient]; !ok {
+			return fmt.Errorf("unknown recipient %s", recipientF)
+		}
+
+		amount, err := decimal.NewFromString(args[2])
+
+		if err != nil {
+			return err
+		}
+
+		if !amount.IsPositive() {
+			return fmt.Errorf("amount should be positive")
+		}
+
+		startsAt, err := parseRuleTime(startsAtF)
+
+		if err != nil {
+			return err
+		}
+
+		endsAt, err := parseRuleTime(endsAtF)
+
+		if err != nil {
+			return err
+		}
+
+		rule, err := referral.NewReferralRewardDAO(db.GetDB()).CreateRewardRule(contracts.CreateReferralRewardRuleParams{
+			Name:      args[0],
+			Event:     event,
+			Recipient: recipient,
+			Amount:    amount.String(),
+			StartsAt:  startsAt,
+			EndsAt:    endsAt,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		log.Printf("added referral rule %d rewarding %s %s coins on %s", rule.ID, rule.Recipient, rule.Amount, rule.Event)
+
+		return nil
+	},
+}
+
+var EnableReferralRule = &cobra.Command{
+	Use:   "enable <id>",
+	Short: "Enable the referral reward rule.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setReferralRuleEnabled(args[0], true)
+	},
+}
+
+var DisableReferralRule = &cobra.Command{
+	Use:   "disable <id>",
+	Short: "Disable the referral reward rule.",
+	Long:  "Disable the referral reward rule. Rewards credited by the rule are kept.",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setReferralRuleEnabled(args[0], false)
+	},
+}
+
+func init() {
+	AddReferralRule.Flags().StringVar(&recipientF, "recipient", string(models.ReferralRewardRecipientInvitor), "who receives the reward, invitor or invitee")
+	AddReferralRule.Flags().StringVar(&startsAtF, "starts-at", "", "date the rule starts to apply, in YYYY-MM-DD")
+	AddReferralRule.Flags().StringVar(&endsAtF, "ends-at", "", "date the rule stops applying, in YYYY-MM-DD")
+
+	ReferralRule.AddCommand(ListReferralRules)
+	ReferralRule.AddCommand(AddReferralRule)
+	ReferralRule.AddCommand(EnableReferralRule)
+	ReferralRule.AddCommand(DisableReferralRule)
+}
+
+func parseRuleTime(s string) (sql.NullTime, error) {
+	if s == "" {
+		return sql.NullTime{}, nil
+	}
+
+	t, err := time.Parse(ruleTimeLayout, s)
+
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
+
+func setReferralRuleEnabled(idStr string, enabled bool) error {
+	ID, err := strconv.Atoi(idStr)
+
+	if err != nil {
+		return err
+	}
+
+	if err := referral.NewReferralRewardDAO(db.GetDB()).SetRewardRuleEnabled(int32(ID), enabled); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("referral rule %d not found", ID)
+		}
+
+		return err
+	}
+
+	log.Printf("set referral rule %d enabled to %t", ID, enabled)
+
+	return nil
+}
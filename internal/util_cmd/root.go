@@ -5,6 +5,9 @@ import (
 	"os"
 
 	"github.com/huangc28/go-darkpanda-backend/config"
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/auth"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/pkg/jwtactor"
 	"github.com/spf13/cobra"
 )
 
@@ -22,13 +25,36 @@ func Execute() {
 
 func initConfig() {
 	config.InitConfig()
+
+	jwtactor.SetKeyring(
+		jwtactor.NewKeyring(
+			config.GetAppConf().JwtSecret,
+			auth.NewSigningKeyDAO(db.GetDB()).GetSigningKeys,
+			0,
+		),
+	)
 }
 
 var SecretF string
 
+// createToken signs the token with the active key of the keyring. If `--secret` is specified,
+// the token is signed by the given secret without `kid` header.
+func createToken(uuid string) (string, error) {
+	if SecretF != "" {
+		return jwtactor.CreateToken(uuid, SecretF)
+	}
+
+	return jwtactor.CreateTokenWithKeyID(uuid)
+}
+
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.PersistentFlags().StringVarP(&SecretF, "secret", "s", "", "verbose output")
 	rootCmd.AddCommand(GenJwtTokenByUuid)
 	rootCmd.AddCommand(GenJwtTokenByName)
+	rootCmd.AddCommand(UserRole)
+	rootCmd.AddCommand(JwtKey)
+	rootCmd.AddCommand(ReferralRule)
+	rootCmd.AddCommand(Agency)
+	rootCmd.AddCommand(PremiumPlan)
 }
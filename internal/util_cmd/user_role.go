@@ -0,0 +1,80 @@
+package utilcmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/huangc28/go-darkpanda-backend/db"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/models"
+	"github.com/huangc28/go-darkpanda-backend/internal/app/user"
+	"github.com/spf13/cobra"
+)
+
+var availableRoles = map[models.UserRoleType]struct{}{
+	models.UserRoleTypeAdmin:   {},
+	models.UserRoleTypeSupport: {},
+	models.UserRoleTypeFinance: {},
+}
+
+var UserRole = &cobra.Command{
+	Use:   "role",
+	Short: "Manage operator roles of users.",
+}
+
+var GrantUserRole = &cobra.Command{
+	Use:   "grant <username> <admin|support|finance>",
+	Short: "Grant operator role to the user.",
+	Long:  "Grant operator role to the user. Role takes effect once the user rotates the access token.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return manageUserRole(args[0], args[1], true)
+	},
+}
+
+var RevokeUserRole = &cobra.Command{
+	Use:   "revoke <username> <admin|support|finance>",
+	Short: "Revoke operator role from the user.",
+	Long:  "Revoke operator role from the user. Role is removed once the user rotates the access token.",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return manageUserRole(args[0], args[1], false)
+	},
+}
+
+func init() {
+	UserRole.AddCommand(GrantUserRole)
+	UserRole.AddCommand(RevokeUserRole)
+}
+
+func manageUserRole(username, roleStr string, grant bool) error {
+	role := models.UserRoleType(roleStr)
+
+	if _, ok := availableRoles[role]; !ok {
+		return fmt.Errorf("unknown role %s", roleStr)
+	}
+
+	userDao := user.NewUserDAO(db.GetDB())
+	u, err := userDao.GetUserByUsername(username, "id")
+
+	if err != nil {
+		return err
+	}
+
+	if grant {
+		if err := userDao.GrantUserRole(u.ID, role); err != nil {
+			return err
+		}
+
+		log.Printf("granted role %s to %s", role, username)
+
+		return nil
+	}
+
+	if err := userDao.RevokeUserRole(u.ID, role); err != nil {
+		return err
+	}
+
+	log.Printf("revoked role %s from %s", role, username)
+
+	return nil
+}